@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v60/github"
@@ -12,26 +14,56 @@ import (
 	"github.com/mikematt33/gh-inspect/pkg/models"
 )
 
-type Analyzer struct{}
+// DefaultChurnExcludeLabels are the PR labels left out of the code churn ratio when none are configured
+var DefaultChurnExcludeLabels = []string{"dependencies", "generated"}
 
-func New() *Analyzer {
-	return &Analyzer{}
+type Analyzer struct {
+	ConventionalCommitThreshold float64  // percent; 0 disables the finding
+	ChurnExcludeLabels          []string // PRs with any of these labels are left out of code churn (case-insensitive)
+}
+
+func New(conventionalCommitThreshold int) *Analyzer {
+	return &Analyzer{
+		ConventionalCommitThreshold: float64(conventionalCommitThreshold),
+		ChurnExcludeLabels:          DefaultChurnExcludeLabels,
+	}
 }
 
 func (a *Analyzer) Name() string {
 	return "activity"
 }
 
+func (a *Analyzer) EstimatedCost(cfg analysis.Config) int {
+	// Overview, PR list, a few pages of commits, then details and reviews for sampled PRs
+	sampled := 10
+	if cfg.IncludeDeep {
+		sampled = 20
+	}
+	// A partial newest stargazer page does not count against MaxStargazerPages
+	stargazerPages := cfg.DepthConfig.MaxStargazerPages
+	if stargazerPages > 0 {
+		stargazerPages++
+	}
+	return 5 + 2*sampled + stargazerPages
+}
+
 func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
 	// TIER 1: Commit Velocity & Bus Factor (Time-bounded)
 	// This respects the cfg.Since window to avoid excessive API calls
 
 	result := models.AnalyzerResult{Name: a.Name()}
 
-	// Get repository metadata for stars/forks
-	repoData, err := client.GetRepository(ctx, repo.Owner, repo.Name)
-	if err != nil {
-		return result, err
+	// Get repository metadata for stars/forks, preferring the batched GraphQL overview
+	var stars, forks, watchers int
+	if overview, err := client.GetRepoOverview(ctx, repo.Owner, repo.Name); err == nil {
+		stars, forks, watchers = overview.Stars, overview.Forks, overview.Watchers
+	} else {
+		repoData, err := client.GetRepository(ctx, repo.Owner, repo.Name)
+		if err != nil {
+			return result, err
+		}
+		// subscribers_count is the real watcher count; watchers_count mirrors stars
+		stars, forks, watchers = repoData.GetStargazersCount(), repoData.GetForksCount(), repoData.GetSubscribersCount()
 	}
 
 	// Fetch recent PRs for code quality metrics
@@ -56,7 +88,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		}
 	}
 
-	commits, err := client.ListCommitsSince(ctx, repo.Owner, repo.Name, cfg.Since)
+	commits, err := client.ListCommitsSince(ctx, repo.Owner, repo.Name, repo.Ref, cfg.Since)
 	if err != nil {
 		// Check if this is an empty repository error
 		// GitHub returns 409 Conflict for empty repositories
@@ -89,6 +121,11 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 	authorCounts := make(map[string]int)
 	firstSeen := make(map[string]time.Time)
 
+	// Contributor trend: distinct authors in each half of the lookback window
+	midpoint := cfg.Since.Add(time.Since(cfg.Since) / 2)
+	firstHalf := make(map[string]bool)
+	secondHalf := make(map[string]bool)
+
 	for _, c := range commits {
 		var author string
 		commitTime := cfg.Since
@@ -108,6 +145,11 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 			if _, exists := firstSeen[author]; !exists {
 				firstSeen[author] = commitTime
 			}
+			if commitTime.Before(midpoint) {
+				firstHalf[author] = true
+			} else {
+				secondHalf[author] = true
+			}
 		}
 	}
 
@@ -119,12 +161,8 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		}
 	}
 
-	busFactor, topAuthors := calculateBusFactor(authorCounts, int(totalCommits))
-
-	// Star and Fork metrics
-	stars := repoData.GetStargazersCount()
-	forks := repoData.GetForksCount()
-	watchers := repoData.GetWatchersCount()
+	busFactor, contributors := calculateBusFactor(authorCounts, int(totalCommits))
+	contributorGrowth, hasGrowth := calculateContributorGrowth(len(firstHalf), len(secondHalf))
 
 	metrics := []models.Metric{
 		{
@@ -162,6 +200,20 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 			DisplayValue: fmt.Sprintf("%d", newContributors),
 			Description:  "Contributors with first commit in window",
 		},
+		{
+			Key:          "contributors_first_half",
+			Value:        float64(len(firstHalf)),
+			Unit:         "count",
+			DisplayValue: fmt.Sprintf("%d", len(firstHalf)),
+			Description:  "Distinct authors in the first half of the window",
+		},
+		{
+			Key:          "contributors_second_half",
+			Value:        float64(len(secondHalf)),
+			Unit:         "count",
+			DisplayValue: fmt.Sprintf("%d", len(secondHalf)),
+			Description:  "Distinct authors in the second half of the window",
+		},
 		{
 			Key:          "stars",
 			Value:        float64(stars),
@@ -185,6 +237,70 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		},
 	}
 
+	if hasGrowth {
+		metrics = append(metrics, models.Metric{
+			Key:          "contributor_growth",
+			Value:        contributorGrowth,
+			Unit:         "percent",
+			DisplayValue: fmt.Sprintf("%+.0f%%", contributorGrowth),
+			Description:  "Change in distinct authors from the first to the second half of the window",
+		})
+	}
+
+	// Star growth within the window (skipped when the stargazer list cannot be read)
+	growth, hasStarGrowth := fetchStarGrowth(ctx, client, repo, stars, cfg.Since, cfg.DepthConfig.MaxStargazerPages)
+	if hasStarGrowth {
+		display := fmt.Sprintf("+%d", growth.Gained)
+		description := "Stars gained in the time window"
+		if growth.Sampled {
+			display = fmt.Sprintf("~+%d", growth.Gained)
+			description = "Stars gained in the time window (extrapolated from the most recent stargazers)"
+		}
+		metrics = append(metrics, models.Metric{
+			Key:          "stars_gained",
+			Value:        float64(growth.Gained),
+			Unit:         "count",
+			DisplayValue: display,
+			Description:  description,
+		}, models.Metric{
+			Key:          "star_growth_rate",
+			Value:        growth.Rate(stars),
+			Unit:         "percent",
+			DisplayValue: fmt.Sprintf("%+.1f%%", growth.Rate(stars)),
+			Description:  "Stars gained relative to the star count at the start of the window",
+		})
+	}
+
+	// Commit Message Quality (sampled from the commits already fetched)
+	msgStats := analyzeCommitMessages(commits)
+	if msgStats.Sampled > 0 {
+		metrics = append(metrics, models.Metric{
+			Key:          "merge_commit_rate",
+			Value:        msgStats.MergeRate,
+			Unit:         "percent",
+			DisplayValue: fmt.Sprintf("%.0f%%", msgStats.MergeRate),
+			Description:  "Percentage of sampled commits that are merge commits",
+		})
+	}
+	if msgStats.Authored > 0 {
+		metrics = append(metrics,
+			models.Metric{
+				Key:          "short_commit_message_rate",
+				Value:        msgStats.ShortRate,
+				Unit:         "percent",
+				DisplayValue: fmt.Sprintf("%.0f%%", msgStats.ShortRate),
+				Description:  fmt.Sprintf("Non-merge commits with a subject under %d characters (sampled)", minCommitSubjectLength),
+			},
+			models.Metric{
+				Key:          "conventional_commit_rate",
+				Value:        msgStats.ConventionalRate,
+				Unit:         "percent",
+				DisplayValue: fmt.Sprintf("%.0f%%", msgStats.ConventionalRate),
+				Description:  "Non-merge commits using conventional-commit prefixes such as feat: or fix: (sampled)",
+			},
+		)
+	}
+
 	// Code Quality Metrics (from PR analysis)
 	if len(filteredPRs) > 0 {
 		var mergedPRs []*github.PullRequest
@@ -193,6 +309,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		var prsWithoutReview int
 		var totalReviewComments int
 		var prsWithSizeData int
+		var churnExcluded int
 
 		// Limit sample size to avoid excessive API calls
 		sampleLimit := 10
@@ -205,6 +322,13 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 			// filteredPRs now only contains merged PRs, no need to check MergedAt
 			mergedPRs = append(mergedPRs, pr)
 
+			// Additions and deletions are per PR, not per path, so vendored or generated
+			// code can only be left out by its PR's labels. Those PRs are not sampled at all.
+			if a.excludedFromChurn(pr) {
+				churnExcluded++
+				continue
+			}
+
 			// Only analyze a sample to respect rate limits
 			if analyzedCount < sampleLimit {
 				// Fetch full PR details for accurate metrics
@@ -243,12 +367,17 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 					churnRatio = 999.0 // Only additions, no deletions
 				}
 
+				description := "Ratio of code additions to deletions (sampled)"
+				if churnExcluded > 0 {
+					description = fmt.Sprintf("Ratio of code additions to deletions (sampled, %d PRs labeled %s excluded)",
+						churnExcluded, strings.Join(a.ChurnExcludeLabels, "/"))
+				}
 				metrics = append(metrics, models.Metric{
 					Key:          "code_churn_ratio",
 					Value:        churnRatio,
 					Unit:         "ratio",
 					DisplayValue: fmt.Sprintf("%.2f:1", churnRatio),
-					Description:  "Ratio of code additions to deletions (sampled)",
+					Description:  description,
 				})
 			}
 
@@ -307,49 +436,266 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		})
 	}
 
-	// Provide context in description about top authors
-	if len(topAuthors) > 0 {
-		// In the future, we can add a specific "finding" or metadata about who the top authors are.
-		// For now, we leave this calculated but unused to avoid an empty branch lint error.
-		_ = topAuthors
+	if a.ConventionalCommitThreshold > 0 && msgStats.Authored >= minCommitsForMessageFinding &&
+		msgStats.ConventionalRate < a.ConventionalCommitThreshold {
+		findings = append(findings, models.Finding{
+			Type:        "low_conventional_commits",
+			Severity:    models.SeverityLow,
+			Message:     fmt.Sprintf("Only %.0f%% of commits follow conventional-commit prefixes (threshold %.0f%%)", msgStats.ConventionalRate, a.ConventionalCommitThreshold),
+			Actionable:  true,
+			Remediation: "Adopt the conventional commits format (feat:, fix:, chore:, ...) and enforce it with a commit-msg hook or PR title check.",
+			Explanation: "Consistent commit prefixes make history easier to scan and allow changelogs and version bumps to be generated automatically.",
+			SuggestedActions: []string{
+				"Document the commit message format in CONTRIBUTING.md",
+				"Add a commitlint check to CI",
+			},
+		})
+	}
+
+	// Only flag a decline once there were enough contributors for the drop to mean something
+	if hasGrowth && contributorGrowth < -50 && len(firstHalf) >= minContributorsForDecline {
+		findings = append(findings, models.Finding{
+			Type:        "contributor_decline",
+			Severity:    models.SeverityMedium,
+			Message:     fmt.Sprintf("Active contributors dropped from %d to %d (%.0f%%) between halves of the window", len(firstHalf), len(secondHalf), contributorGrowth),
+			Actionable:  true,
+			Remediation: "Check whether maintainers have moved on and whether contributions are still being reviewed.",
+			Explanation: "A sharp drop in active contributors often signals a project losing momentum, with knowledge concentrating in fewer people.",
+			SuggestedActions: []string{
+				"Reach out to recently inactive contributors",
+				"Label good first issues to attract new contributors",
+			},
+		})
+	}
+
+	if hasStarGrowth && growth.Gained >= minStarsForRapidGrowth && growth.Rate(stars) >= rapidStarGrowthRate {
+		findings = append(findings, models.Finding{
+			Type:        "rapid_star_growth",
+			Severity:    models.SeverityInfo,
+			Message:     fmt.Sprintf("Gained %d stars (%+.0f%%) in the time window", growth.Gained, growth.Rate(stars)),
+			Explanation: "Fast star growth usually brings new users, issues and first-time contributors.",
+			Remediation: "Make sure issue templates, CONTRIBUTING.md and good first issues are ready for newcomers.",
+		})
+	} else if hasStarGrowth && growth.Gained == 0 && stars >= minStarsForStagnation {
+		findings = append(findings, models.Finding{
+			Type:        "star_growth_stalled",
+			Severity:    models.SeverityInfo,
+			Message:     fmt.Sprintf("No new stars in the time window (%d total)", stars),
+			Explanation: "An established repository gaining no stars may be losing visibility or relevance.",
+		})
+	} else if !hasStarGrowth && stars > maxStargazerPage*100 && cfg.DepthConfig.MaxStargazerPages > 0 {
+		findings = append(findings, models.Finding{
+			Type:     "star_growth_unavailable",
+			Severity: models.SeverityInfo,
+			Message:  fmt.Sprintf("Star growth not measured: the API only lists the first %d stargazers (%d total)", maxStargazerPage*100, stars),
+		})
+	}
+
+	if len(contributors) > topContributorsLimit {
+		contributors = contributors[:topContributorsLimit]
 	}
 
 	return models.AnalyzerResult{
-		Name:     a.Name(),
-		Metrics:  metrics,
-		Findings: findings,
+		Name:            a.Name(),
+		Metrics:         metrics,
+		Findings:        findings,
+		TopContributors: contributors,
 	}, nil
 }
 
-func calculateBusFactor(counts map[string]int, total int) (int, []string) {
-	if total == 0 {
-		return 0, nil
+// topContributorsLimit is how many of the most active authors the result lists
+const topContributorsLimit = 5
+
+const (
+	// commitMessageSampleSize caps how many of the most recent commits are inspected
+	commitMessageSampleSize = 200
+	// minCommitSubjectLength is the subject length below which a message counts as too short
+	minCommitSubjectLength = 10
+	// minCommitsForMessageFinding avoids judging commit discipline on a handful of commits
+	minCommitsForMessageFinding = 10
+)
+
+var conventionalCommitPattern = regexp.MustCompile(`^(feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\([^)]*\))?!?: \S`)
+
+// commitMessageStats summarizes commit message quality over a sample of commits.
+// Short and conventional rates are computed over non-merge commits only, since
+// merge messages are generated by git or GitHub.
+type commitMessageStats struct {
+	Sampled          int
+	Authored         int // non-merge commits in the sample
+	MergeRate        float64
+	ShortRate        float64
+	ConventionalRate float64
+}
+
+func analyzeCommitMessages(commits []*github.RepositoryCommit) commitMessageStats {
+	var stats commitMessageStats
+	var merges, short, conventional int
+
+	for _, c := range commits {
+		if stats.Sampled == commitMessageSampleSize {
+			break
+		}
+		if c.Commit == nil {
+			continue
+		}
+		stats.Sampled++
+
+		subject := strings.TrimSpace(strings.SplitN(c.Commit.GetMessage(), "\n", 2)[0])
+		if len(c.Parents) > 1 || strings.HasPrefix(subject, "Merge pull request ") || strings.HasPrefix(subject, "Merge branch ") {
+			merges++
+			continue
+		}
+
+		stats.Authored++
+		if len(subject) < minCommitSubjectLength {
+			short++
+		}
+		if conventionalCommitPattern.MatchString(subject) {
+			conventional++
+		}
+	}
+
+	if stats.Sampled > 0 {
+		stats.MergeRate = float64(merges) / float64(stats.Sampled) * 100
 	}
+	if stats.Authored > 0 {
+		stats.ShortRate = float64(short) / float64(stats.Authored) * 100
+		stats.ConventionalRate = float64(conventional) / float64(stats.Authored) * 100
+	}
+	return stats
+}
+
+// minContributorsForDecline is the first-half contributor count below which
+// a drop is too small to report as a decline
+const minContributorsForDecline = 3
 
-	type authorCount struct {
-		Name  string
-		Count int
+// calculateContributorGrowth returns the percentage change in contributors between
+// the two halves of the window. It reports false when the first half had none.
+func calculateContributorGrowth(first, second int) (float64, bool) {
+	if first == 0 {
+		return 0, false
 	}
-	var sorted []authorCount
-	for k, v := range counts {
-		sorted = append(sorted, authorCount{k, v})
+	return float64(second-first) / float64(first) * 100, true
+}
+
+const (
+	// maxStargazerPage is the last stargazer page the API serves (40,000 stars); newer stars
+	// of larger repositories cannot be reached, so their growth is not reported
+	maxStargazerPage = 400
+	// minStarsForRapidGrowth and rapidStarGrowthRate gate the rapid_star_growth finding
+	minStarsForRapidGrowth = 20
+	rapidStarGrowthRate    = 10.0
+	// minStarsForStagnation keeps the stalled finding to repositories with an audience
+	minStarsForStagnation = 100
+)
+
+// starGrowth is the number of stars gained within the lookback window
+type starGrowth struct {
+	Gained  int
+	Sampled bool // Gained was extrapolated from the most recent pages only
+}
+
+// Rate returns the gain relative to the star count at the start of the window
+func (g starGrowth) Rate(stars int) float64 {
+	before := stars - g.Gained
+	if before < 1 {
+		before = 1
 	}
+	return float64(g.Gained) / float64(before) * 100
+}
 
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Count > sorted[j].Count
+// fetchStarGrowth walks the stargazer list backwards from its newest page (the API lists
+// the oldest stars first) until it reaches a star older than since. If maxPages full pages
+// run out first, the gain is extrapolated from the time span the fetched stars cover; a
+// partial newest page holds too few stars to extrapolate from, so it is fetched on top.
+func fetchStarGrowth(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, stars int, since time.Time, maxPages int) (starGrowth, bool) {
+	if stars == 0 || maxPages <= 0 {
+		return starGrowth{}, false
+	}
+	lastPage := (stars + 99) / 100
+	if lastPage > maxStargazerPage {
+		return starGrowth{}, false
+	}
+	if stars%100 != 0 {
+		maxPages++
+	}
+
+	gained := 0
+	var oldest time.Time
+	for page := lastPage; page >= 1 && lastPage-page < maxPages; page-- {
+		stargazers, err := client.ListStargazers(ctx, repo.Owner, repo.Name, &github.ListOptions{Page: page, PerPage: 100})
+		if err != nil {
+			return starGrowth{}, false
+		}
+		for _, sg := range stargazers {
+			starredAt := sg.GetStarredAt().Time
+			if starredAt.After(since) {
+				gained++
+			}
+			if oldest.IsZero() || starredAt.Before(oldest) {
+				oldest = starredAt
+			}
+		}
+		if page == 1 || (!oldest.IsZero() && !oldest.After(since)) {
+			return starGrowth{Gained: gained}, true
+		}
+	}
+
+	// Every fetched star is inside the window: scale the sampled rate to the whole window
+	span := time.Since(oldest)
+	if oldest.IsZero() || span <= 0 {
+		return starGrowth{Gained: gained}, true
+	}
+	estimate := int(float64(gained)*time.Since(since).Hours()/span.Hours() + 0.5)
+	if estimate > stars {
+		estimate = stars
+	}
+	return starGrowth{Gained: estimate, Sampled: true}, true
+}
+
+// calculateBusFactor returns how many authors account for half of the commits, along
+// with every author ranked by commit count (ties by name) and their share of commits
+func calculateBusFactor(counts map[string]int, total int) (int, []models.Contributor) {
+	if total == 0 {
+		return 0, nil
+	}
+
+	ranked := make([]models.Contributor, 0, len(counts))
+	for name, count := range counts {
+		ranked = append(ranked, models.Contributor{
+			Name:    name,
+			Commits: count,
+			Percent: float64(count) / float64(total) * 100,
+		})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Commits != ranked[j].Commits {
+			return ranked[i].Commits > ranked[j].Commits
+		}
+		return ranked[i].Name < ranked[j].Name
 	})
 
 	accumulated := 0
 	busFactor := 0
-	var topAuthors []string
-
-	for _, ac := range sorted {
-		accumulated += ac.Count
+	for _, c := range ranked {
+		accumulated += c.Commits
 		busFactor++
-		topAuthors = append(topAuthors, ac.Name)
 		if float64(accumulated)/float64(total) >= 0.5 {
 			break
 		}
 	}
-	return busFactor, topAuthors
+	return busFactor, ranked
+}
+
+// excludedFromChurn reports whether pr carries one of the ChurnExcludeLabels
+func (a *Analyzer) excludedFromChurn(pr *github.PullRequest) bool {
+	for _, label := range pr.Labels {
+		for _, excluded := range a.ChurnExcludeLabels {
+			if strings.EqualFold(label.GetName(), excluded) {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -0,0 +1,347 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// commitClient serves a fixed commit list. Methods not overridden panic via the nil embedded interface.
+type commitClient struct {
+	analysis.Client
+	commits []*github.RepositoryCommit
+	ref     string // ref the commits were last listed on
+}
+
+func (c *commitClient) GetRepoOverview(ctx context.Context, owner, repo string) (*analysis.RepoOverview, error) {
+	return &analysis.RepoOverview{}, nil
+}
+
+func (c *commitClient) GetPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error) {
+	return nil, nil
+}
+
+func (c *commitClient) ListCommitsSince(ctx context.Context, owner, repo, ref string, since time.Time) ([]*github.RepositoryCommit, error) {
+	c.ref = ref
+	return c.commits, nil
+}
+
+func commitBy(author string, at time.Time) *github.RepositoryCommit {
+	return &github.RepositoryCommit{
+		Author: &github.User{Login: github.String(author)},
+		Commit: &github.Commit{Author: &github.CommitAuthor{Date: &github.Timestamp{Time: at}}},
+	}
+}
+
+func metricValue(result models.AnalyzerResult, key string) (float64, bool) {
+	for _, m := range result.Metrics {
+		if m.Key == key {
+			return m.Value, true
+		}
+	}
+	return 0, false
+}
+
+func TestAnalyzeContributorTrend(t *testing.T) {
+	now := time.Now()
+	since := now.Add(-30 * 24 * time.Hour)
+
+	var commits []*github.RepositoryCommit
+	for i := 0; i < 4; i++ {
+		commits = append(commits, commitBy(fmt.Sprintf("early-%d", i), now.Add(-25*24*time.Hour)))
+	}
+	commits = append(commits, commitBy("early-0", now.Add(-2*24*time.Hour)))
+
+	result, err := New(50).Analyze(context.Background(), &commitClient{commits: commits},
+		analysis.TargetRepository{Owner: "o", Name: "r"}, analysis.Config{Since: since})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if v, _ := metricValue(result, "contributors_first_half"); v != 4 {
+		t.Errorf("Expected 4 contributors in first half, got %.0f", v)
+	}
+	if v, _ := metricValue(result, "contributors_second_half"); v != 1 {
+		t.Errorf("Expected 1 contributor in second half, got %.0f", v)
+	}
+	if v, ok := metricValue(result, "contributor_growth"); !ok || v != -75 {
+		t.Errorf("Expected contributor_growth -75%%, got %.0f (present=%v)", v, ok)
+	}
+
+	found := false
+	for _, f := range result.Findings {
+		if f.Type == "contributor_decline" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected contributor_decline finding")
+	}
+}
+
+func TestCalculateContributorGrowth(t *testing.T) {
+	tests := []struct {
+		first, second int
+		want          float64
+		ok            bool
+	}{
+		{0, 3, 0, false},
+		{4, 2, -50, true},
+		{2, 5, 150, true},
+		{3, 3, 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := calculateContributorGrowth(tt.first, tt.second)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("calculateContributorGrowth(%d, %d) = %.0f, %v; want %.0f, %v", tt.first, tt.second, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func commitWithMessage(msg string, parents int) *github.RepositoryCommit {
+	c := &github.RepositoryCommit{Commit: &github.Commit{Message: github.String(msg)}}
+	for i := 0; i < parents; i++ {
+		c.Parents = append(c.Parents, &github.Commit{})
+	}
+	return c
+}
+
+func TestAnalyzeCommitMessages(t *testing.T) {
+	commits := []*github.RepositoryCommit{
+		commitWithMessage("feat(cli): add trend command", 1),
+		commitWithMessage("fix!: drop legacy flag\n\nBREAKING CHANGE: removed", 1),
+		commitWithMessage("wip", 1),
+		commitWithMessage("Update README with install steps", 1),
+		commitWithMessage("Merge pull request #12 from owner/branch", 2),
+		commitWithMessage("Merge branch 'main' into feature", 1),
+	}
+
+	stats := analyzeCommitMessages(commits)
+	if stats.Sampled != 6 || stats.Authored != 4 {
+		t.Fatalf("Expected 6 sampled and 4 authored commits, got %+v", stats)
+	}
+	if stats.ConventionalRate != 50 {
+		t.Errorf("Expected 50%% conventional, got %.0f", stats.ConventionalRate)
+	}
+	if stats.ShortRate != 25 {
+		t.Errorf("Expected 25%% short, got %.0f", stats.ShortRate)
+	}
+	if fmt.Sprintf("%.1f", stats.MergeRate) != "33.3" {
+		t.Errorf("Expected 33.3%% merges, got %.1f", stats.MergeRate)
+	}
+}
+
+func TestAnalyzeFlagsLowConventionalCommits(t *testing.T) {
+	var commits []*github.RepositoryCommit
+	for i := 0; i < 12; i++ {
+		commits = append(commits, commitWithMessage("Tweak things around", 1))
+	}
+	cfg := analysis.Config{Since: time.Now().Add(-30 * 24 * time.Hour)}
+	repo := analysis.TargetRepository{Owner: "o", Name: "r"}
+
+	hasFinding := func(result models.AnalyzerResult) bool {
+		for _, f := range result.Findings {
+			if f.Type == "low_conventional_commits" {
+				return true
+			}
+		}
+		return false
+	}
+
+	result, err := New(50).Analyze(context.Background(), &commitClient{commits: commits}, repo, cfg)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if !hasFinding(result) {
+		t.Error("Expected low_conventional_commits finding below threshold")
+	}
+
+	result, _ = New(0).Analyze(context.Background(), &commitClient{commits: commits}, repo, cfg)
+	if hasFinding(result) {
+		t.Error("Expected no finding when the threshold is disabled")
+	}
+}
+
+// stargazerClient serves stargazers oldest first, 100 per page, like the API
+type stargazerClient struct {
+	analysis.Client
+	starredAt []time.Time
+	requested []int
+}
+
+func (c *stargazerClient) ListStargazers(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Stargazer, error) {
+	c.requested = append(c.requested, opts.Page)
+	var page []*github.Stargazer
+	for i := (opts.Page - 1) * 100; i < opts.Page*100 && i < len(c.starredAt); i++ {
+		page = append(page, &github.Stargazer{StarredAt: &github.Timestamp{Time: c.starredAt[i]}})
+	}
+	return page, nil
+}
+
+// starsEvery returns count star times spaced by interval, oldest first, ending now
+func starsEvery(count int, interval time.Duration) []time.Time {
+	now := time.Now()
+	times := make([]time.Time, count)
+	for i := range times {
+		times[i] = now.Add(-time.Duration(count-i) * interval)
+	}
+	return times
+}
+
+func TestFetchStarGrowth(t *testing.T) {
+	repo := analysis.TargetRepository{Owner: "o", Name: "r"}
+	since := time.Now().Add(-30 * 24 * time.Hour)
+
+	// 250 stars, one a day: the last 30 days are within the newest page
+	client := &stargazerClient{starredAt: starsEvery(250, 24*time.Hour)}
+	growth, ok := fetchStarGrowth(context.Background(), client, repo, 250, since, 3)
+	if !ok || growth.Sampled || growth.Gained != 30 {
+		t.Errorf("Expected exact gain of 30, got %+v (ok=%v)", growth, ok)
+	}
+	if len(client.requested) != 1 || client.requested[0] != 3 {
+		t.Errorf("Expected only the newest page to be fetched, got %v", client.requested)
+	}
+
+	// 1000 stars, one an hour: 720 fall in the window but only 2 pages may be fetched
+	client = &stargazerClient{starredAt: starsEvery(1000, time.Hour)}
+	growth, ok = fetchStarGrowth(context.Background(), client, repo, 1000, since, 2)
+	if !ok || !growth.Sampled || growth.Gained < 700 || growth.Gained > 740 {
+		t.Errorf("Expected a sampled gain near 720, got %+v (ok=%v)", growth, ok)
+	}
+
+	// 1005 stars: the 5 on the partial newest page do not use up the page budget
+	client = &stargazerClient{starredAt: starsEvery(1005, time.Hour)}
+	growth, ok = fetchStarGrowth(context.Background(), client, repo, 1005, since, 1)
+	if !ok || !growth.Sampled || growth.Gained < 700 || growth.Gained > 740 {
+		t.Errorf("Expected a sampled gain near 720, got %+v (ok=%v)", growth, ok)
+	}
+	if len(client.requested) != 2 || client.requested[0] != 11 || client.requested[1] != 10 {
+		t.Errorf("Expected the partial page plus one full page, got %v", client.requested)
+	}
+
+	// 250 stars, one a minute: the extrapolation never exceeds the star count
+	client = &stargazerClient{starredAt: starsEvery(250, time.Minute)}
+	growth, ok = fetchStarGrowth(context.Background(), client, repo, 250, since, 1)
+	if !ok || !growth.Sampled || growth.Gained != 250 {
+		t.Errorf("Expected the estimate to be capped at 250 stars, got %+v (ok=%v)", growth, ok)
+	}
+
+	if _, ok := fetchStarGrowth(context.Background(), client, repo, 50000, since, 3); ok {
+		t.Error("Expected repositories beyond the stargazer pagination limit to be skipped")
+	}
+	if _, ok := fetchStarGrowth(context.Background(), client, repo, 0, since, 3); ok {
+		t.Error("Expected repositories without stars to be skipped")
+	}
+}
+
+func TestStarGrowthRate(t *testing.T) {
+	if rate := (starGrowth{Gained: 20}).Rate(220); rate != 10 {
+		t.Errorf("Expected 10%% growth, got %.1f", rate)
+	}
+	if rate := (starGrowth{Gained: 5}).Rate(5); rate != 500 {
+		t.Errorf("Expected growth from zero to be measured against one star, got %.1f", rate)
+	}
+}
+
+func TestAnalyzeListsCommitsOnRef(t *testing.T) {
+	client := &commitClient{commits: []*github.RepositoryCommit{commitBy("dev", time.Now())}}
+	_, err := New(50).Analyze(context.Background(), client,
+		analysis.TargetRepository{Owner: "o", Name: "r", Ref: "develop"}, analysis.Config{Since: time.Now().Add(-24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if client.ref != "develop" {
+		t.Errorf("Expected commits listed on develop, got %q", client.ref)
+	}
+}
+
+// churnClient serves merged PRs with their size; reviews are empty
+type churnClient struct {
+	commitClient
+	prs     []*github.PullRequest
+	sizes   map[int][2]int // PR number -> additions, deletions
+	fetched []int
+}
+
+func (c *churnClient) GetPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error) {
+	return c.prs, nil
+}
+
+func (c *churnClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error) {
+	c.fetched = append(c.fetched, number)
+	size := c.sizes[number]
+	return &github.PullRequest{Number: github.Int(number), Additions: github.Int(size[0]), Deletions: github.Int(size[1])}, nil
+}
+
+func (c *churnClient) GetReviews(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, error) {
+	return nil, nil
+}
+
+func TestAnalyzeChurnExcludesLabeledPRs(t *testing.T) {
+	merged := &github.Timestamp{Time: time.Now().Add(-time.Hour)}
+	client := &churnClient{
+		prs: []*github.PullRequest{
+			{Number: github.Int(1), MergedAt: merged},
+			{Number: github.Int(2), MergedAt: merged, Labels: []*github.Label{{Name: github.String("Dependencies")}}},
+		},
+		sizes: map[int][2]int{1: {200, 100}, 2: {50000, 10}},
+	}
+	repo := analysis.TargetRepository{Owner: "o", Name: "r"}
+	cfg := analysis.Config{Since: time.Now().Add(-24 * time.Hour)}
+
+	result, err := New(50).Analyze(context.Background(), client, repo, cfg)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if churn, _ := metricValue(result, "code_churn_ratio"); churn != 2 {
+		t.Errorf("Expected the dependencies PR to be left out of churn (2:1), got %.2f", churn)
+	}
+	if len(client.fetched) != 1 || client.fetched[0] != 1 {
+		t.Errorf("Expected only the unlabeled PR to be fetched, got %v", client.fetched)
+	}
+
+	analyzer := New(50)
+	analyzer.ChurnExcludeLabels = []string{"generated"}
+	result, _ = analyzer.Analyze(context.Background(), client, repo, cfg)
+	if churn, _ := metricValue(result, "code_churn_ratio"); churn < 400 {
+		t.Errorf("Expected configured labels to replace the defaults, got %.2f", churn)
+	}
+}
+
+func TestCalculateBusFactor(t *testing.T) {
+	busFactor, ranked := calculateBusFactor(map[string]int{"carol": 2, "alice": 5, "bob": 2, "dave": 1}, 10)
+	if busFactor != 1 {
+		t.Errorf("Expected alice alone to cover half the commits, got bus factor %d", busFactor)
+	}
+	want := []models.Contributor{{Name: "alice", Commits: 5, Percent: 50}, {Name: "bob", Commits: 2, Percent: 20}, {Name: "carol", Commits: 2, Percent: 20}, {Name: "dave", Commits: 1, Percent: 10}}
+	if fmt.Sprint(ranked) != fmt.Sprint(want) {
+		t.Errorf("Expected contributors ranked by commits then name, got %v", ranked)
+	}
+
+	if busFactor, ranked := calculateBusFactor(nil, 0); busFactor != 0 || ranked != nil {
+		t.Errorf("Expected nothing without commits, got %d %v", busFactor, ranked)
+	}
+}
+
+func TestAnalyzeListsTopContributors(t *testing.T) {
+	var commits []*github.RepositoryCommit
+	for i, author := range []string{"a", "b", "c", "d", "e", "f", "a"} {
+		commits = append(commits, commitBy(author, time.Now().Add(-time.Duration(i)*time.Hour)))
+	}
+	result, err := New(0).Analyze(context.Background(), &commitClient{commits: commits},
+		analysis.TargetRepository{Owner: "o", Name: "r"}, analysis.Config{Since: time.Now().Add(-24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(result.TopContributors) != topContributorsLimit {
+		t.Fatalf("Expected the top %d contributors, got %v", topContributorsLimit, result.TopContributors)
+	}
+	if top := result.TopContributors[0]; top.Name != "a" || top.Commits != 2 {
+		t.Errorf("Expected a with 2 commits first, got %+v", top)
+	}
+}
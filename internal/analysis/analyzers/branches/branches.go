@@ -3,6 +3,8 @@ package branches
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v60/github"
@@ -10,13 +12,22 @@ import (
 	"github.com/mikematt33/gh-inspect/pkg/models"
 )
 
+// minNamingCompliance is the branch naming compliance (%) below which a finding is reported
+const minNamingCompliance = 80
+
+// minBranchesForNaming is the number of non-default branches below which naming is not flagged
+const minBranchesForNaming = 5
+
 type Analyzer struct {
-	StaleThresholdDays int
+	StaleThresholdDays         int
+	DivergenceThresholdCommits int
+	NamingPatterns             []string // regexes for branch names, e.g. ^feature/; empty disables the check
 }
 
-func New(staleThresholdDays int) *Analyzer {
+func New(staleThresholdDays, divergenceThresholdCommits int) *Analyzer {
 	return &Analyzer{
-		StaleThresholdDays: staleThresholdDays,
+		StaleThresholdDays:         staleThresholdDays,
+		DivergenceThresholdCommits: divergenceThresholdCommits,
 	}
 }
 
@@ -24,6 +35,11 @@ func (a *Analyzer) Name() string {
 	return "branches"
 }
 
+func (a *Analyzer) EstimatedCost(cfg analysis.Config) int {
+	// Repository, branch list, and one compare per stale branch
+	return 2 + cfg.DepthConfig.MaxBranchCompares
+}
+
 func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
 	var metrics []models.Metric
 	var findings []models.Finding
@@ -35,6 +51,15 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 	}
 	defaultBranch := repoInfo.GetDefaultBranch()
 
+	namingPatterns := make([]*regexp.Regexp, 0, len(a.NamingPatterns))
+	for _, p := range a.NamingPatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return models.AnalyzerResult{Name: a.Name()}, fmt.Errorf("invalid branch naming pattern %q: %w", p, err)
+		}
+		namingPatterns = append(namingPatterns, re)
+	}
+
 	// List all branches
 	opts := &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 100}}
 	branches, _, err := client.GetUnderlyingClient().Repositories.ListBranches(ctx, repo.Owner, repo.Name, opts)
@@ -44,6 +69,9 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 
 	totalBranches := len(branches)
 	staleBranches := 0
+	var staleNames []string
+	var namedBranches int
+	var misnamed []string
 	now := time.Now()
 
 	// Check each branch for staleness
@@ -54,6 +82,14 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 			continue
 		}
 
+		if len(namingPatterns) > 0 {
+			if matchesAny(namingPatterns, branch.GetName()) {
+				namedBranches++
+			} else {
+				misnamed = append(misnamed, branch.GetName())
+			}
+		}
+
 		// Branch list includes commit info - use it directly instead of separate API call
 		if branch.Commit != nil && branch.Commit.Commit != nil && branch.Commit.Commit.Author != nil {
 			lastCommitDate := branch.Commit.Commit.Author.GetDate()
@@ -61,6 +97,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 
 			if int(daysSinceUpdate) > a.StaleThresholdDays {
 				staleBranches++
+				staleNames = append(staleNames, branch.GetName())
 			}
 		}
 	}
@@ -78,6 +115,79 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		Description:  fmt.Sprintf("Branches inactive > %d days", a.StaleThresholdDays),
 	})
 
+	if checked := namedBranches + len(misnamed); checked > 0 {
+		compliance := float64(namedBranches) / float64(checked) * 100
+		metrics = append(metrics, models.Metric{
+			Key:          "branch_naming_compliance",
+			Value:        compliance,
+			Unit:         "%",
+			DisplayValue: fmt.Sprintf("%.0f%%", compliance),
+			Description:  fmt.Sprintf("Non-default branches matching the naming patterns (%d of %d)", namedBranches, checked),
+		})
+
+		if checked >= minBranchesForNaming && compliance < minNamingCompliance {
+			examples := misnamed
+			if len(examples) > 3 {
+				examples = examples[:3]
+			}
+			findings = append(findings, models.Finding{
+				Type:        "branch_naming_noncompliant",
+				Severity:    models.SeverityLow,
+				Message:     fmt.Sprintf("Only %.0f%% of branches follow the naming convention; %d do not (e.g. %s)", compliance, len(misnamed), strings.Join(examples, ", ")),
+				Actionable:  true,
+				Remediation: "Rename branches to match the convention, or enforce it with a branch naming rule in repository rulesets.",
+			})
+		}
+	}
+
+	// Compare stale branches against the default branch to see how far they've diverged.
+	// Capped by depth config since each comparison is a separate API call.
+	maxCompares := cfg.DepthConfig.MaxBranchCompares
+	if len(staleNames) < maxCompares {
+		maxCompares = len(staleNames)
+	}
+	var totalDivergence, compared, fullyMerged int
+	for _, name := range staleNames[:maxCompares] {
+		comparison, _, err := client.GetUnderlyingClient().Repositories.CompareCommits(ctx, repo.Owner, repo.Name, defaultBranch, name, &github.ListOptions{PerPage: 1})
+		if err != nil {
+			continue
+		}
+		ahead, behind := comparison.GetAheadBy(), comparison.GetBehindBy()
+		compared++
+		totalDivergence += ahead + behind
+		if ahead == 0 {
+			fullyMerged++
+		}
+
+		if a.DivergenceThresholdCommits > 0 && behind > a.DivergenceThresholdCommits {
+			findings = append(findings, models.Finding{
+				Type:        "diverged_branch",
+				Severity:    models.SeverityLow,
+				Message:     fmt.Sprintf("Branch %s is %d commits behind %s (%d ahead)", name, behind, defaultBranch, ahead),
+				Location:    branchLocation(repo, name),
+				Actionable:  true,
+				Remediation: "Rebase the branch onto the default branch or delete it if the work is abandoned.",
+			})
+		}
+	}
+
+	if compared > 0 {
+		avgDivergence := float64(totalDivergence) / float64(compared)
+		metrics = append(metrics, models.Metric{
+			Key:          "avg_branch_divergence",
+			Value:        avgDivergence,
+			Unit:         "commits",
+			DisplayValue: fmt.Sprintf("%.1f commits", avgDivergence),
+			Description:  fmt.Sprintf("Average commits ahead + behind %s across %d stale branches", defaultBranch, compared),
+		})
+		metrics = append(metrics, models.Metric{
+			Key:          "stale_branches_fully_merged",
+			Value:        float64(fullyMerged),
+			DisplayValue: fmt.Sprintf("%d", fullyMerged),
+			Description:  "Stale branches with no commits ahead of the default branch (safe to delete)",
+		})
+	}
+
 	// Findings
 	if totalBranches > 50 {
 		findings = append(findings, models.Finding{
@@ -105,3 +215,20 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		Findings: findings,
 	}, nil
 }
+
+func matchesAny(patterns []*regexp.Regexp, name string) bool {
+	for _, re := range patterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// branchLocation links to a branch in the web UI, or names it when the repository URL is unknown
+func branchLocation(repo analysis.TargetRepository, name string) string {
+	if repo.URL == "" {
+		return name
+	}
+	return fmt.Sprintf("%s/tree/%s", repo.URL, name)
+}
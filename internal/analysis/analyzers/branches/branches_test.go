@@ -0,0 +1,203 @@
+package branches
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// stubClient serves a fixed branch list and per-branch comparisons against main
+// through the underlying client
+type stubClient struct {
+	analysis.Client
+	api *github.Client
+
+	compares []string
+}
+
+// testBranch is a branch whose last commit is age old, ahead/behind main
+type testBranch struct {
+	name          string
+	age           time.Duration
+	ahead, behind int
+}
+
+func newStubClient(t *testing.T, branches []testBranch) *stubClient {
+	c := &stubClient{}
+	byName := make(map[string]testBranch, len(branches))
+	for _, b := range branches {
+		byName[b.name] = b
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/branches", func(w http.ResponseWriter, r *http.Request) {
+		list := make([]*github.Branch, 0, len(branches))
+		for _, b := range branches {
+			date := github.Timestamp{Time: time.Now().Add(-b.age)}
+			list = append(list, &github.Branch{
+				Name:   github.String(b.name),
+				Commit: &github.RepositoryCommit{Commit: &github.Commit{Author: &github.CommitAuthor{Date: &date}}},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	})
+	mux.HandleFunc("/repos/o/r/compare/", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/repos/o/r/compare/main...")
+		c.compares = append(c.compares, name)
+		b := byName[name]
+		_ = json.NewEncoder(w).Encode(&github.CommitsComparison{AheadBy: github.Int(b.ahead), BehindBy: github.Int(b.behind)})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c.api = github.NewClient(nil)
+	c.api.BaseURL, _ = url.Parse(srv.URL + "/")
+	return c
+}
+
+func (c *stubClient) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, error) {
+	return &github.Repository{DefaultBranch: github.String("main")}, nil
+}
+
+func (c *stubClient) GetUnderlyingClient() *github.Client {
+	return c.api
+}
+
+const day = 24 * time.Hour
+
+func analyze(t *testing.T, a *Analyzer, client *stubClient, maxCompares int) models.AnalyzerResult {
+	t.Helper()
+	repo := analysis.TargetRepository{Owner: "o", Name: "r", URL: "https://github.example.com/o/r"}
+	cfg := analysis.Config{DepthConfig: analysis.DepthConfig{MaxBranchCompares: maxCompares}}
+	res, err := a.Analyze(context.Background(), client, repo, cfg)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	return res
+}
+
+func metricValues(res models.AnalyzerResult) map[string]float64 {
+	values := make(map[string]float64)
+	for _, m := range res.Metrics {
+		values[m.Key] = m.Value
+	}
+	return values
+}
+
+func findingsOfType(res models.AnalyzerResult, typ string) []models.Finding {
+	var found []models.Finding
+	for _, f := range res.Findings {
+		if f.Type == typ {
+			found = append(found, f)
+		}
+	}
+	return found
+}
+
+func TestAnalyzeStaleBranches(t *testing.T) {
+	branches := []testBranch{{name: "main", age: 400 * day}, {name: "fresh", age: 2 * day}}
+	for i := 0; i < 11; i++ {
+		branches = append(branches, testBranch{name: "old-" + string(rune('a'+i)), age: 120 * day})
+	}
+	res := analyze(t, New(90, 0), newStubClient(t, branches), 0)
+
+	metrics := metricValues(res)
+	if metrics["total_branches"] != 13 {
+		t.Errorf("Expected 13 branches, got %v", metrics["total_branches"])
+	}
+	// The default branch is never stale, however old its last commit
+	if metrics["stale_branches"] != 11 {
+		t.Errorf("Expected 11 stale branches, got %v", metrics["stale_branches"])
+	}
+	if len(findingsOfType(res, "stale_branches")) != 1 {
+		t.Errorf("Expected a stale_branches finding, got %+v", res.Findings)
+	}
+}
+
+func TestAnalyzeDivergence(t *testing.T) {
+	client := newStubClient(t, []testBranch{
+		{name: "main"},
+		{name: "merged", age: 100 * day, ahead: 0, behind: 10},
+		{name: "diverged", age: 100 * day, ahead: 3, behind: 80},
+		{name: "uncompared", age: 100 * day, ahead: 1, behind: 500},
+		{name: "active", age: day, ahead: 2, behind: 200},
+	})
+	res := analyze(t, New(90, 50), client, 2)
+
+	if len(client.compares) != 2 {
+		t.Fatalf("Expected compares capped at 2 stale branches, got %v", client.compares)
+	}
+	metrics := metricValues(res)
+	if metrics["avg_branch_divergence"] != 46.5 {
+		t.Errorf("Expected average divergence 46.5, got %v", metrics["avg_branch_divergence"])
+	}
+	if metrics["stale_branches_fully_merged"] != 1 {
+		t.Errorf("Expected 1 fully merged stale branch, got %v", metrics["stale_branches_fully_merged"])
+	}
+
+	diverged := findingsOfType(res, "diverged_branch")
+	if len(diverged) != 1 {
+		t.Fatalf("Expected 1 diverged_branch finding, got %+v", res.Findings)
+	}
+	if want := "https://github.example.com/o/r/tree/diverged"; diverged[0].Location != want {
+		t.Errorf("Expected location %q, got %q", want, diverged[0].Location)
+	}
+}
+
+func TestAnalyzeNamingCompliance(t *testing.T) {
+	tests := []struct {
+		name           string
+		branches       []string
+		wantCompliance float64
+		wantFinding    bool
+	}{
+		{"compliant", []string{"feature/a", "feature/b", "fix/c", "fix/d", "feature/e"}, 100, false},
+		{"noncompliant", []string{"feature/a", "wip", "tmp", "test", "old"}, 20, true},
+		{"too few branches to flag", []string{"wip", "tmp"}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			branches := []testBranch{{name: "main"}}
+			for _, name := range tt.branches {
+				branches = append(branches, testBranch{name: name, age: day})
+			}
+			a := New(90, 0)
+			a.NamingPatterns = []string{"^feature/", "^fix/"}
+			res := analyze(t, a, newStubClient(t, branches), 0)
+
+			metrics := metricValues(res)
+			if got, ok := metrics["branch_naming_compliance"]; !ok || got != tt.wantCompliance {
+				t.Errorf("Expected compliance %v, got %v (present=%v)", tt.wantCompliance, got, ok)
+			}
+			if got := len(findingsOfType(res, "branch_naming_noncompliant")) == 1; got != tt.wantFinding {
+				t.Errorf("Expected naming finding %v, got %+v", tt.wantFinding, res.Findings)
+			}
+		})
+	}
+}
+
+func TestAnalyzeNamingDisabled(t *testing.T) {
+	res := analyze(t, New(90, 0), newStubClient(t, []testBranch{{name: "main"}, {name: "wip"}}), 0)
+	if _, ok := metricValues(res)["branch_naming_compliance"]; ok {
+		t.Error("Expected no naming compliance metric without patterns")
+	}
+}
+
+func TestAnalyzeInvalidNamingPattern(t *testing.T) {
+	a := New(90, 0)
+	a.NamingPatterns = []string{"feature/("}
+	_, err := a.Analyze(context.Background(), newStubClient(t, nil), analysis.TargetRepository{Owner: "o", Name: "r"}, analysis.Config{})
+	if err == nil || !strings.Contains(err.Error(), "invalid branch naming pattern") {
+		t.Errorf("Expected an invalid pattern error, got %v", err)
+	}
+}
@@ -21,6 +21,11 @@ func (a *Analyzer) Name() string {
 	return "ci"
 }
 
+func (a *Analyzer) EstimatedCost(cfg analysis.Config) int {
+	// One request for the total count, then run pages within the window
+	return 1 + analysis.Pages(cfg.DepthConfig.MaxWorkflowRuns)
+}
+
 func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
 	result := models.AnalyzerResult{Name: "ci"}
 
@@ -81,6 +86,10 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		opts.Page = resp.NextPage
 	}
 
+	if len(allRuns) > maxRuns {
+		allRuns = allRuns[:maxRuns]
+	}
+
 	if len(allRuns) == 0 {
 		return result, nil
 	}
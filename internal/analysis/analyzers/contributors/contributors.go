@@ -0,0 +1,212 @@
+package contributors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// minCommitsForInternalOnly is the sample size below which an all-internal history is not flagged
+const minCommitsForInternalOnly = 20
+
+// commitsPerDay is the commit rate assumed when estimating how many pages the window spans
+const commitsPerDay = 3
+
+// maxEstimatedCommitPages caps the estimate for long or unbounded windows
+const maxEstimatedCommitPages = 10
+
+// Analyzer splits commit authors into internal and external contributors using a
+// configured list of org member logins and company email domains
+type Analyzer struct {
+	InternalMembers []string // GitHub logins, matched case-insensitively
+	InternalDomains []string // commit email domains; subdomains match too
+}
+
+func New(internalMembers, internalDomains []string) *Analyzer {
+	return &Analyzer{InternalMembers: internalMembers, InternalDomains: internalDomains}
+}
+
+func (a *Analyzer) Name() string {
+	return "contributors"
+}
+
+func (a *Analyzer) EstimatedCost(cfg analysis.Config) int {
+	// Nothing is fetched until affiliation is configured
+	if len(a.InternalMembers) == 0 && len(a.InternalDomains) == 0 {
+		return 0
+	}
+	// Commit pages for the window, which the commit list is bounded by
+	if cfg.Since.IsZero() {
+		return maxEstimatedCommitPages
+	}
+	days := int(time.Since(cfg.Since).Hours() / 24)
+	return min(analysis.Pages(days*commitsPerDay), maxEstimatedCommitPages)
+}
+
+func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
+	result := models.AnalyzerResult{Name: a.Name()}
+
+	if len(a.InternalMembers) == 0 && len(a.InternalDomains) == 0 {
+		result.Findings = append(result.Findings, models.Finding{
+			Type:        "contributor_affiliation_unconfigured",
+			Severity:    models.SeverityInfo,
+			Message:     "No internal members or email domains configured; contributors cannot be classified",
+			Actionable:  true,
+			Remediation: "Set analyzers.contributors.params.internal_members or internal_domains in the config file.",
+		})
+		return result, nil
+	}
+
+	commits, err := client.ListCommitsSince(ctx, repo.Owner, repo.Name, repo.Ref, cfg.Since)
+	if err != nil {
+		// GitHub returns 409 Conflict for empty repositories
+		var ghErr *github.ErrorResponse
+		if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == 409 {
+			return result, nil
+		}
+		return result, err
+	}
+
+	split := a.classify(commits)
+	if split.total() == 0 {
+		return result, nil
+	}
+
+	internalRatio := float64(split.InternalCommits) / float64(split.total()) * 100
+	result.Metrics = append(result.Metrics,
+		models.Metric{
+			Key:          "internal_commit_ratio",
+			Value:        internalRatio,
+			Unit:         "%",
+			DisplayValue: fmt.Sprintf("%.1f%%", internalRatio),
+			Description:  "Share of commits in the window by internal contributors",
+		},
+		models.Metric{
+			Key:          "external_commit_ratio",
+			Value:        100 - internalRatio,
+			Unit:         "%",
+			DisplayValue: fmt.Sprintf("%.1f%%", 100-internalRatio),
+			Description:  "Share of commits in the window by external contributors",
+		},
+		models.Metric{
+			Key:          "internal_contributors",
+			Value:        float64(len(split.Internal)),
+			Unit:         "count",
+			DisplayValue: fmt.Sprintf("%d", len(split.Internal)),
+			Description:  "Distinct internal commit authors in the window",
+		},
+		models.Metric{
+			Key:          "external_contributors",
+			Value:        float64(len(split.External)),
+			Unit:         "count",
+			DisplayValue: fmt.Sprintf("%d", len(split.External)),
+			Description:  "Distinct external commit authors in the window",
+		},
+	)
+
+	if split.ExternalCommits == 0 && split.total() >= minCommitsForInternalOnly {
+		result.Findings = append(result.Findings, models.Finding{
+			Type:        "internal_only_development",
+			Severity:    models.SeverityInfo,
+			Message:     fmt.Sprintf("All %d commits in the window came from internal contributors", split.InternalCommits),
+			Actionable:  true,
+			Remediation: "If community contributions are a goal, label good first issues and document the contribution process.",
+			Explanation: "A project with no outside commits depends entirely on internal staffing, and its bus factor does not benefit from community maintainers.",
+			SuggestedActions: []string{
+				"Add or improve CONTRIBUTING.md",
+				"Label approachable issues with 'good first issue'",
+				"Review external pull requests promptly to encourage repeat contributors",
+			},
+		})
+	}
+
+	return result, nil
+}
+
+// affiliation is the internal/external split of commits and their distinct authors
+type affiliation struct {
+	InternalCommits int
+	ExternalCommits int
+	Internal        map[string]bool
+	External        map[string]bool
+}
+
+func (s affiliation) total() int {
+	return s.InternalCommits + s.ExternalCommits
+}
+
+// classify assigns each commit author to internal or external. An author counts as
+// internal when their login is a member or any of their commits uses an internal
+// email, so people committing from a personal address are not split in two. Bot
+// accounts and commits without any author information are left out.
+func (a *Analyzer) classify(commits []*github.RepositoryCommit) affiliation {
+	members := make(map[string]bool, len(a.InternalMembers))
+	for _, m := range a.InternalMembers {
+		members[strings.ToLower(m)] = true
+	}
+
+	commitCounts := make(map[string]int)
+	internal := make(map[string]bool)
+	for _, c := range commits {
+		var login, name, email string
+		if c.Author != nil {
+			if c.Author.GetType() == "Bot" || strings.HasSuffix(c.Author.GetLogin(), "[bot]") {
+				continue
+			}
+			login = c.Author.GetLogin()
+		}
+		if c.Commit != nil && c.Commit.Author != nil {
+			name, email = c.Commit.Author.GetName(), c.Commit.Author.GetEmail()
+		}
+
+		author := login
+		if author == "" {
+			author = name
+		}
+		if author == "" {
+			author = email
+		}
+		if author == "" {
+			continue
+		}
+
+		commitCounts[author]++
+		if members[strings.ToLower(login)] || a.internalEmail(email) {
+			internal[author] = true
+		}
+	}
+
+	split := affiliation{Internal: make(map[string]bool), External: make(map[string]bool)}
+	for author, count := range commitCounts {
+		if internal[author] {
+			split.InternalCommits += count
+			split.Internal[author] = true
+		} else {
+			split.ExternalCommits += count
+			split.External[author] = true
+		}
+	}
+	return split
+}
+
+// internalEmail reports whether email belongs to one of the internal domains or a subdomain of one
+func (a *Analyzer) internalEmail(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	for _, d := range a.InternalDomains {
+		d = strings.ToLower(strings.TrimPrefix(d, "@"))
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package contributors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+)
+
+// commitsClient returns a fixed list of commits
+type commitsClient struct {
+	analysis.Client
+	commits []*github.RepositoryCommit
+}
+
+func (c *commitsClient) ListCommitsSince(ctx context.Context, owner, repo, ref string, since time.Time) ([]*github.RepositoryCommit, error) {
+	return c.commits, nil
+}
+
+func commit(login, email string) *github.RepositoryCommit {
+	c := &github.RepositoryCommit{Commit: &github.Commit{Author: &github.CommitAuthor{Name: github.String("Someone"), Email: github.String(email)}}}
+	if login != "" {
+		c.Author = &github.User{Login: github.String(login)}
+	}
+	return c
+}
+
+func TestClassify(t *testing.T) {
+	a := New([]string{"Alice"}, []string{"example.com"})
+	split := a.classify([]*github.RepositoryCommit{
+		commit("alice", "alice@gmail.com"),           // member by login
+		commit("bob", "bob@eng.example.com"),         // internal subdomain
+		commit("bob", "bob@gmail.com"),               // same author, personal address
+		commit("carol", "carol@notexample.com"),      // external
+		commit("", "dave@example.com"),               // no login, internal email
+		commit("dependabot[bot]", "bot@example.com"), // bots are ignored
+	})
+
+	if split.InternalCommits != 4 || split.ExternalCommits != 1 {
+		t.Errorf("Expected 4 internal and 1 external commits, got %d and %d", split.InternalCommits, split.ExternalCommits)
+	}
+	if len(split.Internal) != 3 || len(split.External) != 1 || !split.External["carol"] {
+		t.Errorf("Unexpected authors: internal %v, external %v", split.Internal, split.External)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	client := &commitsClient{commits: []*github.RepositoryCommit{
+		commit("alice", "alice@example.com"),
+		commit("carol", "carol@gmail.com"),
+		commit("carol", "carol@gmail.com"),
+		commit("erin", "erin@gmail.com"),
+	}}
+	res, err := New(nil, []string{"@example.com"}).Analyze(context.Background(), client, analysis.TargetRepository{Owner: "o", Name: "r"}, analysis.Config{})
+	if err != nil {
+		t.Fatalf("Analyze returned error: %v", err)
+	}
+
+	metrics := make(map[string]float64)
+	for _, m := range res.Metrics {
+		metrics[m.Key] = m.Value
+	}
+	if metrics["internal_commit_ratio"] != 25 || metrics["external_commit_ratio"] != 75 {
+		t.Errorf("Expected 25%%/75%% commit split, got %v", metrics)
+	}
+	if metrics["external_contributors"] != 2 || metrics["internal_contributors"] != 1 {
+		t.Errorf("Expected 1 internal and 2 external contributors, got %v", metrics)
+	}
+}
+
+func TestAnalyzeFindings(t *testing.T) {
+	internalOnly := make([]*github.RepositoryCommit, minCommitsForInternalOnly)
+	for i := range internalOnly {
+		internalOnly[i] = commit("alice", "alice@example.com")
+	}
+
+	tests := []struct {
+		name        string
+		analyzer    *Analyzer
+		commits     []*github.RepositoryCommit
+		wantFinding string
+	}{
+		{"unconfigured", New(nil, nil), internalOnly, "contributor_affiliation_unconfigured"},
+		{"internal only", New([]string{"alice"}, nil), internalOnly, "internal_only_development"},
+		{"too few commits", New([]string{"alice"}, nil), internalOnly[:5], ""},
+		{"external commits", New([]string{"alice"}, nil), append([]*github.RepositoryCommit{commit("carol", "")}, internalOnly...), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.analyzer.Analyze(context.Background(), &commitsClient{commits: tt.commits}, analysis.TargetRepository{Owner: "o", Name: "r"}, analysis.Config{})
+			if err != nil {
+				t.Fatalf("Analyze returned error: %v", err)
+			}
+			got := ""
+			if len(res.Findings) > 0 {
+				got = res.Findings[0].Type
+			}
+			if got != tt.wantFinding {
+				t.Errorf("Expected finding %q, got %q", tt.wantFinding, got)
+			}
+		})
+	}
+}
+
+func TestEstimatedCost(t *testing.T) {
+	a := New(nil, []string{"example.com"})
+	tests := []struct {
+		name  string
+		since time.Time
+		want  int
+	}{
+		{"30 day window", time.Now().AddDate(0, 0, -30), 1},
+		{"90 day window", time.Now().AddDate(0, 0, -90), 3},
+		{"year window is capped", time.Now().AddDate(-1, 0, 0), maxEstimatedCommitPages},
+		{"no window", time.Time{}, maxEstimatedCommitPages},
+	}
+	for _, tt := range tests {
+		if got := a.EstimatedCost(analysis.Config{Since: tt.since}); got != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, got)
+		}
+	}
+
+	if got := New(nil, nil).EstimatedCost(analysis.Config{Since: time.Now().AddDate(0, 0, -30)}); got != 0 {
+		t.Errorf("Expected no requests without affiliation config, got %d", got)
+	}
+}
@@ -44,6 +44,39 @@ var packageManagers = []PackageManager{
 	{Name: "nuget", Files: []string{"packages.config", ".csproj"}, Language: "C#"},
 }
 
+// ManifestFiles returns the manifest and lock files of every known package manager,
+// in detection order. Any of them at the repository root means it has dependencies.
+func ManifestFiles() []string {
+	var files []string
+	for _, pm := range packageManagers {
+		files = append(files, pm.Files...)
+	}
+	return files
+}
+
+// updateConfigs lists the config files of automated dependency update tools, in lookup order
+var updateConfigs = []struct {
+	Tool string
+	File string
+}{
+	{"dependabot", ".github/dependabot.yml"},
+	{"dependabot", ".github/dependabot.yaml"},
+	{"renovate", "renovate.json"},
+	{"renovate", ".renovaterc"},
+	{"renovate", ".renovaterc.json"},
+	{"renovate", ".github/renovate.json"},
+}
+
+func (a *Analyzer) EstimatedCost(cfg analysis.Config) int {
+	// One content lookup per known manifest file, the two Python manifests parsed for counts,
+	// and the repository overview, plus one lookup per update tool config when it is unavailable
+	cost := 3 + len(updateConfigs)
+	for _, pm := range packageManagers {
+		cost += len(pm.Files)
+	}
+	return cost
+}
+
 func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
 	var metrics []models.Metric
 	var findings []models.Finding
@@ -54,7 +87,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 
 	for _, pm := range packageManagers {
 		for _, file := range pm.Files {
-			fileContent, _, err := client.GetContent(ctx, repo.Owner, repo.Name, file)
+			fileContent, _, err := client.GetContentAtRef(ctx, repo.Owner, repo.Name, file, repo.Ref)
 			if err == nil && fileContent != nil && fileContent.Content != nil {
 				content, err := fileContent.GetContent()
 				if err == nil && content != "" {
@@ -147,9 +180,36 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		})
 	}
 
-	// Parse requirements.txt if available
+	// Fetch Python manifests that detection skipped because another marker file matched first
+	if detectedManagers["pip"] || detectedManagers["pipenv"] || detectedManagers["poetry"] {
+		for _, file := range []string{"pyproject.toml", "Pipfile"} {
+			if _, exists := dependencyFiles[file]; exists {
+				continue
+			}
+			fileContent, _, err := client.GetContentAtRef(ctx, repo.Owner, repo.Name, file, repo.Ref)
+			if err == nil && fileContent != nil {
+				if content, err := fileContent.GetContent(); err == nil && content != "" {
+					dependencyFiles[file] = content
+				}
+			}
+		}
+	}
+
+	// Parse the first Python manifest available: requirements.txt, pyproject.toml, then Pipfile
+	pythonSource := ""
+	var deps, pinnedCount int
 	if content, exists := dependencyFiles["requirements.txt"]; exists {
-		deps, pinnedCount := parseRequirementsTxt(content)
+		pythonSource = "requirements.txt"
+		deps, pinnedCount = parseRequirementsTxt(content)
+	} else if content, exists := dependencyFiles["pyproject.toml"]; exists {
+		pythonSource = "pyproject.toml"
+		deps, pinnedCount = parsePyprojectToml(content)
+	} else if content, exists := dependencyFiles["Pipfile"]; exists {
+		pythonSource = "Pipfile"
+		deps, pinnedCount = parsePipfile(content)
+	}
+
+	if pythonSource != "" {
 		totalDeps += deps
 
 		metrics = append(metrics, models.Metric{
@@ -157,7 +217,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 			Value:        float64(deps),
 			Unit:         "count",
 			DisplayValue: fmt.Sprintf("%d", deps),
-			Description:  "Python dependencies",
+			Description:  fmt.Sprintf("Python dependencies (from %s)", pythonSource),
 		})
 
 		if deps > 0 {
@@ -256,6 +316,36 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		})
 	}
 
+	// Check for automated dependency updates
+	tool := detectUpdateTool(ctx, client, repo)
+	if tool != "" {
+		metrics = append(metrics, models.Metric{
+			Key:          "automated_updates",
+			Value:        1,
+			DisplayValue: tool,
+			Description:  "Automated dependency update tool configured",
+		})
+	} else {
+		metrics = append(metrics, models.Metric{
+			Key:          "automated_updates",
+			Value:        0,
+			DisplayValue: "none",
+			Description:  "No automated dependency update tool configured",
+		})
+		findings = append(findings, models.Finding{
+			Type:        "no_automated_updates",
+			Severity:    models.SeverityMedium,
+			Message:     "Dependencies are not updated automatically (no Dependabot or Renovate config)",
+			Actionable:  true,
+			Remediation: "Add .github/dependabot.yml or a Renovate config to receive dependency update PRs.",
+			Explanation: "Without automated updates, vulnerable and outdated dependencies linger until someone notices them manually.",
+			SuggestedActions: []string{
+				"Add .github/dependabot.yml with an entry per package ecosystem",
+				"Or install the Renovate app and commit a renovate.json",
+			},
+		})
+	}
+
 	return models.AnalyzerResult{
 		Name:     a.Name(),
 		Metrics:  metrics,
@@ -263,6 +353,38 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 	}, nil
 }
 
+// detectUpdateTool returns the first automated update tool with a config file on the analyzed
+// ref, or "" when none is configured. On the default branch, the root and .github listing of
+// the repository overview answers this in one (usually cached) request; each config file is
+// only fetched on another ref or when the overview is unavailable.
+func detectUpdateTool(ctx context.Context, client analysis.Client, repo analysis.TargetRepository) string {
+	var overviewPaths []string
+	if repo.Ref == "" { // the overview only lists the default branch
+		if overview, err := client.GetRepoOverview(ctx, repo.Owner, repo.Name); err == nil {
+			overviewPaths = overview.Paths
+		}
+	}
+	if overviewPaths != nil {
+		paths := make(map[string]bool, len(overviewPaths))
+		for _, p := range overviewPaths {
+			paths[p] = true
+		}
+		for _, uc := range updateConfigs {
+			if paths[uc.File] {
+				return uc.Tool
+			}
+		}
+		return ""
+	}
+
+	for _, uc := range updateConfigs {
+		if file, _, err := client.GetContentAtRef(ctx, repo.Owner, repo.Name, uc.File, repo.Ref); err == nil && file != nil {
+			return uc.Tool
+		}
+	}
+	return ""
+}
+
 // parsePackageJSON extracts dependency counts from package.json
 func parsePackageJSON(content string) (int, int) {
 	var pkg struct {
@@ -332,6 +454,199 @@ func parseRequirementsTxt(content string) (int, int) {
 	return total, pinned
 }
 
+// parsePyprojectToml counts runtime dependencies declared in pyproject.toml, either as a
+// PEP 621 [project].dependencies array or as keys of [tool.poetry.dependencies].
+// It returns the total and how many are pinned to an exact version.
+func parsePyprojectToml(content string) (int, int) {
+	total, pinned := 0, 0
+	table := ""
+
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := stripTomlComment(lines[i])
+		if line == "" {
+			continue
+		}
+		if name, ok := tomlTableHeader(line); ok {
+			table = name
+			continue
+		}
+
+		key, value, ok := splitTomlKeyValue(line)
+		if !ok {
+			continue
+		}
+
+		switch table {
+		case "project":
+			if key != "dependencies" {
+				continue
+			}
+			// The array may span several lines; gather until the closing bracket
+			array := value
+			for !tomlArrayClosed(array) && i+1 < len(lines) {
+				i++
+				array += "\n" + stripTomlComment(lines[i])
+			}
+			for _, spec := range tomlStrings(array) {
+				total++
+				if strings.Contains(spec, "==") {
+					pinned++
+				}
+			}
+		case "tool.poetry.dependencies":
+			if key == "python" {
+				continue // interpreter constraint, not a package
+			}
+			total++
+			if isExactPoetryVersion(tomlVersionValue(value)) {
+				pinned++
+			}
+		}
+	}
+
+	return total, pinned
+}
+
+// parsePipfile counts packages in the [packages] table of a Pipfile.
+// It returns the total and how many are pinned with ==.
+func parsePipfile(content string) (int, int) {
+	total, pinned := 0, 0
+	table := ""
+
+	for _, raw := range strings.Split(content, "\n") {
+		line := stripTomlComment(raw)
+		if line == "" {
+			continue
+		}
+		if name, ok := tomlTableHeader(line); ok {
+			table = name
+			continue
+		}
+		if table != "packages" {
+			continue
+		}
+		if _, value, ok := splitTomlKeyValue(line); ok {
+			total++
+			if strings.HasPrefix(tomlVersionValue(value), "==") {
+				pinned++
+			}
+		}
+	}
+
+	return total, pinned
+}
+
+// stripTomlComment trims whitespace and removes a trailing # comment outside of quotes
+func stripTomlComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return strings.TrimSpace(line[:i])
+		}
+	}
+	return strings.TrimSpace(line)
+}
+
+// tomlTableHeader returns the table name for a [table] line
+func tomlTableHeader(line string) (string, bool) {
+	if !strings.HasPrefix(line, "[") || strings.HasPrefix(line, "[[") || !strings.HasSuffix(line, "]") {
+		return "", false
+	}
+	return strings.TrimSpace(line[1 : len(line)-1]), true
+}
+
+// splitTomlKeyValue splits a key = value line, unquoting the key
+func splitTomlKeyValue(line string) (string, string, bool) {
+	idx := strings.Index(line, "=")
+	if idx <= 0 {
+		return "", "", false
+	}
+	key := strings.Trim(strings.TrimSpace(line[:idx]), `"'`)
+	return key, strings.TrimSpace(line[idx+1:]), key != ""
+}
+
+// tomlArrayClosed reports whether an array literal has its closing bracket,
+// ignoring brackets inside strings such as extras ("requests[socks]")
+func tomlArrayClosed(s string) bool {
+	depth := 0
+	var quote rune
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+			if depth == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tomlStrings returns the quoted string values in s
+func tomlStrings(s string) []string {
+	var values []string
+	var quote rune
+	var current strings.Builder
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			values = append(values, current.String())
+			current.Reset()
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+		}
+	}
+	return values
+}
+
+// tomlVersionValue extracts the version constraint from `"1.0"` or `{ version = "1.0", ... }`
+func tomlVersionValue(value string) string {
+	if strings.HasPrefix(value, "{") {
+		inner := strings.Trim(value, "{} ")
+		for _, part := range strings.Split(inner, ",") {
+			if key, v, ok := splitTomlKeyValue(strings.TrimSpace(part)); ok && key == "version" {
+				value = v
+				break
+			}
+		}
+		if !strings.HasPrefix(value, `"`) && !strings.HasPrefix(value, "'") {
+			return "" // git/path dependency without a version
+		}
+	}
+	return strings.Trim(value, `"' `)
+}
+
+// isExactPoetryVersion reports whether a poetry constraint pins a single version.
+// Poetry treats a bare version ("1.2.3") as exact; ^, ~, *, ranges and wildcards are not.
+func isExactPoetryVersion(v string) bool {
+	if v == "" {
+		return false
+	}
+	if strings.HasPrefix(v, "==") {
+		return true
+	}
+	return !strings.ContainsAny(v, "^~*<>=!,|")
+}
+
 // parseCargoToml counts dependencies in Cargo.toml
 func parseCargoToml(content string) int {
 	var cargo struct {
@@ -0,0 +1,205 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestParsePyprojectTomlPEP621(t *testing.T) {
+	total, pinned := parsePyprojectToml(readFixture(t, "pyproject_pep621.toml"))
+	// Optional dependencies and build requirements are not runtime dependencies
+	if total != 5 {
+		t.Errorf("Expected 5 dependencies, got %d", total)
+	}
+	if pinned != 2 {
+		t.Errorf("Expected 2 pinned dependencies, got %d", pinned)
+	}
+}
+
+func TestParsePyprojectTomlPoetry(t *testing.T) {
+	total, pinned := parsePyprojectToml(readFixture(t, "pyproject_poetry.toml"))
+	// python is an interpreter constraint; dev group is excluded
+	if total != 5 {
+		t.Errorf("Expected 5 dependencies, got %d", total)
+	}
+	// requests = "2.31.0" and rich = { version = "13.7.1" } are exact pins
+	if pinned != 2 {
+		t.Errorf("Expected 2 pinned dependencies, got %d", pinned)
+	}
+}
+
+func TestParsePyprojectTomlInlineArray(t *testing.T) {
+	total, pinned := parsePyprojectToml("[project]\nname = \"x\"\ndependencies = [\"a==1.0\", \"b[extra]>=2\"]\n")
+	if total != 2 || pinned != 1 {
+		t.Errorf("Expected (2, 1), got (%d, %d)", total, pinned)
+	}
+}
+
+func TestParsePipfile(t *testing.T) {
+	total, pinned := parsePipfile(readFixture(t, "Pipfile"))
+	if total != 4 {
+		t.Errorf("Expected 4 packages, got %d", total)
+	}
+	if pinned != 2 {
+		t.Errorf("Expected 2 pinned packages, got %d", pinned)
+	}
+}
+
+// contentClient serves a fixed set of files on the default branch, and refFiles on other
+// refs; everything else is not found. With overview set, the root and .github paths of the
+// default branch files are listed in the repository overview.
+type contentClient struct {
+	analysis.Client
+	files    map[string]string
+	refFiles map[string]map[string]string
+	overview bool
+
+	lookups    []string
+	lookupRefs []string
+}
+
+func (c *contentClient) GetRepoOverview(ctx context.Context, owner, repo string) (*analysis.RepoOverview, error) {
+	if !c.overview {
+		return nil, errors.New("graphql unavailable")
+	}
+	paths := []string{}
+	for path := range c.files {
+		paths = append(paths, path)
+	}
+	return &analysis.RepoOverview{Paths: paths}, nil
+}
+
+func (c *contentClient) GetContent(ctx context.Context, owner, repo, path string) (*github.RepositoryContent, []*github.RepositoryContent, error) {
+	return c.GetContentAtRef(ctx, owner, repo, path, "")
+}
+
+func (c *contentClient) GetContentAtRef(ctx context.Context, owner, repo, path, ref string) (*github.RepositoryContent, []*github.RepositoryContent, error) {
+	c.lookups = append(c.lookups, path)
+	c.lookupRefs = append(c.lookupRefs, ref)
+	files := c.files
+	if ref != "" {
+		files = c.refFiles[ref]
+	}
+	content, ok := files[path]
+	if !ok {
+		return nil, nil, errors.New("not found")
+	}
+	return &github.RepositoryContent{Content: github.String(content)}, nil, nil
+}
+
+func TestAnalyzeAutomatedUpdates(t *testing.T) {
+	goMod := "module example.com/x\n\nrequire github.com/a/b v1.0.0\n"
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    string
+		finding bool
+	}{
+		{"dependabot", map[string]string{"go.mod": goMod, ".github/dependabot.yml": "version: 2\n"}, "dependabot", false},
+		{"renovate", map[string]string{"go.mod": goMod, ".renovaterc": "{}"}, "renovate", false},
+		{"none", map[string]string{"go.mod": goMod}, "none", true},
+	}
+
+	for _, tt := range tests {
+		for _, overview := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/overview=%v", tt.name, overview), func(t *testing.T) {
+				client := &contentClient{files: tt.files, overview: overview}
+				result, err := New().Analyze(context.Background(), client, analysis.TargetRepository{Owner: "o", Name: "r"}, analysis.Config{})
+				if err != nil {
+					t.Fatalf("Analyze failed: %v", err)
+				}
+				if overview {
+					for _, path := range client.lookups {
+						if strings.Contains(path, "dependabot") || strings.Contains(path, "renovate") {
+							t.Errorf("Expected the overview to answer update tool detection, but %s was fetched", path)
+						}
+					}
+				}
+
+				got := ""
+				for _, m := range result.Metrics {
+					if m.Key == "automated_updates" {
+						got = m.DisplayValue
+					}
+				}
+				if got != tt.want {
+					t.Errorf("automated_updates = %q, want %q", got, tt.want)
+				}
+
+				hasFinding := false
+				for _, f := range result.Findings {
+					if f.Type == "no_automated_updates" {
+						hasFinding = true
+					}
+				}
+				if hasFinding != tt.finding {
+					t.Errorf("no_automated_updates finding = %v, want %v", hasFinding, tt.finding)
+				}
+			})
+		}
+	}
+}
+
+func TestAnalyzeOnRef(t *testing.T) {
+	client := &contentClient{
+		files:    map[string]string{"go.mod": "module example.com/x\n", ".github/dependabot.yml": "version: 2\n"},
+		refFiles: map[string]map[string]string{"develop": {"package.json": `{"dependencies": {"a": "1.0.0"}}`, "renovate.json": "{}"}},
+		overview: true,
+	}
+	result, err := New().Analyze(context.Background(), client, analysis.TargetRepository{Owner: "o", Name: "r", Ref: "develop"}, analysis.Config{})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	for i, ref := range client.lookupRefs {
+		if ref != "develop" {
+			t.Errorf("Expected %s to be read from develop, got ref %q", client.lookups[i], ref)
+		}
+	}
+	metrics := make(map[string]string)
+	for _, m := range result.Metrics {
+		metrics[m.Key] = m.DisplayValue
+	}
+	if metrics["automated_updates"] != "renovate" {
+		t.Errorf("Expected the update tool configured on develop, got %q", metrics["automated_updates"])
+	}
+	if metrics["package_managers"] != "npm" {
+		t.Errorf("Expected the manifests on develop, got package managers %q", metrics["package_managers"])
+	}
+}
+
+func TestAnalyzeKeepsGuidanceInEveryMode(t *testing.T) {
+	// Renderers trim guidance to the output mode; the analyzer always reports all of it
+	files := map[string]string{"go.mod": "module example.com/x\n"}
+	cfg := analysis.Config{OutputMode: models.OutputModeStatistical}
+	result, err := New().Analyze(context.Background(), &contentClient{files: files}, analysis.TargetRepository{Owner: "o", Name: "r"}, cfg)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(result.Findings) == 0 {
+		t.Fatal("Expected findings")
+	}
+	for _, f := range result.Findings {
+		if f.Explanation == "" || len(f.SuggestedActions) == 0 {
+			t.Errorf("%s: guidance was dropped in statistical mode", f.Type)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package deployments
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+const (
+	// maxDeployments bounds how far back the deployment list is paged
+	maxDeployments = 500
+	// minDeploymentsForFinding avoids judging success rate on a handful of deployments
+	minDeploymentsForFinding = 5
+)
+
+type Analyzer struct {
+	SuccessRateThreshold float64 // percent; 0 disables the finding
+}
+
+func New(successRateThreshold int) *Analyzer {
+	return &Analyzer{SuccessRateThreshold: float64(successRateThreshold)}
+}
+
+func (a *Analyzer) Name() string {
+	return "deployments"
+}
+
+func (a *Analyzer) EstimatedCost(cfg analysis.Config) int {
+	// Deployment list pages, then statuses for each deployment in the window
+	return analysis.Pages(maxDeployments) + statusLookupLimit(cfg)
+}
+
+// statusLookupLimit caps how many deployments get their statuses fetched
+func statusLookupLimit(cfg analysis.Config) int {
+	if cfg.IncludeDeep {
+		return 100
+	}
+	return 30
+}
+
+// deployment is a deployment with its statuses, newest status first
+type deployment struct {
+	Environment string
+	CreatedAt   time.Time
+	Statuses    []*github.DeploymentStatus
+}
+
+func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
+	gh := client.GetUnderlyingClient()
+
+	// Deployments are listed newest first, so stop paging once one predates the window
+	var recent []*github.Deployment
+	opts := &github.DeploymentsListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for page := 0; page < analysis.Pages(maxDeployments); page++ {
+		list, resp, err := gh.Repositories.ListDeployments(ctx, repo.Owner, repo.Name, opts)
+		if err != nil {
+			return models.AnalyzerResult{Name: a.Name()}, err
+		}
+
+		reachedEnd := false
+		for _, d := range list {
+			if d.GetCreatedAt().Before(cfg.Since) {
+				reachedEnd = true
+				break
+			}
+			recent = append(recent, d)
+		}
+		if reachedEnd || resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	var deployments []deployment
+	for i, d := range recent {
+		dep := deployment{Environment: d.GetEnvironment(), CreatedAt: d.GetCreatedAt().Time}
+		if i < statusLookupLimit(cfg) {
+			statuses, _, err := gh.Repositories.ListDeploymentStatuses(ctx, repo.Owner, repo.Name, d.GetID(), &github.ListOptions{PerPage: 100})
+			if err == nil {
+				dep.Statuses = statuses
+			}
+		}
+		deployments = append(deployments, dep)
+	}
+
+	stats := summarize(deployments)
+	days := time.Since(cfg.Since).Hours() / 24
+	perWeek := 0.0
+	if days > 0 {
+		perWeek = float64(stats.Total) / days * 7
+	}
+
+	metrics := []models.Metric{
+		{
+			Key:          "deployments_in_window",
+			Value:        float64(stats.Total),
+			Unit:         "count",
+			DisplayValue: fmt.Sprintf("%d", stats.Total),
+			Description:  "Deployments created in the lookback window",
+		},
+	}
+	if stats.Total == 0 {
+		return models.AnalyzerResult{Name: a.Name(), Metrics: metrics}, nil
+	}
+
+	metrics = append(metrics,
+		models.Metric{
+			Key:          "deployment_frequency_weekly",
+			Value:        perWeek,
+			Unit:         "deployments/week",
+			DisplayValue: fmt.Sprintf("%.1f/week", perWeek),
+			Description:  "Average deployments per week",
+		},
+		models.Metric{
+			Key:          "active_environments",
+			Value:        float64(len(stats.Environments)),
+			Unit:         "count",
+			DisplayValue: fmt.Sprintf("%d", len(stats.Environments)),
+			Description:  fmt.Sprintf("Environments deployed to: %s", strings.Join(stats.Environments, ", ")),
+		},
+	)
+
+	if stats.Finished > 0 {
+		metrics = append(metrics, models.Metric{
+			Key:          "deployment_success_rate",
+			Value:        stats.SuccessRate,
+			Unit:         "percent",
+			DisplayValue: fmt.Sprintf("%.1f%%", stats.SuccessRate),
+			Description:  fmt.Sprintf("Successful deployments out of %d that finished", stats.Finished),
+		})
+	}
+	if stats.Succeeded > 0 {
+		metrics = append(metrics, models.Metric{
+			Key:          "avg_time_to_deploy_minutes",
+			Value:        stats.AvgTimeToDeploy.Minutes(),
+			Unit:         "minutes",
+			DisplayValue: stats.AvgTimeToDeploy.Round(time.Second).String(),
+			Description:  "Average time from deployment creation to its first success status",
+		})
+	}
+
+	var findings []models.Finding
+	if a.SuccessRateThreshold > 0 && stats.Finished >= minDeploymentsForFinding && stats.SuccessRate < a.SuccessRateThreshold {
+		findings = append(findings, models.Finding{
+			Type:        "low_deployment_success_rate",
+			Severity:    models.SeverityMedium,
+			Message:     fmt.Sprintf("Deployment success rate is %.1f%% (threshold %.0f%%)", stats.SuccessRate, a.SuccessRateThreshold),
+			Actionable:  true,
+			Remediation: "Review recent failed deployments and add pre-deploy checks to catch failures earlier.",
+			Explanation: "Failed deployments delay fixes reaching users and often need manual intervention or rollbacks.",
+			SuggestedActions: []string{
+				"Inspect the failing deployment statuses for a common cause",
+				"Add smoke tests or staging deploys before production",
+			},
+		})
+	}
+
+	return models.AnalyzerResult{
+		Name:     a.Name(),
+		Metrics:  metrics,
+		Findings: findings,
+	}, nil
+}
+
+// deploymentStats summarizes the outcome of a set of deployments
+type deploymentStats struct {
+	Total           int
+	Finished        int // deployments that reached success, failure or error
+	Succeeded       int
+	SuccessRate     float64
+	AvgTimeToDeploy time.Duration
+	Environments    []string
+}
+
+// summarize classifies each deployment by its statuses. A deployment that ever
+// reported success counts as successful (later "inactive" statuses only mean it
+// was superseded); one whose statuses include failure or error without success
+// counts as failed; anything else is still pending and left out of the rate.
+func summarize(deployments []deployment) deploymentStats {
+	stats := deploymentStats{Total: len(deployments)}
+	envs := make(map[string]bool)
+	var totalTimeToDeploy time.Duration
+
+	for _, d := range deployments {
+		if d.Environment != "" {
+			envs[d.Environment] = true
+		}
+
+		var firstSuccess time.Time
+		failed := false
+		for _, s := range d.Statuses {
+			switch s.GetState() {
+			case "success":
+				if t := s.GetCreatedAt().Time; firstSuccess.IsZero() || t.Before(firstSuccess) {
+					firstSuccess = t
+				}
+			case "failure", "error":
+				failed = true
+			}
+		}
+
+		switch {
+		case !firstSuccess.IsZero():
+			stats.Finished++
+			stats.Succeeded++
+			totalTimeToDeploy += firstSuccess.Sub(d.CreatedAt)
+		case failed:
+			stats.Finished++
+		}
+	}
+
+	if stats.Finished > 0 {
+		stats.SuccessRate = float64(stats.Succeeded) / float64(stats.Finished) * 100
+	}
+	if stats.Succeeded > 0 {
+		stats.AvgTimeToDeploy = totalTimeToDeploy / time.Duration(stats.Succeeded)
+	}
+	for env := range envs {
+		stats.Environments = append(stats.Environments, env)
+	}
+	sort.Strings(stats.Environments)
+	return stats
+}
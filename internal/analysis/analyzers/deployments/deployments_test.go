@@ -0,0 +1,72 @@
+package deployments
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+)
+
+func status(state string, at time.Time) *github.DeploymentStatus {
+	return &github.DeploymentStatus{State: github.String(state), CreatedAt: &github.Timestamp{Time: at}}
+}
+
+func TestSummarize(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	deployments := []deployment{
+		// Succeeded after 10 minutes, later superseded
+		{Environment: "production", CreatedAt: base, Statuses: []*github.DeploymentStatus{
+			status("inactive", base.Add(time.Hour)),
+			status("success", base.Add(10*time.Minute)),
+			status("in_progress", base.Add(time.Minute)),
+		}},
+		// Failed
+		{Environment: "production", CreatedAt: base, Statuses: []*github.DeploymentStatus{
+			status("failure", base.Add(5*time.Minute)),
+		}},
+		// Retried after an error and succeeded after 20 minutes
+		{Environment: "staging", CreatedAt: base, Statuses: []*github.DeploymentStatus{
+			status("success", base.Add(20*time.Minute)),
+			status("error", base.Add(2*time.Minute)),
+		}},
+		// Still pending, left out of the success rate
+		{Environment: "staging", CreatedAt: base, Statuses: []*github.DeploymentStatus{
+			status("queued", base.Add(time.Minute)),
+		}},
+		// Statuses not fetched
+		{Environment: "preview", CreatedAt: base},
+	}
+
+	stats := summarize(deployments)
+
+	if stats.Total != 5 || stats.Finished != 3 || stats.Succeeded != 2 {
+		t.Errorf("Expected 5 total, 3 finished, 2 succeeded, got %+v", stats)
+	}
+	if stats.SuccessRate < 66.6 || stats.SuccessRate > 66.7 {
+		t.Errorf("Expected success rate of 66.7%%, got %.2f", stats.SuccessRate)
+	}
+	if stats.AvgTimeToDeploy != 15*time.Minute {
+		t.Errorf("Expected 15m average time to deploy, got %s", stats.AvgTimeToDeploy)
+	}
+	if len(stats.Environments) != 3 || stats.Environments[0] != "preview" || stats.Environments[2] != "staging" {
+		t.Errorf("Expected sorted environments [preview production staging], got %v", stats.Environments)
+	}
+}
+
+func TestSummarizeEmpty(t *testing.T) {
+	stats := summarize(nil)
+	if stats.Total != 0 || stats.SuccessRate != 0 || len(stats.Environments) != 0 {
+		t.Errorf("Expected zero stats, got %+v", stats)
+	}
+}
+
+func TestEstimatedCost(t *testing.T) {
+	a := New(90)
+	if got := a.EstimatedCost(analysis.Config{}); got != 35 {
+		t.Errorf("Expected 5 list pages and 30 status lookups, got %d", got)
+	}
+	if got := a.EstimatedCost(analysis.Config{IncludeDeep: true}); got != 105 {
+		t.Errorf("Expected 5 list pages and 100 status lookups in deep mode, got %d", got)
+	}
+}
@@ -10,17 +10,45 @@ import (
 	"github.com/google/go-github/v60/github"
 	"github.com/mikematt33/gh-inspect/internal/analysis"
 	"github.com/mikematt33/gh-inspect/pkg/models"
+	"github.com/mikematt33/gh-inspect/pkg/util"
 )
 
+// DefaultLabelGroups is used when no label groups are configured
+var DefaultLabelGroups = map[string][]string{
+	"priority":         {"priority", "p0", "p1", "p2", "p3"},
+	"needs_triage":     {"needs-triage", "needs triage"},
+	"good_first_issue": {"good first issue", "good-first-issue"},
+}
+
 type Analyzer struct {
 	staleThreshold  time.Duration
 	zombieThreshold time.Duration
+
+	// LabelGroups maps a group name to label prefixes (case-insensitive); open issues
+	// carrying a matching label are counted per group
+	LabelGroups map[string][]string
+	// UntriagedThreshold flags repos with more untriaged issues than this (0 disables)
+	UntriagedThreshold int
+	// ResponseSLAHours is the first-response target for sampled issues (0 disables the SLA metric)
+	ResponseSLAHours int
+}
+
+// maxSLAOffenders caps how many breaching issues the SLA finding lists
+const maxSLAOffenders = 5
+
+// issueResponse records how long a sampled issue waited for its first comment.
+// Unanswered issues record the time they have been waiting so far.
+type issueResponse struct {
+	Number   int
+	Wait     time.Duration
+	Answered bool
 }
 
 func New(staleDays, zombieDays int) *Analyzer {
 	return &Analyzer{
 		staleThreshold:  time.Duration(staleDays) * 24 * time.Hour,
 		zombieThreshold: time.Duration(zombieDays) * 24 * time.Hour,
+		LabelGroups:     DefaultLabelGroups,
 	}
 }
 
@@ -28,6 +56,15 @@ func (a *Analyzer) Name() string {
 	return "issue-hygiene"
 }
 
+func (a *Analyzer) EstimatedCost(cfg analysis.Config) int {
+	// Open and closed issue pages, plus comments for sampled issues
+	sampled := 10
+	if cfg.IncludeDeep {
+		sampled = 30
+	}
+	return 2*analysis.Pages(cfg.DepthConfig.MaxIssues) + sampled
+}
+
 func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
 	// 1. Fetch Open Issues (Oldest Updated first, to find stale/zombie)
 	// Limit to reasonable number to avoid excessive API calls
@@ -42,65 +79,24 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 	}
 
 	openOpts := &github.IssueListByRepoOptions{
-		State:       "open",
-		Sort:        "updated",
-		Direction:   "asc",
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-
-	// Fetch with pagination limit
-	var openIssues []*github.Issue
-	for len(openIssues) < maxIssues {
-		pageSize := maxIssues - len(openIssues)
-		if pageSize > 100 {
-			pageSize = 100
-		}
-		openOpts.PerPage = pageSize
-
-		pageIssues, err := client.GetIssues(ctx, repo.Owner, repo.Name, openOpts)
-		if err != nil {
-			return models.AnalyzerResult{Name: a.Name()}, err
-		}
-
-		if len(pageIssues) == 0 {
-			break
-		}
-
-		openIssues = append(openIssues, pageIssues...)
-		if len(pageIssues) < pageSize {
-			break
-		}
+		State:     "open",
+		Sort:      "updated",
+		Direction: "asc",
+	}
+	openIssues, err := fetchIssues(ctx, client, repo, openOpts, maxIssues)
+	if err != nil {
+		return models.AnalyzerResult{Name: a.Name()}, err
 	}
 
 	// 2. Fetch Recently Closed Issues (for throughput/lifetime)
 	// Also apply same limit
 	closedOpts := &github.IssueListByRepoOptions{
-		State:       "closed",
-		Since:       cfg.Since,
-		ListOptions: github.ListOptions{PerPage: 100},
+		State: "closed",
+		Since: cfg.Since,
 	}
-
-	var closedIssues []*github.Issue
-	for len(closedIssues) < maxIssues {
-		pageSize := maxIssues - len(closedIssues)
-		if pageSize > 100 {
-			pageSize = 100
-		}
-		closedOpts.PerPage = pageSize
-
-		pageIssues, err := client.GetIssues(ctx, repo.Owner, repo.Name, closedOpts)
-		if err != nil {
-			return models.AnalyzerResult{Name: a.Name()}, err
-		}
-
-		if len(pageIssues) == 0 {
-			break
-		}
-
-		closedIssues = append(closedIssues, pageIssues...)
-		if len(pageIssues) < pageSize {
-			break
-		}
+	closedIssues, err := fetchIssues(ctx, client, repo, closedOpts, maxIssues)
+	if err != nil {
+		return models.AnalyzerResult{Name: a.Name()}, err
 	}
 
 	// 3. Calculate Metrics
@@ -112,6 +108,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 	var featureCount int
 	var totalResponseTime time.Duration
 	var responseCount int
+	var untriagedCount int
 
 	now := time.Now()
 
@@ -148,6 +145,11 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 			}
 		}
 
+		// Untriaged: no labels at all and older than the stale threshold
+		if len(issue.Labels) == 0 && now.Sub(createdAt.Time) > a.staleThreshold {
+			untriagedCount++
+		}
+
 		// Assignee coverage
 		if len(issue.Assignees) > 0 {
 			assignedCount++
@@ -175,6 +177,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 
 	// Lifetime calculation
 	var totalLifetime time.Duration
+	var lifetimes []float64 // hours, for percentiles that a few very old issues can't skew
 	var issuesWithLinkedPR int
 
 	for _, issue := range closedIssues {
@@ -183,6 +186,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		}
 		lifetime := issue.GetClosedAt().Sub(issue.GetCreatedAt().Time)
 		totalLifetime += lifetime
+		lifetimes = append(lifetimes, lifetime.Hours())
 
 		// Check if issue has linked PR
 		if issue.PullRequestLinks != nil {
@@ -203,16 +207,23 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		sampleLimit = len(allIssues)
 	}
 
+	var responses []issueResponse
 	for i := 0; i < sampleLimit; i++ {
 		issue := allIssues[i]
 		comments, err := client.GetIssueComments(ctx, repo.Owner, repo.Name, issue.GetNumber(), nil)
-		if err == nil && len(comments) > 0 {
+		if err != nil {
+			continue
+		}
+		if len(comments) > 0 {
 			firstComment := comments[0]
 			responseTime := firstComment.GetCreatedAt().Sub(issue.GetCreatedAt().Time)
 			if responseTime > 0 {
 				totalResponseTime += responseTime
 				responseCount++
+				responses = append(responses, issueResponse{Number: issue.GetNumber(), Wait: responseTime, Answered: true})
 			}
+		} else if issue.GetState() != "closed" {
+			responses = append(responses, issueResponse{Number: issue.GetNumber(), Wait: now.Sub(issue.GetCreatedAt().Time)})
 		}
 	}
 
@@ -221,6 +232,10 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		avgLifetimeHours = totalLifetime.Hours() / float64(len(closedIssues))
 	}
 
+	sort.Float64s(lifetimes)
+	medianLifetimeHours := util.Percentile(lifetimes, 50)
+	p90LifetimeHours := util.Percentile(lifetimes, 90)
+
 	avgResponseHours := 0.0
 	if responseCount > 0 {
 		avgResponseHours = totalResponseTime.Hours() / float64(responseCount)
@@ -253,6 +268,8 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		{Key: "stale_issues", Value: float64(staleCount), DisplayValue: fmt.Sprintf("%d", staleCount), Description: "Inactive issues beyond threshold"},
 		{Key: "zombie_issues", Value: float64(zombieCount), DisplayValue: fmt.Sprintf("%d", zombieCount), Description: "Very old open issues"},
 		{Key: "avg_issue_lifetime", Value: avgLifetimeHours, Unit: "hours", DisplayValue: fmt.Sprintf("%.1fh", avgLifetimeHours), Description: "Average time to close"},
+		{Key: "median_issue_lifetime", Value: medianLifetimeHours, Unit: "hours", DisplayValue: fmt.Sprintf("%.1fh", medianLifetimeHours), Description: "Median time to close"},
+		{Key: "p90_issue_lifetime", Value: p90LifetimeHours, Unit: "hours", DisplayValue: fmt.Sprintf("%.1fh", p90LifetimeHours), Description: "90% of closed issues were closed within this time"},
 		{Key: "avg_first_response_time", Value: avgResponseHours, Unit: "hours", DisplayValue: fmt.Sprintf("%.1fh", avgResponseHours), Description: "Average time to first comment"},
 		{Key: "label_coverage", Value: labeledRatio, Unit: "percent", DisplayValue: fmt.Sprintf("%.0f%%", labeledRatio*100), Description: "% issues with labels"},
 		{Key: "assignee_coverage", Value: assigneeRatio, Unit: "percent", DisplayValue: fmt.Sprintf("%.0f%%", assigneeRatio*100), Description: "% open issues assigned"},
@@ -261,6 +278,82 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		{Key: "feature_count", Value: float64(featureCount), DisplayValue: fmt.Sprintf("%d", featureCount), Description: "Open feature requests"},
 	}
 
+	groupCounts := countLabelGroups(openIssues, a.LabelGroups)
+	groupNames := make([]string, 0, len(groupCounts))
+	for name := range groupCounts {
+		groupNames = append(groupNames, name)
+	}
+	sort.Strings(groupNames)
+	for _, name := range groupNames {
+		metrics = append(metrics, models.Metric{
+			Key:          "label_group_" + name,
+			Value:        float64(groupCounts[name]),
+			DisplayValue: fmt.Sprintf("%d", groupCounts[name]),
+			Description:  fmt.Sprintf("Open issues labeled %s", strings.Join(a.LabelGroups[name], ", ")),
+		})
+	}
+
+	if a.ResponseSLAHours > 0 {
+		sla := time.Duration(a.ResponseSLAHours) * time.Hour
+		rate, eligible, breaches := slaCompliance(responses, sla)
+		if eligible > 0 {
+			metrics = append(metrics, models.Metric{
+				Key:          "response_sla_rate",
+				Value:        rate,
+				Unit:         "percent",
+				DisplayValue: fmt.Sprintf("%.0f%%", rate*100),
+				Description:  fmt.Sprintf("%% of %d sampled issues with a first response within %dh", eligible, a.ResponseSLAHours),
+			})
+		}
+		if len(breaches) > 0 {
+			offenders := make([]string, 0, maxSLAOffenders)
+			for _, b := range breaches {
+				if len(offenders) == maxSLAOffenders {
+					break
+				}
+				if b.Answered {
+					offenders = append(offenders, fmt.Sprintf("#%d (%s)", b.Number, formatWait(b.Wait)))
+				} else {
+					offenders = append(offenders, fmt.Sprintf("#%d (no response after %s)", b.Number, formatWait(b.Wait)))
+				}
+			}
+			findings = append(findings, models.Finding{
+				Type:        "response_sla_breach",
+				Severity:    models.SeverityLow,
+				Message:     fmt.Sprintf("%d of %d sampled issues missed the %dh first-response SLA: %s", len(breaches), eligible, a.ResponseSLAHours, strings.Join(offenders, ", ")),
+				Actionable:  true,
+				Remediation: "Acknowledge new issues within the SLA, even if only to confirm they were seen.",
+				Explanation: "Slow first responses discourage reporters and contributors, and unanswered issues are often re-reported.",
+				SuggestedActions: []string{
+					"Set up a rotating first-responder for new issues",
+					"Use an auto-reply or issue template that sets expectations on response time",
+				},
+			})
+		}
+	}
+
+	staleDays := int(a.staleThreshold.Hours() / 24)
+	metrics = append(metrics, models.Metric{
+		Key:          "untriaged_issues",
+		Value:        float64(untriagedCount),
+		DisplayValue: fmt.Sprintf("%d", untriagedCount),
+		Description:  fmt.Sprintf("Open issues with no labels older than %d days", staleDays),
+	})
+	if a.UntriagedThreshold > 0 && untriagedCount > a.UntriagedThreshold {
+		findings = append(findings, models.Finding{
+			Type:        "untriaged_backlog",
+			Severity:    models.SeverityMedium,
+			Message:     fmt.Sprintf("%d open issues older than %d days have never been labeled (threshold %d)", untriagedCount, staleDays, a.UntriagedThreshold),
+			Actionable:  true,
+			Remediation: "Schedule a triage pass to label, prioritize or close unlabeled issues.",
+			Explanation: "Issues that are never triaged are hard to find and prioritize, and reporters get no signal that they were seen.",
+			SuggestedActions: []string{
+				"Add an issue template that applies a needs-triage label automatically",
+				"Rotate a weekly triage owner for new issues",
+			},
+		})
+	}
+
 	if len(findings) > 0 {
 		sort.Slice(findings, func(i, j int) bool {
 			// sort by severity?
@@ -274,3 +367,89 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		Findings: findings,
 	}, nil
 }
+
+// slaCompliance returns the share of issues answered within sla, the number of issues
+// it could be judged for, and the breaches sorted by longest wait first. Unanswered
+// issues count as breaches once they have waited longer than sla and are skipped before.
+func slaCompliance(responses []issueResponse, sla time.Duration) (rate float64, eligible int, breaches []issueResponse) {
+	var met int
+	for _, r := range responses {
+		switch {
+		case r.Answered && r.Wait <= sla:
+			met++
+		case r.Wait > sla:
+			breaches = append(breaches, r)
+		default:
+			continue // unanswered but still within the SLA
+		}
+		eligible++
+	}
+	if eligible > 0 {
+		rate = float64(met) / float64(eligible)
+	}
+	sort.Slice(breaches, func(i, j int) bool { return breaches[i].Wait > breaches[j].Wait })
+	return rate, eligible, breaches
+}
+
+// formatWait renders a wait as hours below two days and as days above
+func formatWait(d time.Duration) string {
+	if d < 48*time.Hour {
+		return fmt.Sprintf("%.0fh", d.Hours())
+	}
+	return fmt.Sprintf("%.1fd", d.Hours()/24)
+}
+
+// countLabelGroups counts, per group, the issues carrying at least one label that starts
+// with one of the group's prefixes. Every configured group is present in the result.
+func countLabelGroups(issues []*github.Issue, groups map[string][]string) map[string]int {
+	counts := make(map[string]int, len(groups))
+	for name, prefixes := range groups {
+		counts[name] = 0
+		for _, issue := range issues {
+			if hasLabelWithPrefix(issue, prefixes) {
+				counts[name]++
+			}
+		}
+	}
+	return counts
+}
+
+func hasLabelWithPrefix(issue *github.Issue, prefixes []string) bool {
+	for _, label := range issue.Labels {
+		name := strings.ToLower(label.GetName())
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(name, strings.ToLower(prefix)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fetchIssues pages through issues matching opts until limit issues are collected or
+// the listing is exhausted, so no requests are made beyond the configured cap
+func fetchIssues(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, opts *github.IssueListByRepoOptions, limit int) ([]*github.Issue, error) {
+	// Page size must stay constant across requests or page offsets would overlap
+	opts.PerPage = limit
+	if opts.PerPage > 100 {
+		opts.PerPage = 100
+	}
+
+	var issues []*github.Issue
+	for len(issues) < limit {
+		page, err := client.GetIssues(ctx, repo.Owner, repo.Name, opts)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, page...)
+
+		if opts.Page == 0 {
+			break
+		}
+	}
+
+	if len(issues) > limit {
+		issues = issues[:limit]
+	}
+	return issues, nil
+}
@@ -0,0 +1,252 @@
+package issuehygiene
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+)
+
+// pagingClient serves issues in pages and records how many pages were requested.
+// Methods not overridden panic via the nil embedded interface.
+type pagingClient struct {
+	analysis.Client
+	total    int
+	requests int
+}
+
+func (c *pagingClient) GetIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, error) {
+	c.requests++
+	page := opts.Page
+	if page == 0 {
+		page = 1
+	}
+
+	start := (page - 1) * opts.PerPage
+	end := start + opts.PerPage
+	if end > c.total {
+		end = c.total
+	}
+
+	now := github.Timestamp{Time: time.Now()}
+	var issues []*github.Issue
+	for i := start; i < end; i++ {
+		issues = append(issues, &github.Issue{Number: github.Int(i + 1), CreatedAt: &now, UpdatedAt: &now})
+	}
+
+	opts.Page = 0
+	if end < c.total {
+		opts.Page = page + 1
+	}
+	return issues, nil
+}
+
+func (c *pagingClient) GetIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, error) {
+	return nil, nil
+}
+
+func TestAnalyzeRespectsMaxIssues(t *testing.T) {
+	client := &pagingClient{total: 1000}
+	cfg := analysis.Config{
+		Since:       time.Now().Add(-30 * 24 * time.Hour),
+		DepthConfig: analysis.DepthConfig{MaxIssues: 25},
+	}
+
+	result, err := New(30, 180).Analyze(context.Background(), client, analysis.TargetRepository{Owner: "o", Name: "r"}, cfg)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	// One page each for open and closed issues
+	if client.requests != 2 {
+		t.Errorf("Expected 2 requests with max 25 issues, got %d", client.requests)
+	}
+	for _, m := range result.Metrics {
+		if m.Key == "open_issues_total" && m.Value != 25 {
+			t.Errorf("Expected 25 open issues, got %.0f", m.Value)
+		}
+	}
+}
+
+func TestFetchIssuesPaginatesToLimit(t *testing.T) {
+	client := &pagingClient{total: 1000}
+
+	issues, err := fetchIssues(context.Background(), client, analysis.TargetRepository{}, &github.IssueListByRepoOptions{}, 250)
+	if err != nil {
+		t.Fatalf("fetchIssues failed: %v", err)
+	}
+	if len(issues) != 250 {
+		t.Errorf("Expected 250 issues, got %d", len(issues))
+	}
+	if client.requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", client.requests)
+	}
+	if issues[249].GetNumber() != 250 {
+		t.Errorf("Expected contiguous pages, last issue is #%d", issues[249].GetNumber())
+	}
+
+	client = &pagingClient{total: 30}
+	issues, _ = fetchIssues(context.Background(), client, analysis.TargetRepository{}, &github.IssueListByRepoOptions{}, 250)
+	if len(issues) != 30 || client.requests != 1 {
+		t.Errorf("Expected to stop after exhausting 30 issues, got %d issues in %d requests", len(issues), client.requests)
+	}
+}
+
+// fixedClient returns the same open and closed issues on every listing
+type fixedClient struct {
+	analysis.Client
+	open   []*github.Issue
+	closed []*github.Issue
+}
+
+func (c *fixedClient) GetIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, error) {
+	opts.Page = 0
+	if opts.State == "open" {
+		return c.open, nil
+	}
+	return c.closed, nil
+}
+
+func (c *fixedClient) GetIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, error) {
+	return nil, nil
+}
+
+func labeled(number int, age time.Duration, labels ...string) *github.Issue {
+	created := github.Timestamp{Time: time.Now().Add(-age)}
+	issue := &github.Issue{Number: github.Int(number), CreatedAt: &created, UpdatedAt: &created}
+	for _, l := range labels {
+		issue.Labels = append(issue.Labels, &github.Label{Name: github.String(l)})
+	}
+	return issue
+}
+
+func TestCountLabelGroups(t *testing.T) {
+	issues := []*github.Issue{
+		labeled(1, 0, "Priority: High", "bug"),
+		labeled(2, 0, "priority:low", "good first issue"),
+		labeled(3, 0, "needs-triage"),
+		labeled(4, 0),
+	}
+	counts := countLabelGroups(issues, DefaultLabelGroups)
+
+	want := map[string]int{"priority": 2, "needs_triage": 1, "good_first_issue": 1}
+	for group, n := range want {
+		if counts[group] != n {
+			t.Errorf("Expected %d issues in %s, got %d", n, group, counts[group])
+		}
+	}
+
+	counts = countLabelGroups(issues, map[string][]string{"docs": {"documentation"}})
+	if n, ok := counts["docs"]; !ok || n != 0 {
+		t.Errorf("Expected empty configured group to report 0, got %v", counts)
+	}
+}
+
+func TestAnalyzeUntriagedBacklog(t *testing.T) {
+	day := 24 * time.Hour
+	client := &fixedClient{open: []*github.Issue{
+		labeled(1, 60*day),
+		labeled(2, 45*day),
+		labeled(3, 40*day),
+		labeled(4, 50*day, "bug"), // labeled, not untriaged
+		labeled(5, 2*day),         // too new to count
+	}}
+	cfg := analysis.Config{Since: time.Now().Add(-30 * day), DepthConfig: analysis.DepthConfig{MaxIssues: 50}}
+
+	az := New(30, 180)
+	az.UntriagedThreshold = 2
+	result, err := az.Analyze(context.Background(), client, analysis.TargetRepository{Owner: "o", Name: "r"}, cfg)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	var untriaged float64 = -1
+	for _, m := range result.Metrics {
+		if m.Key == "untriaged_issues" {
+			untriaged = m.Value
+		}
+	}
+	if untriaged != 3 {
+		t.Errorf("Expected 3 untriaged issues, got %.0f", untriaged)
+	}
+	found := false
+	for _, f := range result.Findings {
+		if f.Type == "untriaged_backlog" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected untriaged_backlog finding above the threshold")
+	}
+}
+
+func TestAnalyzeIssueLifetimePercentiles(t *testing.T) {
+	day := 24 * time.Hour
+	var closed []*github.Issue
+	for i, lifetime := range []time.Duration{1 * day, 2 * day, 3 * day, 4 * day, 100 * day} {
+		issue := labeled(i+1, lifetime+day)
+		closedAt := github.Timestamp{Time: issue.GetCreatedAt().Add(lifetime)}
+		issue.ClosedAt = &closedAt
+		issue.State = github.String("closed")
+		closed = append(closed, issue)
+	}
+	cfg := analysis.Config{Since: time.Now().Add(-200 * day), DepthConfig: analysis.DepthConfig{MaxIssues: 50}}
+
+	result, err := New(30, 180).Analyze(context.Background(), &fixedClient{closed: closed}, analysis.TargetRepository{Owner: "o", Name: "r"}, cfg)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	metrics := make(map[string]float64)
+	for _, m := range result.Metrics {
+		metrics[m.Key] = m.Value
+	}
+	// One 100-day issue drags the mean far above what most issues experience
+	if metrics["avg_issue_lifetime"] != 528 {
+		t.Errorf("Expected an average lifetime of 528h, got %.1f", metrics["avg_issue_lifetime"])
+	}
+	if metrics["median_issue_lifetime"] != 72 {
+		t.Errorf("Expected a median lifetime of 72h, got %.1f", metrics["median_issue_lifetime"])
+	}
+	if got := metrics["p90_issue_lifetime"]; got < 1478.3 || got > 1478.5 {
+		t.Errorf("Expected an interpolated p90 lifetime of 1478.4h, got %.1f", got)
+	}
+}
+
+func TestSLACompliance(t *testing.T) {
+	h := time.Hour
+	responses := []issueResponse{
+		{Number: 1, Wait: 2 * h, Answered: true},
+		{Number: 2, Wait: 30 * h, Answered: true},
+		{Number: 3, Wait: 100 * h, Answered: true},
+		{Number: 4, Wait: 72 * h},                 // unanswered past the SLA
+		{Number: 5, Wait: 5 * h},                  // unanswered, still within the SLA
+		{Number: 6, Wait: 24 * h, Answered: true}, // exactly on the SLA
+	}
+
+	rate, eligible, breaches := slaCompliance(responses, 24*h)
+	if eligible != 5 {
+		t.Errorf("Expected 5 eligible issues, got %d", eligible)
+	}
+	if rate != 0.4 {
+		t.Errorf("Expected 40%% within SLA, got %.2f", rate)
+	}
+	if len(breaches) != 3 || breaches[0].Number != 3 || breaches[1].Number != 4 || breaches[2].Number != 2 {
+		t.Errorf("Expected breaches sorted by wait (#3, #4, #2), got %+v", breaches)
+	}
+
+	if _, eligible, _ := slaCompliance(nil, 24*h); eligible != 0 {
+		t.Errorf("Expected no eligible issues for an empty sample")
+	}
+}
+
+func TestFormatWait(t *testing.T) {
+	if got := formatWait(5 * time.Hour); got != "5h" {
+		t.Errorf("formatWait(5h) = %q", got)
+	}
+	if got := formatWait(72 * time.Hour); got != "3.0d" {
+		t.Errorf("formatWait(72h) = %q", got)
+	}
+}
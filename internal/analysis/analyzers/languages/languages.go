@@ -0,0 +1,134 @@
+package languages
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// dominanceThreshold is the share of bytes above which a repo is considered single-language
+const dominanceThreshold = 95.0
+
+// toolingLanguages are languages that indicate build, scripting, or infrastructure tooling
+// alongside the main codebase
+var toolingLanguages = map[string]bool{
+	"Shell":      true,
+	"PowerShell": true,
+	"Batchfile":  true,
+	"Makefile":   true,
+	"Dockerfile": true,
+	"CMake":      true,
+	"HCL":        true,
+	"Nix":        true,
+	"Just":       true,
+	"Starlark":   true,
+}
+
+type Analyzer struct{}
+
+func New() *Analyzer {
+	return &Analyzer{}
+}
+
+func (a *Analyzer) Name() string {
+	return "languages"
+}
+
+func (a *Analyzer) EstimatedCost(cfg analysis.Config) int {
+	return 1
+}
+
+func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
+	var metrics []models.Metric
+	var findings []models.Finding
+
+	langs, _, err := client.GetUnderlyingClient().Repositories.ListLanguages(ctx, repo.Owner, repo.Name)
+	if err != nil {
+		return models.AnalyzerResult{Name: a.Name()}, err
+	}
+
+	if len(langs) == 0 {
+		metrics = append(metrics, models.Metric{
+			Key:          "language_count",
+			Value:        0,
+			Unit:         "count",
+			DisplayValue: "0",
+			Description:  "No languages detected",
+		})
+		return models.AnalyzerResult{Name: a.Name(), Metrics: metrics}, nil
+	}
+
+	// Sort by bytes descending (name as tie-breaker for stable output)
+	names := make([]string, 0, len(langs))
+	var totalBytes int
+	for name, bytes := range langs {
+		names = append(names, name)
+		totalBytes += bytes
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if langs[names[i]] != langs[names[j]] {
+			return langs[names[i]] > langs[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	primary := names[0]
+	var primaryShare float64
+	if totalBytes > 0 {
+		primaryShare = float64(langs[primary]) / float64(totalBytes) * 100
+	}
+
+	metrics = append(metrics,
+		models.Metric{
+			Key:          "primary_language",
+			DisplayValue: primary,
+			Description:  "Language with the most bytes of code",
+		},
+		models.Metric{
+			Key:          "language_count",
+			Value:        float64(len(langs)),
+			Unit:         "count",
+			DisplayValue: fmt.Sprintf("%d", len(langs)),
+			Description:  "Number of languages detected",
+		},
+		models.Metric{
+			Key:          "primary_language_pct",
+			Value:        primaryShare,
+			Unit:         "%",
+			DisplayValue: fmt.Sprintf("%.1f%%", primaryShare),
+			Description:  "Share of bytes in the primary language",
+		},
+	)
+
+	hasTooling := false
+	for _, name := range names[1:] {
+		if toolingLanguages[name] {
+			hasTooling = true
+			break
+		}
+	}
+
+	if primaryShare > dominanceThreshold && !hasTooling {
+		findings = append(findings, models.Finding{
+			Type:        "single_language_no_tooling",
+			Severity:    models.SeverityInfo,
+			Message:     fmt.Sprintf("%.1f%% of code is %s with no build or scripting tooling detected", primaryShare, primary),
+			Actionable:  true,
+			Remediation: "Consider adding build automation (Makefile, scripts, Dockerfile) to standardize local workflows.",
+			Explanation: "Repositories without any scripting or build tooling often rely on undocumented manual steps for setup, builds, and releases.",
+			SuggestedActions: []string{
+				"Add a Makefile or task runner with common development targets",
+				"Provide a Dockerfile or dev container for reproducible environments",
+			},
+		})
+	}
+
+	return models.AnalyzerResult{
+		Name:     a.Name(),
+		Metrics:  metrics,
+		Findings: findings,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package languages
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// stubClient serves a fixed languages breakdown through the underlying client
+type stubClient struct {
+	analysis.Client
+	api *github.Client
+}
+
+func newStubClient(t *testing.T, langs map[string]int) *stubClient {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/languages", func(w http.ResponseWriter, r *http.Request) {
+		if langs == nil {
+			http.Error(w, `{"message": "Server Error"}`, http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(langs)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	api := github.NewClient(nil)
+	api.BaseURL, _ = url.Parse(srv.URL + "/")
+	return &stubClient{api: api}
+}
+
+func (c *stubClient) GetUnderlyingClient() *github.Client {
+	return c.api
+}
+
+func analyze(t *testing.T, langs map[string]int) models.AnalyzerResult {
+	t.Helper()
+	res, err := New().Analyze(context.Background(), newStubClient(t, langs), analysis.TargetRepository{Owner: "o", Name: "r"}, analysis.Config{})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	return res
+}
+
+func metricsByKey(res models.AnalyzerResult) map[string]models.Metric {
+	metrics := make(map[string]models.Metric)
+	for _, m := range res.Metrics {
+		metrics[m.Key] = m
+	}
+	return metrics
+}
+
+func TestAnalyzeBreakdown(t *testing.T) {
+	res := analyze(t, map[string]int{"Go": 700, "Shell": 200, "Python": 100})
+	metrics := metricsByKey(res)
+
+	if got := metrics["primary_language"].DisplayValue; got != "Go" {
+		t.Errorf("Expected primary language Go, got %q", got)
+	}
+	if got := metrics["language_count"].Value; got != 3 {
+		t.Errorf("Expected 3 languages, got %v", got)
+	}
+	if got := metrics["primary_language_pct"].Value; got != 70 {
+		t.Errorf("Expected primary share 70%%, got %v", got)
+	}
+	if len(res.Findings) != 0 {
+		t.Errorf("Expected no findings for a mixed repository, got %+v", res.Findings)
+	}
+}
+
+func TestAnalyzePrimaryTieBreaksByName(t *testing.T) {
+	metrics := metricsByKey(analyze(t, map[string]int{"Rust": 500, "C": 500}))
+	if got := metrics["primary_language"].DisplayValue; got != "C" {
+		t.Errorf("Expected ties to break by name, got %q", got)
+	}
+}
+
+func TestAnalyzeNoLanguages(t *testing.T) {
+	res := analyze(t, map[string]int{})
+	metrics := metricsByKey(res)
+
+	if len(res.Metrics) != 1 || metrics["language_count"].Value != 0 {
+		t.Errorf("Expected only a zero language_count, got %+v", res.Metrics)
+	}
+	if len(res.Findings) != 0 {
+		t.Errorf("Expected no findings, got %+v", res.Findings)
+	}
+}
+
+func TestAnalyzeSingleLanguageFinding(t *testing.T) {
+	tests := []struct {
+		name        string
+		langs       map[string]int
+		wantFinding bool
+	}{
+		{"single language without tooling", map[string]int{"Go": 990, "Python": 10}, true},
+		{"single language with tooling", map[string]int{"Go": 990, "Makefile": 10}, false},
+		{"exactly at the threshold", map[string]int{"Go": 950, "Python": 50}, false},
+		{"mixed languages", map[string]int{"Go": 600, "TypeScript": 400}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := analyze(t, tt.langs)
+			found := len(res.Findings) == 1 && res.Findings[0].Type == "single_language_no_tooling"
+			if found != tt.wantFinding {
+				t.Errorf("Expected finding %v, got %+v", tt.wantFinding, res.Findings)
+			}
+		})
+	}
+}
+
+func TestAnalyzeError(t *testing.T) {
+	client := newStubClient(t, nil)
+	_, err := New().Analyze(context.Background(), client, analysis.TargetRepository{Owner: "o", Name: "r"}, analysis.Config{})
+	if err == nil {
+		t.Error("Expected the languages API error to be returned")
+	}
+}
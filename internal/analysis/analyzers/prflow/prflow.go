@@ -3,51 +3,78 @@ package prflow
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/mikematt33/gh-inspect/internal/analysis"
 	"github.com/mikematt33/gh-inspect/pkg/models"
+	"github.com/mikematt33/gh-inspect/pkg/util"
 )
 
+// DefaultSizeSampleSize is how many merged PRs are fetched individually for PR size
+const DefaultSizeSampleSize = 20
+
+// giantPRLines is the number of changed lines above which a PR is reported as giant
+const giantPRLines = 1000
+
+// DefaultBotLogins are the PR authors left out of the human cycle time when none are configured
+var DefaultBotLogins = []string{"dependabot[bot]", "renovate[bot]", "github-actions[bot]"}
+
 type Analyzer struct {
 	StaleThresholdDays int
+	BotLogins          []string // PR authors treated as bots (case-insensitive)
+	SizeSampleSize     int      // merged PRs fetched individually for PR size; 0 disables
 }
 
 func New(staleThresholdDays int) *Analyzer {
 	return &Analyzer{
 		StaleThresholdDays: staleThresholdDays,
+		BotLogins:          DefaultBotLogins,
+		SizeSampleSize:     DefaultSizeSampleSize,
+	}
+}
+
+// isBot reports whether the PR was opened by one of the configured bot logins
+func (a *Analyzer) isBot(pr *github.PullRequest) bool {
+	login := pr.GetUser().GetLogin()
+	for _, bot := range a.BotLogins {
+		if strings.EqualFold(login, bot) {
+			return true
+		}
 	}
+	return false
 }
 
 func (a *Analyzer) Name() string {
 	return "pr-flow"
 }
 
+func (a *Analyzer) EstimatedCost(cfg analysis.Config) int {
+	// PR list pages, reviews for sampled PRs, and a PR detail lookup per size sample
+	reviewed := 5
+	if cfg.IncludeDeep {
+		reviewed = 20
+	}
+	return analysis.Pages(cfg.DepthConfig.MaxPRs) + reviewed + a.SizeSampleSize
+}
+
 func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
 	// 1. Fetch all recent PRs in one call (both open and closed) to avoid multiple API calls
 	// We'll filter by state in memory
-	// Respect MaxPRs from depth config
-	perPage := 100
-	if cfg.DepthConfig.MaxPRs > 0 && cfg.DepthConfig.MaxPRs < 100 {
-		perPage = cfg.DepthConfig.MaxPRs
+	// Respect MaxPRs from depth config, paging until the limit is reached
+	maxPRs := cfg.DepthConfig.MaxPRs
+	if maxPRs == 0 {
+		maxPRs = 100
 	}
-
-	opts := &github.PullRequestListOptions{
-		State:       "all",
-		ListOptions: github.ListOptions{PerPage: perPage},
-		Sort:        "updated",
-		Direction:   "desc",
-	}
-
-	allPRs, err := client.GetPullRequests(ctx, repo.Owner, repo.Name, opts)
+	// Fetch every PR updated in the window, bounded by the depth's PR limit
+	allPRs, err := client.GetPullRequestsSince(ctx, repo.Owner, repo.Name, cfg.Since, analysis.Pages(maxPRs))
 	if err != nil {
 		return models.AnalyzerResult{Name: a.Name()}, err
 	}
-
-	// Apply MaxPRs limit if configured
-	if cfg.DepthConfig.MaxPRs > 0 && len(allPRs) > cfg.DepthConfig.MaxPRs {
-		allPRs = allPRs[:cfg.DepthConfig.MaxPRs]
+	if len(allPRs) > maxPRs {
+		allPRs = allPRs[:maxPRs]
 	}
 
 	// Filter by Config.Since and separate by state
@@ -69,11 +96,36 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 	var selfMergeCount int
 	var draftPRCount int
 	var hasDescriptionCount int
+	// Bot PRs often merge instantly via automerge, so human cycle time is tracked separately
+	var humanMergeTime time.Duration
+	var humanMergedCount int
+	var botPRCount int
+	// Open PRs count toward the bot ratio only when they were opened in the window
+	var openedInWindow int
+
+	for _, pr := range openPRs {
+		if pr.GetCreatedAt().Before(cfg.Since) {
+			continue
+		}
+		openedInWindow++
+		if a.isBot(pr) {
+			botPRCount++
+		}
+	}
 
 	for _, pr := range recentClosedPRs {
+		isBot := a.isBot(pr)
+		if isBot {
+			botPRCount++
+		}
+
 		if pr.MergedAt != nil {
 			mergedCount++
 			totalMergeTime += pr.MergedAt.Sub(pr.CreatedAt.Time)
+			if !isBot {
+				humanMergedCount++
+				humanMergeTime += pr.MergedAt.Sub(pr.CreatedAt.Time)
+			}
 
 			// Check self-merge (author == merger)
 			if pr.User != nil && pr.MergedBy != nil {
@@ -96,7 +148,9 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 
 	// Metrics Calculation
 	var metrics []models.Metric
-	var sizeFindings []models.Finding // Local findings for size analysis
+	var sizes []int
+	var sizeFindings []models.Finding   // Local findings for size analysis
+	var reviewFindings []models.Finding // Local findings for reviewer load
 
 	// 2. Use already fetched PRs for "Time to First Review" (avoid duplicate API call)
 	// Sample from the PRs we already have instead of fetching again
@@ -119,6 +173,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		var totalComments int
 		var totalReviewers int
 		authorReviewerPairs := make(map[string]map[string]bool) // author -> set of reviewers
+		approvalsByReviewer := make(map[string]int)             // reviewer -> others' PRs they approved
 
 		for i, pr := range samplePRs {
 			if i >= limitChecks {
@@ -139,6 +194,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 				// Track unique reviewers and collaboration patterns
 				prAuthor := pr.User.GetLogin()
 				reviewersForThisPR := make(map[string]bool)
+				approversForThisPR := make(map[string]bool) // re-approvals after new pushes count once
 
 				// Count approvals
 				for _, review := range reviews {
@@ -152,6 +208,10 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 						reviewer = review.User.GetLogin()
 					}
 					if reviewer != "" && reviewer != prAuthor {
+						if review.GetState() == "APPROVED" && !approversForThisPR[reviewer] {
+							approversForThisPR[reviewer] = true
+							approvalsByReviewer[reviewer]++
+						}
 						uniqueReviewers[reviewer] = true
 						reviewersForThisPR[reviewer] = true
 
@@ -247,6 +307,32 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 				Description:  "Number of active code reviewers",
 			})
 		}
+
+		// Reviewer load: like the commit bus factor, how few reviewers give half the approvals
+		if busFactor, top, topApprovals, approvals := reviewerBusFactor(approvalsByReviewer); approvals > 0 {
+			metrics = append(metrics, models.Metric{
+				Key:          "reviewer_bus_factor",
+				Value:        float64(busFactor),
+				Unit:         "reviewers",
+				DisplayValue: fmt.Sprintf("%d", busFactor),
+				Description:  fmt.Sprintf("Reviewers accounting for 50%% of %d approvals on sampled PRs", approvals),
+			})
+			share := float64(topApprovals) / float64(approvals)
+			if approvals >= minApprovalsForReviewerFinding && share > 0.5 {
+				reviewFindings = append(reviewFindings, models.Finding{
+					Type:        "reviewer_bottleneck",
+					Severity:    models.SeverityMedium,
+					Message:     fmt.Sprintf("%s gave %d of %d approvals (%.0f%%) on sampled PRs", top, topApprovals, approvals, share*100),
+					Actionable:  true,
+					Remediation: "Spread review ownership across more people.",
+					Explanation: "When one reviewer approves most PRs, merges wait on their availability and knowledge of the code concentrates in one person, even if CODEOWNERS lists several owners.",
+					SuggestedActions: []string{
+						"Add more owners to CODEOWNERS entries and use team review assignment",
+						"Rotate review duty so others build familiarity with the code",
+					},
+				})
+			}
+		}
 	}
 
 	if mergedCount > 0 {
@@ -257,83 +343,44 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 			Unit:         "hours",
 			DisplayValue: fmt.Sprintf("%.1fh", avgTime.Hours()),
 		})
-
-		// PR Size Distribution
-		// Note: ListPullRequests response PR objects might not always have Additions/Deletions populated
-		// unless fetched individually in some API versions.
-		// However, if we are sampling, we can check.
-		// If fields are zero, we might be hitting that limitation.
-		// But let's check our recentClosedPRs.
-
-		var totalAdditions, totalDeletions int
-		var prsWithData int
-
-		for _, pr := range recentClosedPRs {
-			// Check if we have size data (Additions or Deletions > 0 or ChangedFiles > 0)
-			// Usually list endpoints don't return these stats. We need get single PR for that.
-			// Since we want "Size Distribution", and doing it for ALL 100 PRs is expensive...
-			// Let's create a finding if we have "Huge PRs" based on a small sample or if available.
-			// NOTE: google/go-github docs say Additions/Deletions are available on GetSingle, not List.
-
-			// Optimization: We won't fetch individual PRs here to respect rate limits.
-			// We'll rely on what we have. If 0, we skip.
-			if pr.Additions != nil {
-				totalAdditions += *pr.Additions
-				totalDeletions += *pr.Deletions
-				prsWithData++
-			}
+		if humanMergedCount > 0 {
+			avgHuman := humanMergeTime / time.Duration(humanMergedCount)
+			metrics = append(metrics, models.Metric{
+				Key:          "avg_cycle_time_hours_human",
+				Value:        avgHuman.Hours(),
+				Unit:         "hours",
+				DisplayValue: fmt.Sprintf("%.1fh", avgHuman.Hours()),
+				Description:  "Average time to merge, excluding PRs opened by bots",
+			})
 		}
 
-		// If list doesn't return size (likely), we might scan just the top 5 recently merged to check for "Giant PRs"
-		// This fits "Opinionated Insights".
-
-		if prsWithData == 0 && len(recentClosedPRs) > 0 {
-			// Sample top 5 merged PRs for size data
-			// Only fetch individual PRs if absolutely necessary (list doesn't have size data)
-			limit := 5
-			if len(recentClosedPRs) < limit {
-				limit = len(recentClosedPRs)
+		// PR Size: list endpoints don't return additions/deletions, so fetch the most
+		// recently merged PRs individually
+		sizes, sizeFindings = a.sampleSizes(ctx, client, repo, recentClosedPRs)
+		if len(sizes) > 0 {
+			var total int
+			for _, n := range sizes {
+				total += n
 			}
-
-			for i := 0; i < limit; i++ {
-				prNum := recentClosedPRs[i].GetNumber()
-				fullPR, err := client.GetPullRequest(ctx, repo.Owner, repo.Name, prNum)
-				if err == nil {
-					adds := fullPR.GetAdditions()
-					dels := fullPR.GetDeletions()
-					total := adds + dels
-
-					if total > 1000 {
-						sizeFindings = append(sizeFindings, models.Finding{
-							Type:        "giant_pr",
-							Severity:    models.SeverityInfo,
-							Message:     fmt.Sprintf("Large PR detected: #%d has %d changes. Large PRs slow down review.", prNum, total),
-							Actionable:  true,
-							Remediation: "Split PR into smaller chunks.",
-							Explanation: "Large PRs are harder to review thoroughly, leading to missed bugs and slower iteration cycles.",
-							SuggestedActions: []string{
-								"Break this PR into logical, independent smaller PRs",
-								"Use feature flags to merge incomplete features incrementally",
-							},
-						})
-					}
-
-					totalAdditions += adds
-					totalDeletions += dels
-					prsWithData++
-				}
-			}
-		}
-
-		if prsWithData > 0 {
-			avgSize := (totalAdditions + totalDeletions) / prsWithData
-			metrics = append(metrics, models.Metric{
-				Key:          "avg_pr_size_lines",
-				Value:        float64(avgSize),
-				Unit:         "lines",
-				DisplayValue: fmt.Sprintf("%d LOC", avgSize),
-				Description:  "Average lines changed (add+del) per PR (sampled)",
-			})
+			avgSize := total / len(sizes)
+			medianSize := median(sizes)
+			merged := countMerged(recentClosedPRs)
+			metrics = append(metrics,
+				models.Metric{
+					Key:          "avg_pr_size_lines",
+					Value:        float64(avgSize),
+					Unit:         "lines",
+					DisplayValue: fmt.Sprintf("%d LOC", avgSize),
+					Description:  fmt.Sprintf("Average lines changed (add+del) per PR, from the %d most recently merged of %d merged PRs", len(sizes), merged),
+				},
+				models.Metric{
+					Key:          "median_pr_size_lines",
+					Value:        float64(medianSize),
+					Unit:         "lines",
+					DisplayValue: fmt.Sprintf("%d LOC", medianSize),
+					Description:  fmt.Sprintf("Median lines changed (add+del) per PR, from the %d most recently merged of %d merged PRs", len(sizes), merged),
+				},
+			)
 		}
 	}
 
@@ -377,9 +424,50 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		})
 	}
 
+	if windowPRs := totalClosed + openedInWindow; windowPRs > 0 {
+		botRatio := float64(botPRCount) / float64(windowPRs) * 100
+		metrics = append(metrics, models.Metric{
+			Key:          "bot_pr_ratio",
+			Value:        botRatio,
+			Unit:         "percent",
+			DisplayValue: fmt.Sprintf("%.0f%%", botRatio),
+			Description:  "Percentage of PRs in the window opened by bots",
+		})
+	}
+
+	// Open PR backlog: how long the open PRs have been waiting, not just how many are stale
+	now := time.Now()
+	if ages := openPRAgeDays(openPRs, now); len(ages) > 0 {
+		p50, p90 := util.Percentile(ages, 50), util.Percentile(ages, 90)
+		metrics = append(metrics,
+			models.Metric{
+				Key:          "open_pr_age_median_days",
+				Value:        p50,
+				Unit:         "days",
+				DisplayValue: fmt.Sprintf("%.1f days", p50),
+				Description:  fmt.Sprintf("Median age of %d open PRs updated in the window", len(ages)),
+			},
+			models.Metric{
+				Key:          "open_pr_age_p90_days",
+				Value:        p90,
+				Unit:         "days",
+				DisplayValue: fmt.Sprintf("%.1f days", p90),
+				Description:  "90th percentile age of open PRs",
+			},
+		)
+		for _, b := range bucketAges(ages) {
+			metrics = append(metrics, models.Metric{
+				Key:          "open_prs_age_" + b.key,
+				Value:        float64(b.count),
+				Unit:         "count",
+				DisplayValue: fmt.Sprintf("%d", b.count),
+				Description:  fmt.Sprintf("Open PRs aged %s", b.label),
+			})
+		}
+	}
+
 	// 3. Stale PRs (Findings) - use already fetched open PRs
 	var findings []models.Finding
-	now := time.Now()
 
 	for _, pr := range openPRs {
 		if pr.UpdatedAt == nil {
@@ -406,6 +494,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 
 	// Merge findings
 	findings = append(findings, sizeFindings...)
+	findings = append(findings, reviewFindings...)
 
 	return models.AnalyzerResult{
 		Name:     a.Name(),
@@ -413,3 +502,146 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		Findings: findings,
 	}, nil
 }
+
+// sampleSizes fetches the lines changed for the SizeSampleSize most recently merged PRs,
+// so the same PRs are sampled on every run. PRs that fail to load are left out.
+func (a *Analyzer) sampleSizes(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, prs []*github.PullRequest) ([]int, []models.Finding) {
+	var merged []*github.PullRequest
+	for _, pr := range prs {
+		if pr.MergedAt != nil {
+			merged = append(merged, pr)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if !merged[i].MergedAt.Time.Equal(merged[j].MergedAt.Time) {
+			return merged[i].MergedAt.Time.After(merged[j].MergedAt.Time)
+		}
+		return merged[i].GetNumber() > merged[j].GetNumber()
+	})
+	if len(merged) > a.SizeSampleSize {
+		merged = merged[:a.SizeSampleSize]
+	}
+
+	var sizes []int
+	var findings []models.Finding
+	for _, pr := range merged {
+		fullPR, err := client.GetPullRequest(ctx, repo.Owner, repo.Name, pr.GetNumber())
+		if err != nil || fullPR == nil {
+			continue
+		}
+		total := fullPR.GetAdditions() + fullPR.GetDeletions()
+		sizes = append(sizes, total)
+
+		if total > giantPRLines {
+			findings = append(findings, models.Finding{
+				Type:        "giant_pr",
+				Severity:    models.SeverityInfo,
+				Message:     fmt.Sprintf("Large PR detected: #%d has %d changes. Large PRs slow down review.", pr.GetNumber(), total),
+				Location:    pr.GetHTMLURL(),
+				Actionable:  true,
+				Remediation: "Split PR into smaller chunks.",
+				Explanation: "Large PRs are harder to review thoroughly, leading to missed bugs and slower iteration cycles.",
+				SuggestedActions: []string{
+					"Break this PR into logical, independent smaller PRs",
+					"Use feature flags to merge incomplete features incrementally",
+				},
+			})
+		}
+	}
+	return sizes, findings
+}
+
+// openPRAgeDays returns the age in days of each open PR, sorted ascending
+func openPRAgeDays(prs []*github.PullRequest, now time.Time) []float64 {
+	var ages []float64
+	for _, pr := range prs {
+		if pr.CreatedAt == nil {
+			continue
+		}
+		ages = append(ages, now.Sub(pr.CreatedAt.Time).Hours()/24)
+	}
+	sort.Float64s(ages)
+	return ages
+}
+
+// minApprovalsForReviewerFinding keeps a handful of sampled approvals from flagging a bottleneck
+const minApprovalsForReviewerFinding = 5
+
+// reviewerBusFactor returns how many reviewers account for half of the approvals, the
+// reviewer with the most approvals and their count, and the total number of approvals
+func reviewerBusFactor(approvalsByReviewer map[string]int) (busFactor int, top string, topApprovals, total int) {
+	type reviewerCount struct {
+		login string
+		count int
+	}
+	var sorted []reviewerCount
+	for login, count := range approvalsByReviewer {
+		sorted = append(sorted, reviewerCount{login, count})
+		total += count
+	}
+	if total == 0 {
+		return 0, "", 0, 0
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].count != sorted[j].count {
+			return sorted[i].count > sorted[j].count
+		}
+		return sorted[i].login < sorted[j].login
+	})
+
+	accumulated := 0
+	for _, rc := range sorted {
+		accumulated += rc.count
+		busFactor++
+		if float64(accumulated)/float64(total) >= 0.5 {
+			break
+		}
+	}
+	return busFactor, sorted[0].login, sorted[0].count, total
+}
+
+type ageBucket struct {
+	key, label string
+	maxDays    float64 // Exclusive upper bound; 0 means unbounded
+	count      int
+}
+
+// bucketAges counts ages (in days) into the open PR age ranges
+func bucketAges(ages []float64) []ageBucket {
+	buckets := []ageBucket{
+		{key: "0_3d", label: "under 3 days", maxDays: 3},
+		{key: "3_7d", label: "3-7 days", maxDays: 7},
+		{key: "7_30d", label: "7-30 days", maxDays: 30},
+		{key: "30d_plus", label: "30 days or more"},
+	}
+	for _, age := range ages {
+		for i := range buckets {
+			if buckets[i].maxDays == 0 || age < buckets[i].maxDays {
+				buckets[i].count++
+				break
+			}
+		}
+	}
+	return buckets
+}
+
+func countMerged(prs []*github.PullRequest) int {
+	n := 0
+	for _, pr := range prs {
+		if pr.MergedAt != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// median returns the middle value of sizes, averaging the two middle values for an even count
+func median(sizes []int) int {
+	sorted := append([]int(nil), sizes...)
+	sort.Ints(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
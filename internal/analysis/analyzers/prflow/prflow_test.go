@@ -2,12 +2,14 @@ package prflow
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/pkg/models"
 )
 
 // MockClient implements analysis.Client for testing
@@ -34,6 +36,16 @@ func (m *MockClient) GetPullRequests(ctx context.Context, owner, repo string, op
 	return filtered, nil
 }
 
+func (m *MockClient) GetPullRequestsSince(ctx context.Context, owner, repo string, since time.Time, maxPages int) ([]*github.PullRequest, error) {
+	var recent []*github.PullRequest
+	for _, pr := range m.PullRequests {
+		if !pr.GetUpdatedAt().Before(since) {
+			recent = append(recent, pr)
+		}
+	}
+	return recent, nil
+}
+
 func (m *MockClient) GetReviews(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, error) {
 	return m.Reviews[number], nil
 }
@@ -43,7 +55,7 @@ func (m *MockClient) GetPullRequest(ctx context.Context, owner, repo string, num
 }
 
 // Unused methods stubbed
-func (m *MockClient) ListCommitsSince(ctx context.Context, owner, repo string, since time.Time) ([]*github.RepositoryCommit, error) {
+func (m *MockClient) ListCommitsSince(ctx context.Context, owner, repo, ref string, since time.Time) ([]*github.RepositoryCommit, error) {
 	return m.Commits, nil
 }
 func (m *MockClient) GetRateLimit(ctx context.Context) (*github.Rate, error) {
@@ -52,9 +64,15 @@ func (m *MockClient) GetRateLimit(ctx context.Context) (*github.Rate, error) {
 func (m *MockClient) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, error) {
 	return nil, nil
 }
+func (m *MockClient) GetRepoOverview(ctx context.Context, owner, repo string) (*analysis.RepoOverview, error) {
+	return nil, errors.New("graphql not available")
+}
 func (m *MockClient) GetContent(ctx context.Context, owner, repo, path string) (*github.RepositoryContent, []*github.RepositoryContent, error) {
 	return nil, nil, nil
 }
+func (m *MockClient) GetContentAtRef(ctx context.Context, owner, repo, path, ref string) (*github.RepositoryContent, []*github.RepositoryContent, error) {
+	return nil, nil, nil
+}
 func (m *MockClient) GetCombinedStatus(ctx context.Context, owner, repo, ref string) (*github.CombinedStatus, error) {
 	return m.CombinedStatus, nil
 }
@@ -70,6 +88,9 @@ func (m *MockClient) GetWorkflowRuns(ctx context.Context, owner, repo string, op
 func (m *MockClient) ListRepositories(ctx context.Context, org string, opts *github.RepositoryListByOrgOptions) ([]*github.Repository, error) {
 	return m.Repositories, nil
 }
+func (m *MockClient) ListStargazers(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Stargazer, error) {
+	return nil, nil
+}
 func (m *MockClient) GetUnderlyingClient() *github.Client {
 	return nil
 }
@@ -194,3 +215,209 @@ func TestAnalyzer_Analyze(t *testing.T) {
 		t.Error("Expected giant_pr finding for PR #3")
 	}
 }
+
+func TestAnalyzer_BotPRs(t *testing.T) {
+	now := time.Now()
+	merged := func(number int, author string, cycle time.Duration) *github.PullRequest {
+		return &github.PullRequest{
+			Number:    github.Int(number),
+			State:     github.String("closed"),
+			CreatedAt: &github.Timestamp{Time: now.Add(-cycle)},
+			MergedAt:  &github.Timestamp{Time: now},
+			UpdatedAt: &github.Timestamp{Time: now},
+			User:      &github.User{Login: github.String(author)},
+		}
+	}
+
+	mockClient := &MockClient{
+		PullRequests: []*github.PullRequest{
+			merged(1, "dev1", 40*time.Hour),
+			merged(2, "Dependabot[bot]", 0),
+			merged(3, "renovate[bot]", 0),
+			merged(4, "dev2", 20*time.Hour),
+			// Open bot PR from before the window: excluded from the bot ratio
+			{
+				Number:    github.Int(5),
+				State:     github.String("open"),
+				CreatedAt: &github.Timestamp{Time: now.Add(-30 * 24 * time.Hour)},
+				UpdatedAt: &github.Timestamp{Time: now},
+				User:      &github.User{Login: github.String("dependabot[bot]")},
+			},
+		},
+		SinglePR: map[int]*github.PullRequest{},
+	}
+
+	result, err := New(7).Analyze(context.Background(), mockClient, analysis.TargetRepository{Owner: "test", Name: "repo"},
+		analysis.Config{Since: now.Add(-7 * 24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	metrics := make(map[string]float64)
+	for _, m := range result.Metrics {
+		metrics[m.Key] = m.Value
+	}
+	if v := metrics["avg_cycle_time_hours"]; v < 14.9 || v > 15.1 {
+		t.Errorf("Expected overall cycle time ~15h, got %v", v)
+	}
+	if v := metrics["avg_cycle_time_hours_human"]; v < 29.9 || v > 30.1 {
+		t.Errorf("Expected human cycle time ~30h, got %v", v)
+	}
+	if metrics["bot_pr_ratio"] != 50 {
+		t.Errorf("Expected bot_pr_ratio of 50%%, got %v", metrics["bot_pr_ratio"])
+	}
+}
+
+func TestAnalyzer_PRSizeSample(t *testing.T) {
+	now := time.Now()
+	var listed []*github.PullRequest
+	detail := map[int]*github.PullRequest{}
+	// PR n merged n hours ago; only the three most recent should be sampled
+	for n, lines := range map[int]int{1: 10, 2: 300, 3: 20, 4: 5000, 5: 5000} {
+		merged := &github.Timestamp{Time: now.Add(-time.Duration(n) * time.Hour)}
+		listed = append(listed, &github.PullRequest{
+			Number:    github.Int(n),
+			State:     github.String("closed"),
+			CreatedAt: &github.Timestamp{Time: now.Add(-48 * time.Hour)},
+			MergedAt:  merged,
+			UpdatedAt: merged,
+		})
+		detail[n] = &github.PullRequest{Number: github.Int(n), Additions: github.Int(lines), Deletions: github.Int(0)}
+	}
+
+	analyzer := New(7)
+	analyzer.SizeSampleSize = 3
+	result, err := analyzer.Analyze(context.Background(), &MockClient{PullRequests: listed, SinglePR: detail},
+		analysis.TargetRepository{Owner: "test", Name: "repo"}, analysis.Config{Since: now.Add(-7 * 24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	metrics := make(map[string]models.Metric)
+	for _, m := range result.Metrics {
+		metrics[m.Key] = m
+	}
+	if got := metrics["avg_pr_size_lines"]; got.Value != 110 || !strings.Contains(got.Description, "3 most recently merged of 5") {
+		t.Errorf("Expected an average of 110 lines over 3 of 5 PRs, got %v (%q)", got.Value, got.Description)
+	}
+	if got := metrics["median_pr_size_lines"].Value; got != 20 {
+		t.Errorf("Expected a median of 20 lines, got %v", got)
+	}
+	for _, f := range result.Findings {
+		if f.Type == "giant_pr" {
+			t.Errorf("Older PRs outside the sample should not be reported: %s", f.Message)
+		}
+	}
+
+	// A sample size of 0 skips the PR lookups and drops them from the estimate
+	cfg := analysis.Config{Since: now.Add(-7 * 24 * time.Hour)}
+	sampled := analyzer.EstimatedCost(cfg)
+	analyzer.SizeSampleSize = 0
+	if got := sampled - analyzer.EstimatedCost(cfg); got != 3 {
+		t.Errorf("Expected the estimate to drop by the 3 sampled PRs, dropped by %d", got)
+	}
+	result, err = analyzer.Analyze(context.Background(), &MockClient{PullRequests: listed, SinglePR: detail},
+		analysis.TargetRepository{Owner: "test", Name: "repo"}, cfg)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	for _, m := range result.Metrics {
+		if m.Key == "avg_pr_size_lines" || m.Key == "median_pr_size_lines" {
+			t.Errorf("Expected no PR size metrics with sampling off, got %s", m.Key)
+		}
+	}
+}
+
+func TestAnalyzer_OpenPRAges(t *testing.T) {
+	now := time.Now()
+	var prs []*github.PullRequest
+	for n, days := range []int{1, 2, 5, 10, 20, 45} {
+		prs = append(prs, &github.PullRequest{
+			Number:    github.Int(n + 1),
+			State:     github.String("open"),
+			CreatedAt: &github.Timestamp{Time: now.Add(-time.Duration(days) * 24 * time.Hour)},
+			UpdatedAt: &github.Timestamp{Time: now},
+		})
+	}
+
+	result, err := New(7).Analyze(context.Background(), &MockClient{PullRequests: prs, SinglePR: map[int]*github.PullRequest{}},
+		analysis.TargetRepository{Owner: "test", Name: "repo"}, analysis.Config{Since: now.Add(-7 * 24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	metrics := make(map[string]float64)
+	for _, m := range result.Metrics {
+		metrics[m.Key] = m.Value
+	}
+	if v := metrics["open_pr_age_median_days"]; v < 7.4 || v > 7.6 {
+		t.Errorf("Expected a median age of ~7.5 days, got %v", v)
+	}
+	if v := metrics["open_pr_age_p90_days"]; v < 32.4 || v > 32.6 {
+		t.Errorf("Expected a p90 age of ~32.5 days, got %v", v)
+	}
+	want := map[string]float64{"open_prs_age_0_3d": 2, "open_prs_age_3_7d": 1, "open_prs_age_7_30d": 2, "open_prs_age_30d_plus": 1}
+	for key, count := range want {
+		if metrics[key] != count {
+			t.Errorf("Expected %s = %v, got %v", key, count, metrics[key])
+		}
+	}
+}
+
+func TestAnalyzer_ReviewerBusFactor(t *testing.T) {
+	now := time.Now()
+	review := func(login, state string) *github.PullRequestReview {
+		return &github.PullRequestReview{User: &github.User{Login: github.String(login)}, State: github.String(state), SubmittedAt: &github.Timestamp{Time: now}}
+	}
+	var prs []*github.PullRequest
+	reviews := make(map[int][]*github.PullRequestReview)
+	for n := 1; n <= 5; n++ {
+		prs = append(prs, &github.PullRequest{
+			Number:    github.Int(n),
+			State:     github.String("closed"),
+			User:      &github.User{Login: github.String("author")},
+			CreatedAt: &github.Timestamp{Time: now.Add(-time.Hour)},
+			UpdatedAt: &github.Timestamp{Time: now},
+		})
+		reviews[n] = []*github.PullRequestReview{review("alice", "APPROVED"), review("author", "APPROVED")}
+	}
+	reviews[5] = append(reviews[5], review("bob", "APPROVED"), review("carol", "COMMENTED"))
+	// bob re-approves the same PR after new pushes
+	reviews[5] = append(reviews[5], review("bob", "APPROVED"))
+
+	result, err := New(7).Analyze(context.Background(), &MockClient{PullRequests: prs, Reviews: reviews, SinglePR: map[int]*github.PullRequest{}},
+		analysis.TargetRepository{Owner: "test", Name: "repo"}, analysis.Config{Since: now.Add(-7 * 24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	var busFactor float64 = -1
+	for _, m := range result.Metrics {
+		if m.Key == "reviewer_bus_factor" {
+			busFactor = m.Value
+		}
+	}
+	if busFactor != 1 {
+		t.Errorf("Expected a reviewer bus factor of 1, got %v", busFactor)
+	}
+	var bottleneck *models.Finding
+	for i := range result.Findings {
+		if result.Findings[i].Type == "reviewer_bottleneck" {
+			bottleneck = &result.Findings[i]
+		}
+	}
+	// Self-approvals, comments and re-approvals don't count: alice gave 5 of 6 approvals
+	if bottleneck == nil || !strings.Contains(bottleneck.Message, "alice gave 5 of 6 approvals (83%)") {
+		t.Errorf("Expected a reviewer_bottleneck finding for alice, got %+v", bottleneck)
+	}
+}
+
+func TestReviewerBusFactor(t *testing.T) {
+	if bf, _, _, total := reviewerBusFactor(nil); bf != 0 || total != 0 {
+		t.Errorf("Expected 0 without approvals, got %d of %d", bf, total)
+	}
+	bf, top, topCount, total := reviewerBusFactor(map[string]int{"a": 3, "b": 3, "c": 2, "d": 2})
+	if bf != 2 || top != "a" || topCount != 3 || total != 10 {
+		t.Errorf("Expected 2 reviewers led by a (3 of 10), got %d, %s (%d of %d)", bf, top, topCount, total)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v60/github"
@@ -12,6 +13,12 @@ import (
 	"github.com/mikematt33/gh-inspect/pkg/models"
 )
 
+// minChangelogLength is the body length below which release notes are treated as missing
+const minChangelogLength = 50
+
+// autoGeneratedSections are the level-2 headings GitHub's generated release notes use
+var autoGeneratedSections = []string{"## What's Changed", "## New Contributors"}
+
 type Analyzer struct{}
 
 func New() *Analyzer {
@@ -22,6 +29,10 @@ func (a *Analyzer) Name() string {
 	return "releases"
 }
 
+func (a *Analyzer) EstimatedCost(cfg analysis.Config) int {
+	return 1
+}
+
 func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
 	var metrics []models.Metric
 	var findings []models.Finding
@@ -129,6 +140,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 	// Pre-release vs stable ratio
 	preReleaseCount := 0
 	hasChangelogCount := 0
+	handwrittenCount := 0
 	semverCompliant := 0
 	semverPattern := regexp.MustCompile(`^v?\d+\.\d+\.\d+`)
 
@@ -136,9 +148,12 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		if release.GetPrerelease() {
 			preReleaseCount++
 		}
-		if len(release.GetBody()) > 50 {
+		if len(release.GetBody()) > minChangelogLength {
 			hasChangelogCount++
 		}
+		if len(handwrittenNotes(release.GetBody())) > minChangelogLength {
+			handwrittenCount++
+		}
 		if semverPattern.MatchString(release.GetTagName()) {
 			semverCompliant++
 		}
@@ -146,6 +161,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 
 	preReleaseRatio := float64(preReleaseCount) / float64(len(recentReleases)) * 100
 	changelogRatio := float64(hasChangelogCount) / float64(len(recentReleases)) * 100
+	handwrittenRatio := float64(handwrittenCount) / float64(len(recentReleases)) * 100
 	semverRatio := float64(semverCompliant) / float64(len(recentReleases)) * 100
 
 	metrics = append(metrics, models.Metric{
@@ -162,6 +178,13 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		DisplayValue: fmt.Sprintf("%.0f%%", changelogRatio),
 		Description:  "Releases with release notes",
 	})
+	metrics = append(metrics, models.Metric{
+		Key:          "handwritten_changelog_ratio",
+		Value:        handwrittenRatio,
+		Unit:         "percent",
+		DisplayValue: fmt.Sprintf("%.0f%%", handwrittenRatio),
+		Description:  "Releases with hand-written notes (beyond GitHub's generated list)",
+	})
 	metrics = append(metrics, models.Metric{
 		Key:          "semver_compliance",
 		Value:        semverRatio,
@@ -266,6 +289,15 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 			Actionable:  true,
 			Remediation: "Add changelog or release notes to releases.",
 		})
+	} else if handwrittenRatio < 50 {
+		findings = append(findings, models.Finding{
+			Type:        "auto_generated_release_notes",
+			Severity:    models.SeverityInfo,
+			Message:     fmt.Sprintf("%.0f%% of releases only carry auto-generated notes", 100-handwrittenRatio),
+			Actionable:  true,
+			Remediation: "Add a short hand-written summary of highlights and breaking changes above the generated list.",
+			Explanation: "Generated notes list merged PRs but rarely explain upgrade impact or notable changes.",
+		})
 	}
 
 	return models.AnalyzerResult{
@@ -274,3 +306,30 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		Findings: findings,
 	}, nil
 }
+
+// handwrittenNotes strips the sections GitHub adds to auto-generated release notes
+// ("What's Changed", "New Contributors" and the "Full Changelog" compare link) and
+// returns whatever text remains.
+func handwrittenNotes(body string) string {
+	var kept []string
+	skipping := false
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "## ") {
+			skipping = false
+			for _, heading := range autoGeneratedSections {
+				if strings.EqualFold(trimmed, heading) {
+					skipping = true
+				}
+			}
+			if skipping {
+				continue
+			}
+		}
+		if skipping || strings.Contains(trimmed, "Full Changelog") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
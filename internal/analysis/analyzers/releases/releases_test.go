@@ -0,0 +1,37 @@
+package releases
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHandwrittenNotes(t *testing.T) {
+	generated := "## What's Changed\r\n* Fix login redirect by @alice in https://github.com/o/r/pull/12\r\n" +
+		"* Bump deps by @dependabot in https://github.com/o/r/pull/13\r\n\r\n" +
+		"## New Contributors\r\n* @alice made their first contribution in https://github.com/o/r/pull/12\r\n\r\n" +
+		"**Full Changelog**: https://github.com/o/r/compare/v1.0.0...v1.1.0"
+
+	tests := []struct {
+		name            string
+		body            string
+		wantHandwritten bool
+	}{
+		{"generated only", generated, false},
+		{"generated with categories", "## What's Changed\n### Features\n* Add export by @bob in https://github.com/o/r/pull/7\n", false},
+		{"summary above generated", "## Highlights\nThis release adds CSV export and drops support for Go 1.20.\n\n" + generated, true},
+		{"hand-written", "Bug fixes:\n- Handle empty config files without crashing\n- Respect --no-color in the progress bar", true},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes := handwrittenNotes(tt.body)
+			if got := len(notes) > minChangelogLength; got != tt.wantHandwritten {
+				t.Errorf("handwritten = %v, want %v (remaining: %q)", got, tt.wantHandwritten, notes)
+			}
+			if strings.Contains(notes, "Full Changelog") {
+				t.Errorf("Full Changelog link should be stripped: %q", notes)
+			}
+		})
+	}
+}
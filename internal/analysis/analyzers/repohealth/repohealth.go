@@ -3,104 +3,173 @@ package repohealth
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
 
+	"github.com/google/go-github/v60/github"
 	"github.com/mikematt33/gh-inspect/internal/analysis"
 	"github.com/mikematt33/gh-inspect/pkg/models"
 )
 
-type Analyzer struct{}
+// KeyFile is a file the repository is expected to contain. AltPaths are checked in
+// order when Path is missing; Deduction is subtracted from the health score if none exist.
+type KeyFile struct {
+	Path      string
+	AltPaths  []string
+	Severity  models.Severity
+	Deduction int
+}
+
+// DefaultKeyFiles is used when no required files are configured
+var DefaultKeyFiles = []KeyFile{
+	{"LICENSE", nil, models.SeverityHigh, 30},
+	{"README.md", nil, models.SeverityMedium, 10},
+	{"CONTRIBUTING.md", nil, models.SeverityLow, 5},
+	{"SECURITY.md", []string{".github/SECURITY.md"}, models.SeverityMedium, 15},
+	{"CODE_OF_CONDUCT.md", []string{".github/CODE_OF_CONDUCT.md"}, models.SeverityLow, 5},
+	// GitHub looks for CODEOWNERS in .github/, the root, then docs/ and uses the first it finds
+	{".github/CODEOWNERS", []string{"CODEOWNERS", "docs/CODEOWNERS"}, models.SeverityLow, 5},
+}
+
+// Default thresholds of the large_files check
+const (
+	DefaultLargeFileMB = 10  // a single blob at least this large is flagged
+	DefaultLargeTreeMB = 500 // blobs adding up to at least this much are flagged
+)
+
+// largeFilesListed is how many of the biggest blobs the large_files finding names
+const largeFilesListed = 5
+
+type Analyzer struct {
+	KeyFiles    []KeyFile
+	LargeFileMB int // blobs at least this large are flagged; 0 disables
+	LargeTreeMB int // trees whose blobs add up to at least this much are flagged; 0 disables
+}
 
 func New() *Analyzer {
-	return &Analyzer{}
+	return &Analyzer{KeyFiles: DefaultKeyFiles, LargeFileMB: DefaultLargeFileMB, LargeTreeMB: DefaultLargeTreeMB}
 }
 
 func (a *Analyzer) Name() string {
 	return "repo-health"
 }
 
+func (a *Analyzer) EstimatedCost(cfg analysis.Config) int {
+	// Overview, tree, key file contents, default-branch status and webhooks
+	return 11
+}
+
 func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
 	// 1. Get fundamental repo info (for default branch name)
-	r, err := client.GetRepository(ctx, repo.Owner, repo.Name)
-	if err != nil {
-		return models.AnalyzerResult{Name: a.Name()}, err
+	// Prefer the batched GraphQL overview; fall back to individual REST calls if it fails
+	overview, overviewErr := client.GetRepoOverview(ctx, repo.Owner, repo.Name)
+	var defaultBranch, repoURL string
+	if overviewErr == nil {
+		defaultBranch, repoURL = overview.DefaultBranch, overview.URL
+	} else {
+		r, err := client.GetRepository(ctx, repo.Owner, repo.Name)
+		if err != nil {
+			return models.AnalyzerResult{Name: a.Name()}, err
+		}
+		defaultBranch, repoURL = r.GetDefaultBranch(), r.GetHTMLURL()
 	}
-	defaultBranch := r.GetDefaultBranch()
 	if defaultBranch == "" {
 		defaultBranch = "main" // fallback
 	}
 
+	// Files and CI status are checked on the requested ref; the overview only covers the default branch
+	branch, branchLabel := defaultBranch, fmt.Sprintf("default branch (%s)", defaultBranch)
+	if repo.Ref != "" {
+		branch, branchLabel = repo.Ref, repo.Ref
+	}
+
 	var findings []models.Finding
 	var metrics []models.Metric
 	healthScore := 100
 
 	// 2. Check Key Files efficiently using git tree API (1 API call instead of 6+)
-	keyFiles := []struct {
-		Path     string
-		AltPaths []string // Alternative locations
-		Severity models.Severity
-		ScoreDed int
-		Found    bool
-	}{
-		{"LICENSE", nil, models.SeverityHigh, 30, false},
-		{"README.md", nil, models.SeverityMedium, 10, false},
-		{"CONTRIBUTING.md", nil, models.SeverityLow, 5, false},
-		{"SECURITY.md", []string{".github/SECURITY.md"}, models.SeverityMedium, 15, false},
-		{"CODE_OF_CONDUCT.md", []string{".github/CODE_OF_CONDUCT.md"}, models.SeverityLow, 5, false},
-		{".github/CODEOWNERS", nil, models.SeverityLow, 5, false},
-	}
-
-	// Use git tree API to check all files at once (much more efficient)
-	tree, err := client.GetTree(ctx, repo.Owner, repo.Name, defaultBranch, true)
-	if err == nil && tree != nil {
-		// Build a set of all paths in the tree
-		pathSet := make(map[string]bool)
-		for _, entry := range tree.Entries {
+	type keyFileResult struct {
+		KeyFile
+		Found     bool
+		FoundPath string
+	}
+	keyFiles := make([]keyFileResult, len(a.KeyFiles))
+	for i, f := range a.KeyFiles {
+		keyFiles[i] = keyFileResult{KeyFile: f}
+	}
+
+	// Build sets of known paths (much more efficient than per-file checks). The overview only
+	// lists the root and .github entries; the recursive git tree lists every path with its blob
+	// size, so it is fetched when the overview is unavailable, when a path outside the overview
+	// is looked up, and for the large files check.
+	var overviewPaths map[string]bool
+	if overviewErr == nil && overview.Paths != nil && repo.Ref == "" {
+		overviewPaths = make(map[string]bool, len(overview.Paths))
+		for _, p := range overview.Paths {
+			overviewPaths[p] = true
+		}
+	}
+	checkLargeFiles := a.LargeFileMB > 0 || a.LargeTreeMB > 0
+	var tree *github.Tree
+	var treePaths map[string]bool
+	treeFetched := false
+	fetchTree := func() {
+		if treeFetched {
+			return
+		}
+		treeFetched = true
+		t, err := client.GetTree(ctx, repo.Owner, repo.Name, branch, true)
+		if err != nil || t == nil {
+			return
+		}
+		tree = t
+		treePaths = make(map[string]bool, len(t.Entries))
+		for _, entry := range t.Entries {
 			if entry.Path != nil {
-				pathSet[*entry.Path] = true
+				treePaths[*entry.Path] = true
 			}
 		}
+	}
+	if overviewPaths == nil || checkLargeFiles {
+		fetchTree()
+	}
 
-		// Check which key files exist
-		for i := range keyFiles {
-			f := &keyFiles[i]
-			// Check primary path
-			if pathSet[f.Path] {
-				f.Found = true
-				continue
-			}
-			// Check alternative paths
-			for _, altPath := range f.AltPaths {
-				if pathSet[altPath] {
-					f.Found = true
-					break
-				}
-			}
+	// exists reports whether p is on the analyzed branch. Without a tree (e.g. empty repo), or
+	// when a truncated tree does not list p, the path is checked individually.
+	exists := func(p string) bool {
+		if treePaths == nil && overviewPaths != nil && overviewCovers(p) {
+			return overviewPaths[p]
 		}
-	} else {
-		// Fallback to individual checks if tree API fails (e.g., empty repo)
-		for i := range keyFiles {
-			f := &keyFiles[i]
-			// Try root
-			_, _, err := client.GetContent(ctx, repo.Owner, repo.Name, f.Path)
-			if err == nil {
-				f.Found = true
-				continue
-			}
+		fetchTree()
+		if treePaths != nil && (treePaths[p] || !tree.GetTruncated()) {
+			return treePaths[p]
+		}
+		_, _, err := client.GetContentAtRef(ctx, repo.Owner, repo.Name, p, repo.Ref)
+		return err == nil
+	}
 
-			// Try alternative paths
-			for _, altPath := range f.AltPaths {
-				_, _, err := client.GetContent(ctx, repo.Owner, repo.Name, altPath)
-				if err == nil {
-					f.Found = true
-					break
-				}
+	// Check which key files exist, trying the primary path before the alternatives
+	for i := range keyFiles {
+		f := &keyFiles[i]
+		for _, p := range append([]string{f.Path}, f.AltPaths...) {
+			if exists(p) {
+				f.Found, f.FoundPath = true, p
+				break
 			}
 		}
 	}
 
+	// The later checks reuse whichever paths were fetched, preferring the complete tree
+	pathSet := treePaths
+	if pathSet == nil {
+		pathSet = overviewPaths
+	}
+
 	for _, f := range keyFiles {
 		if !f.Found {
-			healthScore -= f.ScoreDed
+			healthScore -= f.Deduction
 			var suggestions []string
 			switch f.Path {
 			case "README.md":
@@ -119,20 +188,92 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 					"Review GitHub's recommended community health files",
 				}
 			}
+			remediation := fmt.Sprintf("Add a %s file to the repository root.", f.Path)
+			if path.Dir(f.Path) != "." {
+				remediation = fmt.Sprintf("Add a %s file to the repository.", f.Path)
+			}
 			findings = append(findings, models.Finding{
 				Type:             "missing_file",
 				Severity:         f.Severity,
 				Message:          fmt.Sprintf("Missing key file: %s", f.Path),
 				Actionable:       true,
-				Remediation:      fmt.Sprintf("Add a %s file to the repository root.", f.Path),
+				Remediation:      remediation,
 				Explanation:      "Missing documentation and community files reduce project discoverability and contributor engagement.",
 				SuggestedActions: suggestions,
 			})
 		}
 	}
 
-	// 3. Check CI Status on Default Branch
-	combinedStatus, err := client.GetCombinedStatus(ctx, repo.Owner, repo.Name, defaultBranch)
+	// 2b. Check CODEOWNERS coverage
+	for _, f := range keyFiles {
+		if path.Base(f.Path) != "CODEOWNERS" || !f.Found {
+			continue
+		}
+		file, _, err := client.GetContentAtRef(ctx, repo.Owner, repo.Name, f.FoundPath, repo.Ref)
+		if err != nil || file == nil {
+			break
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			break
+		}
+
+		rules, catchAll := parseCodeowners(content)
+		catchAllValue, catchAllDisplay := 0.0, "No"
+		if catchAll {
+			catchAllValue, catchAllDisplay = 1, "Yes"
+		}
+		metrics = append(metrics,
+			models.Metric{
+				Key:          "codeowners_rules",
+				Value:        float64(rules),
+				DisplayValue: fmt.Sprintf("%d", rules),
+				Description:  fmt.Sprintf("Ownership rules in %s", f.FoundPath),
+			},
+			models.Metric{
+				Key:          "codeowners_catch_all",
+				Value:        catchAllValue,
+				DisplayValue: catchAllDisplay,
+				Description:  "CODEOWNERS has a catch-all (*) rule",
+			},
+		)
+
+		if !catchAll {
+			findings = append(findings, models.Finding{
+				Type:        "codeowners_no_catch_all",
+				Severity:    models.SeverityLow,
+				Message:     fmt.Sprintf("%s has no catch-all (*) rule", f.FoundPath),
+				Location:    blobURL(repoURL, branch, f.FoundPath),
+				Actionable:  true,
+				Remediation: "Add a '*' rule near the top of CODEOWNERS assigning default owners.",
+				Explanation: "Paths not matched by any CODEOWNERS rule have no required reviewers, so changes to them can slip through review.",
+				SuggestedActions: []string{
+					"Add '* @org/default-team' as the first rule so later rules override it",
+				},
+			})
+		}
+	}
+
+	// 2c. Check for large files committed to the tree
+	if checkLargeFiles && tree != nil {
+		total, large := a.largeBlobs(tree.Entries)
+		metrics = append(metrics, models.Metric{
+			Key:          "tree_size_mb",
+			Value:        bytesToMB(total),
+			Unit:         "MB",
+			DisplayValue: formatMB(total),
+			Description:  fmt.Sprintf("Total size of the files on %s", branchLabel),
+		})
+		if f, ok := a.largeFilesFinding(total, large, tree.GetTruncated()); ok {
+			if f.Location != "" {
+				f.Location = blobURL(repoURL, branch, f.Location)
+			}
+			findings = append(findings, f)
+		}
+	}
+
+	// 3. Check CI Status on the analyzed branch
+	combinedStatus, err := client.GetCombinedStatus(ctx, repo.Owner, repo.Name, branch)
 	if err == nil {
 		// State: pending, success, failure, error
 		state := combinedStatus.GetState()
@@ -141,7 +282,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 			Value:        0, // value not numeric really
 			Unit:         "state",
 			DisplayValue: state,
-			Description:  fmt.Sprintf("CI Status for %s", defaultBranch),
+			Description:  fmt.Sprintf("CI Status for %s", branch),
 		})
 
 		if state == "failure" || state == "error" {
@@ -149,7 +290,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 			findings = append(findings, models.Finding{
 				Type:        "ci_failure",
 				Severity:    models.SeverityHigh,
-				Message:     fmt.Sprintf("CI is failing on default branch (%s)", defaultBranch),
+				Message:     fmt.Sprintf("CI is failing on %s", branchLabel),
 				Actionable:  true,
 				Remediation: "Fix the build break immediately.",
 				Explanation: "Broken builds on the main branch prevent deployments and block other developers from merging their work.",
@@ -166,7 +307,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 			findings = append(findings, models.Finding{
 				Type:        "ci_missing",
 				Severity:    models.SeverityMedium,
-				Message:     "No CI statuses found on default branch",
+				Message:     fmt.Sprintf("No CI statuses found on %s", branchLabel),
 				Actionable:  true,
 				Remediation: "Configure GitHub Actions or an external CI provider.",
 			})
@@ -187,15 +328,27 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 	})
 
 	// 4. Check Branch Protection
-	protection, _, protErr := client.GetUnderlyingClient().Repositories.GetBranchProtection(ctx, repo.Owner, repo.Name, defaultBranch)
-	if protErr == nil && protection != nil {
+	var protected, requiresReviews, requiresChecks bool
+	if overviewErr == nil {
+		protected = overview.BranchProtected
+		requiresReviews = overview.RequiresPRReviews
+		requiresChecks = overview.RequiresStatusChecks
+	} else {
+		protection, _, protErr := client.GetUnderlyingClient().Repositories.GetBranchProtection(ctx, repo.Owner, repo.Name, defaultBranch)
+		if protErr == nil && protection != nil {
+			protected = true
+			requiresReviews = protection.RequiredPullRequestReviews != nil
+			requiresChecks = protection.RequiredStatusChecks != nil
+		}
+	}
+	if protected {
 		metrics = append(metrics, models.Metric{
 			Key:          "branch_protection_enabled",
 			Value:        1,
 			DisplayValue: "Yes",
 			Description:  "Branch protection rules configured",
 		})
-		if protection.RequiredPullRequestReviews != nil {
+		if requiresReviews {
 			metrics = append(metrics, models.Metric{
 				Key:          "requires_pr_reviews",
 				Value:        1,
@@ -203,7 +356,7 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 				Description:  "Requires PR reviews before merge",
 			})
 		}
-		if protection.RequiredStatusChecks != nil {
+		if requiresChecks {
 			metrics = append(metrics, models.Metric{
 				Key:          "requires_status_checks",
 				Value:        1,
@@ -233,31 +386,56 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		})
 	}
 
-	// 5. Check dependency files (reuse tree from earlier if available)
+	// 5. Check dependency files (reuse paths from earlier if available)
 	depFiles := []string{"package.json", "requirements.txt", "pom.xml", "build.gradle", "go.mod", "Cargo.toml", "Gemfile"}
 	depFound := false
-	if tree != nil {
-		// Reuse the tree we already fetched
-		for _, df := range depFiles {
-			for _, entry := range tree.Entries {
-				if entry.Path != nil && *entry.Path == df {
-					depFound = true
-					break
-				}
-			}
-			if depFound {
-				break
-			}
+	for _, df := range depFiles {
+		if exists(df) {
+			depFound = true
+			break
 		}
-	} else {
-		// Fallback to individual checks
-		for _, df := range depFiles {
-			if _, _, err := client.GetContent(ctx, repo.Owner, repo.Name, df); err == nil {
-				depFound = true
-				break
+	}
+
+	// Monorepos often keep manifests only in their projects, so look below the root too.
+	// The overview only lists root and .github paths, so nested manifests need the tree;
+	// without any fetched paths only the root indicators are checked.
+	monorepoPaths := pathSet
+	if monorepoPaths == nil {
+		monorepoPaths = make(map[string]bool)
+		for _, p := range append(append([]string{}, monorepoConfigs...), "packages", "apps") {
+			if exists(p) {
+				monorepoPaths[p] = true
 			}
 		}
 	}
+	indicators := detectMonorepo(monorepoPaths)
+	isMonorepo := len(indicators) > 0
+	monorepoValue, monorepoDisplay := 0.0, "No"
+	if isMonorepo {
+		monorepoValue, monorepoDisplay = 1, "Yes"
+	}
+	metrics = append(metrics, models.Metric{
+		Key:          "is_monorepo",
+		Value:        monorepoValue,
+		DisplayValue: monorepoDisplay,
+		Description:  "Repository contains multiple projects",
+	})
+	if isMonorepo {
+		rootDepFound := depFound
+		if !depFound {
+			depFound = hasNestedFile(monorepoPaths, depFiles)
+		}
+		message := fmt.Sprintf("Monorepo detected (%s)", strings.Join(indicators, ", "))
+		if !rootDepFound && depFound {
+			message += "; dependency files were found in its projects rather than the root"
+		}
+		findings = append(findings, models.Finding{
+			Type:        "monorepo_detected",
+			Severity:    models.SeverityInfo,
+			Message:     message,
+			Explanation: "Monorepos manage dependencies per project, so a dependency file in any project counts instead of requiring one at the root.",
+		})
+	}
 	metrics = append(metrics, models.Metric{
 		Key:          "has_dependency_management",
 		Value:        map[bool]float64{true: 1, false: 0}[depFound],
@@ -272,9 +450,297 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		Description:  "Default branch name",
 	})
 
+	// 6. Check webhook health (needs admin access to the repository)
+	hooks, _, hookErr := client.GetUnderlyingClient().Repositories.ListHooks(ctx, repo.Owner, repo.Name, &github.ListOptions{PerPage: 100})
+	if hookErr == nil {
+		active, failing := summarizeHooks(hooks)
+		metrics = append(metrics,
+			models.Metric{
+				Key:          "webhooks_total",
+				Value:        float64(len(hooks)),
+				DisplayValue: fmt.Sprintf("%d", len(hooks)),
+				Description:  "Repository webhooks configured",
+			},
+			models.Metric{
+				Key:          "webhooks_active",
+				Value:        float64(active),
+				DisplayValue: fmt.Sprintf("%d", active),
+				Description:  "Webhooks that are enabled",
+			},
+			models.Metric{
+				Key:          "webhooks_failing",
+				Value:        float64(len(failing)),
+				DisplayValue: fmt.Sprintf("%d", len(failing)),
+				Description:  "Enabled webhooks whose last delivery failed",
+			},
+		)
+
+		for _, h := range failing {
+			findings = append(findings, models.Finding{
+				Type:        "webhook_failing",
+				Severity:    models.SeverityHigh,
+				Message:     fmt.Sprintf("Webhook %d to %s is failing: %s", h.GetID(), hookHost(h), describeLastResponse(h)),
+				Location:    fmt.Sprintf("Webhook %d", h.GetID()),
+				Actionable:  true,
+				Remediation: "Check the webhook's recent deliveries in the repository settings and fix or remove the endpoint.",
+				Explanation: "Failing webhooks silently stop downstream CI/CD, chat notifications and integrations from receiving events.",
+				SuggestedActions: []string{
+					"Open Settings → Webhooks → Recent Deliveries to see the error response",
+					"Redeliver a failed event once the endpoint is fixed",
+					"Delete webhooks for integrations that are no longer used",
+				},
+			})
+		}
+	} else if analysis.IsPermissionError(hookErr) {
+		findings = append(findings, models.Finding{
+			Type:        "webhooks_unavailable",
+			Severity:    models.SeverityInfo,
+			Message:     "Webhook health not checked: listing webhooks requires admin access to the repository",
+			Remediation: "Run with a token that has admin rights on the repository to include webhook health.",
+		})
+	}
+
 	return models.AnalyzerResult{
 		Name:     a.Name(),
 		Metrics:  metrics,
 		Findings: findings,
 	}, nil
 }
+
+// overviewCovers reports whether the overview paths, which list the root and .github
+// entries, tell if p exists
+func overviewCovers(p string) bool {
+	dir := path.Dir(p)
+	return dir == "." || dir == ".github"
+}
+
+// largeBlobs sums the sizes of the blobs in a tree and returns those of at least
+// LargeFileMB, biggest first. Submodules and directories have no size.
+func (a *Analyzer) largeBlobs(entries []*github.TreeEntry) (total int64, large []*github.TreeEntry) {
+	limit := int64(a.LargeFileMB) << 20
+	for _, e := range entries {
+		if e.GetType() != "blob" {
+			continue
+		}
+		size := int64(e.GetSize())
+		total += size
+		if a.LargeFileMB > 0 && size >= limit {
+			large = append(large, e)
+		}
+	}
+	sort.SliceStable(large, func(i, j int) bool {
+		if large[i].GetSize() != large[j].GetSize() {
+			return large[i].GetSize() > large[j].GetSize()
+		}
+		return large[i].GetPath() < large[j].GetPath()
+	})
+	return total, large
+}
+
+// largeFilesFinding reports large blobs or an oversized tree, naming the biggest offenders.
+// A truncated tree only covers part of the repository, so its total is a lower bound.
+func (a *Analyzer) largeFilesFinding(total int64, large []*github.TreeEntry, truncated bool) (models.Finding, bool) {
+	treeTooLarge := a.LargeTreeMB > 0 && total >= int64(a.LargeTreeMB)<<20
+	if !treeTooLarge && len(large) == 0 {
+		return models.Finding{}, false
+	}
+
+	var parts []string
+	if len(large) > 0 {
+		parts = append(parts, fmt.Sprintf("%d of %d MB or more", len(large), a.LargeFileMB))
+	}
+	if treeTooLarge {
+		parts = append(parts, fmt.Sprintf("%s in total", formatMB(total)))
+	}
+	message := "Large files committed: " + strings.Join(parts, ", ")
+
+	listed := large
+	if len(listed) > largeFilesListed {
+		listed = listed[:largeFilesListed]
+	}
+	if len(listed) > 0 {
+		names := make([]string, len(listed))
+		for i, e := range listed {
+			names[i] = fmt.Sprintf("%s (%s)", e.GetPath(), formatMB(int64(e.GetSize())))
+		}
+		message += "; largest: " + strings.Join(names, ", ")
+	}
+	if truncated {
+		message += " (the tree was truncated, so these totals are a lower bound)"
+	}
+
+	severity := models.SeverityLow
+	if treeTooLarge {
+		severity = models.SeverityMedium
+	}
+	f := models.Finding{
+		Type:        "large_files",
+		Severity:    severity,
+		Message:     message,
+		Actionable:  true,
+		Remediation: "Move large binaries to Git LFS or release assets and remove them from history.",
+		Explanation: "Every clone and fetch downloads committed files and their history, so large binaries slow down CI and onboarding long after they are deleted.",
+		SuggestedActions: []string{
+			"Track binary types with Git LFS ('git lfs track \"*.zip\"') and migrate existing files with 'git lfs migrate import'",
+			"Publish build outputs as release assets or packages instead of committing them",
+		},
+	}
+	if len(listed) > 0 {
+		f.Location = listed[0].GetPath()
+	}
+	return f, true
+}
+
+// blobURL links to a file on the given branch, or returns the bare path when the
+// repository URL is unknown
+func blobURL(repoURL, branch, p string) string {
+	if repoURL == "" {
+		return p
+	}
+	return fmt.Sprintf("%s/blob/%s/%s", strings.TrimSuffix(repoURL, "/"), branch, p)
+}
+
+func bytesToMB(b int64) float64 {
+	return float64(b) / (1 << 20)
+}
+
+// formatMB formats a byte count as megabytes, e.g. "12.3 MB"
+func formatMB(b int64) string {
+	return fmt.Sprintf("%.1f MB", bytesToMB(b))
+}
+
+// monorepoConfigs are workspace tool configs found at the root of multi-project repositories
+var monorepoConfigs = []string{"lerna.json", "nx.json", "turbo.json", "pnpm-workspace.yaml", "rush.json", "go.work"}
+
+// detectMonorepo returns the monorepo indicators present in paths: workspace tool
+// configs, packages/ or apps/ directories, and more than one package.json or go.mod.
+// Paths under vendored or generated directories are ignored.
+func detectMonorepo(paths map[string]bool) []string {
+	var indicators []string
+	for _, cfg := range monorepoConfigs {
+		if paths[cfg] {
+			indicators = append(indicators, cfg)
+		}
+	}
+	for _, dir := range []string{"packages", "apps"} {
+		if paths[dir] {
+			indicators = append(indicators, dir+"/")
+		}
+	}
+
+	manifests := make(map[string]int)
+	for p := range paths {
+		if isVendored(p) {
+			continue
+		}
+		switch base := path.Base(p); base {
+		case "package.json", "go.mod":
+			manifests[base]++
+		}
+	}
+	var counted []string
+	for name, n := range manifests {
+		if n > 1 {
+			counted = append(counted, fmt.Sprintf("%d %s files", n, name))
+		}
+	}
+	sort.Strings(counted)
+	return append(indicators, counted...)
+}
+
+// hasNestedFile reports whether any non-vendored path below the root has one of the given base names
+func hasNestedFile(paths map[string]bool, names []string) bool {
+	for p := range paths {
+		if !strings.Contains(p, "/") || isVendored(p) {
+			continue
+		}
+		base := path.Base(p)
+		for _, name := range names {
+			if base == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isVendored reports whether p is inside a dependency or test fixture directory
+func isVendored(p string) bool {
+	for _, dir := range strings.Split(path.Dir(p), "/") {
+		switch dir {
+		case "node_modules", "vendor", "testdata", "third_party":
+			return true
+		}
+	}
+	return false
+}
+
+// summarizeHooks counts enabled webhooks and returns those whose last delivery failed.
+// GitHub reports "active" for a successful last delivery and "unused" before the first one.
+func summarizeHooks(hooks []*github.Hook) (active int, failing []*github.Hook) {
+	for _, h := range hooks {
+		if !h.GetActive() {
+			continue
+		}
+		active++
+
+		status, _ := h.LastResponse["status"].(string)
+		code, _ := h.LastResponse["code"].(float64)
+		if code >= 400 || (status != "" && status != "active" && status != "unused") {
+			failing = append(failing, h)
+		}
+	}
+	return active, failing
+}
+
+// describeLastResponse formats a hook's last delivery result, e.g. "502 Bad Gateway"
+func describeLastResponse(h *github.Hook) string {
+	var parts []string
+	if code, ok := h.LastResponse["code"].(float64); ok && code > 0 {
+		parts = append(parts, fmt.Sprintf("%.0f", code))
+	}
+	if msg, _ := h.LastResponse["message"].(string); msg != "" {
+		parts = append(parts, msg)
+	} else if status, _ := h.LastResponse["status"].(string); status != "" {
+		parts = append(parts, status)
+	}
+	if len(parts) == 0 {
+		return "unknown error"
+	}
+	return strings.Join(parts, " ")
+}
+
+// hookHost returns only the host of a webhook URL so paths carrying tokens are not reported
+func hookHost(h *github.Hook) string {
+	if h.Config == nil {
+		return h.GetName()
+	}
+	u, err := url.Parse(h.Config.GetURL())
+	if err != nil || u.Host == "" {
+		return h.GetName()
+	}
+	return u.Host
+}
+
+// parseCodeowners counts the ownership rules in a CODEOWNERS file and reports whether
+// a catch-all pattern assigns owners to every path
+func parseCodeowners(content string) (rules int, catchAll bool) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		rules++
+
+		// A pattern without owners explicitly leaves those paths unowned
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "*", "**", "/*", "/**":
+			catchAll = true
+		}
+	}
+	return rules, catchAll
+}
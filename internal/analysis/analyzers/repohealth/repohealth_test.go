@@ -0,0 +1,532 @@
+package repohealth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// stubClient serves a fixed overview, tree and file contents. Calls made through the
+// underlying client (branch protection, webhooks) get a 404.
+type stubClient struct {
+	analysis.Client
+	overview *analysis.RepoOverview // nil makes GetRepoOverview fail
+	tree     []string               // nil makes GetTree fail
+	sizes    map[string]int         // blob sizes in the tree, 100 bytes when unset
+	files    map[string]string      // served by GetContent
+	statuses int                    // combined status count on every ref
+	api      *github.Client
+
+	treeCalls    int
+	treeRefs     []string
+	contentCalls []string
+	contentRefs  []string
+}
+
+func newStubClient(t *testing.T) *stubClient {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+	api := github.NewClient(nil)
+	api.BaseURL, _ = url.Parse(srv.URL + "/")
+	return &stubClient{api: api, files: map[string]string{}, statuses: 1}
+}
+
+func (c *stubClient) GetRepoOverview(ctx context.Context, owner, repo string) (*analysis.RepoOverview, error) {
+	if c.overview == nil {
+		return nil, errors.New("graphql unavailable")
+	}
+	return c.overview, nil
+}
+
+func (c *stubClient) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, error) {
+	return &github.Repository{DefaultBranch: github.String("main")}, nil
+}
+
+func (c *stubClient) GetTree(ctx context.Context, owner, repo, sha string, recursive bool) (*github.Tree, error) {
+	c.treeCalls++
+	c.treeRefs = append(c.treeRefs, sha)
+	if c.tree == nil {
+		return nil, errors.New("empty repository")
+	}
+	entries := make([]*github.TreeEntry, len(c.tree))
+	for i, p := range c.tree {
+		size, ok := c.sizes[p]
+		if !ok {
+			size = 100
+		}
+		entries[i] = &github.TreeEntry{Path: github.String(p), Type: github.String("blob"), Size: github.Int(size)}
+	}
+	return &github.Tree{Entries: entries}, nil
+}
+
+func (c *stubClient) GetContent(ctx context.Context, owner, repo, path string) (*github.RepositoryContent, []*github.RepositoryContent, error) {
+	return c.GetContentAtRef(ctx, owner, repo, path, "")
+}
+
+func (c *stubClient) GetContentAtRef(ctx context.Context, owner, repo, path, ref string) (*github.RepositoryContent, []*github.RepositoryContent, error) {
+	c.contentCalls = append(c.contentCalls, path)
+	c.contentRefs = append(c.contentRefs, ref)
+	content, ok := c.files[path]
+	if !ok {
+		return nil, nil, errors.New("404 Not Found")
+	}
+	return &github.RepositoryContent{Path: github.String(path), Content: github.String(content)}, nil, nil
+}
+
+func (c *stubClient) GetCombinedStatus(ctx context.Context, owner, repo, ref string) (*github.CombinedStatus, error) {
+	return &github.CombinedStatus{State: github.String("success"), TotalCount: github.Int(c.statuses)}, nil
+}
+
+func (c *stubClient) GetUnderlyingClient() *github.Client {
+	return c.api
+}
+
+func analyze(t *testing.T, a *Analyzer, client *stubClient) models.AnalyzerResult {
+	t.Helper()
+	res, err := a.Analyze(context.Background(), client, analysis.TargetRepository{Owner: "o", Name: "r"}, analysis.Config{})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	return res
+}
+
+func findingTypes(res models.AnalyzerResult) []string {
+	var types []string
+	for _, f := range res.Findings {
+		types = append(types, f.Type)
+	}
+	return types
+}
+
+func metricValue(res models.AnalyzerResult, key string) (float64, bool) {
+	for _, m := range res.Metrics {
+		if m.Key == key {
+			return m.Value, true
+		}
+	}
+	return 0, false
+}
+
+func TestParseCodeowners(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantRules    int
+		wantCatchAll bool
+	}{
+		{
+			name: "catch-all with overrides",
+			content: `# Default owners
+* @org/maintainers
+
+/docs/ @org/docs-team
+*.go   @org/go-reviewers @alice
+`,
+			wantRules:    3,
+			wantCatchAll: true,
+		},
+		{
+			name: "no catch-all",
+			content: `/internal/ @org/core
+/cmd/ @bob`,
+			wantRules:    2,
+			wantCatchAll: false,
+		},
+		{
+			name: "catch-all without owners does not count",
+			content: `*
+/src/ @org/core`,
+			wantRules:    2,
+			wantCatchAll: false,
+		},
+		{
+			name:         "empty file",
+			content:      "# nothing here\n\n",
+			wantRules:    0,
+			wantCatchAll: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, catchAll := parseCodeowners(tt.content)
+			if rules != tt.wantRules || catchAll != tt.wantCatchAll {
+				t.Errorf("parseCodeowners() = (%d, %v), want (%d, %v)", rules, catchAll, tt.wantRules, tt.wantCatchAll)
+			}
+		})
+	}
+}
+
+func TestAnalyzeLooksBeyondOverviewPaths(t *testing.T) {
+	a := New()
+	a.LargeFileMB, a.LargeTreeMB = 0, 0
+	a.KeyFiles = []KeyFile{
+		{"LICENSE", nil, models.SeverityHigh, 30},
+		{".github/SECURITY.md", []string{"docs/SECURITY.md"}, models.SeverityMedium, 15},
+	}
+
+	client := newStubClient(t)
+	client.overview = &analysis.RepoOverview{DefaultBranch: "main", BranchProtected: true, Paths: []string{"LICENSE", ".github"}}
+	client.tree = []string{"LICENSE", ".github", "docs", "docs/SECURITY.md"}
+	res := analyze(t, a, client)
+	if score, _ := metricValue(res, "health_score"); score != 100 {
+		t.Errorf("Expected docs/SECURITY.md to be found in the tree, got score %v and findings %v", score, findingTypes(res))
+	}
+	if client.treeCalls != 1 || len(client.contentCalls) != 0 {
+		t.Errorf("Expected one tree lookup for the nested path, got %d trees and contents %v", client.treeCalls, client.contentCalls)
+	}
+
+	// Without a tree, the nested path is looked up on its own
+	client = newStubClient(t)
+	client.overview = &analysis.RepoOverview{DefaultBranch: "main", BranchProtected: true, Paths: []string{"LICENSE", ".github"}}
+	client.files["docs/SECURITY.md"] = "# Security"
+	res = analyze(t, a, client)
+	if score, _ := metricValue(res, "health_score"); score != 100 {
+		t.Errorf("Expected docs/SECURITY.md to be found via GetContent, got score %v", score)
+	}
+	if !reflect.DeepEqual(client.contentCalls, []string{"docs/SECURITY.md"}) {
+		t.Errorf("Expected only the nested path to be fetched, got %v", client.contentCalls)
+	}
+
+	// Covered paths are answered by the overview alone
+	client = newStubClient(t)
+	client.overview = &analysis.RepoOverview{DefaultBranch: "main", BranchProtected: true, Paths: []string{"LICENSE", ".github", ".github/SECURITY.md"}}
+	res = analyze(t, a, client)
+	if score, _ := metricValue(res, "health_score"); score != 100 || client.treeCalls != 0 || len(client.contentCalls) != 0 {
+		t.Errorf("Expected no extra lookups, got score %v, %d trees and contents %v", score, client.treeCalls, client.contentCalls)
+	}
+}
+
+func TestAnalyzeNestedRequiredFiles(t *testing.T) {
+	a := New()
+	a.LargeFileMB, a.LargeTreeMB = 0, 0
+	a.KeyFiles = []KeyFile{
+		{".github/dependabot.yml", []string{".github/renovate.json"}, models.SeverityMedium, 10},
+		{"docs/SUPPORT.md", []string{"support/README.md"}, models.SeverityLow, 5},
+		{"deploy/helm/Chart.yaml", nil, models.SeverityHigh, 20},
+	}
+
+	client := newStubClient(t)
+	client.overview = &analysis.RepoOverview{DefaultBranch: "main", BranchProtected: true, Paths: []string{"go.mod", ".github", ".github/renovate.json", "support"}}
+	client.tree = []string{"go.mod", ".github", ".github/renovate.json", "support", "support/README.md", "deploy", "deploy/helm"}
+	res := analyze(t, a, client)
+
+	if score, _ := metricValue(res, "health_score"); score != 80 {
+		t.Errorf("Expected only the Helm chart to be missing (score 80), got %v", score)
+	}
+	var missing []models.Finding
+	for _, f := range res.Findings {
+		if f.Type == "missing_file" {
+			missing = append(missing, f)
+		}
+	}
+	if len(missing) != 1 || missing[0].Message != "Missing key file: deploy/helm/Chart.yaml" || missing[0].Severity != models.SeverityHigh {
+		t.Fatalf("Expected deploy/helm/Chart.yaml to be the only missing file, got %+v", missing)
+	}
+	if missing[0].Remediation != "Add a deploy/helm/Chart.yaml file to the repository." {
+		t.Errorf("Unexpected remediation %q", missing[0].Remediation)
+	}
+	if client.treeCalls != 1 || len(client.contentCalls) != 0 {
+		t.Errorf("Expected the nested paths to share one tree lookup, got %d trees and contents %v", client.treeCalls, client.contentCalls)
+	}
+}
+
+func TestAnalyzeCodeownersInDocs(t *testing.T) {
+	a := New()
+	a.LargeFileMB, a.LargeTreeMB = 0, 0
+
+	client := newStubClient(t)
+	client.overview = &analysis.RepoOverview{
+		DefaultBranch:   "main",
+		BranchProtected: true,
+		Paths:           []string{"LICENSE", "README.md", "CONTRIBUTING.md", "SECURITY.md", "CODE_OF_CONDUCT.md", "go.mod", "docs", ".github"},
+	}
+	client.tree = append(client.overview.Paths, "docs/CODEOWNERS")
+	client.files["docs/CODEOWNERS"] = "/internal/ @org/core\n"
+	res := analyze(t, a, client)
+
+	if score, _ := metricValue(res, "health_score"); score != 100 {
+		t.Errorf("Expected docs/CODEOWNERS not to be reported missing, got score %v and findings %v", score, findingTypes(res))
+	}
+	if rules, ok := metricValue(res, "codeowners_rules"); !ok || rules != 1 {
+		t.Errorf("Expected 1 rule from docs/CODEOWNERS, got %v (found: %v)", rules, ok)
+	}
+	if catchAll, ok := metricValue(res, "codeowners_catch_all"); !ok || catchAll != 0 {
+		t.Errorf("Expected no catch-all rule, got %v (found: %v)", catchAll, ok)
+	}
+	if types := findingTypes(res); len(types) == 0 || types[0] != "codeowners_no_catch_all" {
+		t.Errorf("Expected the catch-all finding and no missing files, got %v", types)
+	}
+}
+
+func TestAnalyzeOnRef(t *testing.T) {
+	a := New()
+	a.LargeFileMB, a.LargeTreeMB = 0, 0
+	a.KeyFiles = []KeyFile{
+		{"LICENSE", nil, models.SeverityHigh, 30},
+		{".github/CODEOWNERS", nil, models.SeverityLow, 5},
+	}
+
+	// The overview lists the default branch, so it must not answer for develop. Without a
+	// tree, every path is looked up individually on the ref.
+	client := newStubClient(t)
+	client.overview = &analysis.RepoOverview{DefaultBranch: "main", BranchProtected: true, Paths: []string{".github", ".github/CODEOWNERS"}}
+	client.files["LICENSE"] = "MIT"
+	client.files[".github/CODEOWNERS"] = "* @org/core\n"
+	client.statuses = 0
+	res, err := a.Analyze(context.Background(), client, analysis.TargetRepository{Owner: "o", Name: "r", Ref: "develop"}, analysis.Config{})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(client.treeRefs, []string{"develop"}) {
+		t.Errorf("Expected the tree of develop, got %v", client.treeRefs)
+	}
+	if len(client.contentRefs) == 0 {
+		t.Fatal("Expected content lookups on the ref")
+	}
+	for i, ref := range client.contentRefs {
+		if ref != "develop" {
+			t.Errorf("Expected %s to be read from develop, got ref %q", client.contentCalls[i], ref)
+		}
+	}
+	if rules, ok := metricValue(res, "codeowners_rules"); !ok || rules != 1 {
+		t.Errorf("Expected CODEOWNERS to be read from develop, got %v (found: %v)", rules, ok)
+	}
+	var ciMissing bool
+	for _, f := range res.Findings {
+		switch f.Type {
+		case "missing_file":
+			t.Errorf("Expected the key files on develop to be found, got %q", f.Message)
+		case "ci_missing":
+			ciMissing = true
+			if f.Message != "No CI statuses found on develop" {
+				t.Errorf("Expected the finding to name the ref, got %q", f.Message)
+			}
+		}
+	}
+	if !ciMissing {
+		t.Errorf("Expected a ci_missing finding, got %v", findingTypes(res))
+	}
+}
+
+func TestAnalyzeMonorepo(t *testing.T) {
+	a := New()
+	a.KeyFiles = nil
+
+	// The overview only lists the root, so the nested manifests come from the tree
+	client := newStubClient(t)
+	client.overview = &analysis.RepoOverview{DefaultBranch: "main", BranchProtected: true, Paths: []string{"apps", "README.md"}}
+	client.tree = []string{"apps", "README.md", "apps/web/package.json", "apps/api/package.json"}
+	res := analyze(t, a, client)
+	if v, ok := metricValue(res, "is_monorepo"); !ok || v != 1 {
+		t.Errorf("Expected a monorepo, got %v (found: %v)", v, ok)
+	}
+	if v, _ := metricValue(res, "has_dependency_management"); v != 1 {
+		t.Error("Expected project-level package.json files to count as dependency management")
+	}
+	var message string
+	for _, f := range res.Findings {
+		if f.Type == "monorepo_detected" {
+			message = f.Message
+		}
+	}
+	if message != "Monorepo detected (apps/, 2 package.json files); dependency files were found in its projects rather than the root" {
+		t.Errorf("Unexpected monorepo finding %q", message)
+	}
+
+	// A single project is not a monorepo
+	client = newStubClient(t)
+	client.tree = []string{"go.mod", "cmd", "cmd/main.go"}
+	res = analyze(t, a, client)
+	if v, ok := metricValue(res, "is_monorepo"); !ok || v != 0 {
+		t.Errorf("Expected is_monorepo = 0, got %v (found: %v)", v, ok)
+	}
+
+	// Without a tree or overview the root indicators are checked individually
+	client = newStubClient(t)
+	client.files["turbo.json"] = "{}"
+	client.files["package.json"] = "{}"
+	res = analyze(t, a, client)
+	if v, ok := metricValue(res, "is_monorepo"); !ok || v != 1 {
+		t.Errorf("Expected the fallback to detect turbo.json, got %v (found: %v)", v, ok)
+	}
+	if v, _ := metricValue(res, "has_dependency_management"); v != 1 {
+		t.Error("Expected the fallback to find the root package.json")
+	}
+}
+
+func TestAnalyzeLargeFiles(t *testing.T) {
+	a := New()
+	a.KeyFiles = nil
+
+	client := newStubClient(t)
+	client.overview = &analysis.RepoOverview{DefaultBranch: "main", URL: "https://github.com/o/r", BranchProtected: true, Paths: []string{"go.mod"}}
+	client.tree = []string{"go.mod", "assets/demo.mp4"}
+	client.sizes = map[string]int{"assets/demo.mp4": 20 << 20}
+	res := analyze(t, a, client)
+
+	var large *models.Finding
+	for i := range res.Findings {
+		if res.Findings[i].Type == "large_files" {
+			large = &res.Findings[i]
+		}
+	}
+	if large == nil {
+		t.Fatalf("Expected a large_files finding, got %v", findingTypes(res))
+	}
+	if large.Location != "https://github.com/o/r/blob/main/assets/demo.mp4" {
+		t.Errorf("Expected Location to link to the largest file, got %q", large.Location)
+	}
+
+	// 0 disables both thresholds, so the sizes are not needed and the tree is not fetched
+	a.LargeFileMB, a.LargeTreeMB = 0, 0
+	client.treeCalls = 0
+	res = analyze(t, a, client)
+	if _, ok := metricValue(res, "tree_size_mb"); ok || client.treeCalls != 0 {
+		t.Errorf("Expected the disabled check to skip the tree, got %d tree calls and findings %v", client.treeCalls, findingTypes(res))
+	}
+}
+
+func TestSummarizeHooks(t *testing.T) {
+	hook := func(id int64, active bool, lastResponse map[string]interface{}) *github.Hook {
+		return &github.Hook{
+			ID:           github.Int64(id),
+			Active:       github.Bool(active),
+			LastResponse: lastResponse,
+			Config:       &github.HookConfig{URL: github.String("https://ci.example.com/hooks/secret-token")},
+		}
+	}
+	hooks := []*github.Hook{
+		hook(1, true, map[string]interface{}{"code": float64(200), "status": "active", "message": "OK"}),
+		hook(2, true, map[string]interface{}{"code": nil, "status": "unused", "message": nil}),
+		hook(3, true, map[string]interface{}{"code": float64(502), "status": "active", "message": "Bad Gateway"}),
+		hook(4, true, map[string]interface{}{"code": float64(0), "status": "timeout", "message": ""}),
+		hook(5, false, map[string]interface{}{"code": float64(500), "status": "active", "message": "Internal Server Error"}),
+	}
+
+	active, failing := summarizeHooks(hooks)
+	if active != 4 {
+		t.Errorf("Expected 4 active hooks, got %d", active)
+	}
+	if len(failing) != 2 || failing[0].GetID() != 3 || failing[1].GetID() != 4 {
+		t.Fatalf("Expected hooks 3 and 4 to be failing, got %v", failing)
+	}
+	if got := describeLastResponse(failing[0]); got != "502 Bad Gateway" {
+		t.Errorf("describeLastResponse() = %q, want %q", got, "502 Bad Gateway")
+	}
+	if got := describeLastResponse(failing[1]); got != "timeout" {
+		t.Errorf("describeLastResponse() = %q, want %q", got, "timeout")
+	}
+	if got := hookHost(failing[0]); got != "ci.example.com" {
+		t.Errorf("hookHost() = %q, want only the host", got)
+	}
+}
+
+func TestDetectMonorepo(t *testing.T) {
+	set := func(paths ...string) map[string]bool {
+		m := make(map[string]bool, len(paths))
+		for _, p := range paths {
+			m[p] = true
+		}
+		return m
+	}
+	tests := []struct {
+		name  string
+		paths map[string]bool
+		want  []string
+	}{
+		{
+			name:  "single project",
+			paths: set("README.md", "go.mod", "cmd", "cmd/main.go"),
+			want:  nil,
+		},
+		{
+			name:  "workspace config and packages directory",
+			paths: set("turbo.json", "package.json", "packages", "packages/ui/package.json"),
+			want:  []string{"turbo.json", "packages/", "2 package.json files"},
+		},
+		{
+			name:  "multiple go modules",
+			paths: set("services/api/go.mod", "services/worker/go.mod", "tools/go.mod"),
+			want:  []string{"3 go.mod files"},
+		},
+		{
+			name:  "vendored manifests are ignored",
+			paths: set("package.json", "node_modules/left-pad/package.json", "vendor/x/go.mod", "go.mod"),
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectMonorepo(tt.paths); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("detectMonorepo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !hasNestedFile(set("apps/web/package.json"), []string{"package.json"}) {
+		t.Error("Expected a project-level package.json to count as a dependency file")
+	}
+	if hasNestedFile(set("package.json", "node_modules/a/package.json"), []string{"package.json"}) {
+		t.Error("Expected root and vendored files not to count as nested dependency files")
+	}
+}
+
+func TestLargeFilesFinding(t *testing.T) {
+	entry := func(path, typ string, mb float64) *github.TreeEntry {
+		e := &github.TreeEntry{Path: github.String(path), Type: github.String(typ)}
+		if typ == "blob" {
+			e.Size = github.Int(int(mb * (1 << 20)))
+		}
+		return e
+	}
+	entries := []*github.TreeEntry{
+		entry("assets", "tree", 0),
+		entry("assets/intro.mp4", "blob", 48),
+		entry("assets/logo.png", "blob", 0.5),
+		entry("dist/app.zip", "blob", 12),
+		entry("vendor/lib", "commit", 0),
+		entry("README.md", "blob", 0.01),
+	}
+	a := New()
+
+	total, large := a.largeBlobs(entries)
+	if len(large) != 2 || large[0].GetPath() != "assets/intro.mp4" || large[1].GetPath() != "dist/app.zip" {
+		t.Fatalf("Expected the two blobs over 10 MB biggest first, got %v", large)
+	}
+
+	f, ok := a.largeFilesFinding(total, large, false)
+	if !ok {
+		t.Fatal("Expected a large_files finding")
+	}
+	if f.Type != "large_files" || f.Severity != "low" || f.Location != "assets/intro.mp4" {
+		t.Errorf("Unexpected finding %+v", f)
+	}
+	wantMessage := "Large files committed: 2 of 10 MB or more; largest: assets/intro.mp4 (48.0 MB), dist/app.zip (12.0 MB)"
+	if f.Message != wantMessage {
+		t.Errorf("Message = %q, want %q", f.Message, wantMessage)
+	}
+
+	a.LargeTreeMB = 50
+	f, _ = a.largeFilesFinding(total, large, true)
+	if f.Severity != "medium" || !strings.Contains(f.Message, "60.5 MB in total") || !strings.Contains(f.Message, "lower bound") {
+		t.Errorf("Expected an oversized truncated tree to be reported, got %+v", f)
+	}
+
+	a.LargeFileMB, a.LargeTreeMB = 100, 1000
+	total, large = a.largeBlobs(entries)
+	if _, ok := a.largeFilesFinding(total, large, false); ok {
+		t.Error("Expected no finding below both thresholds")
+	}
+}
@@ -3,9 +3,11 @@ package security
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/dependencies"
 	"github.com/mikematt33/gh-inspect/pkg/models"
 )
 
@@ -19,6 +21,11 @@ func (a *Analyzer) Name() string {
 	return "security"
 }
 
+func (a *Analyzer) EstimatedCost(cfg analysis.Config) int {
+	// Dependabot, code scanning and secret scanning alerts, plus repo settings and overview
+	return 5
+}
+
 func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
 	var metrics []models.Metric
 	var findings []models.Finding
@@ -126,6 +133,11 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		})
 	}
 
+	// 4. Secret scanning and push protection settings (only visible to admins)
+	settingMetrics, settingFindings := checkSecretScanning(ctx, client, repo)
+	metrics = append(metrics, settingMetrics...)
+	findings = append(findings, settingFindings...)
+
 	// Add summary metric about security features availability
 	securityFeaturesCount := 0
 	if dependabotAvailable {
@@ -162,3 +174,103 @@ func (a *Analyzer) Analyze(ctx context.Context, client analysis.Client, repo ana
 		Findings: findings,
 	}, nil
 }
+
+// checkSecretScanning reports whether secret scanning and push protection are enabled.
+// GitHub only returns these settings to repository admins, so missing settings or a
+// permission error produce an info finding instead of failing the analyzer.
+func checkSecretScanning(ctx context.Context, client analysis.Client, repo analysis.TargetRepository) ([]models.Metric, []models.Finding) {
+	notVisible := models.Finding{
+		Type:        "security_settings_unavailable",
+		Severity:    models.SeverityInfo,
+		Message:     "Secret scanning settings not checked: they are only visible with admin access to the repository",
+		Remediation: "Run with a token that has admin rights on the repository to include these settings.",
+	}
+
+	r, err := client.GetRepository(ctx, repo.Owner, repo.Name)
+	if err != nil {
+		if analysis.IsPermissionError(err) {
+			return nil, []models.Finding{notVisible}
+		}
+		return nil, nil
+	}
+	settings := r.GetSecurityAndAnalysis()
+	if settings == nil {
+		return nil, []models.Finding{notVisible}
+	}
+
+	scanning := settings.GetSecretScanning().GetStatus() == "enabled"
+	pushProtection := settings.GetSecretScanningPushProtection().GetStatus() == "enabled"
+	metrics := []models.Metric{
+		{
+			Key:          "secret_scanning_enabled",
+			Value:        map[bool]float64{true: 1, false: 0}[scanning],
+			DisplayValue: map[bool]string{true: "Yes", false: "No"}[scanning],
+			Description:  "Secret scanning is enabled",
+		},
+		{
+			Key:          "push_protection_enabled",
+			Value:        map[bool]float64{true: 1, false: 0}[pushProtection],
+			DisplayValue: map[bool]string{true: "Yes", false: "No"}[pushProtection],
+			Description:  "Secret scanning push protection is enabled",
+		},
+	}
+
+	var disabled []string
+	if !scanning {
+		disabled = append(disabled, "secret scanning")
+	}
+	if !pushProtection {
+		disabled = append(disabled, "push protection")
+	}
+	if len(disabled) == 0 || !hasDependencyManifest(ctx, client, repo) {
+		return metrics, nil
+	}
+
+	return metrics, []models.Finding{{
+		Type:        "secret_scanning_disabled",
+		Severity:    models.SeverityHigh,
+		Message:     fmt.Sprintf("%s disabled", capitalize(strings.Join(disabled, " and "))),
+		Actionable:  true,
+		Remediation: "Enable secret scanning and push protection under Settings → Code security.",
+		Explanation: "Repositories with dependencies usually hold API keys and registry tokens; without push protection a leaked credential is only noticed after it is public.",
+		SuggestedActions: []string{
+			"Enable secret scanning to detect credentials already committed",
+			"Enable push protection to block new secrets before they are pushed",
+		},
+	}}
+}
+
+// hasDependencyManifest reports whether the root of the analyzed ref contains one of the
+// dependency manifests or lock files the dependencies analyzer detects
+func hasDependencyManifest(ctx context.Context, client analysis.Client, repo analysis.TargetRepository) bool {
+	manifests := dependencies.ManifestFiles()
+	var overviewPaths []string
+	if repo.Ref == "" { // the overview only lists the default branch
+		if overview, err := client.GetRepoOverview(ctx, repo.Owner, repo.Name); err == nil {
+			overviewPaths = overview.Paths
+		}
+	}
+	if overviewPaths != nil {
+		for _, p := range overviewPaths {
+			for _, m := range manifests {
+				if p == m {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for _, m := range manifests {
+		if _, _, err := client.GetContentAtRef(ctx, repo.Owner, repo.Name, m, repo.Ref); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
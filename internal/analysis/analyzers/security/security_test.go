@@ -0,0 +1,98 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+)
+
+// settingsClient returns a fixed repository and root tree on the default branch, and
+// refPaths on other refs
+type settingsClient struct {
+	analysis.Client
+	repo     *github.Repository
+	repoErr  error
+	paths    []string
+	refPaths map[string][]string
+}
+
+func (c *settingsClient) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, error) {
+	return c.repo, c.repoErr
+}
+
+func (c *settingsClient) GetRepoOverview(ctx context.Context, owner, repo string) (*analysis.RepoOverview, error) {
+	return &analysis.RepoOverview{Paths: c.paths}, nil
+}
+
+func (c *settingsClient) GetContentAtRef(ctx context.Context, owner, repo, path, ref string) (*github.RepositoryContent, []*github.RepositoryContent, error) {
+	for _, p := range c.refPaths[ref] {
+		if p == path {
+			return &github.RepositoryContent{Path: github.String(path)}, nil, nil
+		}
+	}
+	return nil, nil, errors.New("404 Not Found")
+}
+
+func withSettings(scanning, pushProtection string) *github.Repository {
+	return &github.Repository{SecurityAndAnalysis: &github.SecurityAndAnalysis{
+		SecretScanning:               &github.SecretScanning{Status: github.String(scanning)},
+		SecretScanningPushProtection: &github.SecretScanningPushProtection{Status: github.String(pushProtection)},
+	}}
+}
+
+func TestCheckSecretScanning(t *testing.T) {
+	forbidden := &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden}}
+	tests := []struct {
+		name        string
+		client      *settingsClient
+		wantMetrics int
+		wantFinding string
+	}{
+		{"all enabled", &settingsClient{repo: withSettings("enabled", "enabled"), paths: []string{"go.mod"}}, 2, ""},
+		{"push protection off", &settingsClient{repo: withSettings("enabled", "disabled"), paths: []string{"go.mod"}}, 2, "secret_scanning_disabled"},
+		{"off with only a lock file", &settingsClient{repo: withSettings("disabled", "disabled"), paths: []string{"yarn.lock"}}, 2, "secret_scanning_disabled"},
+		{"off without dependencies", &settingsClient{repo: withSettings("disabled", "disabled"), paths: []string{"README.md"}}, 2, ""},
+		{"settings hidden", &settingsClient{repo: &github.Repository{}}, 0, "security_settings_unavailable"},
+		{"forbidden", &settingsClient{repoErr: forbidden}, 0, "security_settings_unavailable"},
+		{"other error", &settingsClient{repoErr: errors.New("boom")}, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics, findings := checkSecretScanning(context.Background(), tt.client, analysis.TargetRepository{Owner: "o", Name: "r"})
+			if len(metrics) != tt.wantMetrics {
+				t.Errorf("Expected %d metrics, got %d", tt.wantMetrics, len(metrics))
+			}
+			got := ""
+			if len(findings) > 0 {
+				got = findings[0].Type
+			}
+			if got != tt.wantFinding {
+				t.Errorf("Expected finding %q, got %q", tt.wantFinding, got)
+			}
+		})
+	}
+}
+
+func TestCheckSecretScanningMessage(t *testing.T) {
+	client := &settingsClient{repo: withSettings("disabled", "disabled"), paths: []string{"package.json"}}
+	_, findings := checkSecretScanning(context.Background(), client, analysis.TargetRepository{Owner: "o", Name: "r"})
+	if len(findings) != 1 || findings[0].Message != "Secret scanning and push protection disabled" {
+		t.Errorf("Unexpected findings: %+v", findings)
+	}
+}
+
+func TestHasDependencyManifestOnRef(t *testing.T) {
+	// The overview lists the default branch, which has no manifest; develop has one
+	client := &settingsClient{paths: []string{"README.md"}, refPaths: map[string][]string{"develop": {"go.mod"}}}
+	if hasDependencyManifest(context.Background(), client, analysis.TargetRepository{Owner: "o", Name: "r"}) {
+		t.Error("Expected no manifest on the default branch")
+	}
+	if !hasDependencyManifest(context.Background(), client, analysis.TargetRepository{Owner: "o", Name: "r", Ref: "develop"}) {
+		t.Error("Expected the manifest on develop to be found")
+	}
+}
@@ -1,38 +1,48 @@
 package analysis
 
+import "fmt"
+
 // DepthConfig defines limits for API pagination and data fetching
 type DepthConfig struct {
-	Name            string
-	MaxPRs          int
-	MaxIssues       int
-	MaxWorkflowRuns int
-	IncludeDeep     bool // For backward compatibility with Config.IncludeDeep
+	Name              string
+	MaxPRs            int
+	MaxIssues         int
+	MaxWorkflowRuns   int
+	MaxBranchCompares int  // Stale branches compared against the default branch
+	MaxStargazerPages int  // Pages of recent stargazers fetched for star growth (sampled beyond this)
+	IncludeDeep       bool // For backward compatibility with Config.IncludeDeep
 }
 
 // Predefined depth configurations
 var (
 	ShallowDepth = DepthConfig{
-		Name:            "shallow",
-		MaxPRs:          50,
-		MaxIssues:       100,
-		MaxWorkflowRuns: 50,
-		IncludeDeep:     false,
+		Name:              "shallow",
+		MaxPRs:            50,
+		MaxIssues:         100,
+		MaxWorkflowRuns:   50,
+		MaxBranchCompares: 10,
+		MaxStargazerPages: 1,
+		IncludeDeep:       false,
 	}
 
 	StandardDepth = DepthConfig{
-		Name:            "standard",
-		MaxPRs:          100,
-		MaxIssues:       200,
-		MaxWorkflowRuns: 100,
-		IncludeDeep:     false,
+		Name:              "standard",
+		MaxPRs:            100,
+		MaxIssues:         200,
+		MaxWorkflowRuns:   100,
+		MaxBranchCompares: 25,
+		MaxStargazerPages: 3,
+		IncludeDeep:       false,
 	}
 
 	DeepDepth = DepthConfig{
-		Name:            "deep",
-		MaxPRs:          500,
-		MaxIssues:       1000,
-		MaxWorkflowRuns: 500,
-		IncludeDeep:     true,
+		Name:              "deep",
+		MaxPRs:            500,
+		MaxIssues:         1000,
+		MaxWorkflowRuns:   500,
+		MaxBranchCompares: 100,
+		MaxStargazerPages: 10,
+		IncludeDeep:       true,
 	}
 )
 
@@ -50,6 +60,26 @@ func GetDepthConfig(depth string) DepthConfig {
 	}
 }
 
+// ResolveDepthConfig returns the limits for the named depth (empty means standard) with
+// any non-zero overrides applied on top, so explicit flags win over depth defaults
+func ResolveDepthConfig(depth string, maxPRs, maxIssues, maxWorkflowRuns int) (DepthConfig, error) {
+	if depth != "" && depth != "shallow" && depth != "standard" && depth != "deep" {
+		return DepthConfig{}, fmt.Errorf("invalid depth: %s (must be shallow, standard, or deep)", depth)
+	}
+	if maxPRs < 0 || maxIssues < 0 || maxWorkflowRuns < 0 {
+		return DepthConfig{}, fmt.Errorf("limits must not be negative (0 = use depth default)")
+	}
+	return GetDepthConfig(depth).ApplyOverrides(maxPRs, maxIssues, maxWorkflowRuns), nil
+}
+
+// Pages returns how many list requests of up to 100 items are needed to fetch limit items
+func Pages(limit int) int {
+	if limit <= 0 {
+		return 1
+	}
+	return (limit + 99) / 100
+}
+
 // ApplyOverrides applies manual overrides to a depth configuration
 func (d DepthConfig) ApplyOverrides(maxPRs, maxIssues, maxWorkflowRuns int) DepthConfig {
 	if maxPRs > 0 {
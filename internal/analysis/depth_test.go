@@ -124,3 +124,40 @@ func TestApplyOverrides(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveDepthConfig(t *testing.T) {
+	tests := []struct {
+		depth                     string
+		maxPRs, maxIssues, maxRun int
+		want                      DepthConfig
+	}{
+		{"shallow", 0, 0, 0, ShallowDepth},
+		{"", 0, 0, 0, StandardDepth},
+		{"deep", 0, 0, 0, DeepDepth},
+	}
+	for _, tt := range tests {
+		got, err := ResolveDepthConfig(tt.depth, tt.maxPRs, tt.maxIssues, tt.maxRun)
+		if err != nil {
+			t.Fatalf("ResolveDepthConfig(%q) returned error: %v", tt.depth, err)
+		}
+		if got != tt.want {
+			t.Errorf("ResolveDepthConfig(%q) = %+v, want %+v", tt.depth, got, tt.want)
+		}
+	}
+
+	// Explicit limits override only the fields that were set
+	got, err := ResolveDepthConfig("shallow", 0, 25, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.MaxIssues != 25 || got.MaxPRs != ShallowDepth.MaxPRs || got.MaxWorkflowRuns != ShallowDepth.MaxWorkflowRuns {
+		t.Errorf("Expected only MaxIssues overridden, got %+v", got)
+	}
+
+	if _, err := ResolveDepthConfig("extreme", 0, 0, 0); err == nil {
+		t.Error("Expected error for unknown depth")
+	}
+	if _, err := ResolveDepthConfig("standard", -1, 0, 0); err == nil {
+		t.Error("Expected error for negative limit")
+	}
+}
@@ -0,0 +1,25 @@
+package analysis
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// IsNotFoundError reports whether the API answered 404, which GitHub also uses for
+// private repositories the token cannot see
+func IsNotFoundError(err error) bool {
+	var ghErr *github.ErrorResponse
+	return errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound
+}
+
+// IsPermissionError reports whether the API denied access. GitHub answers 404 rather
+// than 403 for some admin-only endpoints when the token lacks the required role.
+func IsPermissionError(err error) bool {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil {
+		return false
+	}
+	return ghErr.Response.StatusCode == http.StatusForbidden || ghErr.Response.StatusCode == http.StatusNotFound
+}
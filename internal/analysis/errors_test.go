@@ -0,0 +1,32 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+)
+
+func TestIsPermissionError(t *testing.T) {
+	forbidden := &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden}}
+	if !IsPermissionError(fmt.Errorf("list hooks: %w", forbidden)) {
+		t.Error("Expected wrapped 403 to be a permission error")
+	}
+	serverErr := &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusInternalServerError}}
+	if IsPermissionError(serverErr) || IsPermissionError(errors.New("network down")) {
+		t.Error("Expected 500 and non-API errors not to be permission errors")
+	}
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	notFound := &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+	if !IsNotFoundError(fmt.Errorf("get repo: %w", notFound)) {
+		t.Error("Expected wrapped 404 to be a not-found error")
+	}
+	forbidden := &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden}}
+	if IsNotFoundError(forbidden) || IsNotFoundError(errors.New("network down")) || IsNotFoundError(nil) {
+		t.Error("Expected 403, non-API and nil errors not to be not-found errors")
+	}
+}
@@ -13,7 +13,7 @@ type Config struct {
 	Since       time.Time         // Lookback window (e.g., 30 days)
 	IncludeDeep bool              // If true, perform costlier scans
 	DepthConfig DepthConfig       // Depth configuration with limits
-	OutputMode  models.OutputMode // How to present findings (suggestive, observational, statistical)
+	OutputMode  models.OutputMode // Selected output mode; renderers trim finding guidance to it, so analyzers always fill it in
 }
 
 // Analyzer is the core interface that all inspection logic must implement.
@@ -21,27 +21,39 @@ type Analyzer interface {
 	Name() string
 	// Analyze executes the inspection logic against a specific repository.
 	Analyze(ctx context.Context, client Client, repo TargetRepository, cfg Config) (models.AnalyzerResult, error)
+	// EstimatedCost returns the approximate number of API requests Analyze makes per repository.
+	// It is used for the rate-limit preflight check, so erring high is fine.
+	EstimatedCost(cfg Config) int
 }
 
 // TargetRepository contains the minimal context needed to locate a repo.
 type TargetRepository struct {
 	Owner string
 	Name  string
+	Ref   string // Branch or ref to analyze; empty means the default branch
+	URL   string // Web URL of the repository
 }
 
 // Client defines the subset of GitHub API methods needed by Analyzers.
 type Client interface {
 	GetPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error)
+	// GetPullRequestsSince pages through PRs (open and closed, most recently updated first) until they
+	// predate since, fetching at most maxPages pages of 100 (0 = no limit)
+	GetPullRequestsSince(ctx context.Context, owner, repo string, since time.Time, maxPages int) ([]*github.PullRequest, error)
 	GetReviews(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, error)
-	ListCommitsSince(ctx context.Context, owner, repo string, since time.Time) ([]*github.RepositoryCommit, error)
+	// ListCommitsSince lists commits on ref (empty = default branch) since the given time
+	ListCommitsSince(ctx context.Context, owner, repo, ref string, since time.Time) ([]*github.RepositoryCommit, error)
 	GetRateLimit(ctx context.Context) (*github.Rate, error)
 
 	// Tier 2 additions
 	GetRepository(ctx context.Context, owner, repo string) (*github.Repository, error)
 	GetContent(ctx context.Context, owner, repo, path string) (*github.RepositoryContent, []*github.RepositoryContent, error)
+	// GetContentAtRef is GetContent on a branch, tag or commit; "" means the default branch
+	GetContentAtRef(ctx context.Context, owner, repo, path, ref string) (*github.RepositoryContent, []*github.RepositoryContent, error)
 	GetCombinedStatus(ctx context.Context, owner, repo, ref string) (*github.CombinedStatus, error)
 
 	// Tier 3 additions
+	// GetIssues returns one page of issues and sets opts.Page to the next page (0 when done)
 	GetIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, error)
 	GetIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, error)
 
@@ -52,9 +64,33 @@ type Client interface {
 	ListRepositories(ctx context.Context, org string, opts *github.RepositoryListByOrgOptions) ([]*github.Repository, error)
 	GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error)
 
+	// ListStargazers returns one page of stargazers with the time each star was given
+	ListStargazers(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Stargazer, error)
+
 	// GetUnderlyingClient exposes the raw GitHub client for advanced operations not yet abstracted
 	GetUnderlyingClient() *github.Client
 
 	// GetTree gets a git tree for efficient multi-file checking
 	GetTree(ctx context.Context, owner, repo, sha string, recursive bool) (*github.Tree, error)
+
+	// GetRepoOverview batches repo metadata, branch protection and the root tree into one GraphQL call
+	GetRepoOverview(ctx context.Context, owner, repo string) (*RepoOverview, error)
+}
+
+// RepoOverview is the batched repository snapshot returned by Client.GetRepoOverview.
+type RepoOverview struct {
+	DefaultBranch string
+	URL           string // web URL of the repository
+	Stars         int
+	Forks         int
+	Watchers      int
+
+	// Branch protection state of the default branch
+	BranchProtected      bool
+	RequiresPRReviews    bool
+	RequiresStatusChecks bool
+
+	// Paths contains the entries of the root tree and the .github directory,
+	// or nil when the tree could not be resolved (e.g. empty repository)
+	Paths []string
 }
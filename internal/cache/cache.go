@@ -7,13 +7,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
 // Cache handles disk-based caching with TTL
 type Cache struct {
-	baseDir string
-	ttl     time.Duration
+	baseDir    string
+	ttl        time.Duration            // Default TTL for keys without a prefix override
+	prefixTTLs map[string]time.Duration // Key prefix (e.g. "repo:") -> TTL
 }
 
 // CacheEntry represents a cached item with metadata
@@ -22,6 +24,17 @@ type CacheEntry struct {
 	Data      json.RawMessage `json:"data"`
 	CreatedAt time.Time       `json:"created_at"`
 	ExpiresAt time.Time       `json:"expires_at"`
+	TTL       time.Duration   `json:"ttl,omitempty"` // TTL in effect when the entry was written
+}
+
+// expired reports whether the entry is past its TTL.
+// Entries written before per-entry TTLs existed fall back to the cache-wide default.
+func (e CacheEntry) expired(defaultTTL time.Duration) bool {
+	ttl := e.TTL
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	return time.Now().After(e.CreatedAt.Add(ttl))
 }
 
 // New creates a new cache instance
@@ -40,11 +53,34 @@ func New(baseDir string, ttl time.Duration) (*Cache, error) {
 	}
 
 	return &Cache{
-		baseDir: baseDir,
-		ttl:     ttl,
+		baseDir:    baseDir,
+		ttl:        ttl,
+		prefixTTLs: make(map[string]time.Duration),
 	}, nil
 }
 
+// SetPrefixTTLs configures per-prefix TTLs that override the default for matching keys.
+// Should be called before the cache is shared between goroutines.
+func (c *Cache) SetPrefixTTLs(ttls map[string]time.Duration) {
+	c.prefixTTLs = make(map[string]time.Duration, len(ttls))
+	for prefix, ttl := range ttls {
+		c.prefixTTLs[prefix] = ttl
+	}
+}
+
+// ttlFor returns the TTL for a key, preferring the longest matching prefix override
+func (c *Cache) ttlFor(key string) time.Duration {
+	ttl := c.ttl
+	longest := -1
+	for prefix, prefixTTL := range c.prefixTTLs {
+		if strings.HasPrefix(key, prefix) && len(prefix) > longest {
+			ttl = prefixTTL
+			longest = len(prefix)
+		}
+	}
+	return ttl
+}
+
 // Get retrieves a cached value by key
 func (c *Cache) Get(key string, value interface{}) (bool, error) {
 	cacheFile := c.getCacheFilePath(key)
@@ -67,7 +103,7 @@ func (c *Cache) Get(key string, value interface{}) (bool, error) {
 	}
 
 	// Check if expired
-	if time.Now().After(entry.ExpiresAt) {
+	if entry.expired(c.ttl) {
 		_ = os.Remove(cacheFile)
 		return false, nil // Expired
 	}
@@ -95,12 +131,15 @@ func (c *Cache) Set(key string, value interface{}) error {
 		return fmt.Errorf("failed to marshal value: %w", err)
 	}
 
-	// Create cache entry
+	// Create cache entry, recording the TTL so later reads honor it even if config changes
+	now := time.Now()
+	ttl := c.ttlFor(key)
 	entry := CacheEntry{
 		Key:       key,
 		Data:      data,
-		CreatedAt: time.Now(),
-		ExpiresAt: time.Now().Add(c.ttl),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+		TTL:       ttl,
 	}
 
 	// Marshal cache entry
@@ -159,7 +198,7 @@ func (c *Cache) Stats() (int, int64, error) {
 			continue
 		}
 
-		if time.Now().Before(cacheEntry.ExpiresAt) {
+		if !cacheEntry.expired(c.ttl) {
 			validCount++
 		}
 	}
@@ -442,3 +442,100 @@ func TestManuallyCorruptedEntry(t *testing.T) {
 		t.Error("Expected cache miss for corrupted entry")
 	}
 }
+
+func TestPrefixTTLExpiration(t *testing.T) {
+	tmpDir := t.TempDir()
+	c, err := New(tmpDir, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create cache: %v", err)
+	}
+	c.SetPrefixTTLs(map[string]time.Duration{
+		"workflow:": 100 * time.Millisecond,
+		"repo:":     24 * time.Hour,
+	})
+
+	if err := c.Set("workflow:owner/repo", "runs"); err != nil {
+		t.Fatalf("Failed to set workflow entry: %v", err)
+	}
+	if err := c.Set("repo:owner/repo", "metadata"); err != nil {
+		t.Fatalf("Failed to set repo entry: %v", err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	var value string
+	found, err := c.Get("workflow:owner/repo", &value)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if found {
+		t.Error("Expected workflow entry to expire with its short prefix TTL")
+	}
+
+	found, err = c.Get("repo:owner/repo", &value)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !found {
+		t.Error("Expected repo entry to survive with its long prefix TTL")
+	}
+}
+
+func TestPerEntryTTLSurvivesConfigChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	writer, err := New(tmpDir, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create cache: %v", err)
+	}
+	writer.SetPrefixTTLs(map[string]time.Duration{"repo:": 100 * time.Millisecond})
+
+	if err := writer.Set("repo:owner/repo", "metadata"); err != nil {
+		t.Fatalf("Failed to set entry: %v", err)
+	}
+
+	// A reader with a long default and no overrides must still honor the TTL stored at write time
+	reader, err := New(tmpDir, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create cache: %v", err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	var value string
+	found, err := reader.Get("repo:owner/repo", &value)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if found {
+		t.Error("Expected entry to expire using its stored TTL")
+	}
+}
+
+func TestLegacyEntryFallsBackToDefaultTTL(t *testing.T) {
+	tmpDir := t.TempDir()
+	c, err := New(tmpDir, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create cache: %v", err)
+	}
+
+	// Entry written before per-entry TTLs existed (no ttl field)
+	legacy := map[string]interface{}{
+		"key":        "legacy-key",
+		"data":       json.RawMessage(`"value"`),
+		"created_at": time.Now().Add(-time.Hour),
+		"expires_at": time.Now().Add(-30 * time.Minute),
+	}
+	raw, _ := json.Marshal(legacy)
+	if err := os.WriteFile(c.getCacheFilePath("legacy-key"), raw, 0644); err != nil {
+		t.Fatalf("Failed to write legacy entry: %v", err)
+	}
+
+	var value string
+	found, err := c.Get("legacy-key", &value)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !found {
+		t.Error("Expected legacy entry to be valid under the 24h default TTL")
+	}
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -13,12 +14,14 @@ import (
 
 	"github.com/mikematt33/gh-inspect/internal/config"
 	ghclient "github.com/mikematt33/gh-inspect/internal/github"
+	"github.com/mikematt33/gh-inspect/internal/keyring"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
 
 var (
-	flagNoBrowser bool
+	flagNoBrowser  bool
+	flagAuthStrict bool
 )
 
 var authCmd = &cobra.Command{
@@ -29,27 +32,31 @@ This command helps you authenticate by:
 1. Detecting if the GitHub CLI ('gh') is installed and using its credentials.
 2. Or securely prompting for a Personal Access Token (PAT).
 
-The token is saved to your configuration file for future use.`,
+The token can be saved to the OS keyring (recommended), your shell profile, or the configuration file.`,
 }
 
 var authLoginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Log in to GitHub",
-	Long:  "Authenticate with GitHub using the GitHub CLI or by providing a Personal Access Token.",
-	Run:   runAuth,
+	Long: `Authenticate with GitHub using the GitHub CLI or by providing a Personal Access Token.
+Use --strict in provisioning scripts to exit non-zero when the token is invalid or cannot be stored.
+Use --context to log in to a named context, e.g. a second account or a GitHub Enterprise Server
+(combine with --api-url); the context is created in the config file if it does not exist yet.`,
+	Run: runAuth,
 }
 
 var authStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Check authentication status",
-	Long:  "Display current authentication status and token information.",
-	Run:   runAuthStatus,
+	Long: `Display current authentication status and token information.
+A successful check is remembered for a few minutes; use --revalidate to ask GitHub again.`,
+	Run: runAuthStatus,
 }
 
 var authLogoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Log out from GitHub",
-	Long:  "Remove stored GitHub tokens from configuration file and shell rc files (.bashrc, .zshrc, etc.).",
+	Long:  "Remove stored GitHub tokens from the OS keyring, configuration file and shell rc files (.bashrc, .zshrc, etc.).",
 	Run:   runAuthLogout,
 }
 
@@ -62,6 +69,7 @@ func init() {
 	// Add flags
 	authCmd.PersistentFlags().BoolVar(&flagNoBrowser, "no-browser", false, "Disable browser-based authentication (use device code flow)")
 	authLoginCmd.Flags().BoolVar(&flagNoBrowser, "no-browser", false, "Disable browser-based authentication (use device code flow)")
+	authLoginCmd.Flags().BoolVar(&flagAuthStrict, "strict", false, "Also exit with a non-zero status if the token is invalid or the login is aborted (storage failures always do)")
 }
 
 func runAuth(cmd *cobra.Command, args []string) {
@@ -69,25 +77,32 @@ func runAuth(cmd *cobra.Command, args []string) {
 	fmt.Println("----------------------------")
 
 	// Check for existing authentication
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Printf("⚠️  Error loading config: %v\n", err)
 		cfg = nil
 	}
 
-	var configToken string
+	// Logging in to a context that does not exist yet creates it
+	name := activeContext()
+	var storedToken string
 	if cfg != nil {
-		configToken = cfg.Global.GitHubToken
+		storedToken = configToken(cfg)
+	}
+	if name != "" {
+		fmt.Printf("Context: %s\n", name)
 	}
 
-	token := ghclient.ResolveToken(configToken)
+	token := ghclient.ResolveToken(name, storedToken)
 	if token != "" {
 		fmt.Println("✅ You are already authenticated!")
 		fmt.Println()
 
 		// Show where the token is from
-		if configToken != "" && configToken == token {
+		if storedToken != "" && storedToken == token {
 			fmt.Println("Token source: Config file")
+		} else if ghclient.KeyringToken(name) == token {
+			fmt.Println("Token source: OS keyring")
 		} else if checkGhCLIToken() {
 			fmt.Println("Token source: GitHub CLI (gh)")
 		} else {
@@ -95,8 +110,9 @@ func runAuth(cmd *cobra.Command, args []string) {
 		}
 
 		// Validate the token
-		if err := validateToken(token); err != nil {
-			fmt.Printf("⚠️  Current token is invalid: %v\n", err)
+		tokenErr := validateToken(token)
+		if tokenErr != nil {
+			fmt.Printf("⚠️  Current token is invalid: %v\n", tokenErr)
 			fmt.Println()
 		} else {
 			fmt.Println("Token status: Valid")
@@ -106,6 +122,10 @@ func runAuth(cmd *cobra.Command, args []string) {
 
 		if !promptYesNo("Do you want to change your authentication?") {
 			fmt.Println("No changes made.")
+			if tokenErr != nil {
+				// Keeping an invalid token is a failed login for --strict
+				exitOnAuthError(fmt.Errorf("current token is invalid: %w", tokenErr))
+			}
 			return
 		}
 		fmt.Println()
@@ -121,7 +141,7 @@ func runAuth(cmd *cobra.Command, args []string) {
 	if err == nil {
 		fmt.Printf("Detected GitHub CLI (gh) at %s\n", ghPath)
 		if promptYesNo("Do you want to login using the GitHub CLI? (Recommended)") {
-			loginWithGh()
+			exitOnAuthError(loginWithGh())
 			return
 		}
 		fmt.Println()
@@ -130,31 +150,49 @@ func runAuth(cmd *cobra.Command, args []string) {
 		fmt.Println("GitHub CLI (gh) not found.")
 	}
 
-	loginWithToken()
+	exitOnAuthError(loginWithToken())
+}
+
+// tokenStorageError is a failure to write a token that was accepted for storage. It
+// always exits non-zero, with or without --strict.
+type tokenStorageError struct{ err error }
+
+func (e *tokenStorageError) Error() string { return e.err.Error() }
+func (e *tokenStorageError) Unwrap() error { return e.err }
+
+// exitOnAuthError reports a failed login. Storage failures always exit non-zero; with
+// --strict any other failure (invalid token, aborted prompt) does too, so provisioning
+// scripts notice. Otherwise login keeps its interactive exit status of 0.
+func exitOnAuthError(err error) {
+	if err == nil {
+		return
+	}
+	fmt.Printf("\n❌ %v\n", err)
+	var storageErr *tokenStorageError
+	if flagAuthStrict || errors.As(err, &storageErr) {
+		os.Exit(1)
+	}
 }
 
 func checkGhCLIToken() bool {
-	cmd := exec.Command("gh", "auth", "token")
+	cmd := exec.Command("gh", ghclient.GhCLIArgs("auth", "token")...)
 	return cmd.Run() == nil
 }
 
-func loginWithGh() {
+func loginWithGh() error {
 	// Check if already logged in via gh
-	cmd := exec.Command("gh", "auth", "token")
+	cmd := exec.Command("gh", ghclient.GhCLIArgs("auth", "token")...)
 	if err := cmd.Run(); err == nil {
 		fmt.Println("✅ You are already logged in via GitHub CLI.")
-		tokenBytes, err := exec.Command("gh", "auth", "token").Output()
+		tokenBytes, err := exec.Command("gh", ghclient.GhCLIArgs("auth", "token")...).Output()
 		if err != nil {
-			fmt.Printf("❌ Failed to retrieve token: %v\n", err)
-			return
+			return fmt.Errorf("failed to retrieve token: %w", err)
 		}
 		token := strings.TrimSpace(string(tokenBytes))
 		if !isValidToken(token) {
-			fmt.Println("❌ Retrieved token is invalid or empty.")
-			return
+			return errors.New("retrieved token is invalid or empty")
 		}
-		saveToken(token)
-		return
+		return saveToken(token)
 	}
 
 	// Run login
@@ -166,37 +204,35 @@ func loginWithGh() {
 		fmt.Println("Running 'gh auth login'...")
 		loginArgs = []string{"auth", "login"}
 	}
-	cmd = exec.Command("gh", loginArgs...)
+	cmd = exec.Command("gh", ghclient.GhCLIArgs(loginArgs...)...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
 		fmt.Printf("❌ Login failed: %v\n", err)
 		if promptYesNo("Try pasting a token manually instead?") {
-			loginWithToken()
+			return loginWithToken()
 		}
-		return
+		return fmt.Errorf("gh auth login failed: %w", err)
 	}
 
 	// Fetch token after login
-	tokenBytes, err := exec.Command("gh", "auth", "token").Output()
+	tokenBytes, err := exec.Command("gh", ghclient.GhCLIArgs("auth", "token")...).Output()
 	if err != nil {
-		fmt.Println("❌ Failed to retrieve token after login.")
-		return
+		return errors.New("failed to retrieve token after login")
 	}
 
 	token := strings.TrimSpace(string(tokenBytes))
 	if !isValidToken(token) {
-		fmt.Println("❌ Retrieved token is invalid or empty.")
-		return
+		return errors.New("retrieved token is invalid or empty")
 	}
 
-	saveToken(token)
+	return saveToken(token)
 }
 
-func loginWithToken() {
+func loginWithToken() error {
 	fmt.Println("\nPlease generate a Personal Access Token (PAT) with 'repo' scope.")
-	fmt.Println("Generate one here: https://github.com/settings/tokens/new?scopes=repo&description=gh-inspect")
+	fmt.Printf("Generate one here: https://%s/settings/tokens/new?scopes=repo&description=gh-inspect\n", ghclient.WebHost())
 	fmt.Print("\nPaste your token: ")
 
 	byteToken, err := term.ReadPassword(int(syscall.Stdin))
@@ -206,26 +242,22 @@ func loginWithToken() {
 		reader := bufio.NewReader(os.Stdin)
 		tokenStr, err := reader.ReadString('\n')
 		if err != nil {
-			fmt.Println("❌ Failed to read token from standard input.")
-			return
+			return errors.New("failed to read token from standard input")
 		}
 		tokenStr = strings.TrimSpace(tokenStr)
 		if tokenStr == "" {
-			fmt.Println("❌ Empty token provided.")
-			return
+			return errors.New("empty token provided")
 		}
-		saveToken(tokenStr)
-		return
+		return saveToken(tokenStr)
 	}
 	token := strings.TrimSpace(string(byteToken))
 	fmt.Println() // Newline after input
 
 	if token == "" {
-		fmt.Println("❌ Empty token provided.")
-		return
+		return errors.New("empty token provided")
 	}
 
-	saveToken(token)
+	return saveToken(token)
 }
 
 // validateToken checks if a token is valid by making an API call
@@ -236,32 +268,41 @@ var validateToken = func(token string) error {
 	return err
 }
 
-func saveToken(token string) {
+// getTokenScopes returns the token's OAuth scopes and whether GitHub reported any.
+// This is a variable to allow mocking in tests
+var getTokenScopes = ghclient.GetTokenScopes
+
+// saveToken validates the token and stores it where the user chooses
+func saveToken(token string) error {
 	// Validate token with GitHub API before saving
 	fmt.Println("Validating token...")
 	err := validateToken(token)
 	if err != nil {
-		fmt.Printf("❌ Token validation failed: %v\n", err)
 		fmt.Println("The token may be invalid or expired. Please check and try again.")
-		return
+		return fmt.Errorf("token validation failed: %w", err)
 	}
 
 	fmt.Println()
 	fmt.Println("✅ Token validated successfully!")
 
 	// Ask user where to store the token
-	chooseTokenStorage(token)
+	return chooseTokenStorage(token)
 }
 
-func chooseTokenStorage(token string) {
+func chooseTokenStorage(token string) error {
+	if name := activeContext(); name != "" {
+		return chooseContextTokenStorage(name, token)
+	}
+
 	fmt.Println("How would you like to store your GitHub token?")
 	fmt.Println()
 	fmt.Println("1. Temporary (export for current session only)")
 	fmt.Println("2. Persistent shell (add to .bashrc/.zshrc)")
 	fmt.Println("3. Config file (store in gh-inspect config)")
 	fmt.Println("4. Don't store (I'll use gh CLI or GITHUB_TOKEN)")
+	fmt.Println("5. OS keyring (Keychain, Credential Manager, Secret Service) - recommended")
 	fmt.Println()
-	fmt.Print("Enter choice [1-4]: ")
+	fmt.Print("Enter choice [1-5]: ")
 
 	reader := bufio.NewReader(os.Stdin)
 	choice, _ := reader.ReadString('\n')
@@ -271,14 +312,41 @@ func chooseTokenStorage(token string) {
 	case "1":
 		storeTokenTemporary(token)
 	case "2":
-		storeTokenPersistentShell(token)
+		return storeTokenPersistentShell(token)
 	case "3":
-		storeTokenConfig(token)
+		return storeTokenConfig(token)
 	case "4":
 		fmt.Println("\n✅ Token validated but not stored.")
 		fmt.Println("💡 Use 'export GITHUB_TOKEN=\"your_token\"' or 'gh auth login' to authenticate.")
+	case "5":
+		return storeTokenKeyring(token)
 	default:
-		fmt.Println("\n❌ Invalid choice. Token not stored.")
+		return fmt.Errorf("invalid choice %q, token not stored", choice)
+	}
+	return nil
+}
+
+// chooseContextTokenStorage stores the token of a named context. GITHUB_TOKEN only
+// feeds the default context, so the environment and shell options are not offered.
+func chooseContextTokenStorage(name, token string) error {
+	fmt.Printf("How would you like to store the token for context %q?\n", name)
+	fmt.Println()
+	fmt.Println("1. Config file (store in gh-inspect config)")
+	fmt.Println("2. OS keyring (Keychain, Credential Manager, Secret Service) - recommended")
+	fmt.Println()
+	fmt.Print("Enter choice [1-2]: ")
+
+	reader := bufio.NewReader(os.Stdin)
+	choice, _ := reader.ReadString('\n')
+	choice = strings.TrimSpace(choice)
+
+	switch choice {
+	case "1":
+		return storeTokenConfig(token)
+	case "2":
+		return storeTokenKeyring(token)
+	default:
+		return fmt.Errorf("invalid choice %q, token not stored", choice)
 	}
 }
 
@@ -290,18 +358,16 @@ func storeTokenTemporary(token string) {
 	fmt.Println("This will only be available in your current terminal session.")
 }
 
-func storeTokenPersistentShell(token string) {
+func storeTokenPersistentShell(token string) error {
 	shell := os.Getenv("SHELL")
 	if shell == "" {
-		fmt.Println("\n❌ Could not detect shell. Please add manually.")
-		return
+		return errors.New("could not detect shell, please add the token manually")
 	}
 
 	shellName := filepath.Base(shell)
 	home, err := os.UserHomeDir()
 	if err != nil {
-		fmt.Printf("\n❌ Could not find home directory: %v\n", err)
-		return
+		return fmt.Errorf("could not find home directory: %w", err)
 	}
 
 	var targetFile string
@@ -313,15 +379,14 @@ func storeTokenPersistentShell(token string) {
 	default:
 		fmt.Printf("\n⚠️  Shell '%s' not directly supported. Add this line to your shell config:\n", shellName)
 		fmt.Printf("  export GITHUB_TOKEN=\"%s\"\n", token)
-		return
+		return nil
 	}
 
 	fmt.Printf("\nThis will add 'export GITHUB_TOKEN=...' to %s\n", targetFile)
 	fmt.Println("⚠️  WARNING: This stores the token in plain text in your shell config.")
 
 	if !promptYesNo("Continue?") {
-		fmt.Println("Aborted.")
-		return
+		return errors.New("aborted, token not stored")
 	}
 
 	// Read existing content to check for duplicates
@@ -382,69 +447,90 @@ func storeTokenPersistentShell(token string) {
 	dir := filepath.Dir(targetFile)
 	tmpFile, err := os.CreateTemp(dir, ".gh-inspect-token-*")
 	if err != nil {
-		fmt.Printf("\n❌ Failed to create temporary file: %v\n", err)
-		return
+		return fmt.Errorf("failed to create temporary file: %w", err)
 	}
 	tmpName := tmpFile.Name()
 
 	if _, err := tmpFile.WriteString(newContent); err != nil {
-		fmt.Printf("\n❌ Failed to write to temporary file: %v\n", err)
 		_ = tmpFile.Close()
 		_ = os.Remove(tmpName)
-		return
+		return &tokenStorageError{fmt.Errorf("failed to write to temporary file: %w", err)}
 	}
 
 	if err := tmpFile.Close(); err != nil {
-		fmt.Printf("\n❌ Failed to close temporary file: %v\n", err)
 		_ = os.Remove(tmpName)
-		return
+		return fmt.Errorf("failed to close temporary file: %w", err)
 	}
 
 	// Ensure file has the desired permissions
 	if err := os.Chmod(tmpName, 0644); err != nil {
-		fmt.Printf("\n❌ Failed to set permissions on temporary file: %v\n", err)
 		_ = os.Remove(tmpName)
-		return
+		return fmt.Errorf("failed to set permissions on temporary file: %w", err)
 	}
 
 	// Atomically replace the target file with the new content
 	if err := os.Rename(tmpName, targetFile); err != nil {
-		fmt.Printf("\n❌ Failed to replace shell configuration file: %v\n", err)
 		_ = os.Remove(tmpName)
-		return
+		return &tokenStorageError{fmt.Errorf("failed to replace shell configuration file: %w", err)}
 	}
 
 	fmt.Println("\n✅ Token added to shell configuration.")
 	fmt.Printf("🔄 Restart your terminal or run 'source %s' to activate.\n", targetFile)
+	return nil
 }
 
-func storeTokenConfig(token string) {
+func storeTokenConfig(token string) error {
 	fmt.Println("\n⚠️  WARNING: Storing token in config file as plain text.")
 	fmt.Println("Consider using 'gh auth login' or environment variables for better security.")
 
 	if !promptYesNo("\nContinue with config file storage?") {
-		fmt.Println("Aborted.")
-		return
+		return errors.New("aborted, token not stored")
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
-		fmt.Printf("\n❌ Error loading config: %v\n", err)
-		return
+		return fmt.Errorf("error loading config: %w", err)
 	}
 
 	if cfg == nil {
-		fmt.Println("\n❌ Error: Config structure nil")
-		return
+		return errors.New("config structure nil")
 	}
 
-	cfg.Global.GitHubToken = token
+	setContextToken(cfg, activeContext(), token)
 	if err := saveConfig(cfg); err != nil {
-		fmt.Printf("\n❌ Failed to save config: %v\n", err)
-		os.Exit(1)
+		return &tokenStorageError{fmt.Errorf("failed to save config: %w", err)}
 	}
 
 	fmt.Println("\n✅ Token saved to configuration file.")
+	return nil
+}
+
+func storeTokenKeyring(token string) error {
+	name := activeContext()
+	if err := ghclient.SaveKeyringToken(name, token); err != nil {
+		if errors.Is(err, keyring.ErrUnsupported) {
+			fmt.Println("\nOn Linux, install secret-tool (libsecret-tools) and a Secret Service provider such as GNOME Keyring.")
+			fmt.Println("Run 'gh-inspect auth login' again to choose another option.")
+			return errors.New("no OS keyring is available on this system, token not stored")
+		}
+		fmt.Println("\nRun 'gh-inspect auth login' again to choose another option.")
+		return &tokenStorageError{fmt.Errorf("failed to store token in keyring: %w", err)}
+	}
+
+	// A named context must exist in the config file for --context to find it
+	if name != "" {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("error loading config: %w", err)
+		}
+		setContextToken(cfg, name, "")
+		if err := saveConfig(cfg); err != nil {
+			return &tokenStorageError{fmt.Errorf("failed to save context %q: %w", name, err)}
+		}
+	}
+
+	fmt.Println("\n✅ Token saved to the OS keyring.")
+	return nil
 }
 
 func promptYesNo(question string) bool {
@@ -469,21 +555,58 @@ func runAuthStatus(cmd *cobra.Command, args []string) {
 	fmt.Println("GitHub Authentication Status")
 	fmt.Println("----------------------------")
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Printf("❌ Error loading config: %v\n", err)
 		os.Exit(1)
 	}
 
-	token := ghclient.ResolveToken(cfg.Global.GitHubToken)
+	name := activeContext()
+	if name != "" {
+		fmt.Printf("Context: %s\n", name)
+	}
+	token, err := resolveToken(cfg)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
 	if token == "" {
 		fmt.Println("❌ Not authenticated")
-		fmt.Println("\nRun 'gh-inspect auth' to log in.")
+		if name != "" {
+			fmt.Printf("\nRun 'gh-inspect auth login --context %s' to log in.\n", name)
+		} else {
+			fmt.Println("\nRun 'gh-inspect auth' to log in.")
+		}
 		os.Exit(1)
 	}
 
-	// Validate token and get info
-	err = validateToken(token)
+	// Validate token and get rate limit info, unless it was validated recently
+	if checkedAt, ok := recentTokenValidation(token); ok {
+		fmt.Println("✅ Authenticated")
+		fmt.Printf("   Token validated %d seconds ago (use --revalidate to check again and show the rate limit)\n", int(time.Since(checkedAt).Seconds()))
+	} else {
+		printTokenRateLimit(token)
+	}
+
+	// Show token source
+	storedByGhInspect := true
+	if configToken(cfg) != "" {
+		fmt.Println("   Token source: config file")
+	} else if ghclient.KeyringToken(name) == token {
+		fmt.Println("   Token source: OS keyring")
+	} else {
+		fmt.Println("   Token source: environment or gh CLI")
+		storedByGhInspect = false
+	}
+
+	printTokenScopes(token, storedByGhInspect)
+}
+
+// printTokenRateLimit validates token against GitHub and prints its live rate limit,
+// exiting when the token is rejected
+func printTokenRateLimit(token string) {
+	client := ghclient.NewClient(token)
+	limits, err := checkTokenRateLimit(context.Background(), token, client.GetRateLimit)
 	if err != nil {
 		fmt.Println("❌ Token is invalid or expired")
 		fmt.Printf("   Error: %v\n", err)
@@ -491,15 +614,6 @@ func runAuthStatus(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Get rate limit info
-	client := ghclient.NewClient(token)
-	limits, err := client.GetRateLimit(context.Background())
-	if err != nil {
-		fmt.Println("✅ Authenticated (token is valid)")
-		fmt.Printf("   Could not fetch rate limit info: %v\n", err)
-		return
-	}
-
 	fmt.Println("✅ Authenticated")
 	fmt.Printf("   Rate limit: %d/%d remaining\n", limits.Remaining, limits.Limit)
 	if !limits.Reset.IsZero() {
@@ -515,12 +629,36 @@ func runAuthStatus(cmd *cobra.Command, args []string) {
 		}
 		fmt.Printf("   Resets at: %s (%s)\n", limits.Reset.Format(time.RFC3339), humanReadable)
 	}
+}
 
-	// Show token source
-	if cfg.Global.GitHubToken != "" {
-		fmt.Println("   Token source: config file")
+// printTokenScopes shows the scopes granted to token, warning when a token stored
+// by gh-inspect lacks the repo scope needed for private repositories
+func printTokenScopes(token string, storedByGhInspect bool) {
+	scopes, reported, err := getTokenScopes(token)
+	if err != nil {
+		fmt.Printf("   Could not fetch token scopes: %v\n", err)
+		return
+	}
+	if !reported {
+		fmt.Println("   Scopes: not reported (fine-grained or app token; check its repository access on GitHub)")
+		return
+	}
+
+	if len(scopes) == 0 {
+		fmt.Println("   Scopes: (none)")
 	} else {
-		fmt.Println("   Token source: environment or gh CLI")
+		fmt.Printf("   Scopes: %s\n", strings.Join(scopes, ", "))
+	}
+
+	hasRepo := false
+	for _, s := range scopes {
+		if s == "repo" {
+			hasRepo = true
+		}
+	}
+	if !hasRepo && storedByGhInspect {
+		fmt.Println("⚠️  Token is missing the 'repo' scope: private repositories will not be found (404).")
+		fmt.Println("   Create a token with 'repo' scope and run 'gh-inspect auth login' again.")
 	}
 }
 
@@ -529,12 +667,17 @@ func runAuthLogout(cmd *cobra.Command, args []string) {
 	fmt.Println("---------------------------")
 	fmt.Println()
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Printf("❌ Error loading config: %v\n", err)
 		os.Exit(1)
 	}
 
+	if name := activeContext(); name != "" {
+		logoutContext(cfg, name)
+		return
+	}
+
 	// Check all possible token locations
 	var foundLocations []string
 	hasConfigToken := cfg.Global.GitHubToken != ""
@@ -543,6 +686,11 @@ func runAuthLogout(cmd *cobra.Command, args []string) {
 		foundLocations = append(foundLocations, "config file")
 	}
 
+	hasKeyringToken := ghclient.KeyringToken("") != ""
+	if hasKeyringToken {
+		foundLocations = append(foundLocations, "OS keyring")
+	}
+
 	// Check for GITHUB_TOKEN in environment
 	if os.Getenv("GITHUB_TOKEN") != "" {
 		foundLocations = append(foundLocations, "GITHUB_TOKEN environment variable")
@@ -584,6 +732,9 @@ func runAuthLogout(cmd *cobra.Command, args []string) {
 	}
 	fmt.Println()
 
+	// Forget the last token check so the next command validates again
+	clearTokenValidation()
+
 	// Remove from config
 	if hasConfigToken {
 		cfg.Global.GitHubToken = ""
@@ -594,6 +745,15 @@ func runAuthLogout(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	// Remove from OS keyring
+	if hasKeyringToken {
+		if err := ghclient.DeleteKeyringToken(""); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+			fmt.Printf("❌ Failed to remove token from keyring: %v\n", err)
+		} else {
+			fmt.Println("✅ Removed token from OS keyring")
+		}
+	}
+
 	// Remove from shell rc files
 	for _, shellFile := range foundShellFiles {
 		targetFile := filepath.Join(homeDir, shellFile)
@@ -652,3 +812,47 @@ func runAuthLogout(cmd *cobra.Command, args []string) {
 	fmt.Println()
 	fmt.Println("✅ Logout complete.")
 }
+
+// logoutContext removes the token of a named context from the config file and the OS
+// keyring. The context itself is kept so its api_url survives a later login.
+func logoutContext(cfg *config.Config, name string) {
+	contextCfg, err := contextConfigFrom(cfg, name)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	hasConfigToken := contextCfg.GitHubToken != ""
+	hasKeyringToken := ghclient.KeyringToken(name) != ""
+	if !hasConfigToken && !hasKeyringToken {
+		fmt.Printf("❌ No stored tokens found for context %q.\n", name)
+		return
+	}
+
+	if !promptYesNo(fmt.Sprintf("Do you want to remove the token of context %q?", name)) {
+		fmt.Println("Logout cancelled.")
+		return
+	}
+	fmt.Println()
+
+	clearTokenValidation()
+
+	if hasConfigToken {
+		contextCfg.GitHubToken = ""
+		cfg.Contexts[name] = contextCfg
+		if err := saveConfig(cfg); err != nil {
+			fmt.Printf("❌ Failed to save config: %v\n", err)
+		} else {
+			fmt.Println("✅ Removed token from config file")
+		}
+	}
+	if hasKeyringToken {
+		if err := ghclient.DeleteKeyringToken(name); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+			fmt.Printf("❌ Failed to remove token from keyring: %v\n", err)
+		} else {
+			fmt.Println("✅ Removed token from OS keyring")
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("✅ Logout complete.")
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
@@ -209,3 +210,78 @@ func TestAuthCmd(t *testing.T) {
 		t.Error("authCmd has no subcommands")
 	}
 }
+
+func TestPrintTokenScopes(t *testing.T) {
+	originalGetTokenScopes := getTokenScopes
+	defer func() { getTokenScopes = originalGetTokenScopes }()
+
+	run := func(scopes []string, reported, stored bool) string {
+		getTokenScopes = func(token string) ([]string, bool, error) { return scopes, reported, nil }
+
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+		printTokenScopes("token", stored)
+		_ = w.Close()
+		os.Stdout = oldStdout
+
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		return buf.String()
+	}
+
+	out := run([]string{"read:org"}, true, true)
+	if !strings.Contains(out, "Scopes: read:org") || !strings.Contains(out, "missing the 'repo' scope") {
+		t.Errorf("Expected scopes and missing-repo warning, got: %s", out)
+	}
+
+	if out := run([]string{"read:org"}, true, false); strings.Contains(out, "missing the 'repo' scope") {
+		t.Errorf("Expected no warning for a token not stored by gh-inspect, got: %s", out)
+	}
+	if out := run([]string{"repo", "workflow"}, true, true); strings.Contains(out, "missing") {
+		t.Errorf("Expected no warning with repo scope, got: %s", out)
+	}
+	if out := run(nil, false, true); !strings.Contains(out, "not reported") {
+		t.Errorf("Expected fine-grained token note, got: %s", out)
+	}
+}
+
+func TestStoreTokenErrors(t *testing.T) {
+	// Silence the prompts
+	oldStdout := os.Stdout
+	devNull, _ := os.Open(os.DevNull)
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		_ = devNull.Close()
+	}()
+
+	t.Setenv("SHELL", "")
+	if err := storeTokenPersistentShell("ghp_1234567890abcdefghij"); err == nil {
+		t.Error("Expected an error when the shell cannot be detected")
+	}
+
+	// A file where the config directory should be makes saving fail
+	blocker := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", blocker)
+
+	oldStdin := os.Stdin
+	defer func() { os.Stdin = oldStdin }()
+	r, w, _ := os.Pipe()
+	_, _ = w.WriteString("y\n")
+	_ = w.Close()
+	os.Stdin = r
+
+	err := storeTokenConfig("ghp_1234567890abcdefghij")
+	if err == nil || !strings.Contains(err.Error(), "failed to save config") {
+		t.Errorf("Expected a save error, got %v", err)
+	}
+	// Storage failures exit non-zero even without --strict
+	var storageErr *tokenStorageError
+	if !errors.As(err, &storageErr) {
+		t.Errorf("Expected a save failure to be a storage error, got %T", err)
+	}
+}
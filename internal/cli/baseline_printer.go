@@ -1,17 +1,26 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/mikematt33/gh-inspect/pkg/baseline"
 	"github.com/mikematt33/gh-inspect/pkg/util"
 )
 
-// printComparison prints a comparison result in a human-readable format
-func printComparison(comp *baseline.ComparisonResult) {
-	fmt.Println("\n" + colorBold + "📊 Comparison with Baseline" + colorReset)
-	fmt.Printf("Previous run: %s\n", comp.Previous.Timestamp.Format(time.RFC3339))
+// printComparison prints a comparison result in a human-readable format. branch names
+// the ref analyzed for --compare-branch, or is empty for a comparison with a baseline.
+func printComparison(comp *baseline.ComparisonResult, branch string) {
+	if branch != "" {
+		fmt.Println("\n" + colorBold + "📊 Comparison with " + branch + colorReset)
+		fmt.Printf("Changes on %s relative to %s\n", refLabel(comp.Current.Meta.Ref), branch)
+	} else {
+		fmt.Println("\n" + colorBold + "📊 Comparison with Baseline" + colorReset)
+		fmt.Printf("Previous run: %s\n", comp.Previous.Timestamp.Format(time.RFC3339))
+	}
 	fmt.Println()
 
 	summary := comp.Summary
@@ -39,6 +48,67 @@ func printComparison(comp *baseline.ComparisonResult) {
 
 	// Detailed Changes (show top 5 improvements and degradations)
 	showTopChanges(comp)
+	showFindingChanges(comp)
+}
+
+// showFindingChanges lists findings that appeared or were resolved since the baseline
+func showFindingChanges(comp *baseline.ComparisonResult) {
+	var added, removed []string
+	for _, repoDelta := range comp.Deltas {
+		for _, f := range repoDelta.FindingDiff.AddedFindings {
+			added = append(added, fmt.Sprintf("%s: %s", repoDelta.RepoName, f.Message))
+		}
+		for _, f := range repoDelta.FindingDiff.RemovedFindings {
+			removed = append(removed, fmt.Sprintf("%s: %s", repoDelta.RepoName, f.Message))
+		}
+	}
+
+	for _, group := range []struct {
+		title string
+		color string
+		items []string
+	}{
+		{"New Findings", colorRed, added},
+		{"Resolved Findings", colorGreen, removed},
+	} {
+		if len(group.items) == 0 {
+			continue
+		}
+		fmt.Printf("%s%s (%d):%s\n", group.color, group.title, len(group.items), colorReset)
+		for _, item := range group.items[:util.Min(5, len(group.items))] {
+			fmt.Printf("  • %s\n", item)
+		}
+		fmt.Println()
+	}
+}
+
+// printBaselineWarnings reports on stderr what could not be loaded from an older or newer baseline
+func printBaselineWarnings(path string, b *baseline.Baseline) {
+	for _, w := range b.Warnings {
+		fmt.Fprintf(os.Stderr, "⚠️  %s: %s\n", path, w)
+	}
+}
+
+// writeComparisonJSON writes a comparison result as JSON, indented unless compact is set
+func writeComparisonJSON(w io.Writer, comp *baseline.ComparisonResult, compact bool) error {
+	enc := json.NewEncoder(w)
+	if !compact {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(comp)
+}
+
+// writeComparisonFile writes a comparison result as JSON to path, creating parent directories
+func writeComparisonFile(path string, comp *baseline.ComparisonResult, compact bool) error {
+	out, closeOut, err := openReportOutput(path)
+	if err != nil {
+		return err
+	}
+	if err := writeComparisonJSON(out, comp, compact); err != nil {
+		_ = closeOut()
+		return err
+	}
+	return closeOut()
 }
 
 // printMetricDelta prints a metric change with color coding
@@ -110,8 +180,8 @@ func showTopChanges(comp *baseline.ComparisonResult) {
 	}
 }
 
-// ANSI color codes
-const (
+// ANSI color codes, blanked by disableColor
+var (
 	colorReset  = "\033[0m"
 	colorRed    = "\033[31m"
 	colorGreen  = "\033[32m"
@@ -122,3 +192,9 @@ const (
 	colorWhite  = "\033[37m"
 	colorBold   = "\033[1m"
 )
+
+// disableColor blanks the ANSI color codes so printed output stays plain
+func disableColor() {
+	colorReset, colorRed, colorGreen, colorYellow, colorBlue = "", "", "", "", ""
+	colorPurple, colorCyan, colorWhite, colorBold = "", "", "", ""
+}
@@ -3,6 +3,8 @@ package cli
 import (
 	"fmt"
 	"os"
+	"sort"
+	"time"
 
 	"github.com/mikematt33/gh-inspect/internal/cache"
 	"github.com/spf13/cobra"
@@ -17,7 +19,14 @@ var cacheCmd = &cobra.Command{
 	Short: "Manage the API response cache",
 	Long: `Manage the disk-based cache for GitHub API responses.
 The cache stores API responses locally to reduce API rate limit usage and speed up repeated analyses.
-Cached data expires after 1 hour by default.`,
+Cached data expires after 1 hour by default. Per-prefix TTLs can be set in the
+'cache' section of the config file, e.g.:
+
+  cache:
+    default_ttl: 1h
+    ttl:
+      "repo:": 24h
+      "workflow:": 5m`,
 }
 
 var cacheClearCmd = &cobra.Command{
@@ -85,7 +94,15 @@ func runCacheStats(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	c, err := cache.New(cachePath, 0)
+	// Resolve configured TTLs so entries without a stored TTL are judged against the right default
+	defaultTTL, prefixTTLs := time.Hour, map[string]time.Duration{}
+	if cfg, err := loadConfig(); err == nil {
+		if d, p, err := cfg.Cache.ParseTTLs(); err == nil {
+			defaultTTL, prefixTTLs = d, p
+		}
+	}
+
+	c, err := cache.New(cachePath, defaultTTL)
 	if err != nil {
 		fmt.Printf("Error initializing cache: %v\n", err)
 		os.Exit(1)
@@ -101,5 +118,16 @@ func runCacheStats(cmd *cobra.Command, args []string) {
 	fmt.Printf("  Location: %s\n", cachePath)
 	fmt.Printf("  Entries: %d\n", count)
 	fmt.Printf("  Size: %.2f MB\n", float64(size)/(1024*1024))
-	fmt.Printf("  TTL: 1 hour\n")
+	fmt.Printf("  Default TTL: %s\n", defaultTTL)
+
+	if len(prefixTTLs) > 0 {
+		prefixes := make([]string, 0, len(prefixTTLs))
+		for prefix := range prefixTTLs {
+			prefixes = append(prefixes, prefix)
+		}
+		sort.Strings(prefixes)
+		for _, prefix := range prefixes {
+			fmt.Printf("  TTL %s %s\n", prefix, prefixTTLs[prefix])
+		}
+	}
 }
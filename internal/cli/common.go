@@ -2,9 +2,13 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
@@ -14,33 +18,109 @@ import (
 	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/activity"
 	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/branches"
 	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/ci"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/contributors"
 	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/dependencies"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/deployments"
 	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/issuehygiene"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/languages"
 	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/prflow"
 	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/releases"
 	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/repohealth"
 	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/security"
 	"github.com/mikematt33/gh-inspect/internal/config"
 	ghclient "github.com/mikematt33/gh-inspect/internal/github"
+	"github.com/mikematt33/gh-inspect/internal/logging"
+	"github.com/mikematt33/gh-inspect/internal/report"
+	"github.com/mikematt33/gh-inspect/pkg/insights"
 	"github.com/mikematt33/gh-inspect/pkg/models"
 	"github.com/schollz/progressbar/v3"
+	"github.com/spf13/cobra"
 )
 
 // getClientWithToken initializes a GitHub client with token resolution and validation.
 // It attempts to resolve the token from configuration, environment, or gh CLI.
 // Returns an error if no valid token is found.
 func getClientWithToken(cfg *config.Config) (*ghclient.ClientWrapper, error) {
-	token := ghclient.ResolveToken(cfg.Global.GitHubToken)
+	token, err := resolveToken(cfg)
+	if err != nil {
+		return nil, err
+	}
 	if token == "" {
-		return nil, fmt.Errorf("no GitHub token found. Please run 'gh-inspect auth' to login")
+		return nil, noTokenError()
+	}
+	client := ghclient.NewClient(token)
+	if cfg.Global.RetryMaxAttempts > 0 {
+		client.SetMaxAttempts(cfg.Global.RetryMaxAttempts)
+	}
+	return client, nil
+}
+
+// estimateRequestCost sums the approximate per-repository API cost of the enabled analyzers,
+// plus the repository lookup that skips unavailable repositories and the .gh-inspect.yml
+// lookup unless --no-repo-config is set
+func estimateRequestCost(analyzers []analysis.Analyzer, cfg analysis.Config, opts AnalysisOptions) int {
+	cost := 1
+	if !opts.NoRepoConfig {
+		cost++
+	}
+	for _, az := range analyzers {
+		cost += az.EstimatedCost(cfg)
+	}
+	return cost
+}
+
+// scoringWeightsFromConfig overlays configured scoring weights onto the defaults
+func scoringWeightsFromConfig(sc config.ScoringConfig) insights.ScoringWeights {
+	w := insights.DefaultScoringWeights()
+	overrides := []struct {
+		src *int
+		dst *int
+	}{
+		{sc.CIFailing, &w.CIFailing},
+		{sc.CIUnstable, &w.CIUnstable},
+		{sc.BusFactor, &w.BusFactor},
+		{sc.ZombiesHigh, &w.ZombiesHigh},
+		{sc.ZombiesModerate, &w.ZombiesModerate},
+		{sc.MissingFile, &w.MissingFile},
+		{sc.MissingFilesMax, &w.MissingFilesMax},
+		{sc.StalePRs, &w.StalePRs},
+	}
+	for _, o := range overrides {
+		if o.src != nil {
+			*o.dst = *o.src
+		}
 	}
-	return ghclient.NewClient(token), nil
+	return w
+}
+
+// insightThresholdsFromConfig overlays configured insight thresholds onto the defaults
+func insightThresholdsFromConfig(ic config.InsightsConfig) insights.InsightThresholds {
+	t := insights.DefaultInsightThresholds()
+	for _, o := range []struct {
+		src *float64
+		dst *float64
+	}{
+		{ic.CICritical, &t.CICritical},
+		{ic.CIWarning, &t.CIWarning},
+		{ic.CycleTimeHours, &t.CycleTimeHours},
+		{ic.SelfMergeRate, &t.SelfMergeRate},
+	} {
+		if o.src != nil {
+			*o.dst = *o.src
+		}
+	}
+	if ic.ZombieIssues != nil {
+		t.ZombieIssues = *ic.ZombieIssues
+	}
+	return t
 }
 
 // AnalysisOptions contains the configuration for running repository analysis.
 type AnalysisOptions struct {
 	Repos           []string
 	Since           string
+	SinceDate       string // Absolute start date; takes precedence over Since when set
+	Ref             string // Branch or ref to analyze; empty uses each repository's default branch
 	Depth           string
 	MaxPRs          int
 	MaxIssues       int
@@ -48,10 +128,265 @@ type AnalysisOptions struct {
 	Include         []string
 	Exclude         []string
 	OutputMode      string
+	AnalyzerTimeout int           // Seconds per analyzer run (0 = use config value)
+	Timeout         time.Duration // Deadline for the whole run; a partial report is returned when hit (0 = none)
+	QuietErrors     bool          // Report analyzer errors and timeouts only as findings, not on stderr
+	NoRepoConfig    bool          // Skip each repository's .gh-inspect.yml overrides
+	// Stream receives each repository result as it completes. When set, results are not
+	// kept in the returned report, which then only carries the summary.
+	Stream func(models.RepoResult)
 }
 
 var pipelineRunner = RunAnalysisPipeline
 
+// keyFilesFromConfig converts configured required files to repo-health key file checks
+func keyFilesFromConfig(files []config.RequiredFile) []repohealth.KeyFile {
+	keyFiles := make([]repohealth.KeyFile, 0, len(files))
+	for _, f := range files {
+		severity := models.Severity(strings.ToLower(f.Severity))
+		if severity == "" {
+			severity = models.SeverityMedium
+		}
+		keyFiles = append(keyFiles, repohealth.KeyFile{
+			Path:      f.Path,
+			AltPaths:  f.AltPaths,
+			Severity:  severity,
+			Deduction: f.Deduction,
+		})
+	}
+	return keyFiles
+}
+
+// assignFindingIDs sets each finding's ID so baselines can match findings across runs
+func assignFindingIDs(repo string, results []models.AnalyzerResult) {
+	for i := range results {
+		for j := range results[i].Findings {
+			results[i].Findings[j].ID = results[i].Findings[j].Fingerprint(repo)
+		}
+	}
+}
+
+// resolveSince returns the start of the analysis window: the absolute --since-date
+// when given, otherwise now minus the relative --since duration
+func resolveSince(opts AnalysisOptions, now time.Time) (time.Time, error) {
+	if opts.SinceDate != "" {
+		return parseSinceDate(opts.SinceDate, now)
+	}
+
+	var duration time.Duration
+	var err error
+	if strings.HasSuffix(opts.Since, "d") {
+		daysStr := strings.TrimSuffix(opts.Since, "d")
+		var days int
+		_, scanErr := fmt.Sscanf(daysStr, "%d", &days)
+		if scanErr != nil {
+			err = scanErr
+		} else {
+			duration = time.Duration(days) * 24 * time.Hour
+		}
+	} else {
+		duration, err = time.ParseDuration(opts.Since)
+	}
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time duration format: %s. Use '30d' or '720h'", opts.Since)
+	}
+	return now.Add(-duration), nil
+}
+
+// parseSinceDate accepts YYYY-MM-DD (midnight UTC) or RFC3339 and rejects dates in the future
+func parseSinceDate(value string, now time.Time) (time.Time, error) {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, value)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid since date: %s. Use YYYY-MM-DD or RFC3339 (e.g. 2024-01-01T00:00:00Z)", value)
+	}
+	if t.After(now) {
+		return time.Time{}, fmt.Errorf("invalid since date: %s is in the future", value)
+	}
+	return t, nil
+}
+
+// validateFormat checks --format against report.Formats, less the formats the command
+// does not support
+func validateFormat(format string, unsupported ...report.Format) error {
+	if format == "" {
+		return nil
+	}
+	var valid []string
+	for _, f := range report.Formats {
+		if slices.Contains(unsupported, f) {
+			continue
+		}
+		if string(f) == format {
+			return nil
+		}
+		valid = append(valid, string(f))
+	}
+	return fmt.Errorf("invalid format: %s (must be %s, or %s)", format, strings.Join(valid[:len(valid)-1], ", "), valid[len(valid)-1])
+}
+
+// validateSinceFlags rejects --since and --since-date used together and checks the date format
+func validateSinceFlags(cmd *cobra.Command) error {
+	if flagSinceDate == "" {
+		return nil
+	}
+	if cmd.Flags().Changed("since") {
+		return fmt.Errorf("--since and --since-date cannot be used together")
+	}
+	_, err := parseSinceDate(flagSinceDate, time.Now())
+	return err
+}
+
+// analyzerConcurrency bounds how many analyzers run at once for a single repository,
+// on top of the repo-level concurrency, to avoid bursts against the API
+const analyzerConcurrency = 3
+
+// runRepoAnalyzers runs every analyzer against one repository concurrently and returns
+// the results in registry order. Failures and timeouts become placeholder results with
+// an analyzer_error or analyzer_timeout finding, and are also reported to errOut.
+func runRepoAnalyzers(ctx context.Context, analyzers []analysis.Analyzer, client analysis.Client, target analysis.TargetRepository, cfg analysis.Config, timeout time.Duration, errOut io.Writer) []models.AnalyzerResult {
+	type indexedResult struct {
+		index  int
+		result models.AnalyzerResult
+	}
+
+	sem := make(chan struct{}, analyzerConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	collected := make([]indexedResult, 0, len(analyzers))
+	repoName := target.Owner + "/" + target.Name
+
+	for i, az := range analyzers {
+		wg.Add(1)
+		go func(i int, az analysis.Analyzer) {
+			defer wg.Done()
+
+			// Check for cancellation before each analyzer
+			select {
+			case <-ctx.Done():
+				return
+			case sem <- struct{}{}:
+			}
+			defer func() { <-sem }()
+
+			analyzerStart := time.Now()
+			res, err := runAnalyzerWithTimeout(ctx, az, client, target, cfg, timeout)
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				_, _ = fmt.Fprintf(errOut, "Timeout analyzing %s with %s after %v\n", repoName, az.Name(), timeout)
+				logging.Warn("analyzer timed out", "repo", repoName, "analyzer", az.Name(), "timeout", timeout)
+				res.Name = az.Name()
+				res.Findings = append(res.Findings, models.Finding{
+					Type:        "analyzer_timeout",
+					Severity:    models.SeverityHigh,
+					Message:     fmt.Sprintf("Analysis timed out after %v", timeout),
+					Remediation: "Increase --analyzer-timeout or global.analyzer_timeout_seconds, or reduce scan depth.",
+				})
+			} else if err != nil {
+				_, _ = fmt.Fprintf(errOut, "Error analyzing %s with %s: %v\n", repoName, az.Name(), err)
+				logging.Error("analyzer failed", "repo", repoName, "analyzer", az.Name(), "err", err)
+				// Add placeholder error result
+				res.Name = az.Name()
+				res.Findings = append(res.Findings, models.Finding{
+					Type:     "analyzer_error",
+					Severity: models.SeverityHigh,
+					Message:  fmt.Sprintf("Analysis failed: %v", err),
+				})
+			}
+			res.DurationMs = time.Since(analyzerStart).Milliseconds()
+			logging.Debug("analyzer finished", "repo", repoName, "analyzer", az.Name(), "duration_ms", res.DurationMs,
+				"metrics", len(res.Metrics), "findings", len(res.Findings))
+
+			mu.Lock()
+			collected = append(collected, indexedResult{index: i, result: res})
+			mu.Unlock()
+		}(i, az)
+	}
+	wg.Wait()
+
+	// Restore registry order so output is deterministic
+	sort.Slice(collected, func(a, b int) bool { return collected[a].index < collected[b].index })
+	results := make([]models.AnalyzerResult, len(collected))
+	for i, c := range collected {
+		results[i] = c.result
+	}
+	return results
+}
+
+// allAnalyzersFailed reports whether every analyzer for the repository errored or timed out,
+// i.e. the repository produced no usable results at all
+func allAnalyzersFailed(r models.RepoResult) bool {
+	if len(r.Analyzers) == 0 {
+		return false
+	}
+	for _, az := range r.Analyzers {
+		failed := false
+		for _, f := range az.Findings {
+			if f.Type == "analyzer_error" || f.Type == "analyzer_timeout" {
+				failed = true
+				break
+			}
+		}
+		if !failed {
+			return false
+		}
+	}
+	return true
+}
+
+// withInterrupt returns a context that is cancelled on SIGINT or SIGTERM, printing msg
+// to stderr when that happens (empty msg = silent). Calling cancel stops listening.
+func withInterrupt(parent context.Context, msg string) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-sigChan:
+			if msg != "" {
+				fmt.Fprintln(os.Stderr, "\n⚠️  "+msg)
+			}
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(sigChan)
+		cancel()
+	}
+}
+
+// runAnalyzerWithTimeout runs a single analyzer, giving up after timeout (0 = no limit).
+// The analyzer runs in its own goroutine so one that ignores its context cannot stall the scan.
+// A timed-out run returns context.DeadlineExceeded.
+func runAnalyzerWithTimeout(ctx context.Context, az analysis.Analyzer, client analysis.Client, target analysis.TargetRepository, cfg analysis.Config, timeout time.Duration) (models.AnalyzerResult, error) {
+	if timeout <= 0 {
+		return az.Analyze(ctx, client, target, cfg)
+	}
+
+	azCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		res models.AnalyzerResult
+		err error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		res, err := az.Analyze(azCtx, client, target, cfg)
+		done <- outcome{res, err}
+	}()
+
+	select {
+	case o := <-done:
+		return o.res, o.err
+	case <-azCtx.Done():
+		return models.AnalyzerResult{Name: az.Name()}, azCtx.Err()
+	}
+}
+
 // shouldIncludeAnalyzer determines if an analyzer should be included based on include/exclude filters.
 // If include list is provided, only those analyzers are included.
 // If exclude list is provided, all analyzers except those are included.
@@ -90,39 +425,37 @@ func shouldIncludeAnalyzer(analyzerName string, include, exclude []string) bool
 	return true
 }
 
-// RunAnalysisPipeline executes the complete analysis workflow for the specified repositories.
-// It loads configuration, sets up analyzers, runs analysis concurrently, and aggregates results.
-// The function supports context cancellation and provides progress feedback.
-func RunAnalysisPipeline(opts AnalysisOptions) (*models.Report, error) {
-	// 1. Load Config
-	cfg, err := config.Load()
-	if err != nil {
-		return nil, fmt.Errorf("error loading config: %w", err)
+// autoConcurrency picks the worker count for concurrency_mode "auto". While the remaining
+// rate limit covers at least twice the estimated cost, all maxWorkers are used. Below that,
+// workers scale with remaining/(2*cost), so a run that would nearly exhaust the limit
+// spreads its requests out instead of every worker stalling in the rate-limit wait.
+// The result is always between 1 and maxWorkers.
+func autoConcurrency(maxWorkers, remaining, cost int) int {
+	if maxWorkers < 1 {
+		return 1
 	}
-
-	// 2. Parse Time Window
-	var duration time.Duration
-
-	if strings.HasSuffix(opts.Since, "d") {
-		daysStr := strings.TrimSuffix(opts.Since, "d")
-		var days int
-		_, scanErr := fmt.Sscanf(daysStr, "%d", &days)
-		if scanErr != nil {
-			err = scanErr
-		} else {
-			duration = time.Duration(days) * 24 * time.Hour
-		}
-	} else {
-		duration, err = time.ParseDuration(opts.Since)
+	if cost <= 0 || remaining >= 2*cost {
+		return maxWorkers
 	}
+	workers := maxWorkers * remaining / (2 * cost)
+	if workers < 1 {
+		return 1
+	}
+	return workers
+}
 
+// buildAnalysisConfig resolves the time window, depth limits and output mode for a run
+func buildAnalysisConfig(opts AnalysisOptions) (analysis.Config, error) {
+	since, err := resolveSince(opts, time.Now())
 	if err != nil {
-		return nil, fmt.Errorf("invalid time duration format: %s. Use '30d' or '720h'", opts.Since)
+		return analysis.Config{}, err
 	}
 
 	// Get depth configuration
-	depthCfg := analysis.GetDepthConfig(opts.Depth)
-	depthCfg = depthCfg.ApplyOverrides(opts.MaxPRs, opts.MaxIssues, opts.MaxWorkflowRuns)
+	depthCfg, err := analysis.ResolveDepthConfig(opts.Depth, opts.MaxPRs, opts.MaxIssues, opts.MaxWorkflowRuns)
+	if err != nil {
+		return analysis.Config{}, err
+	}
 
 	// Parse and validate output mode
 	var outputMode models.OutputMode
@@ -134,107 +467,212 @@ func RunAnalysisPipeline(opts AnalysisOptions) (*models.Report, error) {
 	case "statistical":
 		outputMode = models.OutputModeStatistical
 	default:
-		return nil, fmt.Errorf("invalid output mode: %s. Use 'suggestive', 'observational', or 'statistical'", opts.OutputMode)
+		return analysis.Config{}, fmt.Errorf("invalid output mode: %s. Use 'suggestive', 'observational', or 'statistical'", opts.OutputMode)
 	}
 
-	analysisCfg := analysis.Config{
-		Since:       time.Now().Add(-duration),
+	return analysis.Config{
+		Since:       since,
 		IncludeDeep: depthCfg.IncludeDeep,
 		DepthConfig: depthCfg,
 		OutputMode:  outputMode,
-	}
-
-	// 3. Setup Dependencies
-	token := ghclient.ResolveToken(cfg.Global.GitHubToken)
-	if token == "" {
-		return nil, fmt.Errorf("no GitHub token found. Please run 'gh-inspect auth' to login")
-	}
-	client := ghclient.NewClientWithCache(token, !flagNoCache)
-
-	// Pre-flight check for rate limits
-	limits, err := client.GetRateLimit(context.Background())
-	if err != nil {
-		// Warning only - don't fail
-		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Could not check rate limit: %v\n", err)
-	} else {
-		// Estimate cost based on scan depth
-		costPerRepo := 25 // Base estimate (commits, health, basic stats)
-		if depthCfg.IncludeDeep {
-			costPerRepo = 150 // Deep scan includes issue pagination, reviews, etc.
-		}
+	}, nil
+}
 
-		totalCost := costPerRepo * len(opts.Repos)
-		if limits.Remaining < totalCost {
-			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Analysis may exhaust rate limit. Estimated ~%d requests needed, %d remaining.\n", totalCost, limits.Remaining)
-			fmt.Fprintf(os.Stderr, "   Proceeding anyway in 2 seconds (Ctrl+C to cancel)...\n")
-			time.Sleep(2 * time.Second)
-		}
+// analyzerEnabled reports whether an analyzer enabled (or not) in config should run. Naming
+// it in --include runs it even when the config disables it, since it was asked for explicitly.
+func analyzerEnabled(analyzerName string, enabled bool, opts AnalysisOptions) bool {
+	if len(opts.Include) > 0 {
+		return shouldIncludeAnalyzer(analyzerName, opts.Include, opts.Exclude)
 	}
+	return enabled && shouldIncludeAnalyzer(analyzerName, opts.Include, opts.Exclude)
+}
 
-	// Setup Analyzer Registry
+// buildAnalyzers returns the analyzers enabled in the config and selected by --include/--exclude
+func buildAnalyzers(cfg *config.Config, opts AnalysisOptions) []analysis.Analyzer {
 	var analyzers []analysis.Analyzer
 
 	// Always add Activity (Tier 1) if included
 	if shouldIncludeAnalyzer("activity", opts.Include, opts.Exclude) {
-		analyzers = append(analyzers, activity.New())
+		act := activity.New(cfg.Analyzers.Activity.Params.ConventionalCommitThreshold)
+		if len(cfg.Analyzers.Activity.Params.ChurnExcludeLabels) > 0 {
+			act.ChurnExcludeLabels = cfg.Analyzers.Activity.Params.ChurnExcludeLabels
+		}
+		analyzers = append(analyzers, act)
 	}
 
-	if cfg.Analyzers.PRFlow.Enabled && shouldIncludeAnalyzer("pr-flow", opts.Include, opts.Exclude) {
-		analyzers = append(analyzers, prflow.New(cfg.Analyzers.PRFlow.Params.StaleThresholdDays))
+	if analyzerEnabled("pr-flow", cfg.Analyzers.PRFlow.Enabled, opts) {
+		flow := prflow.New(cfg.Analyzers.PRFlow.Params.StaleThresholdDays)
+		if len(cfg.Analyzers.PRFlow.Params.BotLogins) > 0 {
+			flow.BotLogins = cfg.Analyzers.PRFlow.Params.BotLogins
+		}
+		if n := cfg.Analyzers.PRFlow.Params.SizeSampleSize; n != nil {
+			flow.SizeSampleSize = *n
+		}
+		analyzers = append(analyzers, flow)
 	}
 
-	if cfg.Analyzers.RepoHealth.Enabled && shouldIncludeAnalyzer("repo-health", opts.Include, opts.Exclude) {
-		analyzers = append(analyzers, repohealth.New())
+	if analyzerEnabled("repo-health", cfg.Analyzers.RepoHealth.Enabled, opts) {
+		health := repohealth.New()
+		if len(cfg.Analyzers.RepoHealth.RequiredFiles) > 0 {
+			health.KeyFiles = keyFilesFromConfig(cfg.Analyzers.RepoHealth.RequiredFiles)
+		}
+		if mb := cfg.Analyzers.RepoHealth.Params.LargeFileMB; mb != nil {
+			health.LargeFileMB = *mb
+		}
+		if mb := cfg.Analyzers.RepoHealth.Params.LargeTreeMB; mb != nil {
+			health.LargeTreeMB = *mb
+		}
+		analyzers = append(analyzers, health)
 	}
 
-	if cfg.Analyzers.IssueHygiene.Enabled && shouldIncludeAnalyzer("issue-hygiene", opts.Include, opts.Exclude) {
-		analyzers = append(analyzers, issuehygiene.New(
+	if analyzerEnabled("issue-hygiene", cfg.Analyzers.IssueHygiene.Enabled, opts) {
+		hygiene := issuehygiene.New(
 			cfg.Analyzers.IssueHygiene.Params.StaleThresholdDays,
 			cfg.Analyzers.IssueHygiene.Params.ZombieThresholdDays,
-		))
+		)
+		if len(cfg.Analyzers.IssueHygiene.Params.LabelGroups) > 0 {
+			hygiene.LabelGroups = cfg.Analyzers.IssueHygiene.Params.LabelGroups
+		}
+		hygiene.UntriagedThreshold = cfg.Analyzers.IssueHygiene.Params.UntriagedThreshold
+		hygiene.ResponseSLAHours = cfg.Analyzers.IssueHygiene.Params.ResponseSLAHours
+		analyzers = append(analyzers, hygiene)
 	}
 
-	if cfg.Analyzers.CI.Enabled && shouldIncludeAnalyzer("ci", opts.Include, opts.Exclude) {
+	if analyzerEnabled("ci", cfg.Analyzers.CI.Enabled, opts) {
 		analyzers = append(analyzers, ci.New())
 	}
 
-	if cfg.Analyzers.Security.Enabled && shouldIncludeAnalyzer("security", opts.Include, opts.Exclude) {
+	if analyzerEnabled("security", cfg.Analyzers.Security.Enabled, opts) {
 		analyzers = append(analyzers, security.New())
 	}
 
-	if cfg.Analyzers.Releases.Enabled && shouldIncludeAnalyzer("releases", opts.Include, opts.Exclude) {
+	if analyzerEnabled("releases", cfg.Analyzers.Releases.Enabled, opts) {
 		analyzers = append(analyzers, releases.New())
 	}
 
-	if cfg.Analyzers.Branches.Enabled && shouldIncludeAnalyzer("branches", opts.Include, opts.Exclude) {
-		analyzers = append(analyzers, branches.New(cfg.Analyzers.Branches.Params.StaleThresholdDays))
+	if analyzerEnabled("deployments", cfg.Analyzers.Deployments.Enabled, opts) {
+		analyzers = append(analyzers, deployments.New(cfg.Analyzers.Deployments.Params.SuccessRateThreshold))
 	}
 
-	if cfg.Analyzers.Dependencies.Enabled && shouldIncludeAnalyzer("dependencies", opts.Include, opts.Exclude) {
+	if analyzerEnabled("branches", cfg.Analyzers.Branches.Enabled, opts) {
+		branchAnalyzer := branches.New(
+			cfg.Analyzers.Branches.Params.StaleThresholdDays,
+			cfg.Analyzers.Branches.Params.DivergenceThresholdCommits,
+		)
+		branchAnalyzer.NamingPatterns = cfg.Analyzers.Branches.Params.NamingPatterns
+		analyzers = append(analyzers, branchAnalyzer)
+	}
+
+	if analyzerEnabled("dependencies", cfg.Analyzers.Dependencies.Enabled, opts) {
 		analyzers = append(analyzers, dependencies.New())
 	}
 
-	start := time.Now()
+	if analyzerEnabled("languages", cfg.Analyzers.Languages.Enabled, opts) {
+		analyzers = append(analyzers, languages.New())
+	}
 
-	// Setup context with cancellation support
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	if analyzerEnabled("contributors", cfg.Analyzers.Contributors.Enabled, opts) {
+		analyzers = append(analyzers, contributors.New(
+			cfg.Analyzers.Contributors.Params.InternalMembers,
+			cfg.Analyzers.Contributors.Params.InternalDomains,
+		))
+	}
+	return analyzers
+}
 
-	// Handle interrupt signals
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	defer signal.Stop(sigChan)
-	go func() {
-		<-sigChan
-		fmt.Fprintln(os.Stderr, "\n⚠️  Received interrupt signal. Cancelling analysis...")
-		cancel()
-	}()
+// RunAnalysisPipeline executes the complete analysis workflow for the specified repositories.
+// It loads configuration, sets up analyzers, runs analysis concurrently, and aggregates results.
+// The function supports context cancellation and provides progress feedback.
+func RunAnalysisPipeline(opts AnalysisOptions) (*models.Report, error) {
+	// 1. Load Config
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error loading config: %w", err)
+	}
 
-	// Concurrency control
+	// 2. Resolve time window, depth and output mode
+	analysisCfg, err := buildAnalysisConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Setup Dependencies
+	token, err := resolveToken(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, noTokenError()
+	}
+	defaultTTL, prefixTTLs, err := cfg.Cache.ParseTTLs()
+	if err != nil {
+		return nil, err
+	}
+	client := ghclient.NewClientWithCacheTTL(token, !flagNoCache, defaultTTL, prefixTTLs)
+	if cfg.Global.RetryMaxAttempts > 0 {
+		client.SetMaxAttempts(cfg.Global.RetryMaxAttempts)
+	}
+
+	// Setup Analyzer Registry
+	analyzers := buildAnalyzers(cfg, opts)
+
+	// Concurrency control (auto mode may lower it after the rate-limit check)
 	maxworkers := cfg.Global.Concurrency
 	if maxworkers < 1 {
 		maxworkers = 1
 	}
+
+	// Setup context with cancellation support and the optional whole-run deadline.
+	// It is created before the preflight so --timeout and Ctrl+C also bound its waits.
+	parent := context.Background()
+	if opts.Timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		parent, cancelTimeout = context.WithTimeout(parent, opts.Timeout)
+		defer cancelTimeout()
+	}
+	ctx, cancel := withInterrupt(parent, "Received interrupt signal. Cancelling analysis...")
+	defer cancel()
+
+	// Pre-flight check for rate limits, always against the live counts
+	limits, err := checkTokenRateLimit(ctx, token, client.GetRateLimit)
+	if err == nil && limits.Remaining == 0 {
+		if err := handleExhaustedRateLimit(ctx, os.Stderr, limits, stdinIsTerminal(), promptYesNo); err != nil {
+			return nil, err
+		}
+		limits, err = checkTokenRateLimit(ctx, token, client.GetRateLimit)
+	}
+	if err != nil {
+		// Warning only - don't fail
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Could not check rate limit: %v\n", err)
+	} else {
+		totalCost := estimateRequestCost(analyzers, analysisCfg, opts) * len(opts.Repos)
+		logging.Info("rate limit checked", "remaining", limits.Remaining, "limit", limits.Limit,
+			"reset", limits.Reset.Time, "estimated_cost", totalCost)
+		if cfg.Global.ConcurrencyMode == "auto" {
+			maxworkers = autoConcurrency(maxworkers, limits.Remaining, totalCost)
+			if shouldPrintVerbose() {
+				fmt.Fprintf(os.Stderr, "Auto concurrency: %d workers (max %d, ~%d requests needed, %d remaining)\n",
+					maxworkers, cfg.Global.Concurrency, totalCost, limits.Remaining)
+			}
+		}
+		if limits.Remaining < totalCost {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Analysis may exhaust rate limit. Estimated ~%d requests needed, %d remaining.\n", totalCost, limits.Remaining)
+			fmt.Fprintf(os.Stderr, "   Proceeding anyway in 2 seconds (Ctrl+C to cancel)...\n")
+			if err := sleepContext(ctx, 2*time.Second); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Per-analyzer timeout: flag overrides config
+	timeoutSeconds := cfg.Global.AnalyzerTimeoutSeconds
+	if opts.AnalyzerTimeout > 0 {
+		timeoutSeconds = opts.AnalyzerTimeout
+	}
+	analyzerTimeout := time.Duration(timeoutSeconds) * time.Second
+
+	start := time.Now()
+
 	sem := make(chan struct{}, maxworkers)
 	var wg sync.WaitGroup
 	var mu sync.Mutex
@@ -254,20 +692,52 @@ func RunAnalysisPipeline(opts AnalysisOptions) (*models.Report, error) {
 			progressbar.OptionClearOnFinish(),
 		)
 	}
+	// Workers log through progress so their lines don't break up the bar
+	progress := newProgressOutput(bar)
+	defer progress.finish()
+	stdout, stderr := progress.writer(os.Stdout), progress.writer(os.Stderr)
+	client.SetLogOutput(stderr)
+	defer logging.SetOutput(logging.SetOutput(stderr))
+
+	analyzerErrOut := stderr
+	if opts.QuietErrors {
+		analyzerErrOut = io.Discard
+	}
 
 	// Prepare Report Struct matching models/report.go definition
 	fullReport := models.Report{
 		Meta: models.ReportMeta{
-			GeneratedAt: time.Now(),
-			CLIVersion:  Version,
-			Command:     "run", // This might need to be passed in or generic
+			GeneratedAt:   time.Now(),
+			CLIVersion:    Version,
+			Command:       "run", // This might need to be passed in or generic
+			SchemaVersion: models.ReportSchemaVersion,
+			Ref:           opts.Ref,
 		},
 		Repositories: []models.RepoResult{},
 	}
 
+	// Workers hand finished repositories to a single collector, which either keeps them
+	// for the report or streams them out, folding each into the summary as it arrives
+	results := make(chan models.RepoResult)
+	totals := newSummaryTotals(cfg.Global, scoringWeightsFromConfig(cfg.Scoring))
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for r := range results {
+			totals.add(r)
+			if opts.Stream != nil {
+				opts.Stream(r)
+			} else {
+				fullReport.Repositories = append(fullReport.Repositories, r)
+			}
+		}
+	}()
+
 	if shouldPrintInfo() {
 		fmt.Printf("Queueing %d repositories (concurrency: %d)...\n", len(opts.Repos), maxworkers)
 	}
+	logging.Info("analysis started", "repos", len(opts.Repos), "workers", maxworkers, "analyzers", len(analyzers),
+		"depth", opts.Depth, "ref", opts.Ref, "since", analysisCfg.Since)
 
 	for _, repoArg := range opts.Repos {
 		wg.Add(1)
@@ -281,132 +751,262 @@ func RunAnalysisPipeline(opts AnalysisOptions) (*models.Report, error) {
 			case sem <- struct{}{}:
 			}
 			defer func() { <-sem }()
+			repoStart := time.Now()
 
 			parts := strings.Split(arg, "/")
 			if len(parts) != 2 {
-				fmt.Printf("Skipping invalid repo format: %s\n", arg)
+				_, _ = fmt.Fprintf(stdout, "Skipping invalid repo format: %s\n", arg)
 				return
 			}
 
 			owner, name := parts[0], parts[1]
+
+			// Skip repositories that do not exist (or are invisible to this token) up front,
+			// instead of reporting them with empty metrics and one error per analyzer
+			r, err := client.GetRepository(ctx, owner, name)
+			if analysis.IsNotFoundError(err) {
+				logging.Info("repository unavailable", "repo", arg, "err", err)
+				mu.Lock()
+				fullReport.Unavailable = append(fullReport.Unavailable, models.UnavailableRepo{
+					Name:    arg,
+					Type:    "repo_unavailable",
+					Message: "Repository not found or not accessible with this token",
+				})
+				completed++
+				mu.Unlock()
+				progress.increment()
+				return
+			}
+
 			if shouldPrintVerbose() {
-				fmt.Printf("Analyzing %s/%s...\n", owner, name)
+				_, _ = fmt.Fprintf(stdout, "Analyzing %s/%s...\n", owner, name)
 			}
 
 			repoReport := models.RepoResult{
 				Name:      fmt.Sprintf("%s/%s", owner, name),
-				URL:       fmt.Sprintf("https://github.com/%s/%s", owner, name),
+				URL:       r.GetHTMLURL(),
 				Analyzers: []models.AnalyzerResult{},
 			}
 
-			target := analysis.TargetRepository{Owner: owner, Name: name}
-
-			for _, az := range analyzers {
-				// Check for cancellation before each analyzer
-				select {
-				case <-ctx.Done():
-					return
-				default:
+			if repoReport.URL == "" {
+				repoReport.URL = ghclient.RepoWebURL(owner, name)
+			}
+			target := analysis.TargetRepository{Owner: owner, Name: name, Ref: opts.Ref, URL: repoReport.URL}
+
+			// A .gh-inspect.yml committed in the repository overrides analyzer settings for it alone
+			repoAnalyzers := analyzers
+			if !opts.NoRepoConfig {
+				if repoCfg := loadRepoConfig(ctx, client, owner, name, opts.Ref, cfg, stderr); repoCfg != nil {
+					logging.Debug("using repository config", "repo", arg)
+					repoAnalyzers = buildAnalyzers(repoCfg, opts)
 				}
+			}
 
-				res, err := az.Analyze(ctx, client, target, analysisCfg)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error analyzing %s with %s: %v\n", arg, az.Name(), err)
-					// Add placeholder error result
-					res.Name = az.Name()
-					res.Findings = append(res.Findings, models.Finding{
-						Type:     "analyzer_error",
-						Severity: models.SeverityHigh,
-						Message:  fmt.Sprintf("Analysis failed: %v", err),
-					})
-				}
-				repoReport.Analyzers = append(repoReport.Analyzers, res)
+			repoReport.Analyzers = runRepoAnalyzers(ctx, repoAnalyzers, client, target, analysisCfg, analyzerTimeout, analyzerErrOut)
+			assignFindingIDs(repoReport.Name, repoReport.Analyzers)
+			if ctx.Err() != nil {
+				return
 			}
+			repoReport.DurationMs = time.Since(repoStart).Milliseconds()
+			logging.Debug("repository finished", "repo", repoReport.Name, "duration_ms", repoReport.DurationMs)
+
+			results <- repoReport
 
 			mu.Lock()
-			fullReport.Repositories = append(fullReport.Repositories, repoReport)
 			completed++
+			done := completed
+			mu.Unlock()
 			if bar != nil {
-				_ = bar.Add(1)
+				progress.increment()
 			} else if shouldPrintVerbose() {
-				fmt.Printf("✓ Completed %s/%s (%d/%d repositories)\n", owner, name, completed, totalRepos)
+				_, _ = fmt.Fprintf(stdout, "✓ Completed %s/%s (%d/%d repositories)\n", owner, name, done, totalRepos)
 			}
-			mu.Unlock()
 
 		}(repoArg)
 	}
 
 	wg.Wait()
+	close(results)
+	<-collected
 
 	// Finish progress bar
-	if bar != nil {
-		_ = bar.Finish()
-	}
-
-	// Check if analysis was cancelled
-	select {
-	case <-ctx.Done():
+	progress.finish()
+
+	// Check if analysis was cancelled; hitting --timeout keeps the repositories finished so far
+	if errors.Is(parent.Err(), context.DeadlineExceeded) {
+		fullReport.Meta.Note = fmt.Sprintf("Timeout of %s reached: only %d of %d repositories were analyzed",
+			opts.Timeout, totals.summary.TotalReposAnalyzed, totalRepos)
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", fullReport.Meta.Note)
+	} else if ctx.Err() != nil {
 		return nil, fmt.Errorf("analysis cancelled by user")
-	default:
 	}
 
 	durationScan := time.Since(start)
 	fullReport.Meta.Duration = durationScan.String()
+	logging.Info("analysis finished", "repos", totals.summary.TotalReposAnalyzed, "unavailable", len(fullReport.Unavailable), "duration", durationScan)
+
+	fullReport.Summary = totals.finish()
+	sort.Slice(fullReport.Unavailable, func(i, j int) bool { return fullReport.Unavailable[i].Name < fullReport.Unavailable[j].Name })
+	fullReport.Summary.ReposUnavailable = len(fullReport.Unavailable)
+
+	return &fullReport, nil
+}
+
+// healthScoreWeighting describes the configured weighting for the summary, or "" when
+// repos are weighted equally and the plain average already says everything
+func healthScoreWeighting(g config.GlobalConfig) string {
+	strategy := g.HealthScoreWeighting
+	if strategy == "none" {
+		strategy = ""
+	}
+	switch {
+	case len(g.RepoWeights) > 0 && strategy != "":
+		return strategy + " and explicit weights"
+	case len(g.RepoWeights) > 0:
+		return "explicit weights"
+	}
+	return strategy
+}
 
-	// Calculate Global Summary in a single pass
-	fullReport.Summary.TotalReposAnalyzed = len(fullReport.Repositories)
-
-	var sumHealth, sumCISuccess, sumCIRuntime, sumPRCycle float64
-	var countHealth, countCI, countCIRuntime, countPRCycle int
-
-	for _, r := range fullReport.Repositories {
-		for _, az := range r.Analyzers {
-			fullReport.Summary.IssuesFound += len(az.Findings)
-
-			for _, m := range az.Metrics {
-				switch m.Key {
-				case "commits_total":
-					fullReport.Summary.TotalCommits += int(m.Value)
-				case "open_issues_total":
-					fullReport.Summary.TotalOpenIssues += int(m.Value)
-				case "zombie_issues":
-					fullReport.Summary.TotalZombieIssues += int(m.Value)
-				case "health_score":
-					sumHealth += m.Value
-					countHealth++
-					if m.Value < 50.0 {
-						fullReport.Summary.ReposAtRisk++
-					}
-				case "success_rate":
-					sumCISuccess += m.Value
-					countCI++
-				case "avg_runtime":
-					sumCIRuntime += m.Value
-					countCIRuntime++
-				case "bus_factor":
-					if m.Value == 1 {
-						fullReport.Summary.BusFactor1Repos++
-					}
-				case "avg_cycle_time_hours":
-					sumPRCycle += m.Value
-					countPRCycle++
+// repoHealthWeight returns a repo's health score and its weight in the weighted average:
+// its stars or commits (plus one, so new repos still count), or an explicit weight from
+// repoWeights. ok is false when the repo has no health score.
+func repoHealthWeight(r models.RepoResult, strategy string, repoWeights map[string]float64) (score, weight float64, ok bool) {
+	var stars, commits float64
+	for _, az := range r.Analyzers {
+		for _, m := range az.Metrics {
+			switch m.Key {
+			case "health_score":
+				score, ok = m.Value, true
+			case "stars":
+				stars = m.Value
+			case "commits_total":
+				commits = m.Value
+			}
+		}
+	}
+	if !ok {
+		return 0, 0, false
+	}
+
+	weight = 1.0
+	switch strategy {
+	case "stars":
+		weight = 1 + stars
+	case "commits":
+		weight = 1 + commits
+	}
+	if w, found := repoWeights[r.Name]; found {
+		weight = w
+	}
+	return score, weight, true
+}
+
+// summaryTotals builds the global summary one repository at a time, so streamed
+// results never need to be held in memory together
+type summaryTotals struct {
+	summary   models.GlobalSummary
+	global    config.GlobalConfig
+	weighting string
+	weights   insights.ScoringWeights // for the Engineering Health Scores of the riskiest repos
+
+	sumHealth, sumCISuccess, sumCIRuntime, sumPRCycle  float64
+	countHealth, countCI, countCIRuntime, countPRCycle int
+	weightedSum, totalWeight                           float64
+	engineeringScores                                  []models.RiskyRepo
+}
+
+// maxRiskiestRepos caps the leaderboard of lowest Engineering Health Scores in the summary
+const maxRiskiestRepos = 5
+
+func newSummaryTotals(g config.GlobalConfig, w insights.ScoringWeights) *summaryTotals {
+	return &summaryTotals{global: g, weighting: healthScoreWeighting(g), weights: w}
+}
+
+// add folds one analyzed repository into the totals
+func (t *summaryTotals) add(r models.RepoResult) {
+	t.summary.TotalReposAnalyzed++
+	if allAnalyzersFailed(r) {
+		t.summary.ReposFailed++
+	} else if len(r.Analyzers) > 0 {
+		// The same score the per-repository sections show, so the leaderboard matches them
+		score := insights.CalculateEngineeringHealthScoreWithWeights(r, t.weights)
+		t.engineeringScores = append(t.engineeringScores, models.RiskyRepo{Name: r.Name, HealthScore: float64(score)})
+	}
+	if t.weighting != "" {
+		if score, weight, ok := repoHealthWeight(r, t.global.HealthScoreWeighting, t.global.RepoWeights); ok {
+			t.weightedSum += score * weight
+			t.totalWeight += weight
+		}
+	}
+
+	for _, az := range r.Analyzers {
+		t.summary.IssuesFound += len(az.Findings)
+
+		for _, m := range az.Metrics {
+			switch m.Key {
+			case "commits_total":
+				t.summary.TotalCommits += int(m.Value)
+			case "open_issues_total":
+				t.summary.TotalOpenIssues += int(m.Value)
+			case "zombie_issues":
+				t.summary.TotalZombieIssues += int(m.Value)
+			case "health_score":
+				t.sumHealth += m.Value
+				t.countHealth++
+				if m.Value < 50.0 {
+					t.summary.ReposAtRisk++
+				}
+			case "success_rate":
+				t.sumCISuccess += m.Value
+				t.countCI++
+			case "avg_runtime":
+				t.sumCIRuntime += m.Value
+				t.countCIRuntime++
+			case "bus_factor":
+				if m.Value == 1 {
+					t.summary.BusFactor1Repos++
 				}
+			case "avg_cycle_time_hours":
+				t.sumPRCycle += m.Value
+				t.countPRCycle++
 			}
 		}
 	}
+}
 
-	if countHealth > 0 {
-		fullReport.Summary.AvgHealthScore = sumHealth / float64(countHealth)
+// finish returns the summary with averages computed from the totals
+func (t *summaryTotals) finish() models.GlobalSummary {
+	s := t.summary
+	if t.countHealth > 0 {
+		s.AvgHealthScore = t.sumHealth / float64(t.countHealth)
+	}
+	if t.weighting != "" {
+		if t.totalWeight > 0 {
+			s.WeightedHealthScore = t.weightedSum / t.totalWeight
+		}
+		s.HealthScoreWeighting = t.weighting
 	}
-	if countCI > 0 {
-		fullReport.Summary.AvgCISuccessRate = sumCISuccess / float64(countCI)
+	if t.countCI > 0 {
+		s.AvgCISuccessRate = t.sumCISuccess / float64(t.countCI)
 	}
-	if countCIRuntime > 0 {
-		fullReport.Summary.AvgCIRuntime = sumCIRuntime / float64(countCIRuntime)
+	if t.countCIRuntime > 0 {
+		s.AvgCIRuntime = t.sumCIRuntime / float64(t.countCIRuntime)
 	}
-	if countPRCycle > 0 {
-		fullReport.Summary.AvgPRCycleTime = sumPRCycle / float64(countPRCycle)
+	if t.countPRCycle > 0 {
+		s.AvgPRCycleTime = t.sumPRCycle / float64(t.countPRCycle)
 	}
 
-	return &fullReport, nil
+	// Repos finish in any order, so sort by score and then name for a stable list
+	sort.Slice(t.engineeringScores, func(i, j int) bool {
+		a, b := t.engineeringScores[i], t.engineeringScores[j]
+		if a.HealthScore != b.HealthScore {
+			return a.HealthScore < b.HealthScore
+		}
+		return a.Name < b.Name
+	})
+	if n := len(t.engineeringScores); n > 0 {
+		s.RiskiestRepos = append([]models.RiskyRepo(nil), t.engineeringScores[:min(n, maxRiskiestRepos)]...)
+	}
+	return s
 }
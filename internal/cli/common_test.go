@@ -0,0 +1,469 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/ci"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/issuehygiene"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/languages"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/prflow"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/repohealth"
+	"github.com/mikematt33/gh-inspect/internal/config"
+	"github.com/mikematt33/gh-inspect/internal/report"
+	"github.com/mikematt33/gh-inspect/pkg/insights"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// blockingAnalyzer never returns until released, ignoring its context
+type blockingAnalyzer struct {
+	release chan struct{}
+}
+
+func (b *blockingAnalyzer) Name() string { return "blocking" }
+
+func (b *blockingAnalyzer) EstimatedCost(cfg analysis.Config) int { return 0 }
+
+func (b *blockingAnalyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
+	<-b.release
+	return models.AnalyzerResult{Name: b.Name()}, nil
+}
+
+func TestRunAnalyzerWithTimeout(t *testing.T) {
+	az := &blockingAnalyzer{release: make(chan struct{})}
+	defer close(az.release)
+
+	start := time.Now()
+	res, err := runAnalyzerWithTimeout(context.Background(), az, nil, analysis.TargetRepository{}, analysis.Config{}, 50*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+	if res.Name != "blocking" {
+		t.Errorf("Expected result name 'blocking', got %q", res.Name)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Timeout took too long: %v", elapsed)
+	}
+}
+
+func TestRunAnalyzerWithTimeoutParentCancel(t *testing.T) {
+	az := &blockingAnalyzer{release: make(chan struct{})}
+	defer close(az.release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := runAnalyzerWithTimeout(ctx, az, nil, analysis.TargetRepository{}, analysis.Config{}, time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context canceled, got %v", err)
+	}
+}
+
+func TestEstimateRequestCost(t *testing.T) {
+	standard := analysis.Config{DepthConfig: analysis.StandardDepth}
+	deep := analysis.Config{DepthConfig: analysis.DeepDepth, IncludeDeep: true}
+
+	single := []analysis.Analyzer{languages.New()}
+	noRepoConfig := AnalysisOptions{NoRepoConfig: true}
+	if got := estimateRequestCost(single, standard, noRepoConfig); got != 2 {
+		t.Errorf("Expected languages plus the repository lookup to cost 2 requests, got %d", got)
+	}
+	if got := estimateRequestCost(single, standard, AnalysisOptions{}); got != 3 {
+		t.Errorf("Expected the .gh-inspect.yml lookup to add 1 request, got %d", got)
+	}
+
+	set := []analysis.Analyzer{languages.New(), ci.New(), issuehygiene.New(30, 180)}
+	std, dp := estimateRequestCost(set, standard, noRepoConfig), estimateRequestCost(set, deep, noRepoConfig)
+	// repository lookup 1 + languages 1 + ci (1 + 1 page) + issues (2x2 pages + 10 comments)
+	if std != 18 {
+		t.Errorf("Expected standard estimate of 18, got %d", std)
+	}
+	if dp <= std {
+		t.Errorf("Expected deep estimate (%d) to exceed standard (%d)", dp, std)
+	}
+}
+
+// sleepyAnalyzer sleeps before returning and records how many analyzers overlap
+type sleepyAnalyzer struct {
+	name    string
+	delay   time.Duration
+	err     error
+	running *int32
+	peak    *int32
+}
+
+func (s *sleepyAnalyzer) Name() string { return s.name }
+
+func (s *sleepyAnalyzer) EstimatedCost(cfg analysis.Config) int { return 0 }
+
+func (s *sleepyAnalyzer) Analyze(ctx context.Context, client analysis.Client, repo analysis.TargetRepository, cfg analysis.Config) (models.AnalyzerResult, error) {
+	n := atomic.AddInt32(s.running, 1)
+	defer atomic.AddInt32(s.running, -1)
+	for {
+		p := atomic.LoadInt32(s.peak)
+		if n <= p || atomic.CompareAndSwapInt32(s.peak, p, n) {
+			break
+		}
+	}
+	time.Sleep(s.delay)
+	return models.AnalyzerResult{Name: s.name}, s.err
+}
+
+func TestRunRepoAnalyzersConcurrentAndOrdered(t *testing.T) {
+	var running, peak int32
+	var analyzers []analysis.Analyzer
+	names := []string{"a", "b", "c", "d", "e", "f"}
+	for i, name := range names {
+		az := &sleepyAnalyzer{name: name, delay: time.Duration(len(names)-i) * 10 * time.Millisecond, running: &running, peak: &peak}
+		if name == "d" {
+			az.err = errors.New("boom")
+		}
+		analyzers = append(analyzers, az)
+	}
+
+	results := runRepoAnalyzers(context.Background(), analyzers, nil, analysis.TargetRepository{Owner: "o", Name: "r"}, analysis.Config{}, 0, io.Discard)
+
+	if len(results) != len(names) {
+		t.Fatalf("Expected %d results, got %d", len(names), len(results))
+	}
+	for i, res := range results {
+		if res.Name != names[i] {
+			t.Errorf("Expected registry order, got %q at position %d", res.Name, i)
+		}
+	}
+	if len(results[3].Findings) != 1 || results[3].Findings[0].Type != "analyzer_error" {
+		t.Errorf("Expected analyzer_error placeholder for failing analyzer, got %+v", results[3].Findings)
+	}
+	if results[0].DurationMs < 50 {
+		t.Errorf("Expected the slowest analyzer to record its duration, got %dms", results[0].DurationMs)
+	}
+	if peak < 2 || peak > analyzerConcurrency {
+		t.Errorf("Expected between 2 and %d analyzers running at once, got %d", analyzerConcurrency, peak)
+	}
+}
+
+func TestRunRepoAnalyzersErrorOutput(t *testing.T) {
+	var running, peak int32
+	analyzers := []analysis.Analyzer{&sleepyAnalyzer{name: "a", err: errors.New("boom"), running: &running, peak: &peak}}
+	target := analysis.TargetRepository{Owner: "o", Name: "r"}
+
+	var errOut bytes.Buffer
+	results := runRepoAnalyzers(context.Background(), analyzers, nil, target, analysis.Config{}, 0, &errOut)
+	if !strings.Contains(errOut.String(), "Error analyzing o/r with a: boom") {
+		t.Errorf("Expected the error on the error writer, got %q", errOut.String())
+	}
+	if len(results[0].Findings) != 1 || results[0].Findings[0].Type != "analyzer_error" {
+		t.Errorf("Expected analyzer_error finding, got %+v", results[0].Findings)
+	}
+
+	// Quiet mode discards the message but still records the finding
+	results = runRepoAnalyzers(context.Background(), analyzers, nil, target, analysis.Config{}, 0, io.Discard)
+	if len(results[0].Findings) != 1 || results[0].Findings[0].Type != "analyzer_error" {
+		t.Errorf("Expected analyzer_error finding in quiet mode, got %+v", results[0].Findings)
+	}
+}
+
+func TestResolveSince(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	got, err := resolveSince(AnalysisOptions{Since: "30d"}, now)
+	if err != nil || !got.Equal(now.Add(-30*24*time.Hour)) {
+		t.Errorf("Expected 30 days before now, got %v (err %v)", got, err)
+	}
+
+	got, err = resolveSince(AnalysisOptions{Since: "30d", SinceDate: "2024-01-01"}, now)
+	if err != nil || !got.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected since date to take precedence, got %v (err %v)", got, err)
+	}
+
+	got, err = resolveSince(AnalysisOptions{SinceDate: "2024-03-01T09:30:00+02:00"}, now)
+	if err != nil || !got.Equal(time.Date(2024, 3, 1, 7, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected RFC3339 date to be accepted, got %v (err %v)", got, err)
+	}
+
+	for _, bad := range []AnalysisOptions{{Since: "thirty"}, {SinceDate: "01/02/2024"}, {SinceDate: "2025-01-01"}} {
+		if _, err := resolveSince(bad, now); err == nil {
+			t.Errorf("Expected error for %+v", bad)
+		}
+	}
+}
+
+func TestValidateSinceFlags(t *testing.T) {
+	defer func() { flagSince, flagSinceDate = "30d", "" }()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringVarP(&flagSince, "since", "s", "30d", "")
+
+	flagSinceDate = "2024-01-01"
+	if err := validateSinceFlags(cmd); err != nil {
+		t.Errorf("Expected --since-date alone to be valid, got %v", err)
+	}
+
+	_ = cmd.Flags().Set("since", "7d")
+	if err := validateSinceFlags(cmd); err == nil {
+		t.Error("Expected error when --since and --since-date are both set")
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	if err := validateFormat(""); err != nil {
+		t.Errorf("Expected the default format to be valid, got %v", err)
+	}
+	if err := validateFormat("junit", report.FormatBadge); err != nil {
+		t.Errorf("Expected junit to be valid, got %v", err)
+	}
+	err := validateFormat("badge", report.FormatNDJSON, report.FormatBadge)
+	if err == nil || err.Error() != "invalid format: badge (must be text, json, markdown, csv, sarif, score, or junit)" {
+		t.Errorf("Expected badge to be rejected with the remaining formats, got %v", err)
+	}
+}
+
+func TestBuildAnalyzersIncludeOverridesConfig(t *testing.T) {
+	cfg, err := config.LoadFrom("/nonexistent/config.yaml")
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	cfg.Analyzers.Security.Enabled = false
+
+	names := func(opts AnalysisOptions) []string {
+		var out []string
+		for _, az := range buildAnalyzers(cfg, opts) {
+			out = append(out, az.Name())
+		}
+		return out
+	}
+
+	for _, name := range names(AnalysisOptions{}) {
+		if name == "security" {
+			t.Error("Expected security disabled in config to be skipped by default")
+		}
+	}
+	if got := names(AnalysisOptions{Include: []string{"security", "ci"}}); strings.Join(got, ",") != "ci,security" {
+		t.Errorf("Expected --include to run security despite the config, got %v", got)
+	}
+	if got := names(AnalysisOptions{Exclude: []string{"ci"}}); strings.Contains(strings.Join(got, ","), "security") {
+		t.Errorf("Expected --exclude not to enable disabled analyzers, got %v", got)
+	}
+}
+
+func TestBuildAnalyzersLargeFileThresholds(t *testing.T) {
+	cfg, err := config.LoadFrom("/nonexistent/config.yaml")
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	health := func() *repohealth.Analyzer {
+		for _, az := range buildAnalyzers(cfg, AnalysisOptions{Include: []string{"repo-health"}}) {
+			if h, ok := az.(*repohealth.Analyzer); ok {
+				return h
+			}
+		}
+		t.Fatal("Expected a repo-health analyzer")
+		return nil
+	}
+
+	if h := health(); h.LargeFileMB != repohealth.DefaultLargeFileMB || h.LargeTreeMB != repohealth.DefaultLargeTreeMB {
+		t.Errorf("Expected the default thresholds when unset, got %d/%d", h.LargeFileMB, h.LargeTreeMB)
+	}
+
+	off, tree := 0, 200
+	cfg.Analyzers.RepoHealth.Params.LargeFileMB = &off
+	cfg.Analyzers.RepoHealth.Params.LargeTreeMB = &tree
+	if h := health(); h.LargeFileMB != 0 || h.LargeTreeMB != 200 {
+		t.Errorf("Expected 0 to disable the file threshold, got %d/%d", h.LargeFileMB, h.LargeTreeMB)
+	}
+}
+
+func TestBuildAnalyzersSizeSample(t *testing.T) {
+	cfg, err := config.LoadFrom("/nonexistent/config.yaml")
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	flow := func() *prflow.Analyzer {
+		for _, az := range buildAnalyzers(cfg, AnalysisOptions{Include: []string{"pr-flow"}}) {
+			if f, ok := az.(*prflow.Analyzer); ok {
+				return f
+			}
+		}
+		t.Fatal("Expected a pr-flow analyzer")
+		return nil
+	}
+
+	if got := flow().SizeSampleSize; got != prflow.DefaultSizeSampleSize {
+		t.Errorf("Expected the default sample size when unset, got %d", got)
+	}
+
+	off := 0
+	cfg.Analyzers.PRFlow.Params.SizeSampleSize = &off
+	if got := flow().SizeSampleSize; got != 0 {
+		t.Errorf("Expected 0 to disable PR size sampling, got %d", got)
+	}
+}
+
+func TestKeyFilesFromConfig(t *testing.T) {
+	files := keyFilesFromConfig([]config.RequiredFile{
+		{Path: "SUPPORT.md", Severity: "Low", Deduction: 5},
+		{Path: ".github/dependabot.yml", AltPaths: []string{".github/dependabot.yaml"}, Deduction: 10},
+	})
+
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 key files, got %d", len(files))
+	}
+	if files[0].Severity != models.SeverityLow || files[0].Deduction != 5 {
+		t.Errorf("Expected severity to be normalized, got %+v", files[0])
+	}
+	if files[1].Severity != models.SeverityMedium || len(files[1].AltPaths) != 1 {
+		t.Errorf("Expected default medium severity and alt paths kept, got %+v", files[1])
+	}
+}
+
+func TestWeightedHealthScore(t *testing.T) {
+	repo := func(name string, score, stars, commits float64) models.RepoResult {
+		return models.RepoResult{Name: name, Analyzers: []models.AnalyzerResult{
+			{Name: "activity", Metrics: []models.Metric{{Key: "stars", Value: stars}, {Key: "commits_total", Value: commits}}},
+			{Name: "repo-health", Metrics: []models.Metric{{Key: "health_score", Value: score}}},
+		}}
+	}
+	repos := []models.RepoResult{
+		repo("org/flagship", 90, 99, 9),
+		repo("org/abandoned", 30, 0, 0),
+		{Name: "org/no-health"},
+	}
+
+	tests := []struct {
+		name     string
+		strategy string
+		weights  map[string]float64
+		want     float64
+	}{
+		{"none", "none", nil, 60},
+		{"equal explicit weights", "none", map[string]float64{"org/flagship": 1}, 60},
+		{"stars", "stars", nil, (90*100 + 30*1) / 101.0},
+		{"commits", "commits", nil, (90*10 + 30*1) / 11.0},
+		{"explicit overrides strategy", "stars", map[string]float64{"org/abandoned": 100}, 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			totals := newSummaryTotals(config.GlobalConfig{HealthScoreWeighting: tt.strategy, RepoWeights: tt.weights}, insights.DefaultScoringWeights())
+			for _, r := range repos {
+				totals.add(r)
+			}
+			summary := totals.finish()
+			if summary.TotalReposAnalyzed != 3 || summary.AvgHealthScore != 60 || summary.ReposAtRisk != 1 {
+				t.Errorf("Unexpected summary totals: %+v", summary)
+			}
+			got := summary.WeightedHealthScore
+			if tt.strategy == "none" && tt.weights == nil {
+				// Without weighting the summary reports only the plain average
+				if summary.HealthScoreWeighting != "" || got != 0 {
+					t.Errorf("Expected no weighted score for none, got %.3f (%q)", got, summary.HealthScoreWeighting)
+				}
+				got = summary.AvgHealthScore
+			}
+			if got < tt.want-0.001 || got > tt.want+0.001 {
+				t.Errorf("Expected %.3f, got %.3f", tt.want, got)
+			}
+		})
+	}
+
+	if got := healthScoreWeighting(config.GlobalConfig{HealthScoreWeighting: "none"}); got != "" {
+		t.Errorf("Expected no weighting for none, got %q", got)
+	}
+	if got := healthScoreWeighting(config.GlobalConfig{RepoWeights: map[string]float64{"org/a": 2}}); got != "explicit weights" {
+		t.Errorf("Expected explicit weights, got %q", got)
+	}
+}
+
+func TestAutoConcurrency(t *testing.T) {
+	tests := []struct {
+		name                 string
+		max, remaining, cost int
+		want                 int
+	}{
+		{"plenty of headroom", 8, 5000, 1000, 8},
+		{"exactly twice the cost", 8, 2000, 1000, 8},
+		{"scaled down", 8, 1000, 1000, 4},
+		{"nearly exhausted", 8, 100, 1000, 1},
+		{"nothing remaining", 8, 0, 1000, 1},
+		{"unknown cost", 8, 10, 0, 8},
+		{"invalid max", 0, 5000, 1000, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := autoConcurrency(tt.max, tt.remaining, tt.cost); got != tt.want {
+				t.Errorf("autoConcurrency(%d, %d, %d) = %d, want %d", tt.max, tt.remaining, tt.cost, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllAnalyzersFailed(t *testing.T) {
+	failed := func(name, findingType string) models.AnalyzerResult {
+		return models.AnalyzerResult{Name: name, Findings: []models.Finding{{Type: findingType}}}
+	}
+	tests := []struct {
+		name string
+		repo models.RepoResult
+		want bool
+	}{
+		{"all failed", models.RepoResult{Analyzers: []models.AnalyzerResult{failed("ci", "analyzer_error"), failed("activity", "analyzer_timeout")}}, true},
+		{"one succeeded", models.RepoResult{Analyzers: []models.AnalyzerResult{failed("ci", "analyzer_error"), failed("activity", "bus_factor_risk")}}, false},
+		{"no analyzers", models.RepoResult{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := allAnalyzersFailed(tt.repo); got != tt.want {
+				t.Errorf("allAnalyzersFailed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummaryRiskiestRepos(t *testing.T) {
+	riskiest := func(w insights.ScoringWeights) (string, models.GlobalSummary) {
+		totals := newSummaryTotals(config.GlobalConfig{}, w)
+		// The repo-health score and the Engineering Health Score (missing files) rank differently
+		for i, score := range []float64{80, 30, 95, 45, 30, 60, 70} {
+			var findings []models.Finding
+			for n := 0; n <= i; n++ {
+				findings = append(findings, models.Finding{Type: "missing_file"})
+			}
+			totals.add(models.RepoResult{
+				Name: fmt.Sprintf("owner/repo%d", i),
+				Analyzers: []models.AnalyzerResult{{Name: "repo-health", Findings: findings,
+					Metrics: []models.Metric{{Key: "health_score", Value: score}}}},
+			})
+		}
+		totals.add(models.RepoResult{Name: "owner/unscored"})
+
+		summary := totals.finish()
+		var got []string
+		for _, r := range summary.RiskiestRepos {
+			got = append(got, fmt.Sprintf("%s=%.0f", r.Name, r.HealthScore))
+		}
+		return strings.Join(got, ","), summary
+	}
+
+	got, summary := riskiest(insights.DefaultScoringWeights())
+	want := "owner/repo6=65,owner/repo5=70,owner/repo4=75,owner/repo3=80,owner/repo2=85"
+	if got != want {
+		t.Errorf("Expected the %d lowest Engineering Health Scores worst first, got %v", maxRiskiestRepos, got)
+	}
+	if summary.ReposAtRisk != 3 {
+		t.Errorf("Expected 3 repos at risk, got %d", summary.ReposAtRisk)
+	}
+
+	weights := insights.DefaultScoringWeights()
+	weights.MissingFile = 10
+	if got, _ := riskiest(weights); !strings.HasPrefix(got, "owner/repo6=30,") {
+		t.Errorf("Expected the configured scoring weights to be used, got %v", got)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/mikematt33/gh-inspect/internal/config"
 	"github.com/mikematt33/gh-inspect/internal/report"
 	"github.com/spf13/cobra"
 )
@@ -46,7 +45,7 @@ Minimum 2 repositories required. Supports --quiet and --verbose flags.`,
 
 func runComparison(cmd *cobra.Command, args []string) {
 	// Load config to get output mode preference
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Printf("Error loading config: %v\n", err)
 		os.Exit(1)
@@ -63,6 +62,7 @@ func runComparison(cmd *cobra.Command, args []string) {
 	opts := AnalysisOptions{
 		Repos:           args,
 		Since:           flagSince,
+		SinceDate:       flagSinceDate,
 		Depth:           flagDepth,
 		MaxPRs:          flagMaxPRs,
 		MaxIssues:       flagMaxIssues,
@@ -70,6 +70,7 @@ func runComparison(cmd *cobra.Command, args []string) {
 		Include:         flagInclude,
 		Exclude:         flagExclude,
 		OutputMode:      resolvedOutputMode,
+		AnalyzerTimeout: flagAnalyzerTimeout,
 	}
 
 	fullReport, err := pipelineRunner(opts)
@@ -88,7 +89,7 @@ func runComparison(cmd *cobra.Command, args []string) {
 		renderer = &report.ComparisonTextRenderer{}
 	}
 
-	if err := renderer.Render(fullReport, os.Stdout); err != nil {
+	if err := renderer.RenderWithOptions(fullReport, os.Stdout, report.RenderOptions{NoColor: !colorEnabled(os.Stdout)}); err != nil {
 		fmt.Printf("Error rendering report: %v\n", err)
 	}
 }
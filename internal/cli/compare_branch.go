@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mikematt33/gh-inspect/internal/config"
+	"github.com/mikematt33/gh-inspect/pkg/baseline"
+	"github.com/mikematt33/gh-inspect/pkg/insights"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// analyzerFlagNames are the analyzer names accepted by --include and --exclude
+var analyzerFlagNames = []string{"activity", "prflow", "ci", "issues", "security", "releases", "deployments", "branches", "dependencies", "languages", "contributors", "health"}
+
+// refAwareAnalyzers are the analyzers whose results depend on the analyzed ref, by
+// --include name: they read commits or files from it. The others report the same
+// repository-wide data on any branch.
+var refAwareAnalyzers = map[string]bool{
+	"activity": true, "health": true, "repo-health": true, "contributors": true, "dependencies": true, "security": true,
+}
+
+// validateCompareBranchFlags rejects flags that cannot be combined with --compare-branch
+func validateCompareBranchFlags() error {
+	if flagCompareBranch == flagRef {
+		return fmt.Errorf("--compare-branch must differ from --ref (both are %q)", flagRef)
+	}
+	conflicts := []struct {
+		flag string
+		set  bool
+	}{
+		{"--compare-last", flagCompareLast},
+		{"--baseline", flagBaseline != ""},
+		{"--watch", flagWatch != 0},
+		{"--format=ndjson", flagFormat == "ndjson"},
+		{"--dry-run", flagDryRun},
+	}
+	for _, c := range conflicts {
+		if c.set {
+			return fmt.Errorf("--compare-branch cannot be combined with %s", c.flag)
+		}
+	}
+	return nil
+}
+
+// analyzeBranch runs the ref-aware analyzers again on ref and wraps the report as a
+// baseline, so the --ref analysis in current can be compared against it with the
+// baseline comparison. Results of the other analyzers don't depend on the ref, so they
+// are copied from current instead of being fetched twice. The summary is recomputed
+// with the thresholds and scoring weights in cfg.
+func analyzeBranch(opts AnalysisOptions, ref string, current *models.Report, cfg *config.Config) (*baseline.Baseline, error) {
+	opts, err := refAwareOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	opts.Ref = ref
+	if shouldPrintInfo() {
+		fmt.Printf("\nAnalyzing %s for comparison...\n", ref)
+	}
+	branchReport, err := pipelineRunner(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	addRefIndependentResults(branchReport, current, cfg.Global, scoringWeightsFromConfig(cfg.Scoring))
+	return &baseline.Baseline{Timestamp: branchReport.Meta.GeneratedAt, Report: branchReport}, nil
+}
+
+// refAwareOptions narrows the analyzers selected by opts to the ref-aware ones
+func refAwareOptions(opts AnalysisOptions) (AnalysisOptions, error) {
+	if len(opts.Include) > 0 {
+		var include []string
+		for _, name := range opts.Include {
+			if refAwareAnalyzers[name] {
+				include = append(include, name)
+			}
+		}
+		if len(include) == 0 {
+			return opts, fmt.Errorf("--compare-branch needs at least one analyzer that reads the ref (activity, health, contributors, dependencies or security)")
+		}
+		opts.Include = include
+		return opts, nil
+	}
+
+	exclude := append([]string(nil), opts.Exclude...)
+	for _, name := range analyzerFlagNames {
+		if !refAwareAnalyzers[name] {
+			exclude = append(exclude, name)
+		}
+	}
+	opts.Exclude = exclude
+	return opts, nil
+}
+
+// addRefIndependentResults completes the branch report with the analyzer results from
+// current that it did not rerun, then recomputes its summary from the combined results
+func addRefIndependentResults(branch, current *models.Report, global config.GlobalConfig, weights insights.ScoringWeights) {
+	currentRepos := make(map[string]models.RepoResult, len(current.Repositories))
+	for _, repo := range current.Repositories {
+		currentRepos[repo.Name] = repo
+	}
+
+	totals := newSummaryTotals(global, weights)
+	for i := range branch.Repositories {
+		repo := &branch.Repositories[i]
+		rerun := make(map[string]bool, len(repo.Analyzers))
+		for _, az := range repo.Analyzers {
+			rerun[az.Name] = true
+		}
+		for _, az := range currentRepos[repo.Name].Analyzers {
+			if !rerun[az.Name] {
+				repo.Analyzers = append(repo.Analyzers, az)
+			}
+		}
+		totals.add(*repo)
+	}
+
+	unavailable := branch.Summary.ReposUnavailable
+	branch.Summary = totals.finish()
+	branch.Summary.ReposUnavailable = unavailable
+}
+
+// refLabel names a ref in messages; empty stands for the default branch
+func refLabel(ref string) string {
+	if ref == "" {
+		return "the default branch"
+	}
+	return ref
+}
@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/dependencies"
+	"github.com/mikematt33/gh-inspect/internal/config"
+	"github.com/mikematt33/gh-inspect/pkg/baseline"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+func TestValidateCompareBranchFlags(t *testing.T) {
+	defer func() { flagCompareBranch, flagRef, flagCompareLast, flagFormat = "", "", false, "text" }()
+
+	flagCompareBranch, flagRef = "main", "develop"
+	if err := validateCompareBranchFlags(); err != nil {
+		t.Errorf("Expected develop vs main to be valid, got %v", err)
+	}
+
+	flagRef = "main"
+	if err := validateCompareBranchFlags(); err == nil {
+		t.Error("Expected an error when --compare-branch equals --ref")
+	}
+
+	flagRef, flagCompareLast = "", true
+	if err := validateCompareBranchFlags(); err == nil {
+		t.Error("Expected an error combined with --compare-last")
+	}
+
+	flagCompareLast, flagFormat = false, "ndjson"
+	if err := validateCompareBranchFlags(); err == nil {
+		t.Error("Expected an error combined with --format=ndjson")
+	}
+}
+
+func TestAnalyzeBranch(t *testing.T) {
+	originalPipelineRunner := pipelineRunner
+	defer func() { pipelineRunner = originalPipelineRunner; flagQuiet = false }()
+	flagQuiet = true
+
+	// The same repository scores differently on each ref; CI results don't depend on it
+	coverage := map[string]float64{"develop": 60, "main": 80}
+	var branchOpts AnalysisOptions
+	pipelineRunner = func(opts AnalysisOptions) (*models.Report, error) {
+		analyzers := []models.AnalyzerResult{
+			{Name: "activity", Metrics: []models.Metric{{Key: "review_coverage", Value: coverage[opts.Ref]}}},
+		}
+		if opts.Ref == "develop" {
+			analyzers = append(analyzers, models.AnalyzerResult{Name: "ci", Metrics: []models.Metric{{Key: "success_rate", Value: 90}}})
+		} else {
+			branchOpts = opts
+		}
+		return &models.Report{
+			Meta:         models.ReportMeta{Ref: opts.Ref},
+			Repositories: []models.RepoResult{{Name: "owner/repo", Analyzers: analyzers}},
+		}, nil
+	}
+
+	opts := AnalysisOptions{Repos: []string{"owner/repo"}, Ref: "develop", Exclude: []string{"languages"}}
+	current, _ := pipelineRunner(opts)
+	branch, err := analyzeBranch(opts, "main", current, &config.Config{})
+	if err != nil {
+		t.Fatalf("analyzeBranch failed: %v", err)
+	}
+	if branch.Report.Meta.Ref != "main" {
+		t.Errorf("Expected the comparison branch to be analyzed, got ref %q", branch.Report.Meta.Ref)
+	}
+	if opts.Ref != "develop" || len(opts.Exclude) != 1 {
+		t.Errorf("Expected the caller's options to be left alone, got %+v", opts)
+	}
+
+	// Only the ref-aware analyzers run again
+	excluded := strings.Join(branchOpts.Exclude, ",")
+	for _, name := range []string{"languages", "ci", "prflow", "releases"} {
+		if !strings.Contains(excluded, name) {
+			t.Errorf("Expected %s to be skipped on the branch, got exclude %v", name, branchOpts.Exclude)
+		}
+	}
+	for _, name := range []string{"activity", "health", "contributors", "dependencies", "security"} {
+		if strings.Contains(","+excluded+",", ","+name+",") {
+			t.Errorf("Expected %s to run on the branch, got exclude %v", name, branchOpts.Exclude)
+		}
+	}
+	if branch.Report.Summary.AvgCISuccessRate != 90 {
+		t.Errorf("Expected the branch summary to include the copied CI results, got %+v", branch.Report.Summary)
+	}
+
+	comparison := baseline.Compare(current, branch)
+	if len(comparison.Deltas) != 1 || len(comparison.Deltas[0].MetricDiff) != 1 {
+		t.Fatalf("Expected only the ref-aware metric to differ across refs, got %+v", comparison.Deltas)
+	}
+	if change := comparison.Deltas[0].MetricDiff[0]; change.Delta != -20 {
+		t.Errorf("Expected develop to trail main by 20, got %+v", change)
+	}
+}
+
+func TestRefAwareOptions(t *testing.T) {
+	opts, err := refAwareOptions(AnalysisOptions{Include: []string{"ci", "health", "prflow", "contributors"}})
+	if err != nil {
+		t.Fatalf("refAwareOptions failed: %v", err)
+	}
+	if got := strings.Join(opts.Include, ","); got != "health,contributors" {
+		t.Errorf("Expected only the ref-aware analyzers to stay included, got %s", got)
+	}
+
+	if _, err := refAwareOptions(AnalysisOptions{Include: []string{"ci", "releases"}}); err == nil {
+		t.Error("Expected an error when no selected analyzer reads the ref")
+	}
+}
+
+// refFilesClient serves different files on each ref; the overview is unavailable
+type refFilesClient struct {
+	analysis.Client
+	files map[string]map[string]string // ref -> path -> content
+}
+
+func (c *refFilesClient) GetRepoOverview(ctx context.Context, owner, repo string) (*analysis.RepoOverview, error) {
+	return nil, errors.New("graphql unavailable")
+}
+
+func (c *refFilesClient) GetContentAtRef(ctx context.Context, owner, repo, path, ref string) (*github.RepositoryContent, []*github.RepositoryContent, error) {
+	content, ok := c.files[ref][path]
+	if !ok {
+		return nil, nil, errors.New("404 Not Found")
+	}
+	return &github.RepositoryContent{Path: github.String(path), Content: github.String(content)}, nil, nil
+}
+
+func TestAnalyzeBranchComparesDependencies(t *testing.T) {
+	originalPipelineRunner := pipelineRunner
+	defer func() { pipelineRunner = originalPipelineRunner; flagQuiet = false }()
+	flagQuiet = true
+
+	// develop adds a dependency and a Renovate config over main
+	client := &refFilesClient{files: map[string]map[string]string{
+		"develop": {"go.mod": "module x\n\nrequire (\n\ta v1.0.0\n\tb v1.0.0\n)\n", "renovate.json": "{}"},
+		"main":    {"go.mod": "module x\n\nrequire a v1.0.0\n"},
+	}}
+	pipelineRunner = func(opts AnalysisOptions) (*models.Report, error) {
+		repo := models.RepoResult{Name: "owner/repo"}
+		if shouldIncludeAnalyzer("dependencies", opts.Include, opts.Exclude) {
+			res, err := dependencies.New().Analyze(context.Background(), client, analysis.TargetRepository{Owner: "owner", Name: "repo", Ref: opts.Ref}, analysis.Config{})
+			if err != nil {
+				return nil, err
+			}
+			repo.Analyzers = append(repo.Analyzers, res)
+		}
+		return &models.Report{Meta: models.ReportMeta{Ref: opts.Ref}, Repositories: []models.RepoResult{repo}}, nil
+	}
+
+	opts := AnalysisOptions{Repos: []string{"owner/repo"}, Ref: "develop"}
+	current, _ := pipelineRunner(opts)
+	branch, err := analyzeBranch(opts, "main", current, &config.Config{})
+	if err != nil {
+		t.Fatalf("analyzeBranch failed: %v", err)
+	}
+
+	comparison := baseline.Compare(current, branch)
+	if len(comparison.Deltas) != 1 {
+		t.Fatalf("Expected one repository delta, got %+v", comparison.Deltas)
+	}
+	delta := comparison.Deltas[0]
+	var depsChanged bool
+	for _, change := range delta.MetricDiff {
+		if change.Key == "dependencies.go_dependencies" && change.Delta == 1 {
+			depsChanged = true
+		}
+	}
+	if !depsChanged {
+		t.Errorf("Expected develop to have one more Go dependency than main, got %+v", delta.MetricDiff)
+	}
+	if delta.FindingDiff.Removed != 1 || delta.FindingDiff.RemovedFindings[0].Type != "no_automated_updates" {
+		t.Errorf("Expected the Renovate config added on develop to resolve no_automated_updates, got %+v", delta.FindingDiff)
+	}
+}
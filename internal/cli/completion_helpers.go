@@ -10,7 +10,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/mikematt33/gh-inspect/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -20,7 +19,7 @@ import (
 // - Tracks recently used repositories, organizations, and users
 // - Suggests recent items during tab completion
 // - Fetches live data from GitHub API when authenticated
-// - Stores completion history in ~/.config/gh-inspect/completion-history.json
+// - Stores completion history in completion-history.json under the user config directory
 //
 // The completion functions (completeRepositories, completeOrganizations, completeUsers)
 // are registered with Cobra commands via ValidArgsFunction and provide intelligent
@@ -204,8 +203,8 @@ func completeOrganizations(cmd *cobra.Command, args []string, toComplete string)
 	suggestions = append(suggestions, recent...)
 
 	// Try to fetch from GitHub if authenticated
-	cfg, err := config.Load()
-	if err == nil && cfg.Global.GitHubToken != "" {
+	cfg, err := loadConfig()
+	if err == nil && configToken(cfg) != "" {
 		client, err := getClientWithToken(cfg)
 		if err == nil {
 			// Get user's organizations
@@ -259,8 +258,8 @@ func completeUsers(cmd *cobra.Command, args []string, toComplete string) ([]stri
 	suggestions = append(suggestions, recent...)
 
 	// Try to get authenticated user
-	cfg, err := config.Load()
-	if err == nil && cfg.Global.GitHubToken != "" {
+	cfg, err := loadConfig()
+	if err == nil && configToken(cfg) != "" {
 		client, err := getClientWithToken(cfg)
 		if err == nil {
 			user, _, err := client.GetUnderlyingClient().Users.Get(context.Background(), "")
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var flagPruneDays int
+
+var completionHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Manage the history used for completion suggestions",
+	Long: `Manage the recently used repositories, organizations and users that tab completion suggests.
+History is stored in completion-history.json in the user config directory:
+- Linux: ~/.config/gh-inspect/
+- macOS: ~/Library/Application Support/gh-inspect/
+- Windows: %APPDATA%\gh-inspect\`,
+}
+
+var completionHistoryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List completion history entries",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		history, err := loadHistory()
+		if err != nil {
+			fmt.Printf("Error loading completion history: %v\n", err)
+			os.Exit(1)
+		}
+		if len(history.Items) == 0 {
+			fmt.Println("Completion history is empty.")
+			return
+		}
+		printHistory(history)
+	},
+}
+
+var completionHistoryClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all completion history",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := getHistoryPath()
+		if err != nil {
+			fmt.Printf("Error locating completion history: %v\n", err)
+			os.Exit(1)
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("Error clearing completion history: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Completion history cleared")
+	},
+}
+
+var completionHistoryPruneCmd = &cobra.Command{
+	Use:     "prune",
+	Short:   "Remove completion history entries not used recently",
+	Example: `  gh-inspect completion history prune --days=30`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.NoArgs(cmd, args); err != nil {
+			return err
+		}
+		if flagPruneDays < 1 {
+			return fmt.Errorf("invalid --days: %d (must be at least 1)", flagPruneDays)
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		history, err := loadHistory()
+		if err != nil {
+			fmt.Printf("Error loading completion history: %v\n", err)
+			os.Exit(1)
+		}
+
+		removed := pruneHistory(history, time.Now().AddDate(0, 0, -flagPruneDays))
+		if removed > 0 {
+			if err := saveHistory(history); err != nil {
+				fmt.Printf("Error saving completion history: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		fmt.Printf("✅ Removed %d entries not used in the last %d days (%d kept)\n", removed, flagPruneDays, len(history.Items))
+	},
+}
+
+func init() {
+	completionCmd.AddCommand(completionHistoryCmd)
+	completionHistoryCmd.AddCommand(completionHistoryListCmd)
+	completionHistoryCmd.AddCommand(completionHistoryClearCmd)
+	completionHistoryCmd.AddCommand(completionHistoryPruneCmd)
+
+	completionHistoryPruneCmd.Flags().IntVar(&flagPruneDays, "days", 90, "Remove entries not used in this many days")
+}
+
+// pruneHistory drops entries last used before cutoff and returns how many were removed
+func pruneHistory(history *recentHistory, cutoff time.Time) int {
+	kept := history.Items[:0]
+	for _, item := range history.Items {
+		if !item.LastUsed.Before(cutoff) {
+			kept = append(kept, item)
+		}
+	}
+	removed := len(history.Items) - len(kept)
+	history.Items = kept
+	return removed
+}
+
+// printHistory lists history entries grouped by type, most recently used first
+func printHistory(history *recentHistory) {
+	items := append([]recentItem(nil), history.Items...)
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].ItemType != items[j].ItemType {
+			return items[i].ItemType < items[j].ItemType
+		}
+		return items[i].LastUsed.After(items[j].LastUsed)
+	})
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TYPE\tVALUE\tUSES\tLAST USED")
+	for _, item := range items {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", item.ItemType, item.Value, item.UseCount, item.LastUsed.Format("2006-01-02"))
+	}
+	_ = tw.Flush()
+}
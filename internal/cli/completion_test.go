@@ -2,12 +2,16 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/pkg/models"
 	"github.com/spf13/cobra"
 )
 
@@ -337,3 +341,103 @@ func TestRunAutoCompletionUnsupportedShell(t *testing.T) {
 		t.Errorf("Expected unsupported shell message in output, got: %s", output)
 	}
 }
+
+func TestPruneHistory(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", originalXDG) }()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	now := time.Now()
+	history := &recentHistory{Items: []recentItem{
+		{Value: "fresh/repo", LastUsed: now.AddDate(0, 0, -1), UseCount: 3, ItemType: "repo"},
+		{Value: "old-org", LastUsed: now.AddDate(0, 0, -120), UseCount: 1, ItemType: "org"},
+		{Value: "recent-user", LastUsed: now.AddDate(0, 0, -10), UseCount: 2, ItemType: "user"},
+	}}
+	if err := saveHistory(history); err != nil {
+		t.Fatalf("saveHistory failed: %v", err)
+	}
+
+	loaded, err := loadHistory()
+	if err != nil {
+		t.Fatalf("loadHistory failed: %v", err)
+	}
+	if removed := pruneHistory(loaded, now.AddDate(0, 0, -90)); removed != 1 {
+		t.Errorf("Expected 1 entry removed, got %d", removed)
+	}
+	if len(loaded.Items) != 2 {
+		t.Fatalf("Expected 2 entries kept, got %d", len(loaded.Items))
+	}
+	for _, item := range loaded.Items {
+		if item.Value == "old-org" {
+			t.Error("Expected old-org to be pruned")
+		}
+	}
+
+	if removed := pruneHistory(loaded, now.AddDate(0, 0, -5)); removed != 1 || loaded.Items[0].Value != "fresh/repo" {
+		t.Errorf("Expected only fresh/repo to survive a 5 day prune, got %+v", loaded.Items)
+	}
+}
+
+func TestAnalysisCommandsRecordTypedHistory(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", originalXDG) }()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	originalPipelineRunner := pipelineRunner
+	originalGetOrgRepos := getOrgRepositories
+	originalGetUserRepos := getUserRepositories
+	defer func() {
+		pipelineRunner = originalPipelineRunner
+		getOrgRepositories = originalGetOrgRepos
+		getUserRepositories = originalGetUserRepos
+		rootCmd.SetArgs(nil)
+	}()
+
+	listRepos := func(string) ([]*github.Repository, error) {
+		return []*github.Repository{{FullName: github.String("owner/repo1"), Archived: github.Bool(false), Fork: github.Bool(false)}}, nil
+	}
+	getOrgRepositories = listRepos
+	getUserRepositories = listRepos
+	pipelineRunner = func(opts AnalysisOptions) (*models.Report, error) {
+		return &models.Report{Summary: models.GlobalSummary{TotalReposAnalyzed: 1, AvgHealthScore: 90}}, nil
+	}
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+	var errs []error
+	for _, args := range [][]string{{"org", "my-org"}, {"user", "my-user"}} {
+		rootCmd.SetArgs(args)
+		errs = append(errs, rootCmd.Execute())
+	}
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("command failed: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "gh-inspect", "completion-history.json"))
+	if err != nil {
+		t.Fatalf("Expected history file to be written: %v", err)
+	}
+	var history recentHistory
+	if err := json.Unmarshal(data, &history); err != nil {
+		t.Fatalf("Invalid history file: %v", err)
+	}
+
+	types := make(map[string]string)
+	for _, item := range history.Items {
+		types[item.Value] = item.ItemType
+	}
+	if types["my-org"] != "org" {
+		t.Errorf("Expected my-org recorded as org, got %q", types["my-org"])
+	}
+	if types["my-user"] != "user" {
+		t.Errorf("Expected my-user recorded as user, got %q", types["my-user"])
+	}
+}
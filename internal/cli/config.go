@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
@@ -19,7 +20,9 @@ var configCmd = &cobra.Command{
 The configuration file is typically located at:
 - Linux: ~/.config/gh-inspect/config.yaml
 - macOS: ~/Library/Application Support/gh-inspect/config.yaml
-- Windows: %APPDATA%\gh-inspect\config.yaml`,
+- Windows: %APPDATA%\gh-inspect\config.yaml
+
+Use the global --config flag to read and write an alternate file instead.`,
 }
 
 var setTokenCmd = &cobra.Command{
@@ -41,6 +44,21 @@ Examples:
 	Run:  runSet,
 }
 
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [path]",
+	Short: "Check the configuration file for mistakes",
+	Long: `Validate the configuration file without running an analysis.
+Reports unknown keys (which would otherwise be silently ignored) as warnings, and
+invalid values such as an unknown output mode or negative thresholds as errors.
+Exits with a non-zero status if any errors are found.
+
+Without an argument, the file gh-inspect would load is checked (honoring --config).`,
+	Example: `  gh-inspect config validate
+  gh-inspect config validate ./ci-config.yaml`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runConfigValidate,
+}
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List current configuration",
@@ -51,6 +69,7 @@ func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(setTokenCmd)
 	configCmd.AddCommand(setCmd)
+	configCmd.AddCommand(configValidateCmd)
 
 	setCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) != 0 {
@@ -58,16 +77,31 @@ func init() {
 		}
 		return []string{
 			"global.concurrency",
+			"global.concurrency_mode",
 			"global.github_token",
 			"global.output_mode",
+			"global.analyzer_timeout_seconds",
+			"global.retry_max_attempts",
+			"global.baseline_history",
+			"global.health_score_weighting",
+			"global.proxy_url",
+			"global.api_url",
+			"analyzers.activity.params.conventional_commit_threshold",
 			"analyzers.pr_flow.enabled",
 			"analyzers.pr_flow.params.stale_threshold_days",
-			"analyzers.pr_flow.params.cycle_time_target_hours",
+			"analyzers.pr_flow.params.size_sample_size",
 			"analyzers.issue_hygiene.enabled",
 			"analyzers.issue_hygiene.params.stale_threshold_days",
 			"analyzers.issue_hygiene.params.zombie_threshold_days",
+			"analyzers.issue_hygiene.params.untriaged_threshold",
+			"analyzers.issue_hygiene.params.response_sla_hours",
 			"analyzers.repo_health.enabled",
 			"analyzers.ci.enabled",
+			"analyzers.deployments.enabled",
+			"analyzers.deployments.params.success_rate_threshold",
+			"analyzers.branches.params.divergence_threshold_commits",
+			"analyzers.languages.enabled",
+			"analyzers.contributors.enabled",
 		}, cobra.ShellCompDirectiveNoFileComp
 	}
 
@@ -75,12 +109,12 @@ func init() {
 }
 
 func saveConfig(cfg *config.Config) error {
-	configPath, err := config.GetConfigPath()
+	configPath, err := resolveConfigPath()
 	if err != nil {
 		return fmt.Errorf("error resolving config path: %w", err)
 	}
 
-	configDir := configPath[:len(configPath)-len("/config.yaml")]
+	configDir := filepath.Dir(configPath)
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return fmt.Errorf("error creating config directory: %w", err)
 	}
@@ -99,21 +133,67 @@ func saveConfig(cfg *config.Config) error {
 }
 
 func runSetToken(cmd *cobra.Command, args []string) {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Printf("Error loading config: %v\n", err)
 		os.Exit(1)
 	}
 
-	cfg.Global.GitHubToken = args[0]
+	setContextToken(cfg, activeContext(), args[0])
 	if err := saveConfig(cfg); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
 
+func runConfigValidate(cmd *cobra.Command, args []string) {
+	path := flagConfigFile
+	if len(args) == 1 {
+		path = args[0]
+	}
+	path = config.FindConfigFile(path)
+	if path == "" {
+		fmt.Println("No configuration file found; defaults will be used. Run 'gh-inspect init' to create one.")
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Printf("Error reading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	problems, err := config.Validate(data)
+	if err != nil {
+		fmt.Printf("❌ %s is not valid YAML: %v\n", path, err)
+		os.Exit(1)
+	}
+
+	errorCount := 0
+	for _, p := range problems {
+		if !p.Warning {
+			errorCount++
+		}
+		if p.Line > 0 {
+			fmt.Printf("%s:%d: %s\n", path, p.Line, p)
+		} else {
+			fmt.Printf("%s: %s\n", path, p)
+		}
+	}
+
+	switch {
+	case errorCount > 0:
+		fmt.Printf("\n❌ %d error(s), %d warning(s) in %s\n", errorCount, len(problems)-errorCount, path)
+		os.Exit(1)
+	case len(problems) > 0:
+		fmt.Printf("\n⚠️  %d warning(s) in %s\n", len(problems), path)
+	default:
+		fmt.Printf("✅ %s is valid\n", path)
+	}
+}
+
 func runList(cmd *cobra.Command, args []string) {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Printf("Error loading config: %v\n", err)
 		os.Exit(1)
@@ -125,14 +205,14 @@ func runList(cmd *cobra.Command, args []string) {
 		fmt.Printf("Error marshaling config: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Println(string(data))
+	fmt.Println(string(redactTokens(data)))
 }
 
 func runSet(cmd *cobra.Command, args []string) {
 	key := args[0]
 	valStr := args[1]
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Printf("Error loading config: %v\n", err)
 		os.Exit(1)
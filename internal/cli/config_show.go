@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/activity"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/issuehygiene"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/prflow"
+	"github.com/mikematt33/gh-inspect/internal/analysis/analyzers/repohealth"
+	"github.com/mikematt33/gh-inspect/internal/config"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var flagShowResolved bool
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the configuration file or the effective configuration",
+	Long: `Print the configuration file gh-inspect loads (honoring --config).
+
+With --resolved, print the complete effective configuration instead: every analyzer
+toggle, threshold and global setting, with the built-in defaults filled in for
+anything the file leaves unset. GitHub tokens are redacted in both views.`,
+	Example: `  gh-inspect config show
+  gh-inspect config show --resolved
+  gh-inspect --config ./ci-config.yaml config show --resolved`,
+	Args: cobra.NoArgs,
+	Run:  runConfigShow,
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+	configShowCmd.Flags().BoolVar(&flagShowResolved, "resolved", false, "Print the effective configuration with defaults filled in")
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) {
+	path := config.FindConfigFile(flagConfigFile)
+
+	if !flagShowResolved {
+		if path == "" {
+			fmt.Println("No configuration file found; defaults will be used. Run 'gh-inspect config show --resolved' to see them.")
+			return
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Printf("Error reading config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("# %s\n%s", path, redactTokens(data))
+		return
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	data, err := yaml.Marshal(resolveConfig(cfg))
+	if err != nil {
+		fmt.Printf("Error marshaling config: %v\n", err)
+		os.Exit(1)
+	}
+
+	source := "built-in defaults only (no configuration file found)"
+	if path != "" {
+		source = path + " with built-in defaults"
+	}
+	fmt.Printf("# Effective configuration: %s\n%s", source, data)
+}
+
+// tokenValuePattern matches the value of a github_token key, in block or flow style
+var tokenValuePattern = regexp.MustCompile(`(github_token\s*:[ \t]*)("[^"]+"|'[^']+'|[^\s,}#"']+)`)
+
+// redactTokens hides the values of global.github_token and contexts.*.github_token in raw
+// config file contents, leaving everything else, comments included, as written
+func redactTokens(data []byte) []byte {
+	return tokenValuePattern.ReplaceAll(data, []byte("${1}<redacted>"))
+}
+
+// resolveConfig returns a copy of cfg with the defaults that analyzers and the pipeline
+// apply at run time filled in, so nothing shows up as unset. The token is redacted.
+func resolveConfig(cfg *config.Config) config.Config {
+	resolved := *cfg
+
+	g := &resolved.Global
+	if g.GitHubToken != "" {
+		g.GitHubToken = "<redacted>"
+	}
+	if len(cfg.Contexts) > 0 {
+		resolved.Contexts = make(map[string]config.ContextConfig, len(cfg.Contexts))
+		for name, contextCfg := range cfg.Contexts {
+			if contextCfg.GitHubToken != "" {
+				contextCfg.GitHubToken = "<redacted>"
+			}
+			resolved.Contexts[name] = contextCfg
+		}
+	}
+	if g.OutputMode == "" {
+		g.OutputMode = "observational"
+	}
+	if g.ConcurrencyMode == "" {
+		g.ConcurrencyMode = "fixed"
+	}
+	if g.HealthScoreWeighting == "" {
+		g.HealthScoreWeighting = "none"
+	}
+	if resolved.Cache.DefaultTTL == "" {
+		resolved.Cache.DefaultTTL = "1h"
+	}
+
+	w := scoringWeightsFromConfig(cfg.Scoring)
+	resolved.Scoring = config.ScoringConfig{
+		CIFailing:       &w.CIFailing,
+		CIUnstable:      &w.CIUnstable,
+		BusFactor:       &w.BusFactor,
+		ZombiesHigh:     &w.ZombiesHigh,
+		ZombiesModerate: &w.ZombiesModerate,
+		MissingFile:     &w.MissingFile,
+		MissingFilesMax: &w.MissingFilesMax,
+		StalePRs:        &w.StalePRs,
+	}
+
+	t := insightThresholdsFromConfig(cfg.Insights)
+	resolved.Insights = config.InsightsConfig{
+		CICritical:     &t.CICritical,
+		CIWarning:      &t.CIWarning,
+		ZombieIssues:   &t.ZombieIssues,
+		CycleTimeHours: &t.CycleTimeHours,
+		SelfMergeRate:  &t.SelfMergeRate,
+	}
+
+	a := &resolved.Analyzers
+	if len(a.Activity.Params.ChurnExcludeLabels) == 0 {
+		a.Activity.Params.ChurnExcludeLabels = activity.DefaultChurnExcludeLabels
+	}
+	if len(a.PRFlow.Params.BotLogins) == 0 {
+		a.PRFlow.Params.BotLogins = prflow.DefaultBotLogins
+	}
+	if a.PRFlow.Params.SizeSampleSize == nil {
+		n := prflow.DefaultSizeSampleSize
+		a.PRFlow.Params.SizeSampleSize = &n
+	}
+	if len(a.IssueHygiene.Params.LabelGroups) == 0 {
+		a.IssueHygiene.Params.LabelGroups = issuehygiene.DefaultLabelGroups
+	}
+	if len(a.RepoHealth.RequiredFiles) == 0 {
+		for _, f := range repohealth.DefaultKeyFiles {
+			a.RepoHealth.RequiredFiles = append(a.RepoHealth.RequiredFiles, config.RequiredFile{
+				Path:      f.Path,
+				AltPaths:  f.AltPaths,
+				Severity:  strings.ToLower(string(f.Severity)),
+				Deduction: f.Deduction,
+			})
+		}
+	}
+	if a.RepoHealth.Params.LargeFileMB == nil {
+		mb := repohealth.DefaultLargeFileMB
+		a.RepoHealth.Params.LargeFileMB = &mb
+	}
+	if a.RepoHealth.Params.LargeTreeMB == nil {
+		mb := repohealth.DefaultLargeTreeMB
+		a.RepoHealth.Params.LargeTreeMB = &mb
+	}
+	return resolved
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/mikematt33/gh-inspect/internal/config"
@@ -116,3 +117,80 @@ func TestSetConfigValue(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigFlagOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles", "strict.yaml")
+
+	oldFlag := flagConfigFile
+	flagConfigFile = path
+	defer func() { flagConfigFile = oldFlag }()
+
+	resolved, err := resolveConfigPath()
+	assert.NoError(t, err)
+	assert.Equal(t, path, resolved)
+
+	// Missing override file yields defaults
+	cfg, err := loadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, cfg.Global.Concurrency)
+
+	// Writes go to the override path and are read back from it
+	cfg.Global.Concurrency = 12
+	assert.NoError(t, saveConfig(cfg))
+
+	cfg, err = loadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, 12, cfg.Global.Concurrency)
+}
+
+func TestResolveConfig(t *testing.T) {
+	ciFailing := 40
+	cfg := &config.Config{
+		Global:   config.GlobalConfig{GitHubToken: "secret", OutputMode: "suggestive"},
+		Contexts: map[string]config.ContextConfig{"work": {GitHubToken: "work-secret"}},
+		Scoring:  config.ScoringConfig{CIFailing: &ciFailing},
+		Analyzers: config.AnalyzersConfig{
+			PRFlow: config.PRFlowConfig{Params: config.PRFlowParams{BotLogins: []string{"ci-bot"}}},
+		},
+	}
+
+	resolved := resolveConfig(cfg)
+
+	assert.Equal(t, "<redacted>", resolved.Global.GitHubToken)
+	assert.Equal(t, "secret", cfg.Global.GitHubToken, "the loaded config must not be modified")
+	assert.Equal(t, "<redacted>", resolved.Contexts["work"].GitHubToken)
+	assert.Equal(t, "work-secret", cfg.Contexts["work"].GitHubToken, "the loaded config must not be modified")
+	assert.Equal(t, "suggestive", resolved.Global.OutputMode)
+	assert.Equal(t, "fixed", resolved.Global.ConcurrencyMode)
+	assert.Equal(t, "none", resolved.Global.HealthScoreWeighting)
+	assert.Equal(t, 40, *resolved.Scoring.CIFailing)
+	assert.Equal(t, 15, *resolved.Scoring.StalePRs)
+	assert.Nil(t, cfg.Scoring.StalePRs)
+	assert.Equal(t, 72.0, *resolved.Insights.CycleTimeHours)
+	assert.Equal(t, []string{"ci-bot"}, resolved.Analyzers.PRFlow.Params.BotLogins)
+	assert.Equal(t, 20, *resolved.Analyzers.PRFlow.Params.SizeSampleSize)
+	assert.NotEmpty(t, resolved.Analyzers.IssueHygiene.Params.LabelGroups)
+	assert.Equal(t, "LICENSE", resolved.Analyzers.RepoHealth.RequiredFiles[0].Path)
+}
+
+func TestRedactTokens(t *testing.T) {
+	raw := `global:
+  github_token: ghp_secret # personal
+  concurrency: 4
+contexts:
+  work:
+    github_token: "ghp_work"
+  client: {github_token: 'ghp_client', api_url: https://ghe.client.com/api/v3}
+  empty:
+    github_token: ""
+`
+	redacted := string(redactTokens([]byte(raw)))
+
+	for _, secret := range []string{"ghp_secret", "ghp_work", "ghp_client"} {
+		assert.NotContains(t, redacted, secret)
+	}
+	assert.Contains(t, redacted, "github_token: <redacted> # personal")
+	assert.Contains(t, redacted, "{github_token: <redacted>, api_url: https://ghe.client.com/api/v3}")
+	assert.Contains(t, redacted, `github_token: ""`, "an empty token has nothing to hide")
+	assert.Contains(t, redacted, "concurrency: 4")
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mikematt33/gh-inspect/internal/config"
+	ghclient "github.com/mikematt33/gh-inspect/internal/github"
+)
+
+// activeContext returns the auth context named by --context or GH_INSPECT_CONTEXT.
+// "" is the default context.
+func activeContext() string {
+	if flagContext != "" {
+		return flagContext
+	}
+	return os.Getenv(envVarName("context"))
+}
+
+// contextNames returns the auth contexts defined in the config file, sorted
+func contextNames() []string {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil
+	}
+	return sortedContextNames(cfg.Contexts)
+}
+
+func sortedContextNames(contexts map[string]config.ContextConfig) []string {
+	names := make([]string, 0, len(contexts))
+	for name := range contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// contextConfig returns the settings of the active auth context. The default context is
+// built from global.github_token and global.api_url; an unknown context is an error.
+func contextConfig(cfg *config.Config) (config.ContextConfig, error) {
+	return contextConfigFrom(cfg, activeContext())
+}
+
+func contextConfigFrom(cfg *config.Config, name string) (config.ContextConfig, error) {
+	if name == "" {
+		return config.ContextConfig{GitHubToken: cfg.Global.GitHubToken, APIURL: cfg.Global.APIURL}, nil
+	}
+	contextCfg, ok := cfg.Contexts[name]
+	if !ok {
+		if len(cfg.Contexts) == 0 {
+			return config.ContextConfig{}, fmt.Errorf("unknown context %q: no contexts are defined in the config file (run 'gh-inspect auth login --context %s')", name, name)
+		}
+		return config.ContextConfig{}, fmt.Errorf("unknown context %q (available: %s)", name, strings.Join(sortedContextNames(cfg.Contexts), ", "))
+	}
+	return contextCfg, nil
+}
+
+// configToken returns the token stored in the config file for the active context, or ""
+func configToken(cfg *config.Config) string {
+	contextCfg, err := contextConfig(cfg)
+	if err != nil {
+		return ""
+	}
+	return contextCfg.GitHubToken
+}
+
+// resolveToken finds the token of the active context (see ghclient.ResolveToken)
+func resolveToken(cfg *config.Config) (string, error) {
+	contextCfg, err := contextConfig(cfg)
+	if err != nil {
+		return "", err
+	}
+	return ghclient.ResolveToken(activeContext(), contextCfg.GitHubToken), nil
+}
+
+// noTokenError reports that the active context has no token
+func noTokenError() error {
+	if name := activeContext(); name != "" {
+		return fmt.Errorf("no GitHub token found for context %q. Please run 'gh-inspect auth login --context %s' to login", name, name)
+	}
+	return fmt.Errorf("no GitHub token found. Please run 'gh-inspect auth' to login")
+}
+
+// setContextToken stores token in cfg for the named context, creating the context if needed.
+// A token of "" only registers the context (its token then lives in the OS keyring).
+// With --api-url the context also remembers that API URL.
+func setContextToken(cfg *config.Config, name, token string) {
+	if name == "" {
+		cfg.Global.GitHubToken = token
+		return
+	}
+	if cfg.Contexts == nil {
+		cfg.Contexts = make(map[string]config.ContextConfig)
+	}
+	contextCfg := cfg.Contexts[name]
+	if token != "" {
+		contextCfg.GitHubToken = token
+	}
+	if flagAPIURL != "" {
+		contextCfg.APIURL = flagAPIURL
+	}
+	cfg.Contexts[name] = contextCfg
+}
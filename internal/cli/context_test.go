@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikematt33/gh-inspect/internal/config"
+	"github.com/mikematt33/gh-inspect/internal/keyring"
+)
+
+func TestContextConfig(t *testing.T) {
+	cfg := &config.Config{
+		Global: config.GlobalConfig{GitHubToken: "default-token", APIURL: "https://ghe.example.com/api/v3"},
+		Contexts: map[string]config.ContextConfig{
+			"work":   {GitHubToken: "work-token"},
+			"client": {APIURL: "https://ghe.client.com/api/v3"},
+		},
+	}
+
+	contextCfg, err := contextConfigFrom(cfg, "")
+	if err != nil || contextCfg.GitHubToken != "default-token" || contextCfg.APIURL != "https://ghe.example.com/api/v3" {
+		t.Errorf("Expected the default context to use the global settings, got %+v (%v)", contextCfg, err)
+	}
+
+	contextCfg, err = contextConfigFrom(cfg, "work")
+	if err != nil || contextCfg.GitHubToken != "work-token" || contextCfg.APIURL != "" {
+		t.Errorf("Expected the work context without the global API URL, got %+v (%v)", contextCfg, err)
+	}
+
+	_, err = contextConfigFrom(cfg, "personal")
+	if err == nil || !strings.Contains(err.Error(), "client, work") {
+		t.Errorf("Expected an unknown context to list the available ones, got %v", err)
+	}
+}
+
+func TestResolveTokenFromActiveContext(t *testing.T) {
+	keyring.MockInit()
+	oldContext := flagContext
+	defer func() { flagContext = oldContext }()
+
+	cfg := &config.Config{
+		Global:   config.GlobalConfig{GitHubToken: "default-token"},
+		Contexts: map[string]config.ContextConfig{"work": {}},
+	}
+
+	flagContext = ""
+	if token, err := resolveToken(cfg); err != nil || token != "default-token" {
+		t.Errorf("Expected the default token without --context, got %q (%v)", token, err)
+	}
+
+	flagContext = "work"
+	if token, err := resolveToken(cfg); err != nil || token != "" {
+		t.Errorf("Expected no token for a context without one, got %q (%v)", token, err)
+	}
+
+	setContextToken(cfg, "work", "work-token")
+	if token, err := resolveToken(cfg); err != nil || token != "work-token" {
+		t.Errorf("Expected the stored context token, got %q (%v)", token, err)
+	}
+	if cfg.Global.GitHubToken != "default-token" {
+		t.Errorf("Storing a context token must not touch the global token, got %q", cfg.Global.GitHubToken)
+	}
+
+	flagContext = ""
+	t.Setenv("GH_INSPECT_CONTEXT", "personal")
+	if _, err := resolveToken(cfg); err == nil || !strings.Contains(err.Error(), `"personal"`) {
+		t.Errorf("Expected GH_INSPECT_CONTEXT to select an unknown context, got %v", err)
+	}
+}
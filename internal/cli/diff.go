@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mikematt33/gh-inspect/pkg/baseline"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [baseline-a] [baseline-b]",
+	Short: "Compare two saved baseline files offline",
+	Long: `Compare two baseline files saved with --save-baseline without calling the GitHub API.
+The newer baseline (by timestamp) is treated as the current run, regardless of argument order.`,
+	Example: `  gh-inspect diff old-baseline.json new-baseline.json
+  gh-inspect diff archive/2024-01.json archive/2024-06.json --format=json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if flagFormat != "" && flagFormat != "text" && flagFormat != "json" {
+			return fmt.Errorf("invalid format: %s (must be text or json)", flagFormat)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	Run: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVarP(&flagFormat, "format", "f", "text", "Output format (text, json)")
+	_ = diffCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// diffBaselines loads two baseline files and compares the newer one against the older one
+func diffBaselines(pathA, pathB string) (*baseline.ComparisonResult, error) {
+	a, err := baseline.Load(pathA)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pathA, err)
+	}
+	b, err := baseline.Load(pathB)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pathB, err)
+	}
+	printBaselineWarnings(pathA, a)
+	printBaselineWarnings(pathB, b)
+
+	older, newer := a, b
+	if b.Timestamp.Before(a.Timestamp) {
+		older, newer = b, a
+	}
+	if newer.Report == nil {
+		return nil, fmt.Errorf("baseline from %s contains no report", newer.Timestamp.Format("2006-01-02 15:04"))
+	}
+
+	comparison := baseline.Compare(newer.Report, older)
+	if comparison == nil {
+		return nil, fmt.Errorf("baseline from %s contains no report", older.Timestamp.Format("2006-01-02 15:04"))
+	}
+	return comparison, nil
+}
+
+func runDiff(cmd *cobra.Command, args []string) {
+	comparison, err := diffBaselines(args[0], args[1])
+	if err != nil {
+		fmt.Printf("Error comparing baselines: %v\n", err)
+		os.Exit(1)
+	}
+
+	if flagFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(comparison); err != nil {
+			fmt.Printf("Error rendering diff: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	printComparison(comparison, "")
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mikematt33/gh-inspect/pkg/baseline"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffBaselines(t *testing.T) {
+	dir := t.TempDir()
+
+	makeReport := func(health float64) *models.Report {
+		return &models.Report{
+			Summary: models.GlobalSummary{AvgHealthScore: health},
+			Repositories: []models.RepoResult{{
+				Name: "owner/repo",
+				Analyzers: []models.AnalyzerResult{{
+					Name:    "repo-health",
+					Metrics: []models.Metric{{Key: "health_score", Value: health}},
+				}},
+			}},
+		}
+	}
+
+	oldPath := filepath.Join(dir, "old.json")
+	newPath := filepath.Join(dir, "new.json")
+	assert.NoError(t, baseline.Save(makeReport(90), oldPath))
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, baseline.Save(makeReport(70), newPath))
+
+	// Argument order must not matter: the newer baseline is always "current"
+	for _, args := range [][2]string{{oldPath, newPath}, {newPath, oldPath}} {
+		comparison, err := diffBaselines(args[0], args[1])
+		assert.NoError(t, err)
+		if assert.NotNil(t, comparison) {
+			assert.True(t, comparison.Previous.Timestamp.Before(time.Now()))
+			assert.Equal(t, 1, len(comparison.Deltas))
+			assert.Less(t, comparison.Summary.HealthScoreDelta, 0.0)
+		}
+	}
+}
+
+func TestDiffBaselinesMissingFile(t *testing.T) {
+	_, err := diffBaselines(filepath.Join(t.TempDir(), "missing.json"), "also-missing.json")
+	assert.Error(t, err)
+}
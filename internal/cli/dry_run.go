@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+)
+
+// printDryRun describes what an analysis would do: the resolved repositories, the
+// analyzers that would run and the approximate API cost. No analyzer is executed.
+func printDryRun(w io.Writer, opts AnalysisOptions) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+	analysisCfg, err := buildAnalysisConfig(opts)
+	if err != nil {
+		return err
+	}
+	analyzers := buildAnalyzers(cfg, opts)
+
+	_, _ = fmt.Fprintf(w, "Dry run: no analysis will be performed\n\n")
+	_, _ = fmt.Fprintf(w, "Repositories (%d):\n", len(opts.Repos))
+	for _, repo := range opts.Repos {
+		_, _ = fmt.Fprintf(w, "  %s\n", repo)
+	}
+
+	_, _ = fmt.Fprintf(w, "\nAnalyzers (%d):\n", len(analyzers))
+	for _, az := range analyzers {
+		_, _ = fmt.Fprintf(w, "  %-15s ~%d requests/repo\n", az.Name(), az.EstimatedCost(analysisCfg))
+	}
+
+	perRepo := estimateRequestCost(analyzers, analysisCfg, opts)
+	_, _ = fmt.Fprintf(w, "\nLookback: since %s (depth: %s)\n", analysisCfg.Since.Format("2006-01-02"), analysisCfg.DepthConfig.Name)
+	if opts.Ref != "" {
+		_, _ = fmt.Fprintf(w, "Ref: %s\n", opts.Ref)
+	}
+	note := ", including the repository lookup"
+	if !opts.NoRepoConfig {
+		note = ", including the repository and .gh-inspect.yml lookups"
+	}
+	_, _ = fmt.Fprintf(w, "Estimated API requests: ~%d (%d per repository%s)\n", perRepo*len(opts.Repos), perRepo, note)
+	return nil
+}
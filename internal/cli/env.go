@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// envPrefix is prepended to a flag name to form its environment variable,
+// e.g. --output-mode becomes GH_INSPECT_OUTPUT_MODE
+const envPrefix = "GH_INSPECT_"
+
+// envFlags are the analysis flags that can be set through environment variables
+var envFlags = []string{"format", "depth", "since", "include", "exclude", "output-mode", "fail-under", "profile"}
+
+// envVarName returns the environment variable bound to a flag
+func envVarName(flag string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// applyEnvOverrides fills flags not given on the command line from their environment
+// variables. It runs before validation, so precedence is flag > env > config > default
+// and bad values are reported like bad flags.
+func applyEnvOverrides(cmd *cobra.Command) error {
+	for _, name := range envFlags {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || f.Changed {
+			continue
+		}
+		value := os.Getenv(envVarName(name))
+		if value == "" {
+			continue
+		}
+		// An explicit --since-date replaces the lookback window, so it also beats the env var
+		if name == "since" && cmd.Flags().Changed("since-date") {
+			continue
+		}
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("invalid %s=%q: %w", envVarName(name), value, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// envTestCommand returns a command with its own copies of the env-bound flags
+func envTestCommand(depth *string, failUnder *int, include *[]string, since, sinceDate *string) *cobra.Command {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringVar(depth, "depth", "standard", "")
+	cmd.Flags().IntVar(failUnder, "fail-under", 0, "")
+	cmd.Flags().StringSliceVar(include, "include", nil, "")
+	cmd.Flags().StringVar(since, "since", "30d", "")
+	cmd.Flags().StringVar(sinceDate, "since-date", "", "")
+	return cmd
+}
+
+func TestApplyEnvOverrides(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		env           map[string]string
+		wantDepth     string
+		wantFailUnder int
+		wantInclude   []string
+		wantSince     string
+	}{
+		{"defaults", nil, nil, "standard", 0, nil, "30d"},
+		{"env fills unset flags", nil,
+			map[string]string{"GH_INSPECT_DEPTH": "deep", "GH_INSPECT_FAIL_UNDER": "80", "GH_INSPECT_INCLUDE": "activity,ci"},
+			"deep", 80, []string{"activity", "ci"}, "30d"},
+		{"flag beats env", []string{"--depth=shallow", "--fail-under=50"},
+			map[string]string{"GH_INSPECT_DEPTH": "deep", "GH_INSPECT_FAIL_UNDER": "80"},
+			"shallow", 50, nil, "30d"},
+		{"since-date beats env since", []string{"--since-date=2024-01-01"},
+			map[string]string{"GH_INSPECT_SINCE": "90d"},
+			"standard", 0, nil, "30d"},
+		{"env since", nil, map[string]string{"GH_INSPECT_SINCE": "90d"}, "standard", 0, nil, "90d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			var depth, since, sinceDate string
+			var failUnder int
+			var include []string
+			cmd := envTestCommand(&depth, &failUnder, &include, &since, &sinceDate)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags: %v", err)
+			}
+			if err := applyEnvOverrides(cmd); err != nil {
+				t.Fatalf("applyEnvOverrides: %v", err)
+			}
+			if depth != tt.wantDepth || failUnder != tt.wantFailUnder || since != tt.wantSince || !reflect.DeepEqual(include, tt.wantInclude) {
+				t.Errorf("Got depth=%q fail-under=%d include=%v since=%q, want %q %d %v %q",
+					depth, failUnder, include, since, tt.wantDepth, tt.wantFailUnder, tt.wantInclude, tt.wantSince)
+			}
+		})
+	}
+}
+
+func TestApplyEnvOverridesInvalidValue(t *testing.T) {
+	t.Setenv("GH_INSPECT_FAIL_UNDER", "high")
+	var depth, since, sinceDate string
+	var failUnder int
+	var include []string
+	cmd := envTestCommand(&depth, &failUnder, &include, &since, &sinceDate)
+	if err := applyEnvOverrides(cmd); err == nil {
+		t.Error("Expected an error for a non-numeric GH_INSPECT_FAIL_UNDER")
+	}
+}
+
+func TestEnvVarName(t *testing.T) {
+	if got := envVarName("output-mode"); got != "GH_INSPECT_OUTPUT_MODE" {
+		t.Errorf("Expected GH_INSPECT_OUTPUT_MODE, got %s", got)
+	}
+}
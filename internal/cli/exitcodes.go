@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// Exit codes for analysis commands. Distinct codes let CI tell why a run failed.
+const (
+	exitOK                   = 0
+	exitError                = 1 // Generic failure (bad flags, config, API errors)
+	exitHealthBelowThreshold = 2 // Average health score below --fail-under
+	exitRegression           = 3 // Regression against baseline with --fail-on-regression
+	exitAnalyzerErrors       = 4 // One or more analyzers failed or timed out while gating with --fail-under/--fail-on-regression/--fail-on-finding-type
+	exitFindingType          = 5 // A finding listed in --fail-on-finding-type was reported
+)
+
+// formatFailLine builds the single structured line printed before a failing exit,
+// e.g. "FAIL: reason=health_below_threshold score=72 threshold=80"
+func formatFailLine(reason string, fields ...string) string {
+	return strings.Join(append([]string{"FAIL: reason=" + reason}, fields...), " ")
+}
+
+// failField formats a key=value pair for formatFailLine, rounding floats to one decimal
+func failField(key string, value float64) string {
+	return key + "=" + strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
+}
+
+// exitWithFailure prints the structured FAIL line to stderr (keeping stdout parseable
+// for json/csv output) and exits with code
+func exitWithFailure(code int, reason string, fields ...string) {
+	fmt.Fprintln(os.Stderr, formatFailLine(reason, fields...))
+	os.Exit(code)
+}
+
+// findingTypeMatch is a repository that reported a finding type listed in --fail-on-finding-type
+type findingTypeMatch struct {
+	Repo string
+	Type string
+}
+
+// matchFindingTypes returns each repository/finding type pair in the report whose type
+// is one of types, once per pair and in report order
+func matchFindingTypes(report *models.Report, types []string) []findingTypeMatch {
+	wanted := make(map[string]bool, len(types))
+	for _, t := range types {
+		wanted[t] = true
+	}
+
+	var matches []findingTypeMatch
+	for _, repo := range report.Repositories {
+		seen := make(map[string]bool)
+		for _, az := range repo.Analyzers {
+			for _, f := range az.Findings {
+				if wanted[f.Type] && !seen[f.Type] {
+					seen[f.Type] = true
+					matches = append(matches, findingTypeMatch{Repo: repo.Name, Type: f.Type})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// failOnFindingTypes exits with exitFindingType when the report contains any finding
+// type listed in --fail-on-finding-type, naming the repositories that triggered it
+func failOnFindingTypes(report *models.Report) {
+	if len(flagFailOnFindingType) == 0 {
+		return
+	}
+	matches := matchFindingTypes(report, flagFailOnFindingType)
+	if len(matches) == 0 {
+		return
+	}
+
+	var pairs []string
+	if shouldPrintInfo() {
+		fmt.Printf("\n❌ Failure: %d disallowed finding(s) reported:\n", len(matches))
+	}
+	for _, m := range matches {
+		pairs = append(pairs, m.Repo+":"+m.Type)
+		if shouldPrintInfo() {
+			fmt.Printf("  - %s: %s\n", m.Repo, m.Type)
+		}
+	}
+	exitWithFailure(exitFindingType, "finding_type", "matches="+strings.Join(pairs, ","))
+}
+
+// countAnalyzerErrors returns the number of analyzer_error and analyzer_timeout findings in a report
+func countAnalyzerErrors(report *models.Report) int {
+	count := 0
+	for _, repo := range report.Repositories {
+		for _, az := range repo.Analyzers {
+			for _, f := range az.Findings {
+				if f.Type == "analyzer_error" || f.Type == "analyzer_timeout" {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
 	"time"
@@ -30,14 +31,19 @@ type RepoFilter struct {
 	Topics        []string
 	UpdatedWithin time.Duration
 	SkipForks     bool
+	// IncludeArchived keeps archived repositories, which are skipped by default
+	IncludeArchived bool
+	// ExcludeRepos drops repositories whose owner/repo name matches any of these patterns
+	ExcludeRepos []*regexp.Regexp
 }
 
 // NewRepoFilter creates a filter from CLI flags
 func NewRepoFilter() (*RepoFilter, error) {
 	filter := &RepoFilter{
-		Languages: flagFilterLanguage,
-		Topics:    flagFilterTopics,
-		SkipForks: flagFilterSkipForks,
+		Languages:       flagFilterLanguage,
+		Topics:          flagFilterTopics,
+		SkipForks:       flagFilterSkipForks,
+		IncludeArchived: flagIncludeArchived,
 	}
 
 	// Compile name regex if provided
@@ -49,6 +55,15 @@ func NewRepoFilter() (*RepoFilter, error) {
 		filter.NamePattern = pattern
 	}
 
+	// Each --exclude-repos entry must match the whole owner/repo name, ignoring case
+	for _, entry := range splitPatterns(flagExcludeRepos) {
+		pattern, err := regexp.Compile("(?i)^(?:" + entry + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid --exclude-repos pattern %q: %w", entry, err)
+		}
+		filter.ExcludeRepos = append(filter.ExcludeRepos, pattern)
+	}
+
 	// Parse updated duration if provided
 	if flagFilterUpdated != "" {
 		duration, err := parseDuration(flagFilterUpdated)
@@ -61,10 +76,45 @@ func NewRepoFilter() (*RepoFilter, error) {
 	return filter, nil
 }
 
+// splitPatterns splits comma-separated regexes, keeping commas inside (), [] and {} and
+// escaped commas, so quantifiers such as {1,3} survive
+func splitPatterns(values []string) []string {
+	var patterns []string
+	for _, value := range values {
+		depth, start := 0, 0
+		for i := 0; i < len(value); i++ {
+			switch value[i] {
+			case '\\':
+				i++ // skip the escaped character
+			case '(', '[', '{':
+				depth++
+			case ')', ']', '}':
+				if depth > 0 {
+					depth--
+				}
+			case ',':
+				if depth == 0 {
+					patterns = append(patterns, value[start:i])
+					start = i + 1
+				}
+			}
+		}
+		patterns = append(patterns, value[start:])
+	}
+
+	nonEmpty := patterns[:0]
+	for _, p := range patterns {
+		if p = strings.TrimSpace(p); p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return nonEmpty
+}
+
 // Matches returns true if the repository passes all filter criteria
 func (f *RepoFilter) Matches(repo *github.Repository) bool {
-	// Skip archived repositories (always)
-	if repo.GetArchived() {
+	// Skip archived repositories unless explicitly included
+	if repo.GetArchived() && !f.IncludeArchived {
 		return false
 	}
 
@@ -73,6 +123,10 @@ func (f *RepoFilter) Matches(repo *github.Repository) bool {
 		return false
 	}
 
+	if f.excluded(repo) {
+		return false
+	}
+
 	// Name pattern filter
 	if f.NamePattern != nil {
 		if !f.NamePattern.MatchString(repo.GetName()) {
@@ -123,11 +177,22 @@ func (f *RepoFilter) Matches(repo *github.Repository) bool {
 	return true
 }
 
+// excluded reports whether repo matches one of the --exclude-repos patterns
+func (f *RepoFilter) excluded(repo *github.Repository) bool {
+	for _, pattern := range f.ExcludeRepos {
+		if pattern.MatchString(repo.GetFullName()) {
+			return true
+		}
+	}
+	return false
+}
+
 // Stats tracks filtering statistics
 type FilterStats struct {
 	Total         int
 	Archived      int
 	Forks         int
+	Excluded      int
 	NameFiltered  int
 	LangFiltered  int
 	TopicFiltered int
@@ -144,10 +209,12 @@ func FilterRepositories(repos []*github.Repository, filter *RepoFilter) ([]strin
 	var targetRepos []string
 
 	for _, r := range repos {
-		// Track archived separately
+		// Track archived separately, even when they are included
 		if r.GetArchived() {
 			stats.Archived++
-			continue
+			if !filter.IncludeArchived {
+				continue
+			}
 		}
 
 		// Track forks
@@ -161,8 +228,14 @@ func FilterRepositories(repos []*github.Repository, filter *RepoFilter) ([]strin
 		// Apply remaining filters
 		passed := true
 
+		// Explicit exclusions
+		if filter.excluded(r) {
+			stats.Excluded++
+			passed = false
+		}
+
 		// Name filter
-		if filter.NamePattern != nil && !filter.NamePattern.MatchString(r.GetName()) {
+		if passed && filter.NamePattern != nil && !filter.NamePattern.MatchString(r.GetName()) {
 			stats.NameFiltered++
 			passed = false
 		}
@@ -219,3 +292,34 @@ func FilterRepositories(repos []*github.Repository, filter *RepoFilter) ([]strin
 
 	return targetRepos, stats
 }
+
+// printFilterStats writes how many repositories each filter removed
+func printFilterStats(w io.Writer, stats *FilterStats) {
+	_, _ = fmt.Fprintf(w, "found %d total repositories\n", stats.Total)
+	if stats.Archived > 0 && flagIncludeArchived {
+		_, _ = fmt.Fprintf(w, "  %d archived (included)\n", stats.Archived)
+	} else if stats.Archived > 0 {
+		_, _ = fmt.Fprintf(w, "  %d archived (skipped)\n", stats.Archived)
+	}
+	if stats.Forks > 0 && !flagFilterSkipForks {
+		_, _ = fmt.Fprintf(w, "  %d forks (included)\n", stats.Forks)
+	} else if flagFilterSkipForks {
+		_, _ = fmt.Fprintf(w, "  %d forks (filtered)\n", stats.Forks)
+	}
+	if stats.Excluded > 0 {
+		_, _ = fmt.Fprintf(w, "  %d excluded by --exclude-repos\n", stats.Excluded)
+	}
+	if stats.NameFiltered > 0 {
+		_, _ = fmt.Fprintf(w, "  %d filtered by name pattern\n", stats.NameFiltered)
+	}
+	if stats.LangFiltered > 0 {
+		_, _ = fmt.Fprintf(w, "  %d filtered by language\n", stats.LangFiltered)
+	}
+	if stats.TopicFiltered > 0 {
+		_, _ = fmt.Fprintf(w, "  %d filtered by topics\n", stats.TopicFiltered)
+	}
+	if stats.DateFiltered > 0 {
+		_, _ = fmt.Fprintf(w, "  %d filtered by update date\n", stats.DateFiltered)
+	}
+	_, _ = fmt.Fprintf(w, "analyzing %d repositories\n", stats.Passed)
+}
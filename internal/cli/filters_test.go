@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"reflect"
 	"regexp"
 	"testing"
 	"time"
@@ -103,6 +104,12 @@ func TestRepoFilterMatches(t *testing.T) {
 			repo:          createTestRepo("archived-repo", "Go", []string{}, true, false, now),
 			expectedMatch: false,
 		},
+		{
+			name:          "archived repo with include archived - should pass",
+			filter:        &RepoFilter{IncludeArchived: true},
+			repo:          createTestRepo("archived-repo", "Go", []string{}, true, false, now),
+			expectedMatch: true,
+		},
 		{
 			name:          "fork with skip forks - should fail",
 			filter:        &RepoFilter{SkipForks: true},
@@ -286,6 +293,18 @@ func TestFilterRepositories(t *testing.T) {
 		}
 	})
 
+	t.Run("include archived", func(t *testing.T) {
+		filter := &RepoFilter{IncludeArchived: true}
+		results, stats := FilterRepositories(repos, filter)
+
+		if stats.Archived != 1 {
+			t.Errorf("Expected archived repos to still be counted, got %d", stats.Archived)
+		}
+		if stats.Passed != 6 || len(results) != 6 {
+			t.Errorf("Expected 6 passed including the archived repo, got %d (%v)", stats.Passed, results)
+		}
+	})
+
 	t.Run("language filter", func(t *testing.T) {
 		filter := &RepoFilter{Languages: []string{"Go"}}
 		results, stats := FilterRepositories(repos, filter)
@@ -403,6 +422,50 @@ func TestFilterStatistics(t *testing.T) {
 	}
 }
 
+func TestExcludeRepos(t *testing.T) {
+	defer func() { flagExcludeRepos = nil }()
+	now := time.Now()
+	repos := []*github.Repository{
+		createTestRepo("api", "Go", nil, false, false, now),
+		createTestRepo("playground", "Go", nil, false, false, now),
+		createTestRepo("sandbox-1", "Go", nil, false, false, now),
+		createTestRepo("sandbox-2", "Go", nil, false, false, now),
+		createTestRepo("api-sandbox", "Go", nil, false, false, now),
+	}
+
+	flagExcludeRepos = []string{"Owner/Playground", "owner/sandbox-.*"}
+	filter, err := NewRepoFilter()
+	if err != nil {
+		t.Fatalf("NewRepoFilter failed: %v", err)
+	}
+	names, stats := FilterRepositories(repos, filter)
+	if !reflect.DeepEqual(names, []string{"owner/api", "owner/api-sandbox"}) {
+		t.Errorf("Expected whole-name, case-insensitive exclusions, got %v", names)
+	}
+	if stats.Excluded != 3 || stats.Passed != 2 {
+		t.Errorf("Expected 3 excluded and 2 passed, got %+v", stats)
+	}
+	if filter.Matches(repos[1]) || !filter.Matches(repos[0]) {
+		t.Error("Expected Matches to apply the exclusions too")
+	}
+
+	// Commas inside quantifiers and groups belong to the pattern
+	flagExcludeRepos = []string{"owner/sandbox-[0-9]{1,3}, owner/(play,ground|playground)"}
+	filter, err = NewRepoFilter()
+	if err != nil {
+		t.Fatalf("NewRepoFilter failed: %v", err)
+	}
+	names, _ = FilterRepositories(repos, filter)
+	if !reflect.DeepEqual(names, []string{"owner/api", "owner/api-sandbox"}) {
+		t.Errorf("Expected quantifier commas to be kept, got %v", names)
+	}
+
+	flagExcludeRepos = []string{"owner/(unclosed"}
+	if _, err := NewRepoFilter(); err == nil {
+		t.Error("Expected an invalid pattern to be rejected")
+	}
+}
+
 func TestEmptyRepositoryList(t *testing.T) {
 	filter := &RepoFilter{Languages: []string{"Go"}}
 	results, stats := FilterRepositories([]*github.Repository{}, filter)
@@ -5,7 +5,6 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/mikematt33/gh-inspect/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -13,43 +12,141 @@ const defaultConfig = `# gh-inspect Configuration
 
 # Global settings
 global:
-  timeout: "2m"
   concurrency: 5 # Max concurrent repo analysis
+  # concurrency_mode: "auto" # fixed (default) or auto: use fewer workers when the remaining rate limit is low
   output_mode: "observational" # How findings are presented: observational (default), suggestive, statistical
+  analyzer_timeout_seconds: 300 # Max time per analyzer per repo (0 = no limit)
+  retry_max_attempts: 3 # Tries per API request on transient errors (5xx, connection resets)
+  # baseline_history: 30 # Timestamped baselines kept by --save-baseline for the trend command (default 0 = none)
+  # health_score_weighting: "stars" # Also show a weighted average health score: none (default), stars, commits
+  # repo_weights: # Explicit weights per repository, overriding the weighting strategy
+  #   "my-org/flagship": 10
+  # proxy_url: "http://proxy.example.com:8080" # Overrides HTTP_PROXY/HTTPS_PROXY for API and update requests; NO_PROXY still applies
+  # api_url: "https://ghe.example.com/api/v3" # GitHub Enterprise Server API (--api-url overrides it)
   # github_token: "YOUR_TOKEN" # Optional: Store token here (not recommended for shared machines)
 
-# Output configuration
-output:
-  format: "json" # json, markdown, csv
-  path: "./report.json"
-  verbose: false
+# Cache configuration
+# TTLs can be overridden per cache-key prefix (e.g. "repo:", "workflow:")
+cache:
+  default_ttl: "1h"
+  # ttl:
+  #   "repo:": "24h"
+  #   "workflow:": "5m"
+
+# Health score deductions (uncomment to override defaults; 0 disables a deduction)
+# scoring:
+#   ci_failing: 30
+#   ci_unstable: 15
+#   bus_factor: 20
+#   zombie_issues_high: 15
+#   zombie_issues_moderate: 5
+#   missing_file: 5
+#   missing_files_max: 20 # cap on the missing-file total (default 0: uncapped)
+#   stale_prs: 15
+
+# Insight thresholds (uncomment to override defaults)
+# insights:
+#   ci_success_critical: 50 # CI success rate (%) below which CI is critical
+#   ci_success_warning: 80  # ...and below which it is a warning
+#   zombie_issues: 10       # Warn above this many zombie issues
+#   cycle_time_hours: 72    # Note when average PR cycle time exceeds this
+#   self_merge_rate: 50     # Self-merge rate (%) that is critical on an unprotected default branch
+
+# Named flag bundles selected with --profile (flags given on the command line still win)
+# profiles:
+#   security-audit:
+#     include: [security, branches, dependencies, health]
+#     depth: deep
+#     fail_under: 80
+#   velocity:
+#     include: [activity, prflow, ci, releases, deployments]
+#     output_mode: statistical
+#   full:
+#     depth: deep
+#     output_mode: suggestive
+
+# Named GitHub accounts selected with --context (without it, the global token and api_url are used)
+# Store a token for one with: gh-inspect auth login --context work
+# contexts:
+#   work:
+#     github_token: ghp_...
+#   client-ghe:
+#     api_url: https://ghe.example.com/api/v3
 
 # Analyzer Configuration
 # Enable or disable specific analyzers and tune their parameters
 analyzers:
-  pr_flow:
-    enabled: true
+  activity:
     params:
-      stale_threshold_days: 14
-      cycle_time_target_hours: 48
-      exclude_bots: ["dependabot", "renovate"]
+      # Flag repos where fewer than this % of commits use conventional-commit prefixes (default 0 = off)
+      # conventional_commit_threshold: 50
+      # PRs with these labels are left out of code_churn_ratio, e.g. vendored or generated code (default below)
+      # churn_exclude_labels: ["dependencies", "generated"]
 
-  review_health:
+  pr_flow:
     enabled: true
     params:
-      min_approvals_required: 1
+      stale_threshold_days: 14
+      # PR authors left out of avg_cycle_time_hours_human (default below)
+      # bot_logins: ["dependabot[bot]", "renovate[bot]", "github-actions[bot]"]
+      # Recently merged PRs fetched one by one for avg/median PR size (one API call each, 0 = off)
+      # size_sample_size: 20
 
   issue_hygiene:
     enabled: true
     params:
       stale_threshold_days: 60
       zombie_threshold_days: 365
+      # Flag more than this many unlabeled issues older than the stale threshold (0 = off)
+      untriaged_threshold: 10
+      # Target time to a first response on new issues, in hours (0 = off)
+      response_sla_hours: 48
+      # Count open issues per label group (labels matched by prefix, case-insensitive)
+      # label_groups:
+      #   priority: ["priority", "p0", "p1"]
+      #   needs_triage: ["needs-triage"]
+      #   good_first_issue: ["good first issue"]
 
   repo_health:
     enabled: true
+    # Replace the built-in key file checks (LICENSE, README.md, SECURITY.md, ...)
+    # required_files:
+    #   - path: SUPPORT.md
+    #     severity: low
+    #     deduction: 5
+    #   - path: .github/dependabot.yml
+    #     alt_paths: [.github/dependabot.yaml]
+    #     severity: medium
+    #     deduction: 10
+    # Thresholds of the large_files finding (committed files that slow down clones); 0 disables one
+    # params:
+    #   large_file_mb: 10  # Flag single files at least this large
+    #   large_tree_mb: 500 # Flag repositories whose files add up to at least this much
 
   ci:
     enabled: true
+
+  deployments:
+    enabled: true
+    params:
+      # Flag repos whose deployment success rate (%) is below this (0 = off)
+      success_rate_threshold: 90
+
+  branches:
+    enabled: true
+    params:
+      stale_threshold_days: 90
+      # Flag stale branches this many commits behind the default branch
+      divergence_threshold_commits: 100
+      # Regexes branch names should match; reports branch_naming_compliance when set
+      # naming_patterns: ["^feature/", "^bugfix/", "^release/"]
+
+  # Split commit authors into internal and external contributors
+  contributors:
+    enabled: false
+    params:
+      internal_members: []   # GitHub logins of org members
+      internal_domains: []   # company email domains, e.g. example.com
 `
 
 var initCmd = &cobra.Command{
@@ -77,7 +174,7 @@ func createDefaultConfig(path string) error {
 }
 
 func runInit(cmd *cobra.Command, args []string) {
-	configPath, err := config.GetConfigPath()
+	configPath, err := resolveConfigPath()
 	if err != nil {
 		fmt.Printf("Error getting config path: %v\n", err)
 		os.Exit(1)
@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/mikematt33/gh-inspect/internal/config"
 )
 
 func TestInitCmd(t *testing.T) {
@@ -59,3 +61,13 @@ func TestInitCmd(t *testing.T) {
 		t.Errorf("initCmd failed on second run: %v", err)
 	}
 }
+
+func TestDefaultConfigIsValid(t *testing.T) {
+	problems, err := config.Validate([]byte(defaultConfig))
+	if err != nil {
+		t.Fatalf("Default config is not valid YAML: %v", err)
+	}
+	for _, p := range problems {
+		t.Errorf("Default config line %d: %s", p.Line, p)
+	}
+}
@@ -6,13 +6,13 @@ import (
 	"os"
 
 	"github.com/google/go-github/v60/github"
-	"github.com/mikematt33/gh-inspect/internal/config"
 	"github.com/mikematt33/gh-inspect/internal/report"
+	"github.com/mikematt33/gh-inspect/pkg/models"
 	"github.com/spf13/cobra"
 )
 
 var getOrgRepositories = func(orgName string) ([]*github.Repository, error) {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("error loading config: %w", err)
 	}
@@ -25,24 +25,48 @@ var getOrgRepositories = func(orgName string) ([]*github.Repository, error) {
 	return client.ListRepositories(context.Background(), orgName, nil)
 }
 
+// expandOrgRepos lists an organization's repositories and applies the --filter-* flags,
+// returning the remaining owner/repo names (archived repositories are skipped unless
+// --include-archived is set)
+func expandOrgRepos(orgName string) ([]string, error) {
+	repos, err := getOrgRepositories(orgName)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := NewRepoFilter()
+	if err != nil {
+		return nil, fmt.Errorf("error creating filter: %w", err)
+	}
+	targetRepos, _ := FilterRepositories(repos, filter)
+	return targetRepos, nil
+}
+
 var orgCmd = &cobra.Command{
 	Use:   "org [organization]",
 	Short: "Analyze an entire GitHub organization",
 	Long: `Scan all active repositories in a GitHub organization with concurrent analysis.
-Automatically fetches all repositories, filters out archived ones, and runs the health analysis on each.
+Automatically fetches all repositories, filters out archived ones (unless --include-archived is set), and runs the health analysis on each.
 
 Displays a progress bar during analysis. Use --quiet for CI/CD environments.`,
 	Example: `  gh-inspect org my-org
   gh-inspect org my-org --fail-under=80
   gh-inspect org my-org --quiet --format=json
+  gh-inspect org my-org --format=csv > org-metrics.csv
   gh-inspect org my-org --exclude=security,releases
   gh-inspect org my-org --filter-language=go,python
   gh-inspect org my-org --filter-name="^api-.*" --filter-skip-forks
   gh-inspect org my-org --filter-topics=production --filter-updated=90d`,
 	Args: func(cmd *cobra.Command, args []string) error {
+		if err := applyEnvOverrides(cmd); err != nil {
+			return err
+		}
+		if err := applyProfile(cmd); err != nil {
+			return err
+		}
+
 		// Validate format
-		if flagFormat != "" && flagFormat != "text" && flagFormat != "json" && flagFormat != "markdown" {
-			return fmt.Errorf("invalid format: %s (must be text, json, or markdown)", flagFormat)
+		if err := validateFormat(flagFormat, report.FormatBadge); err != nil {
+			return err
 		}
 
 		// Validate depth
@@ -55,6 +79,21 @@ Displays a progress bar during analysis. Use --quiet for CI/CD environments.`,
 			return fmt.Errorf("invalid output mode: %s (must be suggestive, observational, or statistical)", flagOutputMode)
 		}
 
+		// Validate minimum severity
+		if flagMinSeverity != "" && flagMinSeverity != "info" && flagMinSeverity != "low" && flagMinSeverity != "medium" && flagMinSeverity != "high" {
+			return fmt.Errorf("invalid min severity: %s (must be info, low, medium, or high)", flagMinSeverity)
+		}
+
+		if err := validateSinceFlags(cmd); err != nil {
+			return err
+		}
+
+		if flagFormat == "ndjson" {
+			if err := validateNDJSONFlags(); err != nil {
+				return err
+			}
+		}
+
 		if flagListAnalyzers {
 			return nil // Allow no args when listing analyzers
 		}
@@ -77,11 +116,9 @@ func init() {
 }
 
 func runOrgAnalysis(cmd *cobra.Command, args []string) {
+	quietForLineFormats()
 	orgName := args[0]
 
-	// Record organization usage for completions
-	recordUsage(orgName, "org")
-
 	if shouldPrintInfo() {
 		fmt.Printf("Fetching repositories for organization '%s'...\n", orgName)
 	}
@@ -94,6 +131,9 @@ func runOrgAnalysis(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	// Record organization usage for completions once the name is known to resolve
+	recordUsage(orgName, "org")
+
 	// 3. Apply Filters
 	filter, err := NewRepoFilter()
 	if err != nil {
@@ -103,29 +143,8 @@ func runOrgAnalysis(cmd *cobra.Command, args []string) {
 
 	targetRepos, stats := FilterRepositories(repos, filter)
 
-	if shouldPrintInfo() {
-		fmt.Printf("found %d total repositories\n", stats.Total)
-		if stats.Archived > 0 {
-			fmt.Printf("  %d archived (skipped)\n", stats.Archived)
-		}
-		if stats.Forks > 0 && !flagFilterSkipForks {
-			fmt.Printf("  %d forks (included)\n", stats.Forks)
-		} else if flagFilterSkipForks {
-			fmt.Printf("  %d forks (filtered)\n", stats.Forks)
-		}
-		if stats.NameFiltered > 0 {
-			fmt.Printf("  %d filtered by name pattern\n", stats.NameFiltered)
-		}
-		if stats.LangFiltered > 0 {
-			fmt.Printf("  %d filtered by language\n", stats.LangFiltered)
-		}
-		if stats.TopicFiltered > 0 {
-			fmt.Printf("  %d filtered by topics\n", stats.TopicFiltered)
-		}
-		if stats.DateFiltered > 0 {
-			fmt.Printf("  %d filtered by update date\n", stats.DateFiltered)
-		}
-		fmt.Printf("analyzing %d repositories\n", stats.Passed)
+	if shouldPrintInfo() || flagDryRun {
+		printFilterStats(os.Stdout, stats)
 	}
 
 	if len(targetRepos) == 0 {
@@ -134,7 +153,7 @@ func runOrgAnalysis(cmd *cobra.Command, args []string) {
 	}
 
 	// Load config to get output mode preference
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Printf("Error loading config: %v\n", err)
 		os.Exit(1)
@@ -150,8 +169,12 @@ func runOrgAnalysis(cmd *cobra.Command, args []string) {
 
 	// 4. Run Pipeline
 	opts := AnalysisOptions{
-		Repos: targetRepos,
-		Since: flagSince, // Flag from root/org command share the same vars if defined in root?
+		Repos:        targetRepos,
+		Since:        flagSince, // Flag from root/org command share the same vars if defined in root?
+		SinceDate:    flagSinceDate,
+		Ref:          flagRef,
+		QuietErrors:  flagQuietErrors,
+		NoRepoConfig: flagNoRepoConfig,
 		// checks root.go... yes, var flagFormat, flagSince, flagDepth are package variables.
 		Depth:           flagDepth,
 		MaxPRs:          flagMaxPRs,
@@ -160,33 +183,70 @@ func runOrgAnalysis(cmd *cobra.Command, args []string) {
 		Include:         flagInclude,
 		Exclude:         flagExclude,
 		OutputMode:      resolvedOutputMode,
+		AnalyzerTimeout: flagAnalyzerTimeout,
+		Timeout:         flagTimeout,
 	}
 
-	fullReport, err := pipelineRunner(opts)
-	if err != nil {
-		fmt.Printf("Error running analysis: %v\n", err)
-		os.Exit(1)
+	if flagDryRun {
+		if err := printDryRun(os.Stdout, opts); err != nil {
+			fmt.Printf("Error planning analysis: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	// Inject Org-level Stats into Summary (Manual Override)
-	// Currently Report.Summary is rudimentary, but we can set TotalReposAnalyzed at least.
-	fullReport.Summary.TotalReposAnalyzed = len(targetRepos)
-
 	// 5. Render Output
-	var renderer report.Renderer
-	if flagFormat == "json" {
-		renderer = &report.JSONRenderer{}
+	weights, thresholds := scoringWeightsFromConfig(cfg.Scoring), insightThresholdsFromConfig(cfg.Insights)
+	renderOpts := report.RenderOptions{
+		ShowExplanation:   flagExplain,
+		ExplainSummary:    flagExplainSummary,
+		OutputMode:        models.OutputMode(resolvedOutputMode),
+		OnlyFindings:      flagOnlyFindings,
+		MinSeverity:       models.Severity(flagMinSeverity),
+		NoColor:           !colorEnabled(os.Stdout),
+		CompactJSON:       flagCompact,
+		ShowTimings:       shouldPrintVerbose(),
+		MarkdownNoEmoji:   flagMarkdownNoEmoji,
+		ScoringWeights:    &weights,
+		InsightThresholds: &thresholds,
+	}
+
+	// Large organizations can stream repositories as they complete instead
+	var fullReport *models.Report
+	if flagFormat == "ndjson" {
+		fullReport, err = streamAnalysis(opts, os.Stdout, renderOpts)
 	} else {
-		renderer = &report.TextRenderer{}
+		fullReport, err = pipelineRunner(opts)
+	}
+	if err != nil {
+		fmt.Printf("Error running analysis: %v\n", err)
+		os.Exit(1)
 	}
 
-	if err := renderer.Render(fullReport, os.Stdout); err != nil {
-		fmt.Printf("Error rendering report: %v\n", err)
+	if flagFormat != "ndjson" {
+		renderer := report.NewRenderer(report.Format(flagFormat))
+		if err := renderer.RenderWithOptions(fullReport, os.Stdout, renderOpts); err != nil {
+			fmt.Printf("Error rendering report: %v\n", err)
+		}
 	}
 
 	// Exit Code Check
 	if flagFail > 0 && fullReport.Summary.AvgHealthScore < float64(flagFail) {
-		fmt.Printf("\n❌ Failure: Average health score (%.1f) is below threshold (%d).\n", fullReport.Summary.AvgHealthScore, flagFail)
-		os.Exit(1)
+		if shouldPrintInfo() {
+			fmt.Printf("\n❌ Failure: Average health score (%.1f) is below threshold (%d).\n", fullReport.Summary.AvgHealthScore, flagFail)
+		}
+		exitWithFailure(exitHealthBelowThreshold, "health_below_threshold",
+			failField("score", fullReport.Summary.AvgHealthScore), fmt.Sprintf("threshold=%d", flagFail))
+	}
+
+	failOnFindingTypes(fullReport)
+
+	if flagFail > 0 || len(flagFailOnFindingType) > 0 {
+		if n := countAnalyzerErrors(fullReport); n > 0 {
+			if shouldPrintInfo() {
+				fmt.Printf("\n❌ Failure: %d analyzer(s) failed or timed out.\n", n)
+			}
+			exitWithFailure(exitAnalyzerErrors, "analyzer_errors", fmt.Sprintf("count=%d", n))
+		}
 	}
 }
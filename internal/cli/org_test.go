@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/google/go-github/v60/github"
@@ -70,3 +71,80 @@ func TestOrgCmd(t *testing.T) {
 		t.Errorf("Expected output, got empty string")
 	}
 }
+
+func TestExpandOrgRepos(t *testing.T) {
+	originalGetOrgRepos := getOrgRepositories
+	originalSkipForks := flagFilterSkipForks
+	defer func() {
+		getOrgRepositories = originalGetOrgRepos
+		flagFilterSkipForks = originalSkipForks
+	}()
+
+	getOrgRepositories = func(orgName string) ([]*github.Repository, error) {
+		return []*github.Repository{
+			{FullName: github.String("my-org/api"), Archived: github.Bool(false), Fork: github.Bool(false)},
+			{FullName: github.String("my-org/old"), Archived: github.Bool(true), Fork: github.Bool(false)},
+			{FullName: github.String("my-org/fork"), Archived: github.Bool(false), Fork: github.Bool(true)},
+		}, nil
+	}
+	flagFilterSkipForks = true
+
+	repos, err := expandOrgRepos("my-org")
+	if err != nil {
+		t.Fatalf("expandOrgRepos failed: %v", err)
+	}
+	merged := mergeRepos([]string{"other/repo", "my-org/api"}, repos)
+	if len(merged) != 2 || merged[0] != "other/repo" || merged[1] != "my-org/api" {
+		t.Errorf("Expected [other/repo my-org/api], got %v", merged)
+	}
+}
+
+func TestOrgDryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalXDG := os.Getenv("XDG_CONFIG_HOME")
+	originalPipelineRunner := pipelineRunner
+	originalGetOrgRepos := getOrgRepositories
+	originalInclude := flagInclude
+	defer func() {
+		_ = os.Setenv("XDG_CONFIG_HOME", originalXDG)
+		pipelineRunner = originalPipelineRunner
+		getOrgRepositories = originalGetOrgRepos
+		flagInclude = originalInclude
+		flagDryRun = false
+	}()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	getOrgRepositories = func(orgName string) ([]*github.Repository, error) {
+		return []*github.Repository{
+			{FullName: github.String("my-org/api"), Archived: github.Bool(false), Fork: github.Bool(false)},
+			{FullName: github.String("my-org/web"), Archived: github.Bool(false), Fork: github.Bool(false)},
+			{FullName: github.String("my-org/old"), Archived: github.Bool(true), Fork: github.Bool(false)},
+		}, nil
+	}
+	pipelineRunner = func(opts AnalysisOptions) (*models.Report, error) {
+		t.Fatal("Dry run must not run the analysis pipeline")
+		return nil, nil
+	}
+	flagDryRun = true
+	flagInclude = []string{"ci", "releases"}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	runOrgAnalysis(orgCmd, []string{"my-org"})
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	output := buf.String()
+
+	for _, want := range []string{"found 3 total repositories", "1 archived (skipped)", "Repositories (2):", "my-org/web", "Analyzers (2):", "ci", "releases", "Estimated API requests"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected dry run output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "my-org/old") {
+		t.Errorf("Archived repository should not be listed:\n%s", output)
+	}
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/term"
+)
+
+// colorEnabled reports whether output written to w may use emoji and ANSI color.
+// Color is off with --no-color or NO_COLOR, and whenever w is not a terminal.
+func colorEnabled(w io.Writer) bool {
+	if flagNoColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// openReportOutput returns where the rendered report should go: stdout when path is empty,
+// otherwise the file at path, creating parent directories as needed.
+// The returned close function must be called once rendering is done.
+func openReportOutput(path string) (io.Writer, func() error, error) {
+	if path == "" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return f, f.Close, nil
+}
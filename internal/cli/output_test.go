@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenReportOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "reports", "report.json")
+
+	out, closeOut, err := openReportOutput(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = out.Write([]byte(`{"ok":true}`))
+	assert.NoError(t, err)
+	assert.NoError(t, closeOut())
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ok":true}`, string(data))
+}
+
+func TestOpenReportOutputStdout(t *testing.T) {
+	out, closeOut, err := openReportOutput("")
+	assert.NoError(t, err)
+	assert.Equal(t, os.Stdout, out)
+	assert.NoError(t, closeOut())
+}
+
+func TestColorEnabled(t *testing.T) {
+	// Buffers and regular files are never terminals
+	assert.False(t, colorEnabled(&bytes.Buffer{}))
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer func() { _ = f.Close() }()
+	assert.False(t, colorEnabled(f))
+}
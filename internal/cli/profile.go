@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mikematt33/gh-inspect/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// profileNames returns the profiles defined in the config file, sorted
+func profileNames() []string {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil
+	}
+	return sortedProfileNames(cfg.Profiles)
+}
+
+func sortedProfileNames(profiles map[string]config.ProfileConfig) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyProfile fills flags from the profile named by --profile. It runs after
+// applyEnvOverrides, so precedence is flag > env > profile > config > default.
+func applyProfile(cmd *cobra.Command) error {
+	if flagProfile == "" {
+		return nil
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+	return applyProfileFrom(cmd, cfg.Profiles, flagProfile)
+}
+
+func applyProfileFrom(cmd *cobra.Command, profiles map[string]config.ProfileConfig, name string) error {
+	profile, ok := profiles[name]
+	if !ok {
+		if len(profiles) == 0 {
+			return fmt.Errorf("unknown profile %q: no profiles are defined in the config file", name)
+		}
+		return fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(sortedProfileNames(profiles), ", "))
+	}
+
+	values := map[string]string{
+		"include":     strings.Join(profile.Include, ","),
+		"exclude":     strings.Join(profile.Exclude, ","),
+		"depth":       profile.Depth,
+		"output-mode": profile.OutputMode,
+	}
+	if profile.FailUnder != 0 {
+		values["fail-under"] = strconv.Itoa(profile.FailUnder)
+	}
+
+	for _, flag := range []string{"include", "exclude", "depth", "output-mode", "fail-under"} {
+		value := values[flag]
+		f := cmd.Flags().Lookup(flag)
+		if value == "" || f == nil || f.Changed {
+			continue
+		}
+		if err := cmd.Flags().Set(flag, value); err != nil {
+			return fmt.Errorf("invalid %s in profile %q: %w", flag, name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mikematt33/gh-inspect/internal/config"
+)
+
+func TestApplyProfile(t *testing.T) {
+	profiles := map[string]config.ProfileConfig{
+		"security-audit": {Include: []string{"security", "branches"}, Depth: "deep", FailUnder: 80},
+		"velocity":       {Include: []string{"activity", "prflow"}},
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		env           map[string]string
+		wantDepth     string
+		wantFailUnder int
+		wantInclude   []string
+	}{
+		{"profile fills unset flags", nil, nil, "deep", 80, []string{"security", "branches"}},
+		{"flag beats profile", []string{"--depth=shallow", "--include=ci"}, nil, "shallow", 80, []string{"ci"}},
+		{"env beats profile", nil, map[string]string{"GH_INSPECT_FAIL_UNDER": "60"}, "deep", 60, []string{"security", "branches"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			var depth, since, sinceDate string
+			var failUnder int
+			var include []string
+			cmd := envTestCommand(&depth, &failUnder, &include, &since, &sinceDate)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags: %v", err)
+			}
+			if err := applyEnvOverrides(cmd); err != nil {
+				t.Fatalf("applyEnvOverrides: %v", err)
+			}
+			if err := applyProfileFrom(cmd, profiles, "security-audit"); err != nil {
+				t.Fatalf("applyProfileFrom: %v", err)
+			}
+			if depth != tt.wantDepth || failUnder != tt.wantFailUnder || !reflect.DeepEqual(include, tt.wantInclude) {
+				t.Errorf("Got depth=%q fail-under=%d include=%v, want %q %d %v",
+					depth, failUnder, include, tt.wantDepth, tt.wantFailUnder, tt.wantInclude)
+			}
+		})
+	}
+
+	var depth, since, sinceDate string
+	var failUnder int
+	var include []string
+	cmd := envTestCommand(&depth, &failUnder, &include, &since, &sinceDate)
+	err := applyProfileFrom(cmd, profiles, "full")
+	if err == nil || !strings.Contains(err.Error(), "security-audit, velocity") {
+		t.Errorf("Expected an unknown profile to list the available ones, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"io"
+	"sync"
+
+	"github.com/schollz/progressbar/v3"
+)
+
+// progressOutput owns the progress bar of an analysis run. Log lines written through
+// its writers while the bar is shown clear the bar first and redraw it afterwards, so
+// concurrent workers never print into the middle of it. Without a bar the writers pass
+// lines straight through, still one at a time.
+type progressOutput struct {
+	mu  sync.Mutex
+	bar *progressbar.ProgressBar
+}
+
+// newProgressOutput wraps bar, which is nil when progress is not shown
+func newProgressOutput(bar *progressbar.ProgressBar) *progressOutput {
+	return &progressOutput{bar: bar}
+}
+
+// increment advances the bar by one repository
+func (p *progressOutput) increment() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.bar != nil {
+		_ = p.bar.Add(1)
+	}
+}
+
+// finish completes the bar; later writes go straight through
+func (p *progressOutput) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.bar != nil {
+		_ = p.bar.Finish()
+		p.bar = nil
+	}
+}
+
+// writer returns a writer for log lines to w that keeps clear of the bar
+func (p *progressOutput) writer(w io.Writer) io.Writer {
+	return &progressLogWriter{progress: p, w: w}
+}
+
+type progressLogWriter struct {
+	progress *progressOutput
+	w        io.Writer
+}
+
+func (l *progressLogWriter) Write(b []byte) (int, error) {
+	p := l.progress
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.bar != nil {
+		_ = p.bar.Clear()
+	}
+	n, err := l.w.Write(b)
+	if p.bar != nil {
+		_ = p.bar.RenderBlank()
+	}
+	return n, err
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/schollz/progressbar/v3"
+)
+
+func TestProgressOutputClearsBarAroundLogLines(t *testing.T) {
+	var out bytes.Buffer
+	bar := progressbar.NewOptions(2, progressbar.OptionSetWriter(&out), progressbar.OptionSetDescription("Analyzing"))
+	_ = bar.RenderBlank()
+	progress := newProgressOutput(bar)
+
+	_, _ = fmt.Fprintf(progress.writer(&out), "Error analyzing o/r with ci: boom\n")
+
+	s := out.String()
+	logAt := strings.Index(s, "Error analyzing o/r with ci: boom\n")
+	if logAt < 0 {
+		t.Fatalf("Expected the log line intact, got %q", s)
+	}
+	if !strings.HasSuffix(s[:logAt], "\r") {
+		t.Errorf("Expected the bar to be cleared before the log line, got %q", s[:logAt])
+	}
+	if !strings.Contains(s[logAt:], "Analyzing") {
+		t.Errorf("Expected the bar to be redrawn after the log line, got %q", s[logAt:])
+	}
+
+	progress.finish()
+	out.Reset()
+	_, _ = fmt.Fprintf(progress.writer(&out), "after\n")
+	if out.String() != "after\n" {
+		t.Errorf("Expected writes after finish to pass through, got %q", out.String())
+	}
+}
+
+func TestProgressOutputWithoutBarSerializesLines(t *testing.T) {
+	var out bytes.Buffer
+	w := newProgressOutput(nil).writer(&out)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _ = fmt.Fprintf(w, "line %02d\n", i)
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 20 {
+		t.Fatalf("Expected 20 intact lines, got %d: %q", len(lines), out.String())
+	}
+	for _, line := range lines {
+		if len(line) != len("line 00") || !strings.HasPrefix(line, "line ") {
+			t.Errorf("Expected an intact line, got %q", line)
+		}
+	}
+}
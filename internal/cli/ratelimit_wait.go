@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// rateLimitNow and rateLimitSleep are variables to allow mocking in tests
+var (
+	rateLimitNow   = time.Now
+	rateLimitSleep = sleepContext
+)
+
+// sleepContext waits for d, returning ctx's error early if it is cancelled first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// handleExhaustedRateLimit is called by the preflight when no requests are left. It shows
+// when the limit resets and, when interactive, asks whether to wait for it with a
+// countdown; otherwise the run is aborted instead of blocking inside the analyzers.
+// It returns nil once the limit has reset, or ctx's error if --timeout or an interrupt
+// ends the wait first.
+func handleExhaustedRateLimit(ctx context.Context, w io.Writer, limits *github.Rate, interactive bool, confirm func(string) bool) error {
+	reset := limits.Reset.Time
+	wait := reset.Sub(rateLimitNow())
+	if wait <= 0 {
+		return nil
+	}
+
+	fmt.Fprintf(w, "\n⛔ GitHub rate limit exhausted (0/%d requests remaining)\n", limits.Limit)
+	fmt.Fprintf(w, "   Resets at %s (in %s)\n\n", reset.Local().Format("15:04:05 MST"), formatCountdown(wait))
+
+	if !interactive {
+		return fmt.Errorf("rate limit exhausted until %s; re-run after the reset", reset.Local().Format(time.RFC3339))
+	}
+	if !confirm("Wait for the rate limit to reset?") {
+		return fmt.Errorf("aborted: rate limit exhausted until %s", reset.Local().Format(time.RFC3339))
+	}
+
+	return waitForRateLimitReset(ctx, w, reset)
+}
+
+// waitForRateLimitReset counts down on a single line until reset has passed
+func waitForRateLimitReset(ctx context.Context, w io.Writer, reset time.Time) error {
+	for {
+		remaining := reset.Sub(rateLimitNow())
+		if remaining <= 0 {
+			break
+		}
+		fmt.Fprintf(w, "\r⏳ Rate limit resets in %s (Ctrl+C to abort) ", formatCountdown(remaining))
+		step := time.Second
+		if remaining < step {
+			step = remaining
+		}
+		if err := rateLimitSleep(ctx, step); err != nil {
+			fmt.Fprintln(w)
+			return fmt.Errorf("stopped waiting for the rate limit reset: %w", err)
+		}
+	}
+	// One extra second so GitHub has rolled the window over before the first request
+	if err := rateLimitSleep(ctx, time.Second); err != nil {
+		fmt.Fprintln(w)
+		return fmt.Errorf("stopped waiting for the rate limit reset: %w", err)
+	}
+	fmt.Fprintf(w, "\r✅ Rate limit reset, continuing.%-20s\n", "")
+	return nil
+}
+
+// formatCountdown renders d as "1h02m05s", "12m03s" or "45s"
+func formatCountdown(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := int(d / time.Hour)
+	m := int(d % time.Hour / time.Minute)
+	s := int(d % time.Minute / time.Second)
+	switch {
+	case h > 0:
+		return fmt.Sprintf("%dh%02dm%02ds", h, m, s)
+	case m > 0:
+		return fmt.Sprintf("%dm%02ds", m, s)
+	default:
+		return fmt.Sprintf("%ds", s)
+	}
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+func TestHandleExhaustedRateLimit(t *testing.T) {
+	originalNow, originalSleep := rateLimitNow, rateLimitSleep
+	defer func() { rateLimitNow, rateLimitSleep = originalNow, originalSleep }()
+	current := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rateLimitNow = func() time.Time { return current }
+	slept := time.Duration(0)
+	rateLimitSleep = func(_ context.Context, d time.Duration) error { slept += d; current = current.Add(d); return nil }
+
+	limits := func() *github.Rate {
+		return &github.Rate{Limit: 5000, Remaining: 0, Reset: github.Timestamp{Time: current.Add(90 * time.Second)}}
+	}
+	never := func(string) bool {
+		t.Error("Expected no prompt")
+		return false
+	}
+
+	var out bytes.Buffer
+	if err := handleExhaustedRateLimit(context.Background(), &out, limits(), false, never); err == nil {
+		t.Error("Expected a non-interactive run to abort")
+	}
+	if !strings.Contains(out.String(), "0/5000") || !strings.Contains(out.String(), "in 1m30s") {
+		t.Errorf("Expected the limit and reset time to be shown, got:\n%s", out.String())
+	}
+
+	declined := func(string) bool { return false }
+	if err := handleExhaustedRateLimit(context.Background(), &out, limits(), true, declined); err == nil || !strings.Contains(err.Error(), "aborted") {
+		t.Errorf("Expected declining to abort, got %v", err)
+	}
+	if slept != 0 {
+		t.Errorf("Expected no waiting when aborting, slept %v", slept)
+	}
+
+	out.Reset()
+	accepted := func(string) bool { return true }
+	if err := handleExhaustedRateLimit(context.Background(), &out, limits(), true, accepted); err != nil {
+		t.Fatalf("Expected waiting to succeed, got %v", err)
+	}
+	if slept != 91*time.Second {
+		t.Errorf("Expected to wait until the reset plus one second, slept %v", slept)
+	}
+	if !strings.Contains(out.String(), "resets in 1m30s") || !strings.Contains(out.String(), "resets in 1s") {
+		t.Errorf("Expected a countdown, got:\n%s", out.String())
+	}
+
+	past := &github.Rate{Limit: 5000, Remaining: 0, Reset: github.Timestamp{Time: current.Add(-time.Minute)}}
+	if err := handleExhaustedRateLimit(context.Background(), &out, past, false, never); err != nil {
+		t.Errorf("Expected an already reset limit to continue, got %v", err)
+	}
+
+	// A cancelled context (--timeout or Ctrl+C) ends the wait
+	rateLimitSleep = sleepContext
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := handleExhaustedRateLimit(ctx, &out, limits(), true, accepted); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled wait to stop with context.Canceled, got %v", err)
+	}
+}
+
+func TestFormatCountdown(t *testing.T) {
+	cases := map[time.Duration]string{
+		45 * time.Second:                          "45s",
+		12*time.Minute + 3*time.Second:            "12m03s",
+		time.Hour + 2*time.Minute + 5*time.Second: "1h02m05s",
+	}
+	for d, want := range cases {
+		if got := formatCountdown(d); got != want {
+			t.Errorf("formatCountdown(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/internal/config"
+)
+
+// loadRepoConfig returns cfg with the overrides from the repository's .gh-inspect.yml on ref
+// ("" for the default branch) applied, or nil when the repository has none. A file that cannot
+// be decoded or fails validation is reported to warnOut and ignored, so the repository is
+// analyzed with cfg.
+func loadRepoConfig(ctx context.Context, client analysis.Client, owner, name, ref string, cfg *config.Config, warnOut io.Writer) *config.Config {
+	file, _, err := client.GetContentAtRef(ctx, owner, name, config.RepoConfigFile, ref)
+	if err != nil || file == nil {
+		if err != nil && !analysis.IsNotFoundError(err) && shouldPrintVerbose() {
+			_, _ = fmt.Fprintf(warnOut, "Could not read %s in %s/%s: %v\n", config.RepoConfigFile, owner, name, err)
+		}
+		return nil
+	}
+	content, err := file.GetContent()
+	if err == nil {
+		var merged *config.Config
+		if merged, err = config.MergeRepoConfig(cfg, []byte(content)); err == nil {
+			if shouldPrintVerbose() {
+				_, _ = fmt.Fprintf(warnOut, "Using %s overrides for %s/%s\n", config.RepoConfigFile, owner, name)
+			}
+			return merged
+		}
+	}
+	_, _ = fmt.Fprintf(warnOut, "⚠️  Ignoring %s in %s/%s: %v\n", config.RepoConfigFile, owner, name, err)
+	return nil
+}
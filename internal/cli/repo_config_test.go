@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/analysis"
+	"github.com/mikematt33/gh-inspect/internal/config"
+)
+
+// repoConfigClient serves a fixed .gh-inspect.yml, or a 404 when content is empty
+type repoConfigClient struct {
+	analysis.Client
+	content string
+	ref     string // ref of the last lookup
+}
+
+func (c *repoConfigClient) GetContentAtRef(ctx context.Context, owner, repo, path, ref string) (*github.RepositoryContent, []*github.RepositoryContent, error) {
+	c.ref = ref
+	if c.content == "" || path != config.RepoConfigFile {
+		return nil, nil, &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+	}
+	return &github.RepositoryContent{Content: github.String(c.content)}, nil, nil
+}
+
+func TestLoadRepoConfig(t *testing.T) {
+	base, err := config.LoadFrom("/nonexistent/config.yaml")
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+
+	var warnings bytes.Buffer
+	if got := loadRepoConfig(context.Background(), &repoConfigClient{}, "o", "r", "", base, &warnings); got != nil || warnings.Len() > 0 {
+		t.Errorf("Expected no override and no warning without a repo config, got %v %q", got, warnings.String())
+	}
+
+	client := &repoConfigClient{content: "analyzers:\n  pr_flow:\n    params:\n      stale_threshold_days: 3\n"}
+	got := loadRepoConfig(context.Background(), client, "o", "r", "", base, &warnings)
+	if got == nil || got.Analyzers.PRFlow.Params.StaleThresholdDays != 3 {
+		t.Fatalf("Expected the repo's stale threshold of 3, got %+v", got)
+	}
+
+	if got := loadRepoConfig(context.Background(), client, "o", "r", "release/1.x", base, &warnings); got == nil || client.ref != "release/1.x" {
+		t.Errorf("Expected the repo config to be read at the analyzed ref, got ref %q", client.ref)
+	}
+
+	client.content = "analyzers:\n  pr_flow: [oops"
+	if got := loadRepoConfig(context.Background(), client, "o", "r", "", base, &warnings); got != nil {
+		t.Errorf("Expected a malformed repo config to be ignored, got %+v", got)
+	}
+	if !strings.Contains(warnings.String(), "Ignoring .gh-inspect.yml in o/r") {
+		t.Errorf("Expected a warning for the malformed repo config, got %q", warnings.String())
+	}
+}
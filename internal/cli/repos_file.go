@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// repoPattern matches a single owner/repo entry
+var repoPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+// stdinIsTerminal reports whether stdin is interactive rather than a pipe or file
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// readReposFile reads newline-delimited owner/repo entries from path.
+// Blank lines and # comments (whole-line or trailing) are ignored.
+func readReposFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return readRepos(f, path)
+}
+
+// readReposStdin reads repositories from stdin for --repos-stdin or a piped run
+func readReposStdin(r io.Reader) ([]string, error) {
+	return readRepos(r, "stdin")
+}
+
+// useReposStdin reports whether run should read repositories from stdin: when
+// --repos-stdin is set, or when nothing else names a repository and stdin is a pipe
+func useReposStdin(args []string) bool {
+	if flagReposStdin {
+		return true
+	}
+	return len(args) == 0 && flagReposFile == "" && flagReposFromOrg == "" && !flagListAnalyzers && !stdinIsTerminal()
+}
+
+// readRepos parses newline-delimited owner/repo entries, naming source in errors
+func readRepos(r io.Reader, source string) ([]string, error) {
+	var repos []string
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !repoPattern.MatchString(line) {
+			return nil, fmt.Errorf("%s:%d: invalid repository %q (expected owner/repo)", source, lineNum, line)
+		}
+		repos = append(repos, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
+// mergeRepos combines repository lists, preserving first-seen order and dropping duplicates
+func mergeRepos(lists ...[]string) []string {
+	var merged []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, repo := range list {
+			if !seen[repo] {
+				seen[repo] = true
+				merged = append(merged, repo)
+			}
+		}
+	}
+	return merged
+}
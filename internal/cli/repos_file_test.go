@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadReposFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repos.txt")
+	content := "# Backend services\nowner/api\n\n  owner/web  # frontend\nowner/api\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	repos, err := readReposFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"owner/api", "owner/web", "owner/api"}, repos)
+}
+
+func TestReadReposFileInvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repos.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("owner/api\n\nnot-a-repo\n"), 0644))
+
+	_, err := readReposFile(path)
+	if assert.Error(t, err) {
+		assert.True(t, strings.Contains(err.Error(), ":3:"), "error should report line 3: %v", err)
+	}
+}
+
+func TestReadReposStdin(t *testing.T) {
+	repos, err := readReposStdin(strings.NewReader("owner/api\nowner/web # frontend\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"owner/api", "owner/web"}, repos)
+
+	_, err = readReposStdin(strings.NewReader("owner/api\nowner/web\tdescription\n"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "stdin:2:")
+	}
+}
+
+func TestUseReposStdin(t *testing.T) {
+	originalIsTerminal := stdinIsTerminal
+	defer func() {
+		stdinIsTerminal = originalIsTerminal
+		flagReposStdin = false
+		flagReposFile = ""
+	}()
+
+	stdinIsTerminal = func() bool { return false }
+	assert.True(t, useReposStdin(nil), "piped stdin without repos should be read")
+	assert.False(t, useReposStdin([]string{"owner/repo"}), "positional repos take precedence over a pipe")
+	flagReposFile = "repos.txt"
+	assert.False(t, useReposStdin(nil), "--repos-file takes precedence over a pipe")
+	flagReposFile = ""
+
+	stdinIsTerminal = func() bool { return true }
+	assert.False(t, useReposStdin(nil), "an interactive stdin is never read implicitly")
+	flagReposStdin = true
+	assert.True(t, useReposStdin([]string{"owner/repo"}), "--repos-stdin always reads stdin")
+}
+
+func TestMergeRepos(t *testing.T) {
+	merged := mergeRepos([]string{"a/b", "c/d"}, []string{"c/d", "e/f", "a/b"})
+	assert.Equal(t, []string{"a/b", "c/d", "e/f"}, merged)
+}
@@ -3,8 +3,11 @@ package cli
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/mikematt33/gh-inspect/internal/config"
+	ghclient "github.com/mikematt33/gh-inspect/internal/github"
+	"github.com/mikematt33/gh-inspect/internal/logging"
 	"github.com/mikematt33/gh-inspect/internal/report"
 	"github.com/mikematt33/gh-inspect/pkg/baseline"
 	"github.com/mikematt33/gh-inspect/pkg/models"
@@ -20,8 +23,16 @@ var (
 		Short: "GitHub Repository Deep Inspection Tool",
 		Long: `gh-inspect is a CLI tool for comprehensive engineering health analysis of GitHub repositories.
 It measures commit patterns, PR velocity, issue hygiene, CI stability, and more to provide a holistic health score.`,
-		Version:          Version,
-		PersistentPreRun: checkAndInitConfig,
+		Version: Version,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if !colorEnabled(os.Stdout) {
+				disableColor()
+			}
+			applyLogging()
+			checkAndInitConfig(cmd, args)
+			applyProxyConfig()
+			applyAPIURL()
+		},
 		CompletionOptions: cobra.CompletionOptions{
 			DisableDefaultCmd: true,
 		},
@@ -38,16 +49,37 @@ Use --quiet to suppress progress output or --verbose for detailed information.`,
 		Example: `  gh-inspect run owner/repo
   gh-inspect run owner/repo1 owner/repo2 --depth=deep
   gh-inspect run owner/repo --format=json > report.json
+  gh-inspect run owner/repo --format=json --output=reports/report.json
+  gh-inspect run owner/repo --compare-last --comparison-output=reports/delta.json
+  gh-inspect run owner/repo --ref=develop --compare-branch=main
   gh-inspect run owner/repo --format=markdown --explain
+  gh-inspect run owner/repo1 owner/repo2 --format=csv > metrics.csv
+  gh-inspect run owner/repo1 owner/repo2 --format=score --fail-under=70
+  gh-inspect run owner/repo --format=badge --output=badge.json
+  gh-inspect run --repos-file=repos.txt
+  gh repo list my-org --json nameWithOwner --jq '.[].nameWithOwner' | gh-inspect run
+  gh-inspect run owner/repo1 owner/repo2 --repos-from-org=my-org --filter-topics=production
   gh-inspect run owner/repo --quiet --fail-under=80
   gh-inspect run owner/repo --no-cache
   gh-inspect run owner/repo --include=activity,ci,security
   gh-inspect run owner/repo --exclude=branches,releases
   gh-inspect run owner/repo --depth=shallow --max-prs=25
-  gh-inspect run owner/repo --depth=standard --max-workflow-runs=200`,
-		Args: func(cmd *cobra.Command, args []string) error { // Validate format
-			if flagFormat != "" && flagFormat != "text" && flagFormat != "json" && flagFormat != "markdown" {
-				return fmt.Errorf("invalid format: %s (must be text, json, or markdown)", flagFormat)
+  gh-inspect run owner/repo --depth=standard --max-workflow-runs=200
+  gh-inspect run owner/repo --watch=5m --include=ci,deployments`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := applyEnvOverrides(cmd); err != nil {
+				return err
+			}
+			if err := applyProfile(cmd); err != nil {
+				return err
+			}
+
+			// Validate format
+			if err := validateFormat(flagFormat); err != nil {
+				return err
+			}
+			if flagFormat == "badge" && len(args) > 1 {
+				return fmt.Errorf("--format=badge needs exactly one repository, got %d", len(args))
 			}
 
 			// Validate depth
@@ -60,8 +92,39 @@ Use --quiet to suppress progress output or --verbose for detailed information.`,
 				return fmt.Errorf("invalid output mode: %s (must be suggestive, observational, or statistical)", flagOutputMode)
 			}
 
-			if flagListAnalyzers {
-				return nil // Allow no args when listing analyzers
+			// Validate minimum severity
+			if flagMinSeverity != "" && flagMinSeverity != "info" && flagMinSeverity != "low" && flagMinSeverity != "medium" && flagMinSeverity != "high" {
+				return fmt.Errorf("invalid min severity: %s (must be info, low, medium, or high)", flagMinSeverity)
+			}
+
+			if err := validateSinceFlags(cmd); err != nil {
+				return err
+			}
+
+			if flagComparisonOutput != "" && !flagCompareLast && flagBaseline == "" && flagCompareBranch == "" {
+				return fmt.Errorf("--comparison-output requires --compare-last, --baseline or --compare-branch")
+			}
+
+			if flagCompareBranch != "" {
+				if err := validateCompareBranchFlags(); err != nil {
+					return err
+				}
+			}
+
+			if flagWatch != 0 {
+				if err := validateWatchFlags(); err != nil {
+					return err
+				}
+			}
+
+			if flagFormat == "ndjson" {
+				if err := validateNDJSONFlags(); err != nil {
+					return err
+				}
+			}
+
+			if flagListAnalyzers || flagReposFile != "" || flagReposFromOrg != "" || useReposStdin(args) {
+				return nil // Allow no args when listing analyzers or reading repos from a file, org or stdin
 			}
 			return cobra.MinimumNArgs(1)(cmd, args)
 		},
@@ -83,6 +146,7 @@ Use --quiet to suppress progress output or --verbose for detailed information.`,
 var (
 	flagFormat           string
 	flagSince            string
+	flagSinceDate        string
 	flagDepth            string
 	flagMaxPRs           int
 	flagMaxIssues        int
@@ -90,22 +154,60 @@ var (
 	flagFail             int
 	flagQuiet            bool
 	flagVerbose          bool
+	flagConfigFile       string
+	flagAPIURL           string
 	flagInclude          []string
 	flagExclude          []string
 	flagListAnalyzers    bool
+	flagDryRun           bool
 	flagCompareLast      bool
 	flagFailOnRegression bool
 	flagBaseline         string
 	flagSaveBaseline     bool
 	flagExplain          bool
+	flagExplainSummary   bool
+	flagOnlyFindings     bool
+	flagNoColor          bool
+	flagCompact          bool
+	flagMinSeverity      string
 	flagNoCache          bool
+	flagNoRepoConfig     bool
 	flagOutputMode       string
+	flagAnalyzerTimeout  int
+	flagTimeout          time.Duration
+	flagReposFile        string
+	flagReposStdin       bool
+	flagReposFromOrg     string
+	flagOutput           string
+	flagComparisonOutput string
+	flagWatch            time.Duration
 	// Filtering flags
 	flagFilterName      string
 	flagFilterLanguage  []string
 	flagFilterTopics    []string
 	flagFilterUpdated   string
 	flagFilterSkipForks bool
+	flagIncludeArchived bool
+	flagExcludeRepos    []string
+	// Policy flags
+	flagFailOnFindingType []string
+	// Target flags
+	flagRef string
+	// Error reporting flags
+	flagQuietErrors bool
+
+	flagRevalidate bool
+
+	flagMarkdownNoEmoji bool
+
+	flagProfile string
+
+	flagCompareBranch string
+
+	flagLogLevel string
+	flagLogJSON  bool
+
+	flagContext string
 )
 
 // listAnalyzers prints all available analyzers with descriptions
@@ -118,25 +220,32 @@ func listAnalyzers() {
 	fmt.Printf("  %-13s %s\n", "issues", "Issue hygiene, stale issues, and zombie detection")
 	fmt.Printf("  %-13s %s\n", "security", "Security advisories and vulnerability scanning")
 	fmt.Printf("  %-13s %s\n", "releases", "Release frequency, deployment metrics, and versioning patterns")
+	fmt.Printf("  %-13s %s\n", "deployments", "GitHub Deployments frequency, success rate, and environments")
 	fmt.Printf("  %-13s %s\n", "branches", "Branch protection and stale branch detection")
 	fmt.Printf("  %-13s %s\n", "dependencies", "Dependency management and package analysis")
+	fmt.Printf("  %-13s %s\n", "languages", "Language breakdown and primary language share")
+	fmt.Printf("  %-13s %s\n", "contributors", "Internal vs external contributor split (needs config)")
 	fmt.Printf("  %-13s %s\n", "health", "Repository health files (README, LICENSE, CONTRIBUTING, etc.)")
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  --include=activity,ci      Run only specified analyzers")
 	fmt.Println("  --exclude=releases,security  Skip specified analyzers")
 	fmt.Println()
-	fmt.Println("Note: Analyzers can also be enabled/disabled in the config file.")
+	fmt.Println("Note: Analyzers can also be enabled/disabled in the config file; --include runs them even when disabled there.")
 }
 
 // registerAnalysisFlags adds common analysis flags to a command
 func registerAnalysisFlags(cmd *cobra.Command) {
-	cmd.Flags().StringVarP(&flagFormat, "format", "f", "text", "Output format (text, json, markdown)")
+	cmd.Flags().StringVarP(&flagFormat, "format", "f", "text", "Output format (text, json, markdown, csv, sarif, score, ndjson, junit, badge)")
 	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"text", "json", "markdown"}, cobra.ShellCompDirectiveNoFileComp
+		return []string{"text", "json", "markdown", "csv", "sarif", "score", "ndjson", "junit", "badge"}, cobra.ShellCompDirectiveNoFileComp
 	})
+	cmd.Flags().BoolVar(&flagCompact, "compact", false, "Write JSON output on a single line without indentation")
+	cmd.Flags().BoolVar(&flagMarkdownNoEmoji, "markdown-no-emoji", false, "Use text labels such as [GOOD] and [AT RISK] instead of emoji in markdown output")
 
 	cmd.Flags().StringVarP(&flagSince, "since", "s", "30d", "Lookback window (e.g. 30d, 24h)")
+	cmd.Flags().StringVar(&flagSinceDate, "since-date", "", "Absolute start date instead of --since (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().StringVar(&flagRef, "ref", "", "Branch or ref to analyze for files, CI status and commits (default: each repository's default branch)")
 	_ = cmd.RegisterFlagCompletionFunc("since", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"30d", "90d", "180d", "24h", "720h"}, cobra.ShellCompDirectiveNoFileComp
 	})
@@ -149,29 +258,45 @@ func registerAnalysisFlags(cmd *cobra.Command) {
 	cmd.Flags().IntVar(&flagMaxPRs, "max-prs", 0, "Maximum PRs to analyze (0 = use depth default)")
 	cmd.Flags().IntVar(&flagMaxIssues, "max-issues", 0, "Maximum issues to fetch (0 = use depth default)")
 	cmd.Flags().IntVar(&flagMaxWorkflowRuns, "max-workflow-runs", 0, "Maximum CI runs to analyze (0 = use depth default)")
+	cmd.Flags().IntVar(&flagAnalyzerTimeout, "analyzer-timeout", 0, "Per-analyzer timeout in seconds (0 = use config value, default 300)")
+	cmd.Flags().BoolVar(&flagQuietErrors, "quiet-errors", false, "Report analyzer errors only as findings in the report, not on stderr")
+	cmd.Flags().DurationVar(&flagTimeout, "timeout", 0, "Abort the whole run after this duration (e.g. 30m) and report the repositories finished so far (0 = no limit)")
 
-	cmd.Flags().IntVar(&flagFail, "fail-under", 0, "Exit with error code 1 if average health score is below this value")
+	cmd.Flags().IntVar(&flagFail, "fail-under", 0, "Exit with code 2 if average health score is below this value")
+	cmd.Flags().StringSliceVar(&flagFailOnFindingType, "fail-on-finding-type", nil, "Exit with code 5 if any repository reports a finding of this type (repeatable, e.g. no_branch_protection)")
 
-	cmd.Flags().StringSliceVar(&flagInclude, "include", nil, "Only run specified analyzers (comma-separated: activity,prflow,ci,issues,security,releases,branches,dependencies,health)")
+	cmd.Flags().StringSliceVar(&flagInclude, "include", nil, "Only run specified analyzers (comma-separated: activity,prflow,ci,issues,security,releases,deployments,branches,dependencies,languages,contributors,health)")
 	_ = cmd.RegisterFlagCompletionFunc("include", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"activity", "prflow", "ci", "issues", "security", "releases", "branches", "dependencies", "health"}, cobra.ShellCompDirectiveNoFileComp
+		return analyzerFlagNames, cobra.ShellCompDirectiveNoFileComp
 	})
 
-	cmd.Flags().StringSliceVar(&flagExclude, "exclude", nil, "Exclude specified analyzers (comma-separated: activity,prflow,ci,issues,security,releases,branches,dependencies,health)")
+	cmd.Flags().StringSliceVar(&flagExclude, "exclude", nil, "Exclude specified analyzers (comma-separated: activity,prflow,ci,issues,security,releases,deployments,branches,dependencies,languages,contributors,health)")
 	_ = cmd.RegisterFlagCompletionFunc("exclude", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"activity", "prflow", "ci", "issues", "security", "releases", "branches", "dependencies", "health"}, cobra.ShellCompDirectiveNoFileComp
+		return analyzerFlagNames, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	cmd.Flags().StringVar(&flagProfile, "profile", "", "Apply a named profile from the config file (include, exclude, depth, output mode, fail-under)")
+	_ = cmd.RegisterFlagCompletionFunc("profile", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return profileNames(), cobra.ShellCompDirectiveNoFileComp
 	})
 
 	cmd.Flags().BoolVar(&flagListAnalyzers, "list-analyzers", false, "List all available analyzers and exit")
+	cmd.Flags().BoolVar(&flagDryRun, "dry-run", false, "Resolve repositories and analyzers, print the plan with its estimated API cost and exit without analyzing")
 
 	// Baseline/Comparison flags
 	cmd.Flags().BoolVar(&flagCompareLast, "compare-last", false, "Compare with last saved baseline")
 	cmd.Flags().StringVar(&flagBaseline, "baseline", "", "Path to baseline file to compare against")
 	cmd.Flags().BoolVar(&flagSaveBaseline, "save-baseline", false, "Save this run as the new baseline")
-	cmd.Flags().BoolVar(&flagFailOnRegression, "fail-on-regression", false, "Exit with error if regression detected")
+	cmd.Flags().BoolVar(&flagFailOnRegression, "fail-on-regression", false, "Exit with code 3 if regression detected")
 
 	// Scoring transparency
 	cmd.Flags().BoolVar(&flagExplain, "explain", false, "Show detailed score breakdown and improvement tips")
+	cmd.Flags().BoolVar(&flagExplainSummary, "explain-summary", false, "Show which score categories cost the most points across all repositories")
+	cmd.Flags().BoolVar(&flagOnlyFindings, "only-findings", false, "Show only findings, hiding metrics and repositories without findings")
+	cmd.Flags().StringVar(&flagMinSeverity, "min-severity", "", "Hide findings below this severity: info, low, medium, high")
+	_ = cmd.RegisterFlagCompletionFunc("min-severity", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"info", "low", "medium", "high"}, cobra.ShellCompDirectiveNoFileComp
+	})
 
 	// Output mode (how findings are presented)
 	cmd.Flags().StringVar(&flagOutputMode, "output-mode", "observational", "Output mode: suggestive (prescriptive advice), observational (neutral facts, default), statistical (numbers only)")
@@ -181,15 +306,18 @@ func registerAnalysisFlags(cmd *cobra.Command) {
 
 	// Caching
 	cmd.Flags().BoolVar(&flagNoCache, "no-cache", false, "Disable API response caching (forces fresh API calls)")
+	cmd.Flags().BoolVar(&flagNoRepoConfig, "no-repo-config", false, "Ignore .gh-inspect.yml overrides committed in analyzed repositories")
 }
 
-// registerFilterFlags adds repository filtering flags (for org and user commands)
+// registerFilterFlags adds repository filtering flags (for org and user commands, and run --repos-from-org)
 func registerFilterFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&flagFilterName, "filter-name", "", "Filter repositories by name (regex pattern)")
 	cmd.Flags().StringSliceVar(&flagFilterLanguage, "filter-language", nil, "Filter by primary language (comma-separated: go,python,javascript)")
 	cmd.Flags().StringSliceVar(&flagFilterTopics, "filter-topics", nil, "Filter by topics/tags (comma-separated)")
 	cmd.Flags().StringVar(&flagFilterUpdated, "filter-updated", "", "Filter by last update (e.g., 30d, 90d, 180d)")
 	cmd.Flags().BoolVar(&flagFilterSkipForks, "filter-skip-forks", false, "Skip forked repositories")
+	cmd.Flags().BoolVar(&flagIncludeArchived, "include-archived", false, "Analyze archived repositories too (skipped by default)")
+	cmd.Flags().StringArrayVar(&flagExcludeRepos, "exclude-repos", nil, "Skip repositories whose owner/repo name matches (repeatable; comma-separated names or regular expressions, e.g. my-org/playground,my-org/sandbox-.*)")
 }
 
 // shouldPrintInfo returns true if informational messages should be printed (not in quiet mode)
@@ -197,6 +325,14 @@ func shouldPrintInfo() bool {
 	return !flagQuiet
 }
 
+// quietForLineFormats implies --quiet for --format=score, and for --format=ndjson and
+// --format=badge on stdout, so informational messages don't end up between the output lines
+func quietForLineFormats() {
+	if flagFormat == "score" || ((flagFormat == "ndjson" || flagFormat == "badge") && flagOutput == "") {
+		flagQuiet = true
+	}
+}
+
 // shouldPrintVerbose returns true if verbose messages should be printed
 func shouldPrintVerbose() bool {
 	return flagVerbose && !flagQuiet
@@ -211,13 +347,79 @@ func Execute() {
 	}
 }
 
+// resolveConfigPath returns the --config override if set, otherwise the default config path
+func resolveConfigPath() (string, error) {
+	if flagConfigFile != "" {
+		return flagConfigFile, nil
+	}
+	return config.GetConfigPath()
+}
+
+// loadConfig loads the configuration, honoring the --config override
+func loadConfig() (*config.Config, error) {
+	return config.LoadFrom(flagConfigFile)
+}
+
+// applyProxyConfig routes API and update requests through global.proxy_url when it is set.
+// Other config errors are left for the command itself to report.
+func applyProxyConfig() {
+	cfg, err := loadConfig()
+	if err != nil || cfg.Global.ProxyURL == "" {
+		return
+	}
+	if err := ghclient.SetProxyURL(cfg.Global.ProxyURL); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Ignoring global.proxy_url: %v\n", err)
+		return
+	}
+	httpClient = ghclient.NewHTTPClient(httpClient.Timeout)
+}
+
+// applyLogging enables diagnostic logs from --log-level and --log-json.
+// An invalid --log-level is fatal.
+func applyLogging() {
+	if err := logging.Configure(flagLogLevel, flagLogJSON); err != nil {
+		fmt.Printf("Error: --log-level: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// applyAPIURL points API clients at --api-url, or at the api_url of the active context
+// (global.api_url by default) when the flag is not given. An invalid --api-url is fatal;
+// an invalid configured URL is ignored with a warning.
+func applyAPIURL() {
+	if flagAPIURL != "" {
+		if err := ghclient.SetAPIURL(flagAPIURL); err != nil {
+			fmt.Printf("Error: --api-url: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return
+	}
+	// An unknown context is reported by the command when it resolves the token
+	contextCfg, err := contextConfig(cfg)
+	if err != nil || contextCfg.APIURL == "" {
+		return
+	}
+	if err := ghclient.SetAPIURL(contextCfg.APIURL); err != nil {
+		field := "global.api_url"
+		if name := activeContext(); name != "" {
+			field = "contexts." + name + ".api_url"
+		}
+		fmt.Fprintf(os.Stderr, "⚠️  Ignoring %s: %v\n", field, err)
+	}
+}
+
 func checkAndInitConfig(cmd *cobra.Command, args []string) {
-	// Skip for init, config, help, completion, and the new auth command
-	if cmd == initCmd || cmd == configCmd || cmd == authCmd || cmd.Name() == "help" || cmd.Name() == "completion" || cmd.Name() == "__complete" {
+	// Skip for init, config, help, completion (including history), and the new auth command
+	if cmd == initCmd || cmd == configCmd || cmd == configValidateCmd || cmd == configShowCmd || cmd == authCmd || cmd.Name() == "help" || cmd.Name() == "completion" || cmd.Name() == "__complete" ||
+		cmd.HasParent() && cmd.Parent() == completionHistoryCmd {
 		return
 	}
 
-	configPath, err := config.GetConfigPath()
+	configPath, err := resolveConfigPath()
 	if err != nil {
 		// Can't resolve path, probably can't save either. Ignore.
 		return
@@ -244,20 +446,86 @@ func init() {
 	// Add global flags
 	rootCmd.PersistentFlags().BoolVarP(&flagQuiet, "quiet", "q", false, "Suppress non-essential output")
 	rootCmd.PersistentFlags().BoolVarP(&flagVerbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().BoolVar(&flagNoColor, "no-color", false, "Disable color and emoji in output (automatic when stdout is not a terminal)")
+	rootCmd.PersistentFlags().StringVar(&flagConfigFile, "config", "", "Path to an alternate config file (overrides the default location)")
+	rootCmd.PersistentFlags().BoolVar(&flagRevalidate, "revalidate", false, "Check the GitHub token again instead of reusing a check from the last few minutes")
+	rootCmd.PersistentFlags().StringVar(&flagLogLevel, "log-level", "", "Write diagnostic logs to stderr at this level and above: debug, info, warn, error (default: off)")
+	_ = rootCmd.RegisterFlagCompletionFunc("log-level", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return logging.Levels, cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.PersistentFlags().BoolVar(&flagLogJSON, "log-json", false, "Write diagnostic logs as JSON lines (at info level unless --log-level is set)")
+	rootCmd.PersistentFlags().StringVar(&flagAPIURL, "api-url", "", "GitHub Enterprise Server API URL for this run, e.g. https://ghe.example.com/api/v3 (overrides global.api_url)")
+	rootCmd.PersistentFlags().StringVar(&flagContext, "context", "", "Use the token and API URL of this named context from the config file (env: GH_INSPECT_CONTEXT)")
+	_ = rootCmd.RegisterFlagCompletionFunc("context", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return contextNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+	_ = rootCmd.MarkPersistentFlagFilename("config", "yaml", "yml")
 
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(compareCmd)
 	registerAnalysisFlags(runCmd)
+	runCmd.Flags().StringVar(&flagReposFile, "repos-file", "", "Read newline-delimited owner/repo entries from a file (# comments allowed)")
+	runCmd.Flags().BoolVar(&flagReposStdin, "repos-stdin", false, "Read newline-delimited owner/repo entries from stdin (automatic when no repos are given and stdin is a pipe)")
+	runCmd.Flags().StringVar(&flagReposFromOrg, "repos-from-org", "", "Also analyze every active repository in this organization (narrowed by the --filter-* flags)")
+	_ = runCmd.RegisterFlagCompletionFunc("repos-from-org", completeOrganizations)
+	registerFilterFlags(runCmd)
+	runCmd.Flags().StringVarP(&flagOutput, "output", "o", "", "Write the report to a file instead of stdout (parent directories are created)")
+	runCmd.Flags().StringVar(&flagComparisonOutput, "comparison-output", "", "Write the baseline comparison as JSON to a file (with --compare-last, --baseline or --compare-branch)")
+	runCmd.Flags().StringVar(&flagCompareBranch, "compare-branch", "", "Also analyze this branch or ref and compare the --ref analysis against it, like a baseline")
+	runCmd.Flags().DurationVar(&flagWatch, "watch", 0, "Re-run the analysis every interval (e.g. 5m) until interrupted, highlighting changes")
 }
 
 func runAnalysis(cmd *cobra.Command, args []string) {
+	quietForLineFormats()
+	repos := mergeRepos(args)
+	if flagReposFile != "" {
+		fileRepos, err := readReposFile(flagReposFile)
+		if err != nil {
+			fmt.Printf("Error reading repos file: %v\n", err)
+			os.Exit(1)
+		}
+		repos = mergeRepos(repos, fileRepos)
+		if len(repos) == 0 {
+			fmt.Printf("Error: no repositories found in %s\n", flagReposFile)
+			os.Exit(1)
+		}
+	}
+	if useReposStdin(args) {
+		stdinRepos, err := readReposStdin(os.Stdin)
+		if err != nil {
+			fmt.Printf("Error reading repositories from stdin: %v\n", err)
+			os.Exit(1)
+		}
+		repos = mergeRepos(repos, stdinRepos)
+		if len(repos) == 0 {
+			fmt.Println("Error: no repositories found on stdin")
+			os.Exit(1)
+		}
+	}
+	if flagReposFromOrg != "" {
+		if shouldPrintInfo() {
+			fmt.Printf("Fetching repositories for organization '%s'...\n", flagReposFromOrg)
+		}
+		orgRepos, err := expandOrgRepos(flagReposFromOrg)
+		if err != nil {
+			fmt.Printf("Error listing repositories: %v\n", err)
+			os.Exit(1)
+		}
+		recordUsage(flagReposFromOrg, "org")
+		repos = mergeRepos(repos, orgRepos)
+		if len(repos) == 0 {
+			fmt.Printf("Error: no repositories to analyze in organization '%s'\n", flagReposFromOrg)
+			os.Exit(1)
+		}
+	}
+
 	// Record repository usage for completions
-	for _, repo := range args {
+	for _, repo := range repos {
 		recordUsage(repo, "repo")
 	}
 
 	// Load config to get output mode preference
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Printf("Error loading config: %v\n", err)
 		os.Exit(1)
@@ -274,8 +542,12 @@ func runAnalysis(cmd *cobra.Command, args []string) {
 	}
 
 	opts := AnalysisOptions{
-		Repos:           args,
+		Repos:           repos,
 		Since:           flagSince,
+		SinceDate:       flagSinceDate,
+		Ref:             flagRef,
+		QuietErrors:     flagQuietErrors,
+		NoRepoConfig:    flagNoRepoConfig,
 		Depth:           flagDepth,
 		MaxPRs:          flagMaxPRs,
 		MaxIssues:       flagMaxIssues,
@@ -283,6 +555,52 @@ func runAnalysis(cmd *cobra.Command, args []string) {
 		Include:         flagInclude,
 		Exclude:         flagExclude,
 		OutputMode:      resolvedOutputMode,
+		AnalyzerTimeout: flagAnalyzerTimeout,
+		Timeout:         flagTimeout,
+	}
+
+	if flagDryRun {
+		if err := printDryRun(os.Stdout, opts); err != nil {
+			fmt.Printf("Error planning analysis: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Parse output mode from the already-resolved value (respects flag > config > default)
+	outputMode := models.OutputModeObservational // default
+	switch resolvedOutputMode {
+	case "suggestive":
+		outputMode = models.OutputModeSuggestive
+	case "observational", "":
+		outputMode = models.OutputModeObservational
+	case "statistical":
+		outputMode = models.OutputModeStatistical
+	}
+
+	weights, thresholds := scoringWeightsFromConfig(cfg.Scoring), insightThresholdsFromConfig(cfg.Insights)
+	renderOpts := report.RenderOptions{
+		ShowExplanation:   flagExplain,
+		ExplainSummary:    flagExplainSummary,
+		OutputMode:        outputMode,
+		OnlyFindings:      flagOnlyFindings,
+		MinSeverity:       models.Severity(flagMinSeverity),
+		CompactJSON:       flagCompact,
+		ShowTimings:       shouldPrintVerbose(),
+		MarkdownNoEmoji:   flagMarkdownNoEmoji,
+		ScoringWeights:    &weights,
+		InsightThresholds: &thresholds,
+	}
+
+	if flagWatch > 0 {
+		renderOpts.NoColor = !colorEnabled(os.Stdout)
+		watchAnalysis(opts, renderOpts, flagWatch)
+		return
+	}
+
+	if flagFormat == "ndjson" {
+		runStreamingAnalysis(opts, renderOpts)
+		return
 	}
 
 	fullReport, err := pipelineRunner(opts)
@@ -291,9 +609,19 @@ func runAnalysis(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Handle baseline comparison if requested
+	// Handle baseline or branch comparison if requested
 	var comparison *baseline.ComparisonResult
-	if flagCompareLast || flagBaseline != "" {
+	var compareAgainst *baseline.Baseline
+	comparedWith := "baseline"
+	if flagCompareBranch != "" {
+		branchBaseline, err := analyzeBranch(opts, flagCompareBranch, fullReport, cfg)
+		if err != nil {
+			fmt.Printf("Error analyzing %s for comparison: %v\n", flagCompareBranch, err)
+			os.Exit(1)
+		}
+		compareAgainst = branchBaseline
+		comparedWith = flagCompareBranch
+	} else if flagCompareLast || flagBaseline != "" {
 		baselinePath := flagBaseline
 		if baselinePath == "" {
 			baselinePath = baseline.GetDefaultBaselinePath()
@@ -305,15 +633,35 @@ func runAnalysis(cmd *cobra.Command, args []string) {
 				fmt.Printf("⚠️  Could not load baseline for comparison: %v\n", err)
 			}
 		} else {
-			comparison = baseline.Compare(fullReport, previousBaseline)
-			if shouldPrintInfo() {
-				printComparison(comparison)
+			printBaselineWarnings(baselinePath, previousBaseline)
+			compareAgainst = previousBaseline
+		}
+	}
+	// Trends are rendered from an annotated copy so the saved baseline stays clean
+	renderedReport := fullReport
+	if compareAgainst != nil {
+		comparison = baseline.Compare(fullReport, compareAgainst)
+		renderedReport = baseline.WithTrends(fullReport, compareAgainst)
+	}
+	if comparison != nil {
+		// Keep the text version out of JSON on stdout
+		jsonOnStdout := flagFormat == "json" && flagOutput == ""
+		if shouldPrintInfo() && !jsonOnStdout {
+			printComparison(comparison, flagCompareBranch)
+		}
+		if flagComparisonOutput != "" {
+			if err := writeComparisonFile(flagComparisonOutput, comparison, flagCompact); err != nil {
+				fmt.Printf("⚠️  Failed to write comparison: %v\n", err)
+			} else if shouldPrintInfo() && !jsonOnStdout {
+				fmt.Printf("\n✅ Comparison written to %s\n", flagComparisonOutput)
 			}
+		}
 
-			if flagFailOnRegression && comparison != nil && comparison.Summary.HasRegression {
-				fmt.Printf("\n❌ Failure: Regression detected compared to baseline.\n")
-				os.Exit(1)
-			}
+		if flagFailOnRegression && comparison.Summary.HasRegression {
+			fmt.Printf("\n❌ Failure: Regression detected compared to %s.\n", comparedWith)
+			exitWithFailure(exitRegression, "regression_detected",
+				failField("health_delta", comparison.Summary.HealthScoreDelta),
+				fmt.Sprintf("degraded_metrics=%d", comparison.Summary.TotalDegradedMetrics))
 		}
 	}
 
@@ -325,55 +673,96 @@ func runAnalysis(cmd *cobra.Command, args []string) {
 		} else if shouldPrintInfo() {
 			fmt.Printf("\n✅ Baseline saved to %s\n", baselinePath)
 		}
-	}
 
-	// 4. Render Output
-	var renderer report.Renderer
-	switch flagFormat {
-	case "json":
-		renderer = &report.JSONRenderer{}
-	case "markdown":
-		renderer = &report.MarkdownRenderer{}
-	default:
-		renderer = &report.TextRenderer{}
+		if cfg.Global.BaselineHistory > 0 {
+			if _, err := baseline.SaveToHistory(fullReport, baseline.GetDefaultHistoryDir(), cfg.Global.BaselineHistory); err != nil {
+				fmt.Printf("⚠️  Failed to record baseline history: %v\n", err)
+			}
+		}
 	}
 
-	// Parse output mode from the already-resolved value (respects flag > config > default)
-	outputMode := models.OutputModeObservational // default
-	switch resolvedOutputMode {
-	case "suggestive":
-		outputMode = models.OutputModeSuggestive
-	case "observational", "":
-		outputMode = models.OutputModeObservational
-	case "statistical":
-		outputMode = models.OutputModeStatistical
-	}
+	// 4. Render Output
+	renderer := report.NewRenderer(report.Format(flagFormat))
 
-	renderOpts := report.RenderOptions{
-		ShowExplanation: flagExplain,
-		OutputMode:      outputMode,
+	out, closeOut, err := openReportOutput(flagOutput)
+	if err != nil {
+		fmt.Printf("Error writing report: %v\n", err)
+		os.Exit(1)
 	}
-
-	if err := renderer.RenderWithOptions(fullReport, os.Stdout, renderOpts); err != nil {
+	renderOpts.NoColor = !colorEnabled(out)
+	if err := renderer.RenderWithOptions(renderedReport, out, renderOpts); err != nil {
 		fmt.Printf("Error rendering report: %v\n", err)
 	}
+	if err := closeOut(); err != nil {
+		fmt.Printf("Error writing report: %v\n", err)
+		os.Exit(1)
+	}
+	if flagOutput != "" && shouldPrintInfo() {
+		fmt.Printf("\n✅ Report written to %s\n", flagOutput)
+	}
 
 	// Write to GitHub Actions Step Summary if running in GitHub Actions
 	if githubStepSummary := os.Getenv("GITHUB_STEP_SUMMARY"); githubStepSummary != "" && flagFormat == "markdown" {
 		f, err := os.OpenFile(githubStepSummary, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 		if err == nil {
 			defer func() { _ = f.Close() }()
-			_ = renderer.RenderWithOptions(fullReport, f, renderOpts)
+			// The step summary is rendered by GitHub, so keep emoji unless explicitly disabled
+			summaryOpts := renderOpts
+			summaryOpts.NoColor = flagNoColor
+			_ = renderer.RenderWithOptions(renderedReport, f, summaryOpts)
 			if shouldPrintInfo() {
 				fmt.Println("\n✅ Results written to GitHub Actions step summary")
 			}
 		}
 	}
 
+	exitOnRunFailures(fullReport)
+}
+
+// runStreamingAnalysis writes repositories as NDJSON while the analysis runs, for scans
+// too large to hold the whole report in memory
+func runStreamingAnalysis(opts AnalysisOptions, renderOpts report.RenderOptions) {
+	out, closeOut, err := openReportOutput(flagOutput)
+	if err != nil {
+		fmt.Printf("Error writing report: %v\n", err)
+		os.Exit(1)
+	}
+	fullReport, err := streamAnalysis(opts, out, renderOpts)
+	if closeErr := closeOut(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write report: %w", closeErr)
+	}
+	if err != nil {
+		fmt.Printf("Error running analysis: %v\n", err)
+		os.Exit(1)
+	}
+	if flagOutput != "" && shouldPrintInfo() {
+		fmt.Printf("\n✅ Report written to %s\n", flagOutput)
+	}
+
+	exitOnRunFailures(fullReport)
+}
+
+// exitOnRunFailures applies the --fail-under and --fail-on-finding-type checks, and fails
+// a gated run whose analysis was incomplete
+func exitOnRunFailures(fullReport *models.Report) {
 	// Exit Code Check for health score
 	if flagFail > 0 && fullReport.Summary.AvgHealthScore < float64(flagFail) {
+		if shouldPrintInfo() {
+			fmt.Printf("\n❌ Failure: Health score is below the --fail-under threshold.\n")
+		}
+		exitWithFailure(exitHealthBelowThreshold, "health_below_threshold",
+			failField("score", fullReport.Summary.AvgHealthScore), fmt.Sprintf("threshold=%d", flagFail))
+	}
 
-		fmt.Printf("\n❌ Failure: Health score is below the --fail-under threshold.\n")
-		os.Exit(1)
+	failOnFindingTypes(fullReport)
+
+	// When gating CI, a partial analysis shouldn't pass silently
+	if flagFail > 0 || flagFailOnRegression || len(flagFailOnFindingType) > 0 {
+		if n := countAnalyzerErrors(fullReport); n > 0 {
+			if shouldPrintInfo() {
+				fmt.Printf("\n❌ Failure: %d analyzer(s) failed or timed out.\n", n)
+			}
+			exitWithFailure(exitAnalyzerErrors, "analyzer_errors", fmt.Sprintf("count=%d", n))
+		}
 	}
 }
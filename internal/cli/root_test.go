@@ -2,10 +2,14 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
+	"github.com/mikematt33/gh-inspect/pkg/baseline"
 	"github.com/mikematt33/gh-inspect/pkg/models"
 )
 
@@ -56,3 +60,149 @@ func TestRunCmd(t *testing.T) {
 
 	_ = output // Use the output variable to avoid unused variable error
 }
+
+func TestFormatFailLine(t *testing.T) {
+	got := formatFailLine("health_below_threshold", failField("score", 72), "threshold=80")
+	want := "FAIL: reason=health_below_threshold score=72 threshold=80"
+	if got != want {
+		t.Errorf("formatFailLine() = %q, want %q", got, want)
+	}
+
+	if got := failField("score", 79.64); got != "score=79.6" {
+		t.Errorf("failField() = %q, want score=79.6", got)
+	}
+}
+
+func TestCountAnalyzerErrors(t *testing.T) {
+	report := &models.Report{
+		Repositories: []models.RepoResult{{
+			Analyzers: []models.AnalyzerResult{
+				{Name: "ci", Findings: []models.Finding{{Type: "analyzer_error"}}},
+				{Name: "issue-hygiene", Findings: []models.Finding{{Type: "analyzer_timeout"}, {Type: "zombie_issue"}}},
+			},
+		}},
+	}
+	if got := countAnalyzerErrors(report); got != 2 {
+		t.Errorf("countAnalyzerErrors() = %d, want 2", got)
+	}
+}
+
+func TestRunComparisonOutput(t *testing.T) {
+	originalPipelineRunner := pipelineRunner
+	defer func() {
+		pipelineRunner = originalPipelineRunner
+		flagBaseline, flagComparisonOutput, flagQuiet = "", "", false
+		rootCmd.SetArgs(nil)
+	}()
+	t.Setenv("HOME", t.TempDir())
+
+	report := func(score float64) *models.Report {
+		return &models.Report{Repositories: []models.RepoResult{{
+			Name:      "owner/repo",
+			Analyzers: []models.AnalyzerResult{{Name: "repo-health", Metrics: []models.Metric{{Key: "health_score", Value: score}}}},
+		}}}
+	}
+	dir := t.TempDir()
+	baselinePath := filepath.Join(dir, "baseline.json")
+	if err := baseline.Save(report(60), baselinePath); err != nil {
+		t.Fatalf("Failed to save baseline: %v", err)
+	}
+	pipelineRunner = func(opts AnalysisOptions) (*models.Report, error) { return report(80), nil }
+
+	comparisonPath := filepath.Join(dir, "out", "delta.json")
+	flagFormat, flagFail = "text", 0
+	rootCmd.SetArgs([]string{"run", "owner/repo", "--quiet", "--baseline=" + baselinePath, "--comparison-output=" + comparisonPath})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	data, err := os.ReadFile(comparisonPath)
+	if err != nil {
+		t.Fatalf("Comparison file not written: %v", err)
+	}
+	var comp baseline.ComparisonResult
+	if err := json.Unmarshal(data, &comp); err != nil {
+		t.Fatalf("Comparison file is not valid JSON: %v", err)
+	}
+	if len(comp.Deltas) != 1 || comp.Deltas[0].RepoName != "owner/repo" {
+		t.Errorf("Unexpected comparison deltas: %+v", comp.Deltas)
+	}
+}
+
+func TestRunJSONWithBaselineRendersReport(t *testing.T) {
+	originalPipelineRunner := pipelineRunner
+	defer func() {
+		pipelineRunner = originalPipelineRunner
+		flagBaseline, flagOutput, flagFormat, flagQuiet = "", "", "text", false
+		rootCmd.SetArgs(nil)
+	}()
+	t.Setenv("HOME", t.TempDir())
+
+	report := func(score float64) *models.Report {
+		return &models.Report{Repositories: []models.RepoResult{{
+			Name:      "owner/repo",
+			Analyzers: []models.AnalyzerResult{{Name: "repo-health", Metrics: []models.Metric{{Key: "health_score", Value: score}}}},
+		}}}
+	}
+	dir := t.TempDir()
+	baselinePath := filepath.Join(dir, "baseline.json")
+	if err := baseline.Save(report(60), baselinePath); err != nil {
+		t.Fatalf("Failed to save baseline: %v", err)
+	}
+	pipelineRunner = func(opts AnalysisOptions) (*models.Report, error) { return report(80), nil }
+
+	reportPath := filepath.Join(dir, "report.json")
+	flagFail = 0
+	rootCmd.SetArgs([]string{"run", "owner/repo", "--quiet", "--format=json", "--output=" + reportPath, "--baseline=" + baselinePath})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("Report not written: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Report is not valid JSON: %v", err)
+	}
+	if _, ok := fields["repositories"]; !ok {
+		t.Errorf("Expected the report itself, got keys %v", reflect.ValueOf(fields).MapKeys())
+	}
+	if _, ok := fields["deltas"]; ok {
+		t.Error("Expected the comparison to stay out of the JSON report")
+	}
+}
+
+func TestComparisonOutputRequiresBaseline(t *testing.T) {
+	defer func() { flagComparisonOutput = "" }()
+	flagComparisonOutput = "delta.json"
+	flagCompareLast, flagBaseline = false, ""
+	if err := runCmd.Args(runCmd, []string{"owner/repo"}); err == nil {
+		t.Error("Expected an error for --comparison-output without a baseline")
+	}
+}
+
+func TestMatchFindingTypes(t *testing.T) {
+	report := &models.Report{
+		Repositories: []models.RepoResult{
+			{Name: "owner/a", Analyzers: []models.AnalyzerResult{
+				{Name: "branches", Findings: []models.Finding{{Type: "no_branch_protection"}}},
+				{Name: "ci", Findings: []models.Finding{{Type: "ci_failure"}, {Type: "ci_failure"}}},
+			}},
+			{Name: "owner/b", Analyzers: []models.AnalyzerResult{
+				{Name: "ci", Findings: []models.Finding{{Type: "flaky_workflow"}}},
+			}},
+		},
+	}
+
+	got := matchFindingTypes(report, []string{"ci_failure", "no_branch_protection"})
+	want := []findingTypeMatch{{"owner/a", "no_branch_protection"}, {"owner/a", "ci_failure"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("matchFindingTypes() = %v, want %v", got, want)
+	}
+
+	if got := matchFindingTypes(report, []string{"zombie_issue"}); len(got) != 0 {
+		t.Errorf("Expected no matches, got %v", got)
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mikematt33/gh-inspect/internal/report"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// validateNDJSONFlags rejects --format=ndjson combinations that need the full report in memory
+func validateNDJSONFlags() error {
+	conflicts := []struct {
+		flag string
+		set  bool
+	}{
+		{"--compare-last", flagCompareLast},
+		{"--baseline", flagBaseline != ""},
+		{"--save-baseline", flagSaveBaseline},
+		{"--fail-on-regression", flagFailOnRegression},
+	}
+	for _, c := range conflicts {
+		if c.set {
+			return fmt.Errorf("--format=ndjson cannot be combined with %s", c.flag)
+		}
+	}
+	return nil
+}
+
+// streamAnalysis runs the analysis writing each repository to out as NDJSON as soon as
+// it completes, followed by the summary trailer. The returned report has the full
+// summary but keeps only the repositories the exit code checks still need: those with
+// analyzer errors or a finding type listed in --fail-on-finding-type.
+func streamAnalysis(opts AnalysisOptions, out io.Writer, renderOpts report.RenderOptions) (*models.Report, error) {
+	stream := report.NewNDJSONStream(out, renderOpts)
+
+	var kept []models.RepoResult
+	var writeErr error
+	// Called from a single collector goroutine, so no locking is needed
+	opts.Stream = func(r models.RepoResult) {
+		if writeErr == nil {
+			writeErr = stream.WriteRepo(r)
+		}
+		single := &models.Report{Repositories: []models.RepoResult{r}}
+		if countAnalyzerErrors(single) > 0 || len(matchFindingTypes(single, flagFailOnFindingType)) > 0 {
+			kept = append(kept, r)
+		}
+	}
+
+	fullReport, err := pipelineRunner(opts)
+	if err != nil {
+		return nil, err
+	}
+	if writeErr != nil {
+		return nil, fmt.Errorf("failed to write report: %w", writeErr)
+	}
+	if err := stream.WriteSummary(fullReport); err != nil {
+		return nil, fmt.Errorf("failed to write report: %w", err)
+	}
+
+	fullReport.Repositories = kept
+	return fullReport, nil
+}
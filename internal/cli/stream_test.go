@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mikematt33/gh-inspect/internal/config"
+	"github.com/mikematt33/gh-inspect/internal/report"
+	"github.com/mikematt33/gh-inspect/pkg/insights"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+func TestStreamAnalysis(t *testing.T) {
+	originalPipelineRunner := pipelineRunner
+	defer func() {
+		pipelineRunner = originalPipelineRunner
+		flagFailOnFindingType = nil
+	}()
+	flagFailOnFindingType = []string{"ci_failing"}
+
+	repos := []models.RepoResult{
+		{Name: "owner/clean"},
+		{Name: "owner/failing", Analyzers: []models.AnalyzerResult{{Name: "ci", Findings: []models.Finding{{Type: "ci_failing"}}}}},
+		{Name: "owner/broken", Analyzers: []models.AnalyzerResult{{Name: "pr-flow", Findings: []models.Finding{{Type: "analyzer_error"}}}}},
+	}
+	pipelineRunner = func(opts AnalysisOptions) (*models.Report, error) {
+		if opts.Stream == nil {
+			t.Fatal("Expected a stream callback")
+		}
+		totals := newSummaryTotals(config.GlobalConfig{}, insights.DefaultScoringWeights())
+		for _, r := range repos {
+			totals.add(r)
+			opts.Stream(r)
+		}
+		return &models.Report{Summary: totals.finish()}, nil
+	}
+
+	var buf bytes.Buffer
+	got, err := streamAnalysis(AnalysisOptions{}, &buf, report.RenderOptions{})
+	if err != nil {
+		t.Fatalf("streamAnalysis failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || !strings.Contains(lines[3], `"type":"summary"`) {
+		t.Fatalf("Expected three repository lines and a summary trailer, got:\n%s", buf.String())
+	}
+	if got.Summary.TotalReposAnalyzed != 3 || got.Summary.IssuesFound != 2 {
+		t.Errorf("Unexpected summary: %+v", got.Summary)
+	}
+	if len(got.Repositories) != 2 || got.Repositories[0].Name != "owner/failing" || got.Repositories[1].Name != "owner/broken" {
+		t.Errorf("Expected only the repositories needed for exit code checks, got %+v", got.Repositories)
+	}
+}
+
+func TestValidateNDJSONFlags(t *testing.T) {
+	defer func() { flagBaseline = "" }()
+
+	if err := validateNDJSONFlags(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	flagBaseline = "baseline.json"
+	if err := validateNDJSONFlags(); err == nil || !strings.Contains(err.Error(), "--baseline") {
+		t.Errorf("Expected a --baseline conflict, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// tokenValidationTTL is how long a successful token check is trusted before
+// `auth status` asks GitHub again
+const tokenValidationTTL = 5 * time.Minute
+
+// tokenValidation records the last successful check of a token. Only a hash of the
+// token is stored, so a different token never matches and is always checked. Rate
+// limits are never cached: they change with every request and are always fetched live.
+type tokenValidation struct {
+	TokenHash   string    `json:"token_hash"`
+	ValidatedAt time.Time `json:"validated_at"`
+}
+
+// tokenCacheNow is a variable to allow mocking in tests
+var tokenCacheNow = time.Now
+
+// getTokenCachePath returns the path of the validation record, next to the config file
+// (honoring --config)
+func getTokenCachePath() (string, error) {
+	configPath, err := resolveConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "token-validation.json"), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadTokenValidation returns the record for token if it is younger than tokenValidationTTL
+func loadTokenValidation(token string) *tokenValidation {
+	path, err := getTokenCachePath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var v tokenValidation
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil
+	}
+	age := tokenCacheNow().Sub(v.ValidatedAt)
+	if v.TokenHash != hashToken(token) || age < 0 || age > tokenValidationTTL {
+		return nil
+	}
+	return &v
+}
+
+// saveTokenValidation writes the record; failures only cost a fresh check next time
+func saveTokenValidation(v *tokenValidation) {
+	path, err := getTokenCachePath()
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0600)
+}
+
+// clearTokenValidation forgets the last successful check
+func clearTokenValidation() {
+	if path, err := getTokenCachePath(); err == nil {
+		_ = os.Remove(path)
+	}
+}
+
+// recentTokenValidation returns when token was last checked successfully, unless that
+// was longer than tokenValidationTTL ago or --revalidate is set
+func recentTokenValidation(token string) (time.Time, bool) {
+	if flagRevalidate {
+		return time.Time{}, false
+	}
+	v := loadTokenValidation(token)
+	if v == nil {
+		return time.Time{}, false
+	}
+	return v.ValidatedAt, true
+}
+
+// checkTokenRateLimit validates token by fetching its live rate limit with fetch. A
+// successful check is recorded for recentTokenValidation; a failed one clears the record.
+func checkTokenRateLimit(ctx context.Context, token string, fetch func(context.Context) (*github.Rate, error)) (*github.Rate, error) {
+	limits, err := fetch(ctx)
+	if err != nil {
+		clearTokenValidation()
+		return nil, err
+	}
+	saveTokenValidation(&tokenValidation{TokenHash: hashToken(token), ValidatedAt: tokenCacheNow()})
+	return limits, nil
+}
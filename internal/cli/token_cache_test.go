@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+func TestCheckTokenRateLimit(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	originalNow := tokenCacheNow
+	defer func() { tokenCacheNow = originalNow; flagRevalidate = false }()
+	current := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tokenCacheNow = func() time.Time { return current }
+
+	calls := 0
+	remaining := 4000
+	fetch := func(context.Context) (*github.Rate, error) {
+		calls++
+		return &github.Rate{Limit: 5000, Remaining: remaining}, nil
+	}
+
+	if _, ok := recentTokenValidation("token-a"); ok {
+		t.Fatal("Expected no validation before the first check")
+	}
+	if _, err := checkTokenRateLimit(context.Background(), "token-a", fetch); err != nil || calls != 1 {
+		t.Fatalf("Expected a fresh check, got err=%v calls=%d", err, calls)
+	}
+	if checkedAt, ok := recentTokenValidation("token-a"); !ok || !checkedAt.Equal(current) {
+		t.Errorf("Expected the check from %v to be remembered, got %v (ok=%v)", current, checkedAt, ok)
+	}
+
+	// Rate limits are always fetched live, never served from the record
+	remaining = 3500
+	limits, err := checkTokenRateLimit(context.Background(), "token-a", fetch)
+	if err != nil || calls != 2 || limits.Remaining != 3500 {
+		t.Errorf("Expected live limits, got %+v (err=%v, calls=%d)", limits, err, calls)
+	}
+
+	// A different token is never served from the record
+	if _, ok := recentTokenValidation("token-b"); ok {
+		t.Error("Expected a changed token to need a fresh check")
+	}
+
+	flagRevalidate = true
+	if _, ok := recentTokenValidation("token-a"); ok {
+		t.Error("Expected --revalidate to ignore the recorded check")
+	}
+	flagRevalidate = false
+
+	current = current.Add(tokenValidationTTL + time.Second)
+	if _, ok := recentTokenValidation("token-a"); ok {
+		t.Error("Expected an expired check to need a fresh one")
+	}
+
+	// A failed check forgets the previous success
+	_, _ = checkTokenRateLimit(context.Background(), "token-b", fetch)
+	failing := func(context.Context) (*github.Rate, error) { return nil, errors.New("401 Bad credentials") }
+	if _, err := checkTokenRateLimit(context.Background(), "token-b", failing); err == nil {
+		t.Error("Expected the failed check to return its error")
+	}
+	if _, ok := recentTokenValidation("token-b"); ok {
+		t.Error("Expected a failed check to clear the recorded validation")
+	}
+
+	_, _ = checkTokenRateLimit(context.Background(), "token-b", fetch)
+	clearTokenValidation()
+	if _, ok := recentTokenValidation("token-b"); ok {
+		t.Error("Expected clearTokenValidation to remove the record")
+	}
+}
+
+func TestTokenCachePathFollowsConfigFlag(t *testing.T) {
+	dir := t.TempDir()
+	originalConfig := flagConfigFile
+	defer func() { flagConfigFile = originalConfig }()
+	flagConfigFile = filepath.Join(dir, "team.yaml")
+
+	path, err := getTokenCachePath()
+	if err != nil {
+		t.Fatalf("getTokenCachePath failed: %v", err)
+	}
+	if want := filepath.Join(dir, "token-validation.json"); path != want {
+		t.Errorf("Expected %s next to --config, got %s", want, path)
+	}
+}
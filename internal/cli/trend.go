@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mikematt33/gh-inspect/pkg/baseline"
+	"github.com/mikematt33/gh-inspect/pkg/insights"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagTrendLast int
+	flagTrendDir  string
+)
+
+var trendCmd = &cobra.Command{
+	Use:   "trend [owner/repo...]",
+	Short: "Show how repository health changed across saved baselines",
+	Long: `Show health score, CI success rate and zombie issue counts over the last N baselines
+recorded by --save-baseline. Pass repositories to limit the output; by default every
+repository found in the history is shown. No GitHub API calls are made.`,
+	Example: `  gh-inspect trend
+  gh-inspect trend owner/repo --last 20
+  gh-inspect trend --format=json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if flagFormat != "" && flagFormat != "text" && flagFormat != "json" {
+			return fmt.Errorf("invalid format: %s (must be text or json)", flagFormat)
+		}
+		if flagTrendLast < 1 {
+			return fmt.Errorf("--last must be at least 1")
+		}
+		return nil
+	},
+	Run: runTrend,
+}
+
+func init() {
+	rootCmd.AddCommand(trendCmd)
+	trendCmd.Flags().IntVarP(&flagTrendLast, "last", "n", 10, "Number of most recent baselines to include")
+	trendCmd.Flags().StringVar(&flagTrendDir, "dir", "", "Baseline history directory (default ~/.gh-inspect/baselines)")
+	trendCmd.Flags().StringVarP(&flagFormat, "format", "f", "text", "Output format (text, json)")
+	_ = trendCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// TrendPoint holds the tracked values for one repository in one baseline.
+// Metrics missing from a baseline are nil.
+type TrendPoint struct {
+	Timestamp     time.Time `json:"timestamp"`
+	HealthScore   int       `json:"health_score"`
+	CISuccessRate *float64  `json:"ci_success_rate"`
+	ZombieIssues  *float64  `json:"zombie_issues"`
+}
+
+// RepoTrend is the chronological series of points for a repository
+type RepoTrend struct {
+	Repo   string       `json:"repo"`
+	Points []TrendPoint `json:"points"`
+}
+
+// buildTrends groups baseline history into per-repository series, optionally limited to
+// repos, scoring each point with weights so trends match what `run` reports
+func buildTrends(history []*baseline.Baseline, repos []string, weights insights.ScoringWeights) []RepoTrend {
+	wanted := make(map[string]bool, len(repos))
+	for _, r := range repos {
+		wanted[r] = true
+	}
+
+	byRepo := make(map[string]*RepoTrend)
+	for _, b := range history {
+		if b.Report == nil {
+			continue
+		}
+		for _, repo := range b.Report.Repositories {
+			if len(wanted) > 0 && !wanted[repo.Name] {
+				continue
+			}
+			t, ok := byRepo[repo.Name]
+			if !ok {
+				t = &RepoTrend{Repo: repo.Name}
+				byRepo[repo.Name] = t
+			}
+			t.Points = append(t.Points, TrendPoint{
+				Timestamp:     b.Timestamp,
+				HealthScore:   insights.CalculateEngineeringHealthScoreWithWeights(repo, weights),
+				CISuccessRate: findMetric(repo, "ci", "success_rate"),
+				ZombieIssues:  findMetric(repo, "issue-hygiene", "zombie_issues"),
+			})
+		}
+	}
+
+	trends := make([]RepoTrend, 0, len(byRepo))
+	for _, t := range byRepo {
+		trends = append(trends, *t)
+	}
+	sort.Slice(trends, func(i, j int) bool { return trends[i].Repo < trends[j].Repo })
+	return trends
+}
+
+// findMetric returns the value of an analyzer metric, or nil when it is absent
+func findMetric(repo models.RepoResult, analyzer, key string) *float64 {
+	for _, az := range repo.Analyzers {
+		if az.Name != analyzer {
+			continue
+		}
+		for _, m := range az.Metrics {
+			if m.Key == key {
+				v := m.Value
+				return &v
+			}
+		}
+	}
+	return nil
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values as block characters scaled between their min and max.
+// Missing values are drawn as spaces.
+func sparkline(values []*float64) string {
+	var lo, hi float64
+	first := true
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		if first || *v < lo {
+			lo = *v
+		}
+		if first || *v > hi {
+			hi = *v
+		}
+		first = false
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		switch {
+		case v == nil:
+			sb.WriteRune(' ')
+		case hi == lo:
+			sb.WriteRune(sparkBlocks[len(sparkBlocks)/2])
+		default:
+			idx := int((*v - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+			sb.WriteRune(sparkBlocks[idx])
+		}
+	}
+	return sb.String()
+}
+
+func runTrend(cmd *cobra.Command, args []string) {
+	dir := flagTrendDir
+	if dir == "" {
+		dir = baseline.GetDefaultHistoryDir()
+	}
+
+	history, err := baseline.LoadHistory(dir, flagTrendLast)
+	if err != nil {
+		fmt.Printf("Error loading baseline history: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	trends := buildTrends(history, args, scoringWeightsFromConfig(cfg.Scoring))
+
+	if flagFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(trends); err != nil {
+			fmt.Printf("Error rendering trend: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(trends) == 0 {
+		fmt.Printf("No baseline history found in %s.\n", dir)
+		fmt.Println("Set global.baseline_history (e.g. 'gh-inspect config set global.baseline_history 30') and run 'gh-inspect run <repo> --save-baseline' to start recording history.")
+		return
+	}
+
+	fmt.Println(colorBold + "📈 Health Trend" + colorReset)
+	for _, t := range trends {
+		printRepoTrend(t)
+	}
+}
+
+// printRepoTrend prints sparklines and first/last values for one repository
+func printRepoTrend(t RepoTrend) {
+	first, last := t.Points[0], t.Points[len(t.Points)-1]
+	fmt.Printf("\n%s%s%s (%d baselines, %s → %s)\n", colorBold, t.Repo, colorReset,
+		len(t.Points), first.Timestamp.Format("2006-01-02"), last.Timestamp.Format("2006-01-02"))
+
+	health := make([]*float64, len(t.Points))
+	ci := make([]*float64, len(t.Points))
+	zombies := make([]*float64, len(t.Points))
+	for i, p := range t.Points {
+		h := float64(p.HealthScore)
+		health[i] = &h
+		ci[i] = p.CISuccessRate
+		zombies[i] = p.ZombieIssues
+	}
+
+	printTrendRow("Health Score", health, "%.0f")
+	printTrendRow("CI Success Rate", ci, "%.1f%%")
+	printTrendRow("Zombie Issues", zombies, "%.0f")
+}
+
+func printTrendRow(label string, values []*float64, valueFormat string) {
+	format := func(v *float64) string {
+		if v == nil {
+			return "n/a"
+		}
+		return fmt.Sprintf(valueFormat, *v)
+	}
+	fmt.Printf("  %-16s %s  %s → %s\n", label, sparkline(values), format(values[0]), format(values[len(values)-1]))
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikematt33/gh-inspect/pkg/baseline"
+	"github.com/mikematt33/gh-inspect/pkg/insights"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTrends(t *testing.T) {
+	now := time.Now()
+	snapshot := func(offset time.Duration, ciRate float64, withZombies bool) *baseline.Baseline {
+		analyzers := []models.AnalyzerResult{
+			{Name: "ci", Metrics: []models.Metric{{Key: "success_rate", Value: ciRate}}},
+		}
+		if withZombies {
+			analyzers = append(analyzers, models.AnalyzerResult{
+				Name:    "issue-hygiene",
+				Metrics: []models.Metric{{Key: "zombie_issues", Value: 4}},
+			})
+		}
+		return &baseline.Baseline{
+			Timestamp: now.Add(offset),
+			Report: &models.Report{Repositories: []models.RepoResult{
+				{Name: "owner/b", Analyzers: analyzers},
+				{Name: "owner/a", Analyzers: analyzers},
+			}},
+		}
+	}
+
+	history := []*baseline.Baseline{
+		snapshot(-2*time.Hour, 80, false),
+		snapshot(-time.Hour, 95, true),
+	}
+
+	trends := buildTrends(history, nil, insights.DefaultScoringWeights())
+	if assert.Len(t, trends, 2) {
+		assert.Equal(t, "owner/a", trends[0].Repo)
+		assert.Len(t, trends[0].Points, 2)
+		assert.Equal(t, 95.0, *trends[0].Points[1].CISuccessRate)
+		assert.Nil(t, trends[0].Points[0].ZombieIssues)
+		assert.Equal(t, 4.0, *trends[0].Points[1].ZombieIssues)
+	}
+
+	filtered := buildTrends(history, []string{"owner/b"}, insights.DefaultScoringWeights())
+	if assert.Len(t, filtered, 1) {
+		assert.Equal(t, "owner/b", filtered[0].Repo)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	v := func(f float64) *float64 { return &f }
+
+	assert.Equal(t, "▁█", sparkline([]*float64{v(1), v(10)}))
+	assert.Equal(t, "▅▅▅", sparkline([]*float64{v(5), v(5), v(5)}))
+	assert.Equal(t, "▁ █", sparkline([]*float64{v(0), nil, v(2)}))
+	assert.Equal(t, "", sparkline(nil))
+}
@@ -17,13 +17,13 @@ import (
 	"strings"
 	"time"
 
+	ghclient "github.com/mikematt33/gh-inspect/internal/github"
 	"github.com/spf13/cobra"
 )
 
-// httpClient is used for all HTTP requests with a reasonable timeout
-var httpClient = &http.Client{
-	Timeout: 30 * time.Second,
-}
+// httpClient is used for all HTTP requests with a reasonable timeout.
+// It honors the proxy environment variables and global.proxy_url.
+var httpClient = ghclient.NewHTTPClient(30 * time.Second)
 
 var (
 	updateCheckOnly bool
@@ -84,6 +84,7 @@ type Release struct {
 }
 
 func getLatestRelease() (*Release, error) {
+	// Releases are published on github.com, so --api-url and global.api_url do not apply here
 	resp, err := httpClient.Get("https://api.github.com/repos/mikematt33/gh-inspect/releases/latest")
 	if err != nil {
 		return nil, err
@@ -6,8 +6,8 @@ import (
 	"os"
 
 	"github.com/google/go-github/v60/github"
-	"github.com/mikematt33/gh-inspect/internal/config"
 	"github.com/mikematt33/gh-inspect/internal/report"
+	"github.com/mikematt33/gh-inspect/pkg/models"
 	"github.com/spf13/cobra"
 )
 
@@ -25,9 +25,16 @@ Displays a progress bar during analysis. Use --quiet for CI/CD environments.`,
   gh-inspect user octocat --filter-language=javascript
   gh-inspect user octocat --filter-skip-forks --filter-updated=180d`,
 	Args: func(cmd *cobra.Command, args []string) error {
+		if err := applyEnvOverrides(cmd); err != nil {
+			return err
+		}
+		if err := applyProfile(cmd); err != nil {
+			return err
+		}
+
 		// Validate format
-		if flagFormat != "" && flagFormat != "text" && flagFormat != "json" && flagFormat != "markdown" {
-			return fmt.Errorf("invalid format: %s (must be text, json, or markdown)", flagFormat)
+		if err := validateFormat(flagFormat, report.FormatNDJSON, report.FormatBadge); err != nil {
+			return err
 		}
 
 		// Validate depth
@@ -40,6 +47,15 @@ Displays a progress bar during analysis. Use --quiet for CI/CD environments.`,
 			return fmt.Errorf("invalid output mode: %s (must be suggestive, observational, or statistical)", flagOutputMode)
 		}
 
+		// Validate minimum severity
+		if flagMinSeverity != "" && flagMinSeverity != "info" && flagMinSeverity != "low" && flagMinSeverity != "medium" && flagMinSeverity != "high" {
+			return fmt.Errorf("invalid min severity: %s (must be info, low, medium, or high)", flagMinSeverity)
+		}
+
+		if err := validateSinceFlags(cmd); err != nil {
+			return err
+		}
+
 		if flagListAnalyzers {
 			return nil // Allow no args when listing analyzers
 		}
@@ -56,7 +72,7 @@ Displays a progress bar during analysis. Use --quiet for CI/CD environments.`,
 }
 
 var getUserRepositories = func(username string) ([]*github.Repository, error) {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("error loading config: %w", err)
 	}
@@ -76,11 +92,9 @@ func init() {
 }
 
 func runUserAnalysis(cmd *cobra.Command, args []string) {
+	quietForLineFormats()
 	username := args[0]
 
-	// Record user usage for completions
-	recordUsage(username, "user")
-
 	if shouldPrintInfo() {
 		fmt.Printf("Fetching repositories for user '%s'...\n", username)
 	}
@@ -91,6 +105,9 @@ func runUserAnalysis(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	// Record user usage for completions once the name is known to resolve
+	recordUsage(username, "user")
+
 	// Apply Filters
 	filter, err := NewRepoFilter()
 	if err != nil {
@@ -100,29 +117,8 @@ func runUserAnalysis(cmd *cobra.Command, args []string) {
 
 	targetRepos, stats := FilterRepositories(repos, filter)
 
-	if shouldPrintInfo() {
-		fmt.Printf("found %d total repositories\n", stats.Total)
-		if stats.Archived > 0 {
-			fmt.Printf("  %d archived (skipped)\n", stats.Archived)
-		}
-		if stats.Forks > 0 && !flagFilterSkipForks {
-			fmt.Printf("  %d forks (included)\n", stats.Forks)
-		} else if flagFilterSkipForks {
-			fmt.Printf("  %d forks (filtered)\n", stats.Forks)
-		}
-		if stats.NameFiltered > 0 {
-			fmt.Printf("  %d filtered by name pattern\n", stats.NameFiltered)
-		}
-		if stats.LangFiltered > 0 {
-			fmt.Printf("  %d filtered by language\n", stats.LangFiltered)
-		}
-		if stats.TopicFiltered > 0 {
-			fmt.Printf("  %d filtered by topics\n", stats.TopicFiltered)
-		}
-		if stats.DateFiltered > 0 {
-			fmt.Printf("  %d filtered by update date\n", stats.DateFiltered)
-		}
-		fmt.Printf("analyzing %d repositories\n", stats.Passed)
+	if shouldPrintInfo() || flagDryRun {
+		printFilterStats(os.Stdout, stats)
 	}
 
 	if len(targetRepos) == 0 {
@@ -131,7 +127,7 @@ func runUserAnalysis(cmd *cobra.Command, args []string) {
 	}
 
 	// Load config to get output mode preference
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Printf("Error loading config: %v\n", err)
 		os.Exit(1)
@@ -148,6 +144,10 @@ func runUserAnalysis(cmd *cobra.Command, args []string) {
 	opts := AnalysisOptions{
 		Repos:           targetRepos,
 		Since:           flagSince, // Uses flags from root (or init above)
+		SinceDate:       flagSinceDate,
+		Ref:             flagRef,
+		QuietErrors:     flagQuietErrors,
+		NoRepoConfig:    flagNoRepoConfig,
 		Depth:           flagDepth,
 		MaxPRs:          flagMaxPRs,
 		MaxIssues:       flagMaxIssues,
@@ -155,6 +155,16 @@ func runUserAnalysis(cmd *cobra.Command, args []string) {
 		Include:         flagInclude,
 		Exclude:         flagExclude,
 		OutputMode:      resolvedOutputMode,
+		AnalyzerTimeout: flagAnalyzerTimeout,
+		Timeout:         flagTimeout,
+	}
+
+	if flagDryRun {
+		if err := printDryRun(os.Stdout, opts); err != nil {
+			fmt.Printf("Error planning analysis: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
 	fullReport, err := pipelineRunner(opts)
@@ -163,21 +173,41 @@ func runUserAnalysis(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	fullReport.Summary.TotalReposAnalyzed = len(targetRepos)
-
-	var renderer report.Renderer
-	if flagFormat == "json" {
-		renderer = &report.JSONRenderer{}
-	} else {
-		renderer = &report.TextRenderer{}
-	}
-
-	if err := renderer.Render(fullReport, os.Stdout); err != nil {
+	renderer := report.NewRenderer(report.Format(flagFormat))
+
+	weights, thresholds := scoringWeightsFromConfig(cfg.Scoring), insightThresholdsFromConfig(cfg.Insights)
+	if err := renderer.RenderWithOptions(fullReport, os.Stdout, report.RenderOptions{
+		ShowExplanation:   flagExplain,
+		OutputMode:        models.OutputMode(resolvedOutputMode),
+		OnlyFindings:      flagOnlyFindings,
+		MinSeverity:       models.Severity(flagMinSeverity),
+		NoColor:           !colorEnabled(os.Stdout),
+		CompactJSON:       flagCompact,
+		ExplainSummary:    flagExplainSummary,
+		ShowTimings:       shouldPrintVerbose(),
+		MarkdownNoEmoji:   flagMarkdownNoEmoji,
+		ScoringWeights:    &weights,
+		InsightThresholds: &thresholds,
+	}); err != nil {
 		fmt.Printf("Error rendering report: %v\n", err)
 	}
 
 	if flagFail > 0 && fullReport.Summary.AvgHealthScore < float64(flagFail) {
-		fmt.Printf("\n❌ Failure: Average health score (%.1f) is below threshold (%d).\n", fullReport.Summary.AvgHealthScore, flagFail)
-		os.Exit(1)
+		if shouldPrintInfo() {
+			fmt.Printf("\n❌ Failure: Average health score (%.1f) is below threshold (%d).\n", fullReport.Summary.AvgHealthScore, flagFail)
+		}
+		exitWithFailure(exitHealthBelowThreshold, "health_below_threshold",
+			failField("score", fullReport.Summary.AvgHealthScore), fmt.Sprintf("threshold=%d", flagFail))
+	}
+
+	failOnFindingTypes(fullReport)
+
+	if flagFail > 0 || len(flagFailOnFindingType) > 0 {
+		if n := countAnalyzerErrors(fullReport); n > 0 {
+			if shouldPrintInfo() {
+				fmt.Printf("\n❌ Failure: %d analyzer(s) failed or timed out.\n", n)
+			}
+			exitWithFailure(exitAnalyzerErrors, "analyzer_errors", fmt.Sprintf("count=%d", n))
+		}
 	}
 }
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mikematt33/gh-inspect/internal/report"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// minWatchInterval keeps watch mode from exhausting the API rate limit
+const minWatchInterval = 30 * time.Second
+
+// clearScreen clears the terminal and moves the cursor home
+const clearScreen = "\033[H\033[2J"
+
+// validateWatchFlags rejects --watch combinations that only make sense for a single run
+func validateWatchFlags() error {
+	if flagWatch < minWatchInterval {
+		return fmt.Errorf("invalid watch interval: %s (must be at least %s)", flagWatch, minWatchInterval)
+	}
+	if flagFormat != "" && flagFormat != "text" {
+		return fmt.Errorf("--watch only supports text output (got --format=%s)", flagFormat)
+	}
+	conflicts := []struct {
+		flag string
+		set  bool
+	}{
+		{"--output", flagOutput != ""},
+		{"--dry-run", flagDryRun},
+		{"--compare-last", flagCompareLast},
+		{"--baseline", flagBaseline != ""},
+		{"--save-baseline", flagSaveBaseline},
+		{"--comparison-output", flagComparisonOutput != ""},
+		{"--fail-under", flagFail > 0},
+		{"--fail-on-regression", flagFailOnRegression},
+		{"--fail-on-finding-type", len(flagFailOnFindingType) > 0},
+	}
+	for _, c := range conflicts {
+		if c.set {
+			return fmt.Errorf("--watch cannot be combined with %s", c.flag)
+		}
+	}
+	return nil
+}
+
+// watchAnalysis re-runs the analysis every interval until interrupted, always
+// fetching fresh data from the API
+func watchAnalysis(opts AnalysisOptions, renderOpts report.RenderOptions, interval time.Duration) {
+	flagNoCache = true
+
+	ctx, cancel := withInterrupt(context.Background(), "")
+	defer cancel()
+
+	watchLoop(ctx, os.Stdout, opts, renderOpts, interval)
+	if shouldPrintInfo() {
+		fmt.Println("\nStopped watching.")
+	}
+}
+
+// watchLoop renders a fresh report each interval, marking metric changes since the
+// previous successful run. A failed run is reported and retried on the next tick.
+func watchLoop(ctx context.Context, w io.Writer, opts AnalysisOptions, renderOpts report.RenderOptions, interval time.Duration) {
+	renderer := &report.TextRenderer{}
+	var previous *models.Report
+
+	for {
+		current, err := pipelineRunner(opts)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if renderOpts.NoColor {
+			_, _ = fmt.Fprintln(w, "==================================================")
+		} else {
+			_, _ = fmt.Fprint(w, clearScreen)
+		}
+		_, _ = fmt.Fprintf(w, "Watching %d repositories every %s. Last run: %s (Ctrl+C to stop)\n",
+			len(opts.Repos), interval, time.Now().Format("15:04:05"))
+
+		if err != nil {
+			_, _ = fmt.Fprintf(w, "Error running analysis: %v\n", err)
+		} else {
+			renderOpts.Previous = previous
+			if err := renderer.RenderWithOptions(current, w, renderOpts); err != nil {
+				_, _ = fmt.Fprintf(w, "Error rendering report: %v\n", err)
+			}
+			previous = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
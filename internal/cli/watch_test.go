@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikematt33/gh-inspect/internal/report"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+func TestValidateWatchFlags(t *testing.T) {
+	defer func() {
+		flagWatch, flagFormat, flagOutput, flagSaveBaseline = 0, "text", "", false
+	}()
+
+	flagWatch, flagFormat = time.Minute, "text"
+	if err := validateWatchFlags(); err != nil {
+		t.Errorf("Expected valid watch flags, got %v", err)
+	}
+
+	flagWatch = time.Second
+	if err := validateWatchFlags(); err == nil {
+		t.Error("Expected error for an interval below the minimum")
+	}
+
+	flagWatch, flagFormat = time.Minute, "json"
+	if err := validateWatchFlags(); err == nil {
+		t.Error("Expected error for non-text format")
+	}
+
+	flagFormat, flagSaveBaseline = "text", true
+	if err := validateWatchFlags(); err == nil || !strings.Contains(err.Error(), "--save-baseline") {
+		t.Errorf("Expected conflict with --save-baseline, got %v", err)
+	}
+}
+
+func TestWatchLoopRerunsUntilCancelled(t *testing.T) {
+	originalPipelineRunner := pipelineRunner
+	defer func() { pipelineRunner = originalPipelineRunner }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := 0
+	pipelineRunner = func(opts AnalysisOptions) (*models.Report, error) {
+		runs++
+		switch runs {
+		case 2:
+			return nil, fmt.Errorf("rate limited")
+		case 4:
+			cancel()
+		}
+		return &models.Report{Repositories: []models.RepoResult{{
+			Name:      "owner/repo",
+			Analyzers: []models.AnalyzerResult{{Name: "ci", Metrics: []models.Metric{{Key: "runs", Value: float64(runs)}}}},
+		}}}, nil
+	}
+
+	var buf bytes.Buffer
+	watchLoop(ctx, &buf, AnalysisOptions{Repos: []string{"owner/repo"}}, report.RenderOptions{NoColor: true}, time.Millisecond)
+
+	if runs != 4 {
+		t.Errorf("Expected 4 runs before cancellation, got %d", runs)
+	}
+	out := buf.String()
+	if strings.Count(out, "Watching 1 repositories") != 3 {
+		t.Errorf("Expected a header for each completed run:\n%s", out)
+	}
+	if !strings.Contains(out, "Error running analysis: rate limited") {
+		t.Errorf("Expected the failed run to be reported:\n%s", out)
+	}
+	// The third run is compared with the first, since the second failed
+	if !strings.Contains(out, "(+2.00)") {
+		t.Errorf("Expected a delta against the last successful run:\n%s", out)
+	}
+}
@@ -4,30 +4,141 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	yaml "gopkg.in/yaml.v3"
 )
 
 type Config struct {
 	Global    GlobalConfig    `yaml:"global"`
+	Cache     CacheConfig     `yaml:"cache"`
+	Scoring   ScoringConfig   `yaml:"scoring,omitempty"`
+	Insights  InsightsConfig  `yaml:"insights,omitempty"`
 	Analyzers AnalyzersConfig `yaml:"analyzers"`
+	// Profiles are named flag bundles selected with --profile
+	Profiles map[string]ProfileConfig `yaml:"profiles,omitempty"`
+	// Contexts are named GitHub accounts selected with --context
+	Contexts map[string]ContextConfig `yaml:"contexts,omitempty"`
 }
 
 type GlobalConfig struct {
 	Concurrency int    `yaml:"concurrency"`
 	GitHubToken string `yaml:"github_token,omitempty"`
 	OutputMode  string `yaml:"output_mode,omitempty"` // observational (default), suggestive, statistical
+	// AnalyzerTimeoutSeconds bounds each analyzer run per repository (0 = no timeout)
+	AnalyzerTimeoutSeconds int `yaml:"analyzer_timeout_seconds,omitempty"`
+	// RetryMaxAttempts is how many times a request is tried on transient API errors (5xx, connection resets)
+	RetryMaxAttempts int `yaml:"retry_max_attempts,omitempty"`
+	// BaselineHistory is how many timestamped baselines --save-baseline keeps for `trend` (0 = keep none)
+	BaselineHistory int `yaml:"baseline_history,omitempty"`
+	// HealthScoreWeighting selects how repos are weighted in the summary's weighted health score: none, stars or commits
+	HealthScoreWeighting string `yaml:"health_score_weighting,omitempty"`
+	// RepoWeights sets explicit weights for owner/repo names, overriding the weighting strategy
+	RepoWeights map[string]float64 `yaml:"repo_weights,omitempty"`
+	// ConcurrencyMode is "fixed" (default) or "auto", which lowers the worker count below
+	// Concurrency when the remaining rate limit barely covers the estimated cost
+	ConcurrencyMode string `yaml:"concurrency_mode,omitempty"`
+	// ProxyURL routes API and update requests through this proxy, overriding HTTP(S)_PROXY (NO_PROXY still applies)
+	ProxyURL string `yaml:"proxy_url,omitempty"`
+	// APIURL is the API root of a GitHub Enterprise Server to analyze instead of github.com
+	APIURL string `yaml:"api_url,omitempty"`
+}
+
+// CacheConfig controls how long cached API responses stay fresh.
+// TTL maps cache-key prefixes (e.g. "repo:", "workflow:") to durations such as "24h" or "5m".
+type CacheConfig struct {
+	DefaultTTL string            `yaml:"default_ttl,omitempty"`
+	TTL        map[string]string `yaml:"ttl,omitempty"`
+}
+
+// ParseTTLs returns the default TTL and the per-prefix overrides as durations
+func (c CacheConfig) ParseTTLs() (time.Duration, map[string]time.Duration, error) {
+	defaultTTL := time.Hour
+	if c.DefaultTTL != "" {
+		d, err := time.ParseDuration(c.DefaultTTL)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid cache.default_ttl %q: %w", c.DefaultTTL, err)
+		}
+		defaultTTL = d
+	}
+
+	prefixTTLs := make(map[string]time.Duration, len(c.TTL))
+	for prefix, v := range c.TTL {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid cache.ttl for %q: %w", prefix, err)
+		}
+		prefixTTLs[prefix] = d
+	}
+
+	return defaultTTL, prefixTTLs, nil
+}
+
+// ScoringConfig overrides the point deductions used for the engineering health score.
+// Unset fields keep the built-in default; an explicit 0 disables that deduction.
+type ScoringConfig struct {
+	CIFailing       *int `yaml:"ci_failing,omitempty"`
+	CIUnstable      *int `yaml:"ci_unstable,omitempty"`
+	BusFactor       *int `yaml:"bus_factor,omitempty"`
+	ZombiesHigh     *int `yaml:"zombie_issues_high,omitempty"`
+	ZombiesModerate *int `yaml:"zombie_issues_moderate,omitempty"`
+	MissingFile     *int `yaml:"missing_file,omitempty"`
+	MissingFilesMax *int `yaml:"missing_files_max,omitempty"`
+	StalePRs        *int `yaml:"stale_prs,omitempty"`
+}
+
+// InsightsConfig overrides the thresholds at which insights are raised.
+// Unset fields keep the built-in default.
+type InsightsConfig struct {
+	CICritical     *float64 `yaml:"ci_success_critical,omitempty"` // percent
+	CIWarning      *float64 `yaml:"ci_success_warning,omitempty"`  // percent
+	ZombieIssues   *int     `yaml:"zombie_issues,omitempty"`
+	CycleTimeHours *float64 `yaml:"cycle_time_hours,omitempty"`
+	SelfMergeRate  *float64 `yaml:"self_merge_rate,omitempty"` // percent
+}
+
+// ProfileConfig holds flag values applied by --profile. Unset fields leave the flag alone,
+// and flags given on the command line or through the environment beat the profile.
+type ProfileConfig struct {
+	Include    []string `yaml:"include,omitempty"`
+	Exclude    []string `yaml:"exclude,omitempty"`
+	Depth      string   `yaml:"depth,omitempty"`
+	OutputMode string   `yaml:"output_mode,omitempty"`
+	FailUnder  int      `yaml:"fail_under,omitempty"`
+}
+
+// ContextConfig is a named auth context: the token and optional Enterprise API URL of one
+// GitHub account. Without --context, global.github_token and global.api_url are used.
+type ContextConfig struct {
+	GitHubToken string `yaml:"github_token,omitempty"`
+	APIURL      string `yaml:"api_url,omitempty"`
 }
 
 type AnalyzersConfig struct {
+	Activity     ActivityConfig     `yaml:"activity"`
 	PRFlow       PRFlowConfig       `yaml:"pr_flow"`
 	IssueHygiene IssueHygieneConfig `yaml:"issue_hygiene"`
 	RepoHealth   RepoHealthConfig   `yaml:"repo_health"`
 	CI           CIConfig           `yaml:"ci"`
 	Security     SecurityConfig     `yaml:"security"`
 	Releases     ReleasesConfig     `yaml:"releases"`
+	Deployments  DeploymentsConfig  `yaml:"deployments"`
 	Branches     BranchesConfig     `yaml:"branches"`
 	Dependencies DependenciesConfig `yaml:"dependencies"`
+	Languages    LanguagesConfig    `yaml:"languages"`
+	Contributors ContributorsConfig `yaml:"contributors"`
+}
+
+type ActivityConfig struct {
+	Params ActivityParams `yaml:"params"`
+}
+
+type ActivityParams struct {
+	// ConventionalCommitThreshold flags repos where fewer than this percentage of
+	// sampled commits follow conventional-commit prefixes (0 disables the finding)
+	ConventionalCommitThreshold int `yaml:"conventional_commit_threshold,omitempty"`
+	// ChurnExcludeLabels lists PR labels left out of code_churn_ratio (unset = dependencies, generated)
+	ChurnExcludeLabels []string `yaml:"churn_exclude_labels,omitempty"`
 }
 
 type PRFlowConfig struct {
@@ -37,6 +148,10 @@ type PRFlowConfig struct {
 
 type PRFlowParams struct {
 	StaleThresholdDays int `yaml:"stale_threshold_days"`
+	// BotLogins lists PR authors excluded from the human cycle time (unset = dependabot, renovate, github-actions)
+	BotLogins []string `yaml:"bot_logins,omitempty"`
+	// SizeSampleSize is how many recently merged PRs are fetched for PR size metrics (unset = 20, 0 = off)
+	SizeSampleSize *int `yaml:"size_sample_size,omitempty"`
 }
 
 type IssueHygieneConfig struct {
@@ -47,10 +162,34 @@ type IssueHygieneConfig struct {
 type IssueHygieneParams struct {
 	StaleThresholdDays  int `yaml:"stale_threshold_days"`
 	ZombieThresholdDays int `yaml:"zombie_threshold_days"`
+	// LabelGroups maps a group name to label prefixes counted per group (unset = built-in groups)
+	LabelGroups map[string][]string `yaml:"label_groups,omitempty"`
+	// UntriagedThreshold flags more unlabeled issues older than the stale threshold than this (0 disables)
+	UntriagedThreshold int `yaml:"untriaged_threshold"`
+	// ResponseSLAHours is the target time to a first response on an issue (0 disables)
+	ResponseSLAHours int `yaml:"response_sla_hours"`
 }
 
 type RepoHealthConfig struct {
 	Enabled bool `yaml:"enabled"`
+	// RequiredFiles replaces the built-in key file checks when set
+	RequiredFiles []RequiredFile   `yaml:"required_files,omitempty"`
+	Params        RepoHealthParams `yaml:"params,omitempty"`
+}
+
+type RepoHealthParams struct {
+	// LargeFileMB flags committed files at least this large in the large_files finding (unset = 10, 0 = off)
+	LargeFileMB *int `yaml:"large_file_mb,omitempty"`
+	// LargeTreeMB flags repositories whose files add up to at least this much (unset = 500, 0 = off)
+	LargeTreeMB *int `yaml:"large_tree_mb,omitempty"`
+}
+
+// RequiredFile is a file every repository is expected to contain
+type RequiredFile struct {
+	Path      string   `yaml:"path"`
+	AltPaths  []string `yaml:"alt_paths,omitempty"`
+	Severity  string   `yaml:"severity"`  // info, low, medium or high (default medium)
+	Deduction int      `yaml:"deduction"` // health score points lost when missing
 }
 
 type CIConfig struct {
@@ -65,6 +204,16 @@ type ReleasesConfig struct {
 	Enabled bool `yaml:"enabled"`
 }
 
+type DeploymentsConfig struct {
+	Enabled bool              `yaml:"enabled"`
+	Params  DeploymentsParams `yaml:"params"`
+}
+
+type DeploymentsParams struct {
+	// SuccessRateThreshold flags repos whose deployment success rate (percent) falls below it (0 disables)
+	SuccessRateThreshold int `yaml:"success_rate_threshold"`
+}
+
 type BranchesConfig struct {
 	Enabled bool         `yaml:"enabled"`
 	Params  BranchParams `yaml:"params"`
@@ -72,12 +221,33 @@ type BranchesConfig struct {
 
 type BranchParams struct {
 	StaleThresholdDays int `yaml:"stale_threshold_days"`
+	// DivergenceThresholdCommits flags stale branches this many commits behind the default branch
+	DivergenceThresholdCommits int `yaml:"divergence_threshold_commits"`
+	// NamingPatterns are regexes non-default branch names should match, e.g. ^feature/ (unset = no check)
+	NamingPatterns []string `yaml:"naming_patterns,omitempty"`
 }
 
 type DependenciesConfig struct {
 	Enabled bool `yaml:"enabled"`
 }
 
+type LanguagesConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// ContributorsConfig is disabled by default because it needs to know who counts as internal
+type ContributorsConfig struct {
+	Enabled bool               `yaml:"enabled"`
+	Params  ContributorsParams `yaml:"params"`
+}
+
+type ContributorsParams struct {
+	// InternalMembers lists GitHub logins of org members (case-insensitive)
+	InternalMembers []string `yaml:"internal_members,omitempty"`
+	// InternalDomains lists company email domains; commits from subdomains count too
+	InternalDomains []string `yaml:"internal_domains,omitempty"`
+}
+
 func GetConfigPath() (string, error) {
 	// Respect XDG_CONFIG_HOME if set (useful for testing and Linux users)
 	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
@@ -91,14 +261,28 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(configDir, "gh-inspect", "config.yaml"), nil
 }
 
+// Load reads the configuration from the first file found in the standard search locations.
 func Load() (*Config, error) {
+	return LoadFrom("")
+}
+
+// LoadFrom reads the configuration from path, skipping the standard search locations.
+// An empty path behaves like Load. A missing file yields the defaults.
+func LoadFrom(path string) (*Config, error) {
 	// Defaults
 	cfg := &Config{
 		Global: GlobalConfig{
 			Concurrency: 5,
 			OutputMode:  "observational", // default mode
+
+			AnalyzerTimeoutSeconds: 300,
+			RetryMaxAttempts:       3,
+		},
+		Cache: CacheConfig{
+			DefaultTTL: "1h",
 		},
 		Analyzers: AnalyzersConfig{
+			Activity: ActivityConfig{},
 			PRFlow: PRFlowConfig{
 				Enabled: true,
 				Params: PRFlowParams{
@@ -110,6 +294,8 @@ func Load() (*Config, error) {
 				Params: IssueHygieneParams{
 					StaleThresholdDays:  30,
 					ZombieThresholdDays: 180,
+					UntriagedThreshold:  10,
+					ResponseSLAHours:    48,
 				},
 			},
 			RepoHealth: RepoHealthConfig{
@@ -124,46 +310,69 @@ func Load() (*Config, error) {
 			Releases: ReleasesConfig{
 				Enabled: true,
 			},
+			Deployments: DeploymentsConfig{
+				Enabled: true,
+				Params: DeploymentsParams{
+					SuccessRateThreshold: 90,
+				},
+			},
 			Branches: BranchesConfig{
 				Enabled: true,
 				Params: BranchParams{
-					StaleThresholdDays: 90,
+					StaleThresholdDays:         90,
+					DivergenceThresholdCommits: 100,
 				},
 			},
 			Dependencies: DependenciesConfig{
 				Enabled: true,
 			},
+			Languages: LanguagesConfig{
+				Enabled: true,
+			},
 		},
 	}
 
 	// Try loading from file
+	if p := FindConfigFile(path); p != "" {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", p, err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// FindConfigFile returns the config file LoadFrom would read, or "" if none exists.
+// An explicit path replaces the standard search locations.
+func FindConfigFile(path string) string {
 	// Priorities: ./config.yaml, $XDG_CONFIG_HOME/gh-inspect/config.yaml, $HOME/.gh-inspect.yaml
 	configDirs := []string{"config.yaml"} // Local override
 
-	// Standard User Config Dir
-	if userConfigDir, err := os.UserConfigDir(); err == nil {
-		configDirs = append(configDirs, userConfigDir+"/gh-inspect/config.yaml")
-	}
+	if path != "" {
+		// Explicit path replaces the search list entirely
+		configDirs = []string{path}
+	} else {
+		// Standard User Config Dir
+		if userConfigDir, err := os.UserConfigDir(); err == nil {
+			configDirs = append(configDirs, userConfigDir+"/gh-inspect/config.yaml")
+		}
 
-	// Legacy fallback
-	if home := os.Getenv("HOME"); home != "" {
-		configDirs = append(configDirs, home+"/.gh-inspect.yaml")
+		// Legacy fallback
+		if home := os.Getenv("HOME"); home != "" {
+			configDirs = append(configDirs, home+"/.gh-inspect.yaml")
+		}
 	}
 
 	for _, p := range configDirs {
 		if _, err := os.Stat(p); err == nil {
-			data, err := os.ReadFile(p)
-			if err != nil {
-				return nil, err
-			}
-			if err := yaml.Unmarshal(data, cfg); err != nil {
-				return nil, fmt.Errorf("error parsing %s: %w", p, err)
-			}
-			return cfg, nil
+			return p
 		}
 	}
-
-	return cfg, nil
+	return ""
 }
 
 // Save writes the configuration to the user's config file
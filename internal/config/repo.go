@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// RepoConfigFile is the file an analyzed repository can commit to override analyzer settings for itself
+const RepoConfigFile = ".gh-inspect.yml"
+
+// repoConfig is the part of the configuration a repository may override
+type repoConfig struct {
+	Analyzers AnalyzersConfig `yaml:"analyzers"`
+}
+
+// MergeRepoConfig returns a copy of base with the analyzer params of a repository's
+// .gh-inspect.yml applied over it. Which analyzers run, and the global, cache and scoring
+// settings, stay with the user running gh-inspect, so a file setting them is rejected.
+// base is never modified; an invalid file returns an error so the caller can fall back to base.
+func MergeRepoConfig(base *Config, data []byte) (*Config, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return base, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping with an analyzers section", root.Line)
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, analyzers := root.Content[i], root.Content[i+1]
+		if key.Value != "analyzers" {
+			return nil, fmt.Errorf("line %d: %s cannot be set per repository; only analyzers can be overridden", key.Line, key.Value)
+		}
+		if analyzers.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(analyzers.Content); j += 2 {
+			name, settings := analyzers.Content[j], analyzers.Content[j+1]
+			if settings.Kind != yaml.MappingNode {
+				continue
+			}
+			for k := 0; k < len(settings.Content); k += 2 {
+				if setting := settings.Content[k]; setting.Value != "params" {
+					return nil, fmt.Errorf("line %d: analyzers.%s.%s cannot be set per repository; only params can be overridden",
+						setting.Line, name.Value, setting.Value)
+				}
+			}
+		}
+	}
+
+	problems, err := Validate(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range problems {
+		if !p.Warning {
+			return nil, fmt.Errorf("line %d: %s: %s", p.Line, p.Field, p.Message)
+		}
+	}
+
+	// Copy the analyzer settings through YAML so the repo file cannot write into
+	// maps shared with base
+	baseData, err := yaml.Marshal(repoConfig{Analyzers: base.Analyzers})
+	if err != nil {
+		return nil, err
+	}
+	var merged repoConfig
+	if err := yaml.Unmarshal(baseData, &merged); err != nil {
+		return nil, err
+	}
+	if err := root.Decode(&merged); err != nil {
+		return nil, err
+	}
+
+	cfg := *base
+	cfg.Analyzers = merged.Analyzers
+	return &cfg, nil
+}
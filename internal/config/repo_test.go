@@ -0,0 +1,62 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeRepoConfig(t *testing.T) {
+	base, err := LoadFrom("/nonexistent/config.yaml")
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	base.Analyzers.IssueHygiene.Params.LabelGroups = map[string][]string{"bugs": {"bug"}}
+
+	merged, err := MergeRepoConfig(base, []byte(`analyzers:
+  pr_flow:
+    params:
+      stale_threshold_days: 30
+  issue_hygiene:
+    params:
+      label_groups:
+        docs: ["documentation"]
+`))
+	if err != nil {
+		t.Fatalf("MergeRepoConfig failed: %v", err)
+	}
+	if got := merged.Analyzers.PRFlow.Params.StaleThresholdDays; got != 30 {
+		t.Errorf("Expected stale threshold override of 30, got %d", got)
+	}
+	if !merged.Analyzers.Security.Enabled || !merged.Analyzers.CI.Enabled {
+		t.Errorf("Expected analyzer toggles to keep the base value, got security=%v ci=%v", merged.Analyzers.Security.Enabled, merged.Analyzers.CI.Enabled)
+	}
+	if got := merged.Analyzers.Branches.Params.StaleThresholdDays; got != 90 {
+		t.Errorf("Expected unset values to keep the base value 90, got %d", got)
+	}
+	if len(merged.Analyzers.IssueHygiene.Params.LabelGroups) != 2 {
+		t.Errorf("Expected label groups to be merged, got %v", merged.Analyzers.IssueHygiene.Params.LabelGroups)
+	}
+
+	// The base config is shared by every repository and must not change
+	if base.Analyzers.PRFlow.Params.StaleThresholdDays != 14 || !base.Analyzers.Security.Enabled || len(base.Analyzers.IssueHygiene.Params.LabelGroups) != 1 {
+		t.Errorf("Expected base config to be unchanged, got %+v", base.Analyzers)
+	}
+
+	for name, data := range map[string]string{
+		"invalid yaml":   "analyzers: [",
+		"invalid value":  "analyzers:\n  pr_flow:\n    params:\n      stale_threshold_days: -1\n",
+		"global setting": "global:\n  github_token: abc\n",
+		"enabled toggle": "analyzers:\n  security:\n    enabled: false\n",
+		"required files": "analyzers:\n  repo_health:\n    required_files: []\n",
+	} {
+		if _, err := MergeRepoConfig(base, []byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := MergeRepoConfig(base, []byte("global:\n  github_token: abc\n")); err == nil || !strings.Contains(err.Error(), "only analyzers") {
+		t.Errorf("Expected global settings to be rejected, got %v", err)
+	}
+	if _, err := MergeRepoConfig(base, []byte("analyzers:\n  security:\n    enabled: false\n")); err == nil || !strings.Contains(err.Error(), "analyzers.security.enabled cannot be set") {
+		t.Errorf("Expected analyzer toggles to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Problem describes one issue found while validating a config file.
+// Unknown keys are reported as warnings; invalid values are errors.
+type Problem struct {
+	Line    int
+	Field   string
+	Message string
+	Warning bool
+}
+
+func (p Problem) String() string {
+	level := "error"
+	if p.Warning {
+		level = "warning"
+	}
+	return fmt.Sprintf("%s: %s: %s", level, p.Field, p.Message)
+}
+
+// ValidOutputModes lists the accepted values for global.output_mode
+var ValidOutputModes = []string{"observational", "suggestive", "statistical"}
+
+// ValidConcurrencyModes lists the accepted values for global.concurrency_mode
+var ValidConcurrencyModes = []string{"fixed", "auto"}
+
+// ValidHealthScoreWeightings lists the accepted values for global.health_score_weighting
+var ValidHealthScoreWeightings = []string{"none", "stars", "commits"}
+
+// ValidDepths lists the accepted values for a profile's depth
+var ValidDepths = []string{"shallow", "standard", "deep"}
+
+// ValidSeverities lists the accepted severities for configured required files
+var ValidSeverities = []string{"info", "low", "medium", "high"}
+
+// Validate checks raw config YAML against the known schema: it warns about keys the
+// loader would silently ignore and reports values outside their valid range.
+// A non-nil error means the file is not valid YAML at all.
+func Validate(data []byte) ([]Problem, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil // empty file: all defaults
+	}
+	root := doc.Content[0]
+
+	v := &validator{lines: make(map[string]int), badLines: make(map[int]bool)}
+	v.walk(root, reflect.TypeOf(Config{}), "")
+
+	var cfg Config
+	if err := root.Decode(&cfg); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, err
+		}
+		for _, msg := range typeErr.Errors {
+			v.addTypeError(msg)
+		}
+	}
+
+	v.checkValues(&cfg)
+
+	sort.SliceStable(v.problems, func(i, j int) bool { return v.problems[i].Line < v.problems[j].Line })
+	return v.problems, nil
+}
+
+type validator struct {
+	lines    map[string]int // dotted field path -> line of its key
+	badLines map[int]bool   // lines whose value failed to decode
+	problems []Problem
+}
+
+// walk records the line of every known key and flags keys with no matching struct field.
+// Map values such as profiles.<name> are walked too, so their keys are checked as well.
+func (v *validator) walk(node *yaml.Node, typ reflect.Type, prefix string) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	if typ.Kind() == reflect.Map {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			path := prefix + "." + node.Content[i].Value
+			v.lines[path] = node.Content[i].Line
+			v.walk(node.Content[i+1], typ.Elem(), path)
+		}
+		return
+	}
+	if typ.Kind() != reflect.Struct {
+		return
+	}
+
+	fields := yamlFields(typ)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valNode := node.Content[i], node.Content[i+1]
+		path := keyNode.Value
+		if prefix != "" {
+			path = prefix + "." + keyNode.Value
+		}
+
+		fieldType, ok := fields[keyNode.Value]
+		if !ok {
+			msg := "unknown key (it will be ignored)"
+			if prefix == "analyzers" {
+				msg = fmt.Sprintf("unknown analyzer (valid: %s)", strings.Join(sortedKeys(fields), ", "))
+			}
+			v.problems = append(v.problems, Problem{Line: keyNode.Line, Field: path, Message: msg, Warning: true})
+			continue
+		}
+
+		v.lines[path] = keyNode.Line
+		v.walk(valNode, fieldType, path)
+	}
+}
+
+// addTypeError converts a yaml type error such as "line 3: cannot unmarshal ..." into a Problem
+func (v *validator) addTypeError(msg string) {
+	p := Problem{Field: "value", Message: msg}
+	var line int
+	if n, _ := fmt.Sscanf(msg, "line %d:", &line); n == 1 {
+		p.Line = line
+		v.badLines[line] = true
+		p.Message = strings.TrimSpace(msg[strings.Index(msg, ":")+1:])
+		for path, l := range v.lines {
+			if l == line {
+				p.Field = path
+			}
+		}
+	}
+	v.problems = append(v.problems, p)
+}
+
+// checkValues validates the fields that were explicitly set in the file
+func (v *validator) checkValues(cfg *Config) {
+	check := func(path string, valid bool, format string, args ...interface{}) {
+		line, set := v.lines[path]
+		if set && !valid && !v.badLines[line] {
+			v.problems = append(v.problems, Problem{Line: line, Field: path, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	g := cfg.Global
+	check("global.concurrency", g.Concurrency >= 1, "must be at least 1 (got %d)", g.Concurrency)
+	check("global.concurrency_mode", g.ConcurrencyMode == "" || contains(ValidConcurrencyModes, g.ConcurrencyMode),
+		"invalid concurrency mode %q (valid: %s)", g.ConcurrencyMode, strings.Join(ValidConcurrencyModes, ", "))
+	check("global.output_mode", g.OutputMode == "" || contains(ValidOutputModes, g.OutputMode),
+		"invalid output mode %q (valid: %s)", g.OutputMode, strings.Join(ValidOutputModes, ", "))
+	check("global.analyzer_timeout_seconds", g.AnalyzerTimeoutSeconds >= 0, "must not be negative (0 = no timeout)")
+	check("global.retry_max_attempts", g.RetryMaxAttempts >= 0, "must not be negative")
+	check("global.baseline_history", g.BaselineHistory >= 0, "must not be negative (0 = keep none)")
+	check("global.health_score_weighting", g.HealthScoreWeighting == "" || contains(ValidHealthScoreWeightings, g.HealthScoreWeighting),
+		"invalid weighting %q (valid: %s)", g.HealthScoreWeighting, strings.Join(ValidHealthScoreWeightings, ", "))
+	if g.ProxyURL != "" {
+		u, err := url.Parse(g.ProxyURL)
+		check("global.proxy_url", err == nil && u.Scheme != "" && u.Host != "",
+			"invalid proxy URL %q (e.g. http://proxy.example.com:8080)", g.ProxyURL)
+	}
+	if g.APIURL != "" {
+		u, err := url.Parse(g.APIURL)
+		check("global.api_url", err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+			"invalid API URL %q (e.g. https://ghe.example.com/api/v3)", g.APIURL)
+	}
+	for name, ctx := range cfg.Contexts {
+		if ctx.APIURL != "" {
+			u, err := url.Parse(ctx.APIURL)
+			check("contexts", err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+				"context %q has an invalid API URL %q (e.g. https://ghe.example.com/api/v3)", name, ctx.APIURL)
+		}
+	}
+	for name, p := range cfg.Profiles {
+		path := "profiles." + name
+		check(path+".depth", p.Depth == "" || contains(ValidDepths, p.Depth),
+			"invalid depth %q (valid: %s)", p.Depth, strings.Join(ValidDepths, ", "))
+		check(path+".output_mode", p.OutputMode == "" || contains(ValidOutputModes, p.OutputMode),
+			"invalid output mode %q (valid: %s)", p.OutputMode, strings.Join(ValidOutputModes, ", "))
+		check(path+".fail_under", p.FailUnder >= 0 && p.FailUnder <= 100, "must be between 0 and 100 (got %d)", p.FailUnder)
+	}
+	for repo, weight := range g.RepoWeights {
+		check("global.repo_weights", weight >= 0, "weight for %s must not be negative (got %g)", repo, weight)
+	}
+
+	if cfg.Cache.DefaultTTL != "" {
+		_, err := time.ParseDuration(cfg.Cache.DefaultTTL)
+		check("cache.default_ttl", err == nil, "invalid duration %q (e.g. 1h, 30m)", cfg.Cache.DefaultTTL)
+	}
+	for prefix, ttl := range cfg.Cache.TTL {
+		if _, err := time.ParseDuration(ttl); err != nil {
+			v.problems = append(v.problems, Problem{Line: v.lines["cache.ttl"], Field: "cache.ttl." + prefix,
+				Message: fmt.Sprintf("invalid duration %q (e.g. 24h, 5m)", ttl)})
+		}
+	}
+
+	sc := cfg.Scoring
+	for path, val := range map[string]*int{
+		"scoring.ci_failing":             sc.CIFailing,
+		"scoring.ci_unstable":            sc.CIUnstable,
+		"scoring.bus_factor":             sc.BusFactor,
+		"scoring.zombie_issues_high":     sc.ZombiesHigh,
+		"scoring.zombie_issues_moderate": sc.ZombiesModerate,
+		"scoring.missing_file":           sc.MissingFile,
+		"scoring.stale_prs":              sc.StalePRs,
+	} {
+		if val != nil {
+			check(path, *val >= 0, "must not be negative (0 disables the deduction)")
+		}
+	}
+	if sc.MissingFilesMax != nil {
+		check("scoring.missing_files_max", *sc.MissingFilesMax >= 0, "must not be negative (0 leaves the deduction uncapped)")
+	}
+
+	in := cfg.Insights
+	for path, val := range map[string]*float64{
+		"insights.ci_success_critical": in.CICritical,
+		"insights.ci_success_warning":  in.CIWarning,
+		"insights.self_merge_rate":     in.SelfMergeRate,
+	} {
+		if val != nil {
+			check(path, *val >= 0 && *val <= 100, "must be between 0 and 100 (got %g)", *val)
+		}
+	}
+	if in.ZombieIssues != nil {
+		check("insights.zombie_issues", *in.ZombieIssues >= 0, "must not be negative")
+	}
+	if in.CycleTimeHours != nil {
+		check("insights.cycle_time_hours", *in.CycleTimeHours >= 0, "must not be negative")
+	}
+	if in.CICritical != nil && in.CIWarning != nil {
+		check("insights.ci_success_warning", *in.CIWarning >= *in.CICritical,
+			"should not be lower than ci_success_critical (%g < %g)", *in.CIWarning, *in.CICritical)
+	}
+
+	a := cfg.Analyzers
+	for path, val := range map[string]int{
+		"analyzers.pr_flow.params.stale_threshold_days":          a.PRFlow.Params.StaleThresholdDays,
+		"analyzers.issue_hygiene.params.stale_threshold_days":    a.IssueHygiene.Params.StaleThresholdDays,
+		"analyzers.issue_hygiene.params.zombie_threshold_days":   a.IssueHygiene.Params.ZombieThresholdDays,
+		"analyzers.branches.params.stale_threshold_days":         a.Branches.Params.StaleThresholdDays,
+		"analyzers.branches.params.divergence_threshold_commits": a.Branches.Params.DivergenceThresholdCommits,
+	} {
+		check(path, val > 0, "must be greater than 0 (got %d)", val)
+	}
+	threshold := a.Activity.Params.ConventionalCommitThreshold
+	check("analyzers.activity.params.conventional_commit_threshold", threshold >= 0 && threshold <= 100,
+		"must be between 0 and 100 (got %d)", threshold)
+	successRate := a.Deployments.Params.SuccessRateThreshold
+	check("analyzers.deployments.params.success_rate_threshold", successRate >= 0 && successRate <= 100,
+		"must be between 0 and 100 (got %d)", successRate)
+	if n := a.PRFlow.Params.SizeSampleSize; n != nil {
+		check("analyzers.pr_flow.params.size_sample_size", *n >= 0, "must not be negative (0 disables PR size metrics)")
+	}
+	check("analyzers.issue_hygiene.params.untriaged_threshold", a.IssueHygiene.Params.UntriagedThreshold >= 0,
+		"must not be negative (0 disables the finding)")
+	check("analyzers.issue_hygiene.params.response_sla_hours", a.IssueHygiene.Params.ResponseSLAHours >= 0,
+		"must not be negative (0 disables the SLA)")
+	for group, prefixes := range a.IssueHygiene.Params.LabelGroups {
+		check("analyzers.issue_hygiene.params.label_groups", len(prefixes) > 0, "group %q has no labels", group)
+	}
+	for path, val := range map[string]*int{
+		"analyzers.repo_health.params.large_file_mb": a.RepoHealth.Params.LargeFileMB,
+		"analyzers.repo_health.params.large_tree_mb": a.RepoHealth.Params.LargeTreeMB,
+	} {
+		if val != nil {
+			check(path, *val >= 0, "must not be negative (0 disables the check)")
+		}
+	}
+	for i, f := range a.RepoHealth.RequiredFiles {
+		check("analyzers.repo_health.required_files", f.Path != "", "entry %d has no path", i+1)
+		check("analyzers.repo_health.required_files", f.Severity == "" || contains(ValidSeverities, strings.ToLower(f.Severity)),
+			"entry %d (%s) has invalid severity %q (valid: %s)", i+1, f.Path, f.Severity, strings.Join(ValidSeverities, ", "))
+		check("analyzers.repo_health.required_files", f.Deduction >= 0, "entry %d (%s) has a negative deduction", i+1, f.Path)
+	}
+	for i, p := range a.Branches.Params.NamingPatterns {
+		_, err := regexp.Compile(p)
+		check("analyzers.branches.params.naming_patterns", p != "" && err == nil, "entry %d (%q) is not a valid regex", i+1, p)
+	}
+	for i, d := range a.Contributors.Params.InternalDomains {
+		check("analyzers.contributors.params.internal_domains", d != "" && !strings.Contains(strings.TrimPrefix(d, "@"), "@"),
+			"entry %d (%q) is not an email domain (e.g. example.com)", i+1, d)
+	}
+	stale, zombie := a.IssueHygiene.Params.StaleThresholdDays, a.IssueHygiene.Params.ZombieThresholdDays
+	check("analyzers.issue_hygiene.params.zombie_threshold_days", zombie <= 0 || zombie >= stale,
+		"should not be lower than stale_threshold_days (%d < %d)", zombie, stale)
+}
+
+// yamlFields maps yaml key names to field types for a struct
+func yamlFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+func sortedKeys(m map[string]reflect.Type) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
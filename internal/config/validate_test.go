@@ -0,0 +1,259 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	data := []byte(`global:
+  concurrency: 0
+  output_mode: verbose
+  timeout: "2m"
+cache:
+  default_ttl: "soon"
+scoring:
+  ci_failing: -5
+analyzers:
+  pr_flow:
+    enabled: true
+    params:
+      stale_threshold_days: abc
+  code_review:
+    enabled: true
+  issue_hygiene:
+    params:
+      stale_threshold_days: 60
+      zombie_threshold_days: 30
+`)
+
+	problems, err := Validate(data)
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+
+	want := map[string]struct {
+		line    int
+		warning bool
+	}{
+		"global.concurrency":                                   {2, false},
+		"global.output_mode":                                   {3, false},
+		"global.timeout":                                       {4, true},
+		"cache.default_ttl":                                    {6, false},
+		"scoring.ci_failing":                                   {8, false},
+		"analyzers.pr_flow.params.stale_threshold_days":        {13, false},
+		"analyzers.code_review":                                {14, true},
+		"analyzers.issue_hygiene.params.zombie_threshold_days": {19, false},
+	}
+
+	got := make(map[string]Problem)
+	for _, p := range problems {
+		got[p.Field] = p
+	}
+	for field, w := range want {
+		p, ok := got[field]
+		if !ok {
+			t.Errorf("Expected a problem for %s, got %v", field, problems)
+			continue
+		}
+		if p.Line != w.line || p.Warning != w.warning {
+			t.Errorf("%s: got line %d warning %v, want line %d warning %v", field, p.Line, p.Warning, w.line, w.warning)
+		}
+	}
+	if len(problems) != len(want) {
+		t.Errorf("Expected %d problems, got %d: %v", len(want), len(problems), problems)
+	}
+
+	if !strings.Contains(got["analyzers.code_review"].Message, "pr_flow") {
+		t.Errorf("Expected unknown analyzer message to list valid analyzers, got %q", got["analyzers.code_review"].Message)
+	}
+}
+
+func TestValidateCleanConfig(t *testing.T) {
+	problems, err := Validate([]byte(`global:
+  concurrency: 5
+  output_mode: suggestive
+cache:
+  default_ttl: 1h
+  ttl:
+    "repo:": 24h
+analyzers:
+  branches:
+    params:
+      divergence_threshold_commits: 50
+`))
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+}
+
+func TestValidateInvalidYAML(t *testing.T) {
+	if _, err := Validate([]byte("global:\n  concurrency: [1,\n")); err == nil {
+		t.Error("Expected error for malformed YAML")
+	}
+}
+
+func TestValidateRequiredFiles(t *testing.T) {
+	problems, err := Validate([]byte(`analyzers:
+  repo_health:
+    required_files:
+      - path: SUPPORT.md
+        severity: low
+        deduction: 5
+      - path: .github/dependabot.yml
+        alt_paths: [.github/dependabot.yaml]
+        severity: urgent
+        deduction: -10
+`))
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %v", problems)
+	}
+	for _, p := range problems {
+		if p.Field != "analyzers.repo_health.required_files" || p.Line != 3 || !strings.Contains(p.Message, "entry 2") {
+			t.Errorf("Unexpected problem: %+v", p)
+		}
+	}
+}
+
+func TestValidateHealthScoreWeighting(t *testing.T) {
+	problems, err := Validate([]byte(`global:
+  health_score_weighting: forks
+  repo_weights:
+    my-org/api: -1
+`))
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %v", problems)
+	}
+	if problems[0].Field != "global.health_score_weighting" || problems[1].Field != "global.repo_weights" {
+		t.Errorf("Unexpected problems: %v", problems)
+	}
+}
+
+func TestValidateConcurrencyMode(t *testing.T) {
+	problems, err := Validate([]byte("global:\n  concurrency_mode: adaptive\n"))
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Field != "global.concurrency_mode" || problems[0].Line != 2 {
+		t.Errorf("Unexpected problems: %v", problems)
+	}
+
+	problems, _ = Validate([]byte("global:\n  concurrency_mode: auto\n"))
+	if len(problems) != 0 {
+		t.Errorf("Expected auto to be valid, got %v", problems)
+	}
+}
+
+func TestValidateProxyURL(t *testing.T) {
+	problems, err := Validate([]byte("global:\n  proxy_url: proxy.example.com:8080\n"))
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Field != "global.proxy_url" {
+		t.Errorf("Unexpected problems: %v", problems)
+	}
+
+	problems, _ = Validate([]byte("global:\n  proxy_url: http://proxy.example.com:8080\n"))
+	if len(problems) != 0 {
+		t.Errorf("Expected a full proxy URL to be valid, got %v", problems)
+	}
+}
+
+func TestValidateAPIURL(t *testing.T) {
+	problems, err := Validate([]byte("global:\n  api_url: ghe.example.com\n"))
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Field != "global.api_url" {
+		t.Errorf("Unexpected problems: %v", problems)
+	}
+
+	problems, _ = Validate([]byte("global:\n  api_url: https://ghe.example.com/api/v3\n"))
+	if len(problems) != 0 {
+		t.Errorf("Expected a full API URL to be valid, got %v", problems)
+	}
+
+	problems, _ = Validate([]byte("contexts:\n  client:\n    api_url: ghe.example.com\n  work: {}\n"))
+	if len(problems) != 1 || problems[0].Field != "contexts" || !strings.Contains(problems[0].Message, `"client"`) {
+		t.Errorf("Expected the context's API URL to be invalid, got %v", problems)
+	}
+}
+
+func TestValidateInsightThresholds(t *testing.T) {
+	problems, err := Validate([]byte("insights:\n  ci_success_critical: 90\n  ci_success_warning: 70\n  self_merge_rate: 150\n  zombie_issues: 5\n"))
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	fields := make(map[string]bool)
+	for _, p := range problems {
+		fields[p.Field] = true
+	}
+	if len(problems) != 2 || !fields["insights.ci_success_warning"] || !fields["insights.self_merge_rate"] {
+		t.Errorf("Unexpected problems: %v", problems)
+	}
+}
+
+func TestValidateContributorDomains(t *testing.T) {
+	problems, err := Validate([]byte("analyzers:\n  contributors:\n    params:\n      internal_domains: [example.com, alice@example.com]\n"))
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Field != "analyzers.contributors.params.internal_domains" {
+		t.Errorf("Unexpected problems: %v", problems)
+	}
+}
+
+func TestValidateBranchNamingPatterns(t *testing.T) {
+	problems, err := Validate([]byte("analyzers:\n  branches:\n    params:\n      naming_patterns: [\"^feature/\", \"^(bugfix/\"]\n"))
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Field != "analyzers.branches.params.naming_patterns" || !strings.Contains(problems[0].Message, "entry 2") {
+		t.Errorf("Unexpected problems: %v", problems)
+	}
+}
+
+func TestValidateProfiles(t *testing.T) {
+	data := []byte(`profiles:
+  ci:
+    depth: shallow
+    fail_under: 70
+  nightly:
+    depht: deep
+    depth: thorough
+    output_mode: loud
+    fail_under: 120
+`)
+	problems, err := Validate(data)
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+
+	want := map[string]struct {
+		line    int
+		warning bool
+	}{
+		"profiles.nightly.depht":       {6, true},
+		"profiles.nightly.depth":       {7, false},
+		"profiles.nightly.output_mode": {8, false},
+		"profiles.nightly.fail_under":  {9, false},
+	}
+	if len(problems) != len(want) {
+		t.Errorf("Expected %d problems, got %v", len(want), problems)
+	}
+	for _, p := range problems {
+		w, ok := want[p.Field]
+		if !ok || p.Line != w.line || p.Warning != w.warning {
+			t.Errorf("Unexpected problem: %+v", p)
+		}
+	}
+}
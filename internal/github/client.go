@@ -3,8 +3,10 @@ package github
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +14,8 @@ import (
 	"github.com/google/go-github/v60/github"
 	"github.com/mikematt33/gh-inspect/internal/analysis"
 	"github.com/mikematt33/gh-inspect/internal/cache"
+	"github.com/mikematt33/gh-inspect/internal/keyring"
+	"github.com/mikematt33/gh-inspect/internal/logging"
 )
 
 // Ensure ClientWrapper satisfies the interface
@@ -21,22 +25,82 @@ var _ analysis.Client = (*ClientWrapper)(nil)
 type ClientWrapper struct {
 	client    *github.Client
 	repoCache map[string]*github.Repository
-	cacheMu   sync.RWMutex
-	diskCache *cache.Cache
-	useCache  bool
+	// overviewCache shares GraphQL overviews between analyzers scanning the same repo
+	overviewCache map[string]*analysis.RepoOverview
+	cacheMu       sync.RWMutex
+	diskCache     *cache.Cache
+	useCache      bool
+
+	// Retry policy for transient API errors (see retryTransport)
+	maxAttempts    int
+	retryBaseDelay time.Duration
+	// secondaryDelay is the wait after a secondary rate limit without a Retry-After header
+	secondaryDelay time.Duration
+	// secondaryUntil ends the current secondary rate limit backoff, which requests from
+	// every goroutine wait out before they are sent
+	secondaryUntil time.Time
+	secondaryMu    sync.Mutex
+
+	// logOut receives rate limit warnings (nil = stderr)
+	logOut io.Writer
 }
 
-// ResolveToken attempts to find a GitHub token from:
+// Keyring entry used to store the token saved by 'gh-inspect auth'
+const (
+	keyringService = "gh-inspect"
+	keyringUser    = "github-token"
+)
+
+// keyringUserFor returns the keyring entry of an auth context; "" is the default context
+func keyringUserFor(contextName string) string {
+	if contextName == "" {
+		return keyringUser
+	}
+	return keyringUser + ":" + contextName
+}
+
+// KeyringToken returns the token of contextName stored in the OS keyring, or "" if there is none
+func KeyringToken(contextName string) string {
+	token, err := keyring.Get(keyringService, keyringUserFor(contextName))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// SaveKeyringToken stores the token of contextName in the OS keyring
+func SaveKeyringToken(contextName, token string) error {
+	return keyring.Set(keyringService, keyringUserFor(contextName), token)
+}
+
+// DeleteKeyringToken removes the token of contextName from the OS keyring.
+// It returns keyring.ErrNotFound when no token was stored.
+func DeleteKeyringToken(contextName string) error {
+	return keyring.Delete(keyringService, keyringUserFor(contextName))
+}
+
+// ResolveToken attempts to find the GitHub token of an auth context from:
 // 1. Config file (if passed)
-// 2. "gh auth token" command
-// 3. GITHUB_TOKEN environment variable
-func ResolveToken(configToken string) string {
+// 2. OS keyring
+// 3. "gh auth token" command
+// 4. GITHUB_TOKEN environment variable
+// The gh CLI and GITHUB_TOKEN belong to the default context (""), so a named context
+// only resolves from the config file and its own keyring entry.
+func ResolveToken(contextName, configToken string) string {
 	if configToken != "" {
 		return configToken
 	}
 
-	// 2. Try gh CLI
-	cmd := exec.Command("gh", "auth", "token")
+	// 2. Try OS keyring
+	if token := KeyringToken(contextName); token != "" {
+		return token
+	}
+	if contextName != "" {
+		return ""
+	}
+
+	// 3. Try gh CLI
+	cmd := exec.Command("gh", GhCLIArgs("auth", "token")...)
 	out, err := cmd.Output()
 	if err == nil {
 		token := strings.TrimSpace(string(out))
@@ -45,7 +109,7 @@ func ResolveToken(configToken string) string {
 		}
 	}
 
-	// 2. Try Env var
+	// 4. Try Env var
 	return os.Getenv("GITHUB_TOKEN")
 }
 
@@ -56,25 +120,48 @@ func NewClient(token string) *ClientWrapper {
 
 // NewClientWithCache creates a new GitHub client wrapper with cache control.
 func NewClientWithCache(token string, useCache bool) *ClientWrapper {
-	var ghClient *github.Client
-	if token == "" {
-		ghClient = github.NewClient(nil)
-	} else {
-		ghClient = github.NewClient(nil).WithAuthToken(token)
-	}
+	return NewClientWithCacheTTL(token, useCache, time.Hour, nil)
+}
 
+// NewClientWithCacheTTL creates a new GitHub client wrapper whose disk cache uses
+// defaultTTL for all keys except those matching a prefix in prefixTTLs.
+func NewClientWithCacheTTL(token string, useCache bool, defaultTTL time.Duration, prefixTTLs map[string]time.Duration) *ClientWrapper {
 	wrapper := &ClientWrapper{
-		client:    ghClient,
-		repoCache: make(map[string]*github.Repository),
-		useCache:  useCache,
+		repoCache:     make(map[string]*github.Repository),
+		overviewCache: make(map[string]*analysis.RepoOverview),
+		useCache:      useCache,
+		maxAttempts:   DefaultMaxAttempts,
 	}
 
+	// Retries sit below go-github so every request is covered, including those made
+	// through GetUnderlyingClient
+	httpClient := NewHTTPClient(0)
+	httpClient.Transport = &retryTransport{base: httpClient.Transport, client: wrapper}
+	ghClient := github.NewClient(httpClient)
+	if token != "" {
+		ghClient = ghClient.WithAuthToken(token)
+	}
+	if apiURL != nil {
+		// SetAPIURL already validated the URL, so this cannot fail
+		uploadURL := apiURL.Scheme + "://" + apiURL.Host + "/"
+		if enterprise, err := ghClient.WithEnterpriseURLs(apiURL.String(), uploadURL); err == nil {
+			ghClient = enterprise
+		}
+	}
+
+	wrapper.client = ghClient
+
 	// Initialize disk cache if enabled
 	if useCache {
 		cachePath, err := cache.GetDefaultCachePath()
+		if err == nil && apiURL != nil {
+			// Keep responses from different GitHub instances apart
+			cachePath = filepath.Join(cachePath, "hosts", apiURL.Host)
+		}
 		if err == nil {
-			c, err := cache.New(cachePath, time.Hour)
+			c, err := cache.New(cachePath, defaultTTL)
 			if err == nil {
+				c.SetPrefixTTLs(withListTTLs(prefixTTLs))
 				wrapper.diskCache = c
 			}
 		}
@@ -83,15 +170,32 @@ func NewClientWithCache(token string, useCache bool) *ClientWrapper {
 	return wrapper
 }
 
-// checkRateLimit inspects the response for rate limit headers
-func (c *ClientWrapper) checkRateLimit(resp *github.Response) {
+// SetLogOutput sends rate limit warnings to w instead of stderr, e.g. to keep them
+// from breaking up a progress bar
+func (c *ClientWrapper) SetLogOutput(w io.Writer) {
+	c.logOut = w
+}
+
+func (c *ClientWrapper) logf(format string, args ...any) {
+	w := c.logOut
+	if w == nil {
+		w = os.Stderr
+	}
+	_, _ = fmt.Fprintf(w, format, args...)
+}
+
+// checkRateLimit inspects the response for rate limit headers. When the limit is
+// exhausted it waits for the reset, or until ctx is done (--timeout or an interrupt).
+func (c *ClientWrapper) checkRateLimit(ctx context.Context, resp *github.Response) {
 	if resp == nil {
 		return
 	}
 
+	logging.Debug("rate limit", "remaining", resp.Rate.Remaining, "limit", resp.Rate.Limit, "reset", resp.Rate.Reset.Time)
+
 	// Simple warning if low
 	if resp.Rate.Remaining < 50 {
-		fmt.Fprintf(os.Stderr, "⚠️ GitHub Rate Limit Low: %d/%d (Resets at %s)\n",
+		c.logf("⚠️ GitHub Rate Limit Low: %d/%d (Resets at %s)\n",
 			resp.Rate.Remaining, resp.Rate.Limit, resp.Rate.Reset)
 	}
 
@@ -100,8 +204,11 @@ func (c *ClientWrapper) checkRateLimit(resp *github.Response) {
 	if resp.Rate.Remaining == 0 {
 		sleepDuration := time.Until(resp.Rate.Reset.Time)
 		if sleepDuration > 0 {
-			fmt.Fprintf(os.Stderr, "⛔ Rate limit exceeded. Sleeping for %v...\n", sleepDuration)
-			time.Sleep(sleepDuration + 1*time.Second)
+			c.logf("⛔ Rate limit exceeded. Sleeping for %v...\n", sleepDuration)
+			select {
+			case <-ctx.Done():
+			case <-time.After(sleepDuration + 1*time.Second):
+			}
 		}
 	}
 }
@@ -115,6 +222,41 @@ func (c *ClientWrapper) GetRateLimit(ctx context.Context) (*github.Rate, error)
 	return startRate(rates.Core), nil
 }
 
+// TokenScopes returns the OAuth scopes granted to the client's token, read from the
+// X-OAuth-Scopes header of a request to the API root. The boolean is false when the
+// header is absent, as with fine-grained personal access tokens and GitHub App tokens.
+func (c *ClientWrapper) TokenScopes(ctx context.Context) ([]string, bool, error) {
+	req, err := c.client.NewRequest("GET", "", nil)
+	if err != nil {
+		return nil, false, err
+	}
+	resp, err := c.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	header, ok := resp.Header["X-Oauth-Scopes"]
+	if !ok {
+		return nil, false, nil
+	}
+	return parseScopes(strings.Join(header, ",")), true, nil
+}
+
+// GetTokenScopes returns the OAuth scopes granted to token (see ClientWrapper.TokenScopes)
+func GetTokenScopes(token string) ([]string, bool, error) {
+	return NewClientWithCache(token, false).TokenScopes(context.Background())
+}
+
+func parseScopes(header string) []string {
+	scopes := []string{}
+	for _, s := range strings.Split(header, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
 func startRate(r *github.Rate) *github.Rate {
 	return r
 }
@@ -141,7 +283,7 @@ func (c *ClientWrapper) ListUserRepositories(ctx context.Context, user string, o
 		if err != nil {
 			return nil, err
 		}
-		c.checkRateLimit(resp)
+		c.checkRateLimit(ctx, resp)
 		allRepos = append(allRepos, repos...)
 
 		if resp.NextPage == 0 {
@@ -160,26 +302,73 @@ func (c *ClientWrapper) GetUnderlyingClient() *github.Client {
 // GetPullRequests implements analysis.Client.
 // Returns a single page of pull requests - callers should handle pagination if needed
 func (c *ClientWrapper) GetPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error) {
-	prs, resp, err := c.client.PullRequests.List(ctx, owner, repo, opts)
-	if resp != nil {
-		c.checkRateLimit(resp)
-	}
+	prs, _, err := c.listPullRequests(ctx, owner, repo, opts)
 	return prs, err
 }
 
+// listPullRequests fetches one page of pull requests through the list cache
+func (c *ClientWrapper) listPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+	return cachedList(ctx, c, listCacheKey("pulls:", owner, repo, opts), func() ([]*github.PullRequest, *github.Response, error) {
+		return c.client.PullRequests.List(ctx, owner, repo, opts)
+	})
+}
+
+// GetPullRequestsSince implements analysis.Client. Pages are sorted by most recently
+// updated, so paging stops at the first PR last updated before since.
+func (c *ClientWrapper) GetPullRequestsSince(ctx context.Context, owner, repo string, since time.Time, maxPages int) ([]*github.PullRequest, error) {
+	var allPRs []*github.PullRequest
+	opts := &github.PullRequestListOptions{
+		State:       "all",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for page := 0; maxPages == 0 || page < maxPages; page++ {
+		prs, resp, err := c.listPullRequests(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range prs {
+			if pr.GetUpdatedAt().Before(since) {
+				return allPRs, nil
+			}
+			allPRs = append(allPRs, pr)
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return allPRs, nil
+}
+
 // GetReviews implements analysis.Client.
 func (c *ClientWrapper) GetReviews(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, error) {
 	reviews, resp, err := c.client.PullRequests.ListReviews(ctx, owner, repo, number, opts)
 	if resp != nil {
-		c.checkRateLimit(resp)
+		c.checkRateLimit(ctx, resp)
 	}
 	return reviews, err
 }
 
+// ListStargazers returns one page of stargazers. go-github requests the star media type,
+// so each entry carries StarredAt.
+func (c *ClientWrapper) ListStargazers(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Stargazer, error) {
+	stargazers, resp, err := c.client.Activity.ListStargazers(ctx, owner, repo, opts)
+	if resp != nil {
+		c.checkRateLimit(ctx, resp)
+	}
+	return stargazers, err
+}
+
 // ListCommitsSince implements Smart Pagination for commits
-func (c *ClientWrapper) ListCommitsSince(ctx context.Context, owner, repo string, since time.Time) ([]*github.RepositoryCommit, error) {
+func (c *ClientWrapper) ListCommitsSince(ctx context.Context, owner, repo, ref string, since time.Time) ([]*github.RepositoryCommit, error) {
 	var allCommits []*github.RepositoryCommit
 	opts := &github.CommitsListOptions{
+		SHA:         ref, // empty lists the default branch
 		ListOptions: github.ListOptions{PerPage: 100},
 		Since:       since, // GitHub API handles filtering naturally here
 	}
@@ -192,7 +381,7 @@ func (c *ClientWrapper) ListCommitsSince(ctx context.Context, owner, repo string
 		allCommits = append(allCommits, commits...)
 
 		if resp != nil {
-			c.checkRateLimit(resp)
+			c.checkRateLimit(ctx, resp)
 			if resp.NextPage == 0 {
 				break
 			}
@@ -247,7 +436,15 @@ func (c *ClientWrapper) GetRepository(ctx context.Context, owner, repo string) (
 }
 
 func (c *ClientWrapper) GetContent(ctx context.Context, owner, repo, path string) (*github.RepositoryContent, []*github.RepositoryContent, error) {
-	fileContent, dirContent, _, err := c.client.Repositories.GetContents(ctx, owner, repo, path, nil)
+	return c.GetContentAtRef(ctx, owner, repo, path, "")
+}
+
+func (c *ClientWrapper) GetContentAtRef(ctx context.Context, owner, repo, path, ref string) (*github.RepositoryContent, []*github.RepositoryContent, error) {
+	var opts *github.RepositoryContentGetOptions
+	if ref != "" {
+		opts = &github.RepositoryContentGetOptions{Ref: ref}
+	}
+	fileContent, dirContent, _, err := c.client.Repositories.GetContents(ctx, owner, repo, path, opts)
 	return fileContent, dirContent, err
 }
 
@@ -259,47 +456,42 @@ func (c *ClientWrapper) GetCombinedStatus(ctx context.Context, owner, repo, ref
 func (c *ClientWrapper) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error) {
 	pr, resp, err := c.client.PullRequests.Get(ctx, owner, repo, number)
 	if resp != nil {
-		c.checkRateLimit(resp)
+		c.checkRateLimit(ctx, resp)
 	}
 	return pr, err
 }
 
 // GetIssues implements analysis.Client.
-// Auto-paginates up to a reasonable limit to avoid excessive API calls
+// GetIssues fetches a single page of issues (pull requests are filtered out) and advances
+// opts.Page to the next page, leaving it at 0 once the last page has been read.
+// Callers paginate themselves so they can stop as soon as their limit is reached.
 func (c *ClientWrapper) GetIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, error) {
-	var allIssues []*github.Issue
-
 	if opts.PerPage == 0 {
 		opts.PerPage = 100
 	}
 
-	// Prevent unbounded pagination - caller should handle limits
-	// This method will paginate automatically but not infinitely
-	maxPages := 5 // Maximum 5 pages (500 issues with perPage=100)
-	pageCount := 0
-
-	for {
-		issues, resp, err := c.client.Issues.ListByRepo(ctx, owner, repo, opts)
-		if err != nil {
-			return nil, err
-		}
-		if resp != nil {
-			c.checkRateLimit(resp)
-		}
+	// The window start changes on every run, so key on the hour to let re-runs share pages
+	keyOpts := *opts
+	keyOpts.Since = opts.Since.Truncate(time.Hour)
+	issues, resp, err := cachedList(ctx, c, listCacheKey("issues:", owner, repo, keyOpts), func() ([]*github.Issue, *github.Response, error) {
+		return c.client.Issues.ListByRepo(ctx, owner, repo, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		for _, issue := range issues {
-			if !issue.IsPullRequest() {
-				allIssues = append(allIssues, issue)
-			}
-		}
+	opts.Page = 0
+	if resp != nil {
+		opts.Page = resp.NextPage
+	}
 
-		pageCount++
-		if resp == nil || resp.NextPage == 0 || pageCount >= maxPages {
-			break
+	var result []*github.Issue
+	for _, issue := range issues {
+		if !issue.IsPullRequest() {
+			result = append(result, issue)
 		}
-		opts.Page = resp.NextPage
 	}
-	return allIssues, nil
+	return result, nil
 }
 
 func (c *ClientWrapper) GetIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, error) {
@@ -319,7 +511,7 @@ func (c *ClientWrapper) GetIssueComments(ctx context.Context, owner, repo string
 		if err != nil {
 			return nil, err
 		}
-		c.checkRateLimit(resp)
+		c.checkRateLimit(ctx, resp)
 		all = append(all, comments...)
 
 		pageCount++
@@ -333,11 +525,9 @@ func (c *ClientWrapper) GetIssueComments(ctx context.Context, owner, repo string
 
 // GetWorkflowRuns implements analysis.Client.
 func (c *ClientWrapper) GetWorkflowRuns(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
-	runs, resp, err := c.client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
-	if resp != nil {
-		c.checkRateLimit(resp)
-	}
-	return runs, resp, err
+	return cachedList(ctx, c, listCacheKey("workflow:", owner, repo, opts), func() (*github.WorkflowRuns, *github.Response, error) {
+		return c.client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
+	})
 }
 
 // ListRepositories implements analysis.Client.
@@ -358,7 +548,7 @@ func (c *ClientWrapper) ListRepositories(ctx context.Context, org string, opts *
 
 	repos, resp, err := c.client.Repositories.ListByOrg(ctx, org, opts)
 	if resp != nil {
-		c.checkRateLimit(resp)
+		c.checkRateLimit(ctx, resp)
 	}
 
 	// If the caller wants all pages, they can't easily do it with this signature returning just []*Repo
@@ -390,7 +580,7 @@ func (c *ClientWrapper) ListRepositories(ctx context.Context, org string, opts *
 			if err != nil {
 				return nil, err
 			}
-			c.checkRateLimit(nextResp)
+			c.checkRateLimit(ctx, nextResp)
 			allRepos = append(allRepos, repos...)
 			resp = nextResp
 		}
@@ -405,9 +595,151 @@ func (c *ClientWrapper) GetTree(ctx context.Context, owner, repo, sha string, re
 	return tree, err
 }
 
-// Note: Future optimization opportunity - Implement GraphQL queries for batching
-// GraphQL could combine multiple REST calls into single queries, e.g.:
-// - Fetch repo metadata + branch protection + CI status in one query
-// - Batch PR queries with review data included
-// - Get multiple file contents or tree in one query
-// This would significantly reduce API calls for analyzers that need related data
+// repoOverviewQuery fetches everything repohealth and activity need in a single request.
+// The root and .github trees are resolved via HEAD, which points at the default branch.
+const repoOverviewQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    url
+    stargazerCount
+    forkCount
+    watchers { totalCount }
+    defaultBranchRef {
+      name
+      branchProtectionRule {
+        requiresApprovingReviews
+        requiredApprovingReviewCount
+        requiresCodeOwnerReviews
+        requiresStatusChecks
+      }
+    }
+    root: object(expression: "HEAD:") { ... on Tree { entries { name } } }
+    dotGithub: object(expression: "HEAD:.github") { ... on Tree { entries { name } } }
+  }
+}`
+
+type graphQLTree struct {
+	Entries []struct {
+		Name string `json:"name"`
+	} `json:"entries"`
+}
+
+type repoOverviewResponse struct {
+	Data struct {
+		Repository *struct {
+			URL            string `json:"url"`
+			StargazerCount int    `json:"stargazerCount"`
+			ForkCount      int    `json:"forkCount"`
+			Watchers       struct {
+				TotalCount int `json:"totalCount"`
+			} `json:"watchers"`
+			DefaultBranchRef *struct {
+				Name                 string `json:"name"`
+				BranchProtectionRule *struct {
+					RequiresApprovingReviews     bool `json:"requiresApprovingReviews"`
+					RequiredApprovingReviewCount int  `json:"requiredApprovingReviewCount"`
+					RequiresCodeOwnerReviews     bool `json:"requiresCodeOwnerReviews"`
+					RequiresStatusChecks         bool `json:"requiresStatusChecks"`
+				} `json:"branchProtectionRule"`
+			} `json:"defaultBranchRef"`
+			Root      *graphQLTree `json:"root"`
+			DotGithub *graphQLTree `json:"dotGithub"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphqlPath returns the GraphQL endpoint relative to the REST base URL: /graphql on
+// api.github.com, and /api/graphql beside /api/v3/ on GitHub Enterprise Server
+func (c *ClientWrapper) graphqlPath() string {
+	if strings.HasSuffix(c.client.BaseURL.Path, "/api/v3/") {
+		return "../graphql"
+	}
+	return "graphql"
+}
+
+// GetRepoOverview implements analysis.Client.
+// Replaces the separate repository, tree and branch protection REST calls with one GraphQL query.
+// Callers should fall back to the REST methods when this returns an error.
+func (c *ClientWrapper) GetRepoOverview(ctx context.Context, owner, repo string) (*analysis.RepoOverview, error) {
+	cacheKey := fmt.Sprintf("overview:%s/%s", owner, repo)
+
+	c.cacheMu.RLock()
+	if cached, ok := c.overviewCache[cacheKey]; ok {
+		c.cacheMu.RUnlock()
+		return cached, nil
+	}
+	c.cacheMu.RUnlock()
+
+	if c.diskCache != nil {
+		var cached analysis.RepoOverview
+		if found, err := c.diskCache.Get(cacheKey, &cached); err == nil && found {
+			c.cacheMu.Lock()
+			c.overviewCache[cacheKey] = &cached
+			c.cacheMu.Unlock()
+			return &cached, nil
+		}
+	}
+
+	body := map[string]interface{}{
+		"query":     repoOverviewQuery,
+		"variables": map[string]string{"owner": owner, "name": repo},
+	}
+	var out repoOverviewResponse
+	req, err := c.client.NewRequest("POST", c.graphqlPath(), body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(ctx, req, &out)
+	if resp != nil {
+		c.checkRateLimit(ctx, resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(out.Errors) > 0 {
+		return nil, fmt.Errorf("graphql: %s", out.Errors[0].Message)
+	}
+	r := out.Data.Repository
+	if r == nil {
+		return nil, fmt.Errorf("graphql: repository %s/%s not found", owner, repo)
+	}
+
+	overview := &analysis.RepoOverview{
+		URL:      r.URL,
+		Stars:    r.StargazerCount,
+		Forks:    r.ForkCount,
+		Watchers: r.Watchers.TotalCount,
+	}
+	if r.DefaultBranchRef != nil {
+		overview.DefaultBranch = r.DefaultBranchRef.Name
+		if rule := r.DefaultBranchRef.BranchProtectionRule; rule != nil {
+			overview.BranchProtected = true
+			// REST reports required_pull_request_reviews whenever any review setting is on
+			overview.RequiresPRReviews = rule.RequiresApprovingReviews || rule.RequiredApprovingReviewCount > 0 || rule.RequiresCodeOwnerReviews
+			overview.RequiresStatusChecks = rule.RequiresStatusChecks
+		}
+	}
+	if r.Root != nil {
+		overview.Paths = make([]string, 0, len(r.Root.Entries))
+		for _, e := range r.Root.Entries {
+			overview.Paths = append(overview.Paths, e.Name)
+		}
+		if r.DotGithub != nil {
+			for _, e := range r.DotGithub.Entries {
+				overview.Paths = append(overview.Paths, ".github/"+e.Name)
+			}
+		}
+	}
+
+	c.cacheMu.Lock()
+	c.overviewCache[cacheKey] = overview
+	c.cacheMu.Unlock()
+
+	if c.diskCache != nil {
+		_ = c.diskCache.Set(cacheKey, overview)
+	}
+
+	return overview, nil
+}
@@ -0,0 +1,148 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+func TestGetPullRequestsSincePaginates(t *testing.T) {
+	now := time.Now().UTC()
+	since := now.Add(-72 * time.Hour)
+	updated := func(h int) string { return now.Add(-time.Duration(h) * time.Hour).Format(time.RFC3339) }
+
+	var calls int32
+	var srvURL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		next := fmt.Sprintf(`<%s/repos/owner/repo/pulls?page=%%d>; rel="next"`, srvURL)
+		switch r.URL.Query().Get("page") {
+		case "", "1":
+			w.Header().Set("Link", fmt.Sprintf(next, 2))
+			_, _ = fmt.Fprintf(w, `[{"number": 1, "updated_at": %q}, {"number": 2, "updated_at": %q}]`, updated(1), updated(24))
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(next, 3))
+			_, _ = fmt.Fprintf(w, `[{"number": 3, "updated_at": %q}, {"number": 4, "updated_at": %q}]`, updated(48), updated(96))
+		default:
+			t.Errorf("Requested page %s after reaching PRs older than since", r.URL.Query().Get("page"))
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer srv.Close()
+	srvURL = srv.URL
+
+	c := newTestClient(t, srv.URL)
+	prs, err := c.GetPullRequestsSince(context.Background(), "owner", "repo", since, 0)
+	if err != nil {
+		t.Fatalf("GetPullRequestsSince failed: %v", err)
+	}
+	if len(prs) != 3 || prs[2].GetNumber() != 3 {
+		t.Errorf("Expected PRs 1-3 inside the window, got %d PRs", len(prs))
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected 2 page requests, got %d", got)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	prs, err = c.GetPullRequestsSince(context.Background(), "owner", "repo", since, 1)
+	if err != nil {
+		t.Fatalf("GetPullRequestsSince failed: %v", err)
+	}
+	if len(prs) != 2 || atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("Expected the page cap to stop after 1 page, got %d PRs in %d calls", len(prs), atomic.LoadInt32(&calls))
+	}
+}
+
+func TestGetRepoOverview(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Method != http.MethodPost || r.URL.Path != "/graphql" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"repository": {
+			"url": "https://github.com/owner/repo",
+			"stargazerCount": 42, "forkCount": 7, "watchers": {"totalCount": 3},
+			"defaultBranchRef": {"name": "trunk", "branchProtectionRule": {
+				"requiresApprovingReviews": false, "requiredApprovingReviewCount": 0,
+				"requiresCodeOwnerReviews": true, "requiresStatusChecks": false}},
+			"root": {"entries": [{"name": "README.md"}, {"name": ".github"}]},
+			"dotGithub": {"entries": [{"name": "CODEOWNERS"}]}
+		}}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	overview, err := c.GetRepoOverview(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("GetRepoOverview failed: %v", err)
+	}
+	if overview.DefaultBranch != "trunk" || overview.URL != "https://github.com/owner/repo" || overview.Stars != 42 || overview.Forks != 7 || overview.Watchers != 3 {
+		t.Errorf("Unexpected repository metadata: %+v", overview)
+	}
+	if !overview.BranchProtected || !overview.RequiresPRReviews || overview.RequiresStatusChecks {
+		t.Errorf("Expected code owner reviews to count as required PR reviews, got %+v", overview)
+	}
+	want := []string{"README.md", ".github", ".github/CODEOWNERS"}
+	if fmt.Sprint(overview.Paths) != fmt.Sprint(want) {
+		t.Errorf("Expected paths %v, got %v", want, overview.Paths)
+	}
+
+	if _, err := c.GetRepoOverview(context.Background(), "owner", "repo"); err != nil || atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("Expected the second lookup to be cached, got %d calls (%v)", atomic.LoadInt32(&calls), err)
+	}
+}
+
+func TestGetRepoOverviewEmptyAndErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]string `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		if req.Variables["name"] == "missing" {
+			_, _ = w.Write([]byte(`{"data": {"repository": null}, "errors": [{"message": "Could not resolve to a Repository"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"repository": {"defaultBranchRef": null, "root": null, "dotGithub": null}}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	overview, err := c.GetRepoOverview(context.Background(), "owner", "empty")
+	if err != nil {
+		t.Fatalf("GetRepoOverview failed: %v", err)
+	}
+	if overview.Paths != nil || overview.BranchProtected || overview.DefaultBranch != "" {
+		t.Errorf("Expected an empty repository to have no paths or protection, got %+v", overview)
+	}
+
+	if _, err := c.GetRepoOverview(context.Background(), "owner", "missing"); err == nil || !strings.Contains(err.Error(), "Could not resolve") {
+		t.Errorf("Expected the GraphQL error to be returned, got %v", err)
+	}
+}
+
+func TestCheckRateLimitWaitStopsWithContext(t *testing.T) {
+	c := NewClientWithCache("", false)
+	c.SetLogOutput(io.Discard)
+	resp := &github.Response{Rate: github.Rate{Limit: 5000, Remaining: 0, Reset: github.Timestamp{Time: time.Now().Add(time.Hour)}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	c.checkRateLimit(ctx, resp)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected a cancelled context to end the wait, waited %v", elapsed)
+	}
+}
@@ -0,0 +1,161 @@
+package github
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// proxyURL overrides the proxy environment variables when set (see SetProxyURL)
+var proxyURL *url.URL
+
+// SetProxyURL routes GitHub API and download requests through raw (global.proxy_url)
+// instead of the HTTP_PROXY/HTTPS_PROXY environment variables; hosts listed in NO_PROXY
+// still connect directly. An empty raw restores the environment settings. It only
+// affects clients created afterwards.
+func SetProxyURL(raw string) error {
+	if raw == "" {
+		proxyURL = nil
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q (e.g. http://proxy.example.com:8080)", raw)
+	}
+	proxyURL = u
+	return nil
+}
+
+// apiURL points API clients at a GitHub Enterprise Server instead of api.github.com (see SetAPIURL)
+var apiURL *url.URL
+
+// SetAPIURL sends API requests to the GitHub Enterprise Server at raw, e.g.
+// https://ghe.example.com (/api/v3/ is added when missing). An empty raw restores
+// api.github.com. It only affects API clients created afterwards; update downloads
+// use their own HTTP client and keep targeting github.com, where releases are published.
+func SetAPIURL(raw string) error {
+	if raw == "" {
+		apiURL = nil
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid API URL %q (e.g. https://ghe.example.com/api/v3)", raw)
+	}
+	apiURL = u
+	return nil
+}
+
+// WebHost returns the host serving the GitHub web UI and gh CLI logins: the host of the
+// configured API URL without a leading "api.", or github.com
+func WebHost() string {
+	if apiURL == nil {
+		return "github.com"
+	}
+	return strings.TrimPrefix(apiURL.Host, "api.")
+}
+
+// RepoWebURL returns the web URL of a repository on the configured GitHub instance
+func RepoWebURL(owner, repo string) string {
+	scheme := "https"
+	if apiURL != nil {
+		scheme = apiURL.Scheme
+	}
+	return fmt.Sprintf("%s://%s/%s/%s", scheme, WebHost(), owner, repo)
+}
+
+// GhCLIArgs returns the gh arguments for a command such as "auth token", adding
+// --hostname when an Enterprise Server API URL is set so gh uses that host's login
+func GhCLIArgs(args ...string) []string {
+	if apiURL != nil {
+		args = append(args, "--hostname", WebHost())
+	}
+	return args
+}
+
+// NewHTTPClient returns an HTTP client that uses the configured proxy, or the
+// HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables when none is set.
+// A zero timeout leaves request deadlines to the caller's context.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if proxy := proxyURL; proxy != nil {
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			if bypassProxy(req.URL, noProxyEnv()) {
+				return nil, nil
+			}
+			return proxy, nil
+		}
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+// noProxyEnv returns NO_PROXY, or no_proxy when it is unset
+func noProxyEnv() string {
+	if v := os.Getenv("NO_PROXY"); v != "" {
+		return v
+	}
+	return os.Getenv("no_proxy")
+}
+
+// bypassProxy reports whether u should skip the configured proxy, following the
+// rules http.ProxyFromEnvironment applies: localhost and loopback addresses are never
+// proxied, and noProxy is a comma-separated list of "*", IP addresses, CIDR ranges and
+// host names with an optional port, where "example.com" also covers its subdomains and
+// ".example.com" or "*.example.com" covers only the subdomains.
+func bypassProxy(u *url.URL, noProxy string) bool {
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[u.Scheme]
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	if ip != nil && ip.IsLoopback() {
+		return true
+	}
+
+	for _, entry := range strings.Split(strings.ToLower(noProxy), ",") {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "":
+			continue
+		case entry == "*":
+			return true
+		case strings.Contains(entry, "/"):
+			if _, cidr, err := net.ParseCIDR(entry); err == nil && ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+
+		entryHost, entryPort := entry, ""
+		if h, p, err := net.SplitHostPort(entry); err == nil {
+			entryHost, entryPort = h, p
+		}
+		if entryPort != "" && entryPort != port {
+			continue
+		}
+		if entryIP := net.ParseIP(entryHost); entryIP != nil {
+			if ip != nil && ip.Equal(entryIP) {
+				return true
+			}
+			continue
+		}
+		entryHost = strings.TrimPrefix(entryHost, "*")
+		if strings.HasPrefix(entryHost, ".") {
+			if strings.HasSuffix(host, entryHost) {
+				return true
+			}
+		} else if host == entryHost || strings.HasSuffix(host, "."+entryHost) {
+			return true
+		}
+	}
+	return false
+}
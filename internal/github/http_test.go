@@ -0,0 +1,134 @@
+package github
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	defer func() { _ = SetProxyURL("") }()
+	t.Setenv("NO_PROXY", "")
+	t.Setenv("no_proxy", "")
+
+	client := NewHTTPClient(30 * time.Second)
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok || transport.Proxy == nil {
+		t.Fatal("Expected a transport with a Proxy function")
+	}
+	if client.Timeout != 30*time.Second {
+		t.Errorf("Expected 30s timeout, got %s", client.Timeout)
+	}
+
+	if err := SetProxyURL("http://proxy.example.com:8080"); err != nil {
+		t.Fatalf("SetProxyURL failed: %v", err)
+	}
+	transport = NewHTTPClient(0).Transport.(*http.Transport)
+	req, _ := http.NewRequest("GET", "https://api.github.com/rate_limit", nil)
+	proxy, err := transport.Proxy(req)
+	if err != nil || proxy == nil || proxy.Host != "proxy.example.com:8080" {
+		t.Errorf("Expected configured proxy, got %v (err=%v)", proxy, err)
+	}
+
+	t.Setenv("NO_PROXY", "ghe.example.com")
+	req, _ = http.NewRequest("GET", "https://ghe.example.com/api/v3/rate_limit", nil)
+	if proxy, err := transport.Proxy(req); err != nil || proxy != nil {
+		t.Errorf("Expected NO_PROXY hosts to connect directly, got %v (err=%v)", proxy, err)
+	}
+
+	for _, bad := range []string{"proxy.example.com", "://nope"} {
+		if err := SetProxyURL(bad); err == nil {
+			t.Errorf("Expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestBypassProxy(t *testing.T) {
+	tests := []struct {
+		url     string
+		noProxy string
+		want    bool
+	}{
+		{"https://api.github.com/", "", false},
+		{"http://localhost:8080/", "", true},
+		{"http://127.0.0.1/", "", true},
+		{"https://api.github.com/", "*", true},
+		{"https://api.github.com/", "example.com, github.com", true},
+		{"https://github.com/", "github.com", true},
+		{"https://notgithub.com/", "github.com", false},
+		{"https://api.github.com/", ".github.com", true},
+		{"https://github.com/", ".github.com", false},
+		{"https://api.github.com/", "*.github.com", true},
+		{"https://API.GitHub.com/", "api.github.com", true},
+		{"https://ghe.corp:8443/", "ghe.corp:8443", true},
+		{"https://ghe.corp/", "ghe.corp:8443", false},
+		{"https://ghe.corp/", "ghe.corp:443", true},
+		{"https://10.1.2.3/", "10.0.0.0/8", true},
+		{"https://192.168.1.1/", "10.0.0.0/8", false},
+		{"https://10.1.2.3/", "10.1.2.3", true},
+		{"https://[::5]/", "::5", true},
+	}
+
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.url)
+		if got := bypassProxy(u, tt.noProxy); got != tt.want {
+			t.Errorf("bypassProxy(%s, %q) = %v, want %v", tt.url, tt.noProxy, got, tt.want)
+		}
+	}
+}
+
+func TestSetAPIURL(t *testing.T) {
+	defer func() { _ = SetAPIURL("") }()
+
+	if err := SetAPIURL("https://ghe.example.com"); err != nil {
+		t.Fatalf("SetAPIURL failed: %v", err)
+	}
+	enterprise := NewClientWithCache("token", false)
+	if got := enterprise.GetUnderlyingClient().BaseURL.String(); got != "https://ghe.example.com/api/v3/" {
+		t.Errorf("Expected the enterprise API root, got %s", got)
+	}
+	if got := enterprise.graphqlPath(); got != "../graphql" {
+		t.Errorf("Expected GraphQL beside /api/v3/, got %s", got)
+	}
+
+	if err := SetAPIURL(""); err != nil {
+		t.Fatalf("SetAPIURL reset failed: %v", err)
+	}
+	public := NewClientWithCache("token", false)
+	if got := public.GetUnderlyingClient().BaseURL.String(); got != "https://api.github.com/" || public.graphqlPath() != "graphql" {
+		t.Errorf("Expected api.github.com after reset, got %s", got)
+	}
+
+	for _, bad := range []string{"ghe.example.com", "ftp://ghe.example.com", "://nope"} {
+		if err := SetAPIURL(bad); err == nil {
+			t.Errorf("Expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestWebHost(t *testing.T) {
+	defer func() { _ = SetAPIURL("") }()
+
+	_ = SetAPIURL("")
+	if got := RepoWebURL("o", "r"); got != "https://github.com/o/r" {
+		t.Errorf("Expected a github.com URL by default, got %s", got)
+	}
+	if got := strings.Join(GhCLIArgs("auth", "token"), " "); got != "auth token" {
+		t.Errorf("Expected no --hostname by default, got %q", got)
+	}
+
+	_ = SetAPIURL("https://ghe.example.com/api/v3")
+	if got := RepoWebURL("o", "r"); got != "https://ghe.example.com/o/r" {
+		t.Errorf("Expected the Enterprise Server host, got %s", got)
+	}
+	if got := strings.Join(GhCLIArgs("auth", "token"), " "); got != "auth token --hostname ghe.example.com" {
+		t.Errorf("Expected gh to use the Enterprise Server login, got %q", got)
+	}
+
+	_ = SetAPIURL("https://api.octocorp.ghe.com")
+	if got := WebHost(); got != "octocorp.ghe.com" {
+		t.Errorf("Expected the api. prefix to be dropped, got %s", got)
+	}
+}
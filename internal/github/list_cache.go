@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/logging"
+)
+
+// DefaultListTTL is how long cached list pages (workflow runs, pull requests, issues)
+// stay fresh unless cache.ttl overrides their prefix. Lists change far more often than
+// repository metadata, so they expire sooner than the default TTL.
+const DefaultListTTL = 10 * time.Minute
+
+// listCachePrefixes are the cache-key prefixes used for list endpoints
+var listCachePrefixes = []string{"workflow:", "pulls:", "issues:"}
+
+// withListTTLs returns the configured prefix TTLs with DefaultListTTL filled in for
+// list prefixes the config does not mention
+func withListTTLs(prefixTTLs map[string]time.Duration) map[string]time.Duration {
+	merged := make(map[string]time.Duration, len(prefixTTLs)+len(listCachePrefixes))
+	for _, prefix := range listCachePrefixes {
+		merged[prefix] = DefaultListTTL
+	}
+	for prefix, ttl := range prefixTTLs {
+		merged[prefix] = ttl
+	}
+	return merged
+}
+
+// listCacheKey builds a cache key from the endpoint prefix, the repository and the
+// list options, so each page, state and time window is cached separately
+func listCacheKey(prefix, owner, repo string, opts interface{}) string {
+	encoded, _ := json.Marshal(opts)
+	return fmt.Sprintf("%s%s/%s:%s", prefix, owner, repo, encoded)
+}
+
+// cachedPage is one page of a list endpoint together with its pagination cursor
+type cachedPage[T any] struct {
+	Items    T   `json:"items"`
+	NextPage int `json:"next_page"`
+}
+
+// cachedList serves a list page from the disk cache when possible and caches fresh
+// results. A cache hit returns a response carrying only NextPage, so callers can keep
+// paging; rate-limit checks only apply to real API responses.
+func cachedList[T any](ctx context.Context, c *ClientWrapper, key string, fetch func() (T, *github.Response, error)) (T, *github.Response, error) {
+	if c.diskCache != nil {
+		var cached cachedPage[T]
+		if found, err := c.diskCache.Get(key, &cached); err == nil && found {
+			logging.Debug("cache hit", "key", key)
+			return cached.Items, &github.Response{NextPage: cached.NextPage}, nil
+		}
+		logging.Debug("cache miss", "key", key)
+	}
+
+	items, resp, err := fetch()
+	if resp != nil {
+		c.checkRateLimit(ctx, resp)
+	}
+	if err == nil && c.diskCache != nil {
+		page := cachedPage[T]{Items: items}
+		if resp != nil {
+			page.NextPage = resp.NextPage
+		}
+		_ = c.diskCache.Set(key, page)
+	}
+	return items, resp, err
+}
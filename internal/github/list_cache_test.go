@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/mikematt33/gh-inspect/internal/cache"
+)
+
+func TestListEndpointsUseDiskCache(t *testing.T) {
+	var calls int32
+	var srvURL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/actions/runs?page=2>; rel="next"`, srvURL))
+		_, _ = w.Write([]byte(`{"total_count": 150, "workflow_runs": [{"id": 1, "conclusion": "success"}]}`))
+	}))
+	defer srv.Close()
+	srvURL = srv.URL
+
+	c := newTestClient(t, srv.URL)
+	diskCache, err := cache.New(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create cache: %v", err)
+	}
+	c.diskCache = diskCache
+
+	opts := &github.ListWorkflowRunsOptions{Created: ">=2024-01-01", ListOptions: github.ListOptions{PerPage: 100}}
+	for i := 0; i < 2; i++ {
+		runs, resp, err := c.GetWorkflowRuns(context.Background(), "owner", "repo", opts)
+		if err != nil {
+			t.Fatalf("GetWorkflowRuns failed: %v", err)
+		}
+		if runs.GetTotalCount() != 150 || len(runs.WorkflowRuns) != 1 || resp.NextPage != 2 {
+			t.Errorf("Call %d: unexpected result %d runs, total %d, next page %d", i+1, len(runs.WorkflowRuns), runs.GetTotalCount(), resp.NextPage)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected the second call to be served from cache, got %d API calls", got)
+	}
+
+	// A different window is a different cache entry
+	opts.Created = ">=2024-02-01"
+	if _, _, err := c.GetWorkflowRuns(context.Background(), "owner", "repo", opts); err != nil {
+		t.Fatalf("GetWorkflowRuns failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected a new window to hit the API, got %d API calls", got)
+	}
+}
+
+func TestWithListTTLs(t *testing.T) {
+	ttls := withListTTLs(map[string]time.Duration{"workflow:": time.Minute, "repo:": 24 * time.Hour})
+	if ttls["workflow:"] != time.Minute {
+		t.Errorf("Configured TTL should win, got %s", ttls["workflow:"])
+	}
+	if ttls["pulls:"] != DefaultListTTL || ttls["issues:"] != DefaultListTTL {
+		t.Errorf("Expected default list TTLs, got %v", ttls)
+	}
+	if ttls["repo:"] != 24*time.Hour {
+		t.Errorf("Unrelated prefixes should be kept, got %v", ttls)
+	}
+}
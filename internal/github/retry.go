@@ -0,0 +1,234 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mikematt33/gh-inspect/internal/logging"
+)
+
+const (
+	// DefaultMaxAttempts is the number of tries (including the first) for a transient failure
+	DefaultMaxAttempts = 3
+
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	maxRetryDelay         = 30 * time.Second
+
+	// GitHub asks clients to wait at least a minute after a secondary rate limit that
+	// doesn't say how long to wait
+	defaultSecondaryDelay = time.Minute
+	// maxSecondaryWaits bounds how often one request waits out a secondary rate limit;
+	// these waits don't use up the attempts for transient errors
+	maxSecondaryWaits = 5
+)
+
+// retryKind says whether and how a failed request is retried
+type retryKind int
+
+const (
+	noRetry            retryKind = iota
+	transientError               // 5xx responses and connection resets, bounded by maxAttempts
+	secondaryRateLimit           // 403/429 secondary (abuse) rate limits, bounded by maxSecondaryWaits
+)
+
+// SetMaxAttempts sets how many times a request is tried before giving up on transient errors.
+// Values below 1 disable retries. Secondary rate limits are waited out regardless.
+func (c *ClientWrapper) SetMaxAttempts(n int) {
+	if n < 1 {
+		n = 1
+	}
+	c.maxAttempts = n
+}
+
+// retryTransport retries API requests on 5xx responses and connection resets with
+// exponential backoff and jitter. A Retry-After hint from the server takes precedence
+// over the computed backoff. Secondary (abuse) rate limits are waited out separately:
+// per Retry-After, or secondaryDelay without one, up to maxSecondaryWaits times. The
+// limit applies to the whole token, so the backoff is shared by every request of the
+// client. Being an http.RoundTripper, it covers wrapper methods and GetUnderlyingClient
+// calls alike.
+type retryTransport struct {
+	base http.RoundTripper
+	// client holds the retry policy, which can change after the transport is built
+	client *ClientWrapper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c := t.client
+	maxAttempts := c.maxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	baseDelay := c.retryBaseDelay
+	if baseDelay <= 0 {
+		baseDelay = defaultRetryBaseDelay
+	}
+	secondaryDelay := c.secondaryDelay
+	if secondaryDelay <= 0 {
+		secondaryDelay = defaultSecondaryDelay
+	}
+
+	attempt, secondaryWaits := 1, 0
+	for {
+		if err := c.waitSecondaryBackoff(req.Context()); err != nil {
+			return nil, err
+		}
+		resp, err := t.base.RoundTrip(req)
+		kind, retryAfter := classifyResponse(req.Context(), resp, err)
+		if kind == noRetry {
+			if err != nil {
+				logging.Debug("request failed", "attempts", attempt, "err", err)
+			}
+			return resp, err
+		}
+		// A request body can only be sent again if it can be rewound
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		var delay time.Duration
+		switch {
+		case kind == secondaryRateLimit && secondaryWaits < maxSecondaryWaits:
+			secondaryWaits++
+			wait := retryAfter
+			if wait <= 0 {
+				wait = secondaryDelay
+			}
+			// Requests hitting the limit during a backoff only extend it, so it is reported once
+			if c.extendSecondaryBackoff(wait) {
+				c.logf("⏳ GitHub secondary rate limit hit. Waiting %v before retrying...\n", wait)
+			}
+			logging.Debug("secondary rate limit", "wait", secondaryWaits, "delay", wait, "url", req.URL.Path)
+			// The shared backoff is waited out before the next attempt
+		case kind == transientError && attempt < maxAttempts:
+			delay = retryAfter
+			if delay <= 0 {
+				delay = baseDelay << (attempt - 1)
+				if delay > maxRetryDelay {
+					delay = maxRetryDelay
+				}
+				delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+			}
+			logging.Warn("retrying request after transient error", "attempt", attempt, "delay", delay, "url", req.URL.Path, "err", err)
+			attempt++
+		default:
+			logging.Debug("request failed", "attempts", attempt, "err", err)
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// waitSecondaryBackoff blocks until the client's secondary rate limit backoff is over,
+// or ctx is done
+func (c *ClientWrapper) waitSecondaryBackoff(ctx context.Context) error {
+	c.secondaryMu.Lock()
+	wait := time.Until(c.secondaryUntil)
+	c.secondaryMu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+// extendSecondaryBackoff makes the client's secondary rate limit backoff last at least
+// wait from now. It reports whether this starts a new backoff rather than extending one
+// already in progress.
+func (c *ClientWrapper) extendSecondaryBackoff(wait time.Duration) bool {
+	c.secondaryMu.Lock()
+	defer c.secondaryMu.Unlock()
+	now := time.Now()
+	started := !c.secondaryUntil.After(now)
+	if until := now.Add(wait); until.After(c.secondaryUntil) {
+		c.secondaryUntil = until
+	}
+	return started
+}
+
+// classifyResponse says whether a response (or transport error) should be retried,
+// along with any server-provided wait time
+func classifyResponse(ctx context.Context, resp *http.Response, err error) (retryKind, time.Duration) {
+	if err != nil {
+		if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return noRetry, 0
+		}
+		if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return transientError, 0
+		}
+		return noRetry, 0
+	}
+
+	switch {
+	case resp.StatusCode >= 500:
+		return transientError, parseRetryAfter(resp)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return secondaryRateLimit, parseRetryAfter(resp)
+	case resp.StatusCode == http.StatusForbidden:
+		// Primary rate limits are handled by checkRateLimit, not retried here
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			return noRetry, 0
+		}
+		if isSecondaryRateLimit(resp) {
+			return secondaryRateLimit, parseRetryAfter(resp)
+		}
+	}
+	return noRetry, 0
+}
+
+// isSecondaryRateLimit reports whether a 403 response is a secondary rate limit: GitHub
+// signals them with Retry-After or a telling message. The body is restored after reading.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	if resp.Header.Get("Retry-After") != "" {
+		return true
+	}
+	if resp.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return err == nil && strings.Contains(strings.ToLower(string(body)), "secondary rate limit")
+}
+
+// parseRetryAfter reads a Retry-After header expressed in seconds
+func parseRetryAfter(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	secs, err := strconv.Atoi(v)
+	if err != nil || secs < 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
@@ -0,0 +1,270 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// newTestClient returns a ClientWrapper pointed at the given test server with fast retries
+func newTestClient(t *testing.T, serverURL string) *ClientWrapper {
+	t.Helper()
+	c := NewClientWithCache("", false)
+	u, err := url.Parse(serverURL + "/")
+	if err != nil {
+		t.Fatalf("Failed to parse server URL: %v", err)
+	}
+	c.client.BaseURL = u
+	c.retryBaseDelay = time.Millisecond
+	c.secondaryDelay = time.Millisecond
+	return c
+}
+
+func TestRetryOnServerError(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"number": 1}]`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	prs, err := c.GetPullRequests(context.Background(), "owner", "repo", &github.PullRequestListOptions{})
+	if err != nil {
+		t.Fatalf("Expected success after retries, got %v", err)
+	}
+	if len(prs) != 1 || prs[0].GetNumber() != 1 {
+		t.Errorf("Unexpected pull requests: %v", prs)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("Expected 3 calls, got %d", got)
+	}
+}
+
+func TestRetryCoversUnderlyingClient(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"name": "main"}]`))
+	}))
+	defer srv.Close()
+
+	// Analyzers call the go-github client directly for endpoints the wrapper lacks
+	c := newTestClient(t, srv.URL)
+	branches, _, err := c.GetUnderlyingClient().Repositories.ListBranches(context.Background(), "owner", "repo", nil)
+	if err != nil || len(branches) != 1 {
+		t.Fatalf("Expected success after a retry, got %v (err %v)", branches, err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected 2 calls, got %d", got)
+	}
+}
+
+func TestRetryResendsRequestBody(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "repository") {
+			t.Errorf("Expected the GraphQL query on every attempt, got %q", body)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"repository": {"stargazerCount": 3}}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	overview, err := c.GetRepoOverview(context.Background(), "owner", "repo")
+	if err != nil || overview.Stars != 3 {
+		t.Fatalf("Expected the overview after a retry, got %+v (err %v)", overview, err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected 2 calls, got %d", got)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	c.SetMaxAttempts(2)
+	if _, err := c.GetPullRequests(context.Background(), "owner", "repo", &github.PullRequestListOptions{}); err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected 2 calls, got %d", got)
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	if _, err := c.GetPullRequests(context.Background(), "owner", "repo", &github.PullRequestListOptions{}); err == nil {
+		t.Fatal("Expected 404 error")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected a single call for 4xx, got %d", got)
+	}
+}
+
+func TestSecondaryRateLimitHonorsRetryAfter(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "You have exceeded a secondary rate limit.", "documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"number": 1}]`))
+	}))
+	defer srv.Close()
+
+	// A secondary limit is waited out even with transient retries disabled
+	c := newTestClient(t, srv.URL)
+	c.SetMaxAttempts(1)
+	start := time.Now()
+	prs, err := c.GetPullRequests(context.Background(), "owner", "repo", &github.PullRequestListOptions{})
+	if err != nil || len(prs) != 1 {
+		t.Fatalf("Expected success after the secondary rate limit, got %v (err %v)", prs, err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected to wait for Retry-After (1s), waited %v", elapsed)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected 2 calls, got %d", got)
+	}
+}
+
+func TestSecondaryRateLimitWaitsAreBounded(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	if _, err := c.GetPullRequests(context.Background(), "owner", "repo", &github.PullRequestListOptions{}); err == nil {
+		t.Fatal("Expected an error once the secondary rate limit persists")
+	}
+	if got := atomic.LoadInt32(&calls); got != maxSecondaryWaits+1 {
+		t.Errorf("Expected %d calls, got %d", maxSecondaryWaits+1, got)
+	}
+}
+
+// syncBuilder collects log output written from several goroutines
+type syncBuilder struct {
+	mu sync.Mutex
+	sb strings.Builder
+}
+
+func (b *syncBuilder) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sb.Write(p)
+}
+
+func (b *syncBuilder) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sb.String()
+}
+
+func TestSecondaryRateLimitBackoffIsShared(t *testing.T) {
+	// Requests in the first half second are rate limited; the backoff lasts a second
+	start := time.Now()
+	var mu sync.Mutex
+	var served []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if time.Since(start) < 500*time.Millisecond {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		mu.Lock()
+		served = append(served, time.Now())
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	log := &syncBuilder{}
+	c.logOut = log
+
+	var wg sync.WaitGroup
+	request := func() {
+		defer wg.Done()
+		if _, err := c.GetPullRequests(context.Background(), "owner", "repo", &github.PullRequestListOptions{}); err != nil {
+			t.Errorf("Expected success after the backoff, got %v", err)
+		}
+	}
+	wg.Add(2)
+	go request()
+	go request()
+	for !strings.Contains(log.String(), "secondary rate limit") {
+		time.Sleep(time.Millisecond)
+	}
+	// A request started during the backoff waits it out instead of hitting the limit
+	wg.Add(1)
+	go request()
+	wg.Wait()
+
+	if len(served) != 3 {
+		t.Errorf("Expected 3 successful calls, got %d", len(served))
+	}
+	for _, at := range served {
+		if at.Sub(start) < 900*time.Millisecond {
+			t.Errorf("Expected every request to wait for the shared backoff, one was served after %v", at.Sub(start))
+		}
+	}
+	if n := strings.Count(log.String(), "secondary rate limit"); n != 1 {
+		t.Errorf("Expected the secondary rate limit to be reported once, got %d times:\n%s", n, log.String())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if d := parseRetryAfter(resp); d != 0 {
+		t.Errorf("Expected 0 without header, got %v", d)
+	}
+	resp.Header.Set("Retry-After", "7")
+	if d := parseRetryAfter(resp); d != 7*time.Second {
+		t.Errorf("Expected 7s, got %v", d)
+	}
+}
@@ -0,0 +1,44 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestTokenScopes(t *testing.T) {
+	tests := []struct {
+		name         string
+		header       *string
+		wantScopes   []string
+		wantReported bool
+	}{
+		{"classic token", strPtr("repo, read:org,workflow"), []string{"repo", "read:org", "workflow"}, true},
+		{"no scopes granted", strPtr(""), []string{}, true},
+		{"fine-grained token", nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != nil {
+					w.Header().Set("X-OAuth-Scopes", *tt.header)
+				}
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+
+			scopes, reported, err := newTestClient(t, srv.URL).TokenScopes(context.Background())
+			if err != nil {
+				t.Fatalf("TokenScopes failed: %v", err)
+			}
+			if reported != tt.wantReported || !reflect.DeepEqual(scopes, tt.wantScopes) {
+				t.Errorf("TokenScopes() = %v, %v; want %v, %v", scopes, reported, tt.wantScopes, tt.wantReported)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
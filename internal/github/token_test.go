@@ -0,0 +1,50 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/mikematt33/gh-inspect/internal/keyring"
+)
+
+func TestResolveTokenPrefersConfigThenKeyring(t *testing.T) {
+	keyring.MockInit()
+
+	if err := SaveKeyringToken("", "keyring-token"); err != nil {
+		t.Fatalf("SaveKeyringToken failed: %v", err)
+	}
+	if got := ResolveToken("", "config-token"); got != "config-token" {
+		t.Errorf("Expected config token to win, got %q", got)
+	}
+	if got := ResolveToken("", ""); got != "keyring-token" {
+		t.Errorf("Expected keyring token, got %q", got)
+	}
+
+	if err := DeleteKeyringToken(""); err != nil {
+		t.Fatalf("DeleteKeyringToken failed: %v", err)
+	}
+	if KeyringToken("") != "" {
+		t.Error("Expected keyring to be empty after delete")
+	}
+}
+
+func TestResolveTokenNamedContext(t *testing.T) {
+	keyring.MockInit()
+	t.Setenv("GITHUB_TOKEN", "env-token")
+
+	if err := SaveKeyringToken("", "default-token"); err != nil {
+		t.Fatalf("SaveKeyringToken failed: %v", err)
+	}
+	if got := ResolveToken("work", ""); got != "" {
+		t.Errorf("Expected a named context without a token to resolve nothing, got %q", got)
+	}
+
+	if err := SaveKeyringToken("work", "work-token"); err != nil {
+		t.Fatalf("SaveKeyringToken failed: %v", err)
+	}
+	if got := ResolveToken("work", ""); got != "work-token" {
+		t.Errorf("Expected the context's keyring token, got %q", got)
+	}
+	if got := ResolveToken("", ""); got != "default-token" {
+		t.Errorf("Expected the default context to keep its own token, got %q", got)
+	}
+}
@@ -0,0 +1,68 @@
+// Package keyring stores secrets in the operating system's credential store:
+// the macOS Keychain, Windows Credential Manager, or the Secret Service on Linux.
+package keyring
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when no secret is stored for the service and user
+	ErrNotFound = errors.New("secret not found in keyring")
+	// ErrUnsupported is returned when no OS keyring is available on this system
+	ErrUnsupported = errors.New("no OS keyring available")
+)
+
+// Provider is a backend that can store, read and delete secrets
+type Provider interface {
+	Get(service, user string) (string, error)
+	Set(service, user, secret string) error
+	Delete(service, user string) error
+}
+
+// provider is the active backend; tests replace it via MockInit
+var provider Provider = osProvider{}
+
+// Get returns the secret stored for service and user
+func Get(service, user string) (string, error) {
+	return provider.Get(service, user)
+}
+
+// Set stores secret for service and user, replacing any existing value
+func Set(service, user, secret string) error {
+	return provider.Set(service, user, secret)
+}
+
+// Delete removes the secret for service and user.
+// It returns ErrNotFound when nothing was stored.
+func Delete(service, user string) error {
+	return provider.Delete(service, user)
+}
+
+// MockInit replaces the OS keyring with an in-memory store, for tests
+func MockInit() {
+	provider = &mockProvider{secrets: make(map[string]string)}
+}
+
+type mockProvider struct {
+	secrets map[string]string
+}
+
+func (m *mockProvider) Get(service, user string) (string, error) {
+	secret, ok := m.secrets[service+"/"+user]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return secret, nil
+}
+
+func (m *mockProvider) Set(service, user, secret string) error {
+	m.secrets[service+"/"+user] = secret
+	return nil
+}
+
+func (m *mockProvider) Delete(service, user string) error {
+	if _, ok := m.secrets[service+"/"+user]; !ok {
+		return ErrNotFound
+	}
+	delete(m.secrets, service+"/"+user)
+	return nil
+}
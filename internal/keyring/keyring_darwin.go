@@ -0,0 +1,52 @@
+package keyring
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// osProvider uses the macOS Keychain through the security(1) tool
+type osProvider struct{}
+
+// security exits with this code when the item does not exist
+const errSecItemNotFound = 44
+
+func (osProvider) Get(service, user string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", service, "-a", user, "-w").Output()
+	if err != nil {
+		return "", keychainError(err)
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+func (osProvider) Set(service, user, secret string) error {
+	// Run interactively and pass the secret hex-encoded on stdin so it never appears in the process list
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %q -a %q -X %s\n",
+		service, user, hex.EncodeToString([]byte(secret))))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("keychain: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (osProvider) Delete(service, user string) error {
+	if err := exec.Command("security", "delete-generic-password", "-s", service, "-a", user).Run(); err != nil {
+		return keychainError(err)
+	}
+	return nil
+}
+
+func keychainError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == errSecItemNotFound {
+		return ErrNotFound
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return ErrUnsupported
+	}
+	return fmt.Errorf("keychain: %w", err)
+}
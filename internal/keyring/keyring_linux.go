@@ -0,0 +1,53 @@
+package keyring
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// osProvider uses the Secret Service (GNOME Keyring, KWallet) through secret-tool(1)
+type osProvider struct{}
+
+func (osProvider) Get(service, user string) (string, error) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return "", ErrUnsupported
+	}
+	out, err := exec.Command("secret-tool", "lookup", "service", service, "username", user).Output()
+	if err != nil {
+		// secret-tool exits 1 with no output when nothing matches
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) == 0 {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("secret service: %w", err)
+	}
+	if len(out) == 0 {
+		return "", ErrNotFound
+	}
+	return string(out), nil
+}
+
+func (osProvider) Set(service, user, secret string) error {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return ErrUnsupported
+	}
+	cmd := exec.Command("secret-tool", "store", "--label", service+" ("+user+")", "service", service, "username", user)
+	cmd.Stdin = strings.NewReader(secret)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("secret service: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (p osProvider) Delete(service, user string) error {
+	// clear succeeds even when nothing matches, so look the secret up first
+	if _, err := p.Get(service, user); err != nil {
+		return err
+	}
+	if out, err := exec.Command("secret-tool", "clear", "service", service, "username", user).CombinedOutput(); err != nil {
+		return fmt.Errorf("secret service: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+//go:build !darwin && !linux && !windows
+
+package keyring
+
+// osProvider reports that no keyring is available on this platform
+type osProvider struct{}
+
+func (osProvider) Get(service, user string) (string, error) { return "", ErrUnsupported }
+
+func (osProvider) Set(service, user, secret string) error { return ErrUnsupported }
+
+func (osProvider) Delete(service, user string) error { return ErrUnsupported }
@@ -0,0 +1,28 @@
+package keyring
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMockKeyring(t *testing.T) {
+	orig := provider
+	defer func() { provider = orig }()
+	MockInit()
+
+	if _, err := Get("svc", "user"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound before Set, got %v", err)
+	}
+	if err := Set("svc", "user", "s3cret"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if got, err := Get("svc", "user"); err != nil || got != "s3cret" {
+		t.Errorf("Get = %q, %v; want s3cret", got, err)
+	}
+	if err := Delete("svc", "user"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := Delete("svc", "user"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound on second Delete, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Package logging provides the diagnostic logger shared by the GitHub client and the
+// analysis pipeline. Diagnostic logs are off unless --log-level or --log-json is given,
+// and they are kept apart from the ✅/⚠️ messages meant for users.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Levels lists the accepted --log-level values, most verbose first
+var Levels = []string{"debug", "info", "warn", "error"}
+
+// output lets the destination change after the logger is configured, e.g. to keep
+// log lines clear of a progress bar
+type output struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (o *output) Write(b []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.w.Write(b)
+}
+
+var (
+	out    = &output{w: os.Stderr}
+	logger = slog.New(discardHandler{})
+)
+
+// discardHandler drops every record; it is the logger until Configure enables logging
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
+
+// ParseLevel converts a --log-level value to a slog level
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("invalid log level %q (must be %s)", level, strings.Join(Levels, ", "))
+}
+
+// Configure enables diagnostic logs at level and above, as logfmt-style text or as
+// one JSON object per line. An empty level keeps logging off unless asJSON is set,
+// which then logs at info.
+func Configure(level string, asJSON bool) error {
+	if level == "" {
+		if !asJSON {
+			logger = slog.New(discardHandler{})
+			return nil
+		}
+		level = "info"
+	}
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+	if asJSON {
+		logger = slog.New(slog.NewJSONHandler(out, opts))
+	} else {
+		logger = slog.New(slog.NewTextHandler(out, opts))
+	}
+	return nil
+}
+
+// SetOutput sends log lines to w (stderr by default) and returns the previous destination
+func SetOutput(w io.Writer) io.Writer {
+	out.mu.Lock()
+	defer out.mu.Unlock()
+	previous := out.w
+	out.w = w
+	return previous
+}
+
+// Debug, Info, Warn and Error log msg with key/value pairs at their level
+func Debug(msg string, args ...any) { logger.Debug(msg, args...) }
+func Info(msg string, args ...any)  { logger.Info(msg, args...) }
+func Warn(msg string, args ...any)  { logger.Warn(msg, args...) }
+func Error(msg string, args ...any) { logger.Error(msg, args...) }
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestConfigure(t *testing.T) {
+	var buf bytes.Buffer
+	previous := SetOutput(&buf)
+	defer func() { SetOutput(previous); _ = Configure("", false) }()
+
+	if err := Configure("", false); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	Error("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("Expected logging to be off by default, got %q", buf.String())
+	}
+
+	if err := Configure("warn", false); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	Info("too quiet")
+	Warn("retrying", "attempt", 2)
+	if out := buf.String(); strings.Contains(out, "too quiet") || !strings.Contains(out, "level=WARN msg=retrying attempt=2") {
+		t.Errorf("Expected only the warning as text, got %q", out)
+	}
+
+	buf.Reset()
+	if err := Configure("", true); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	Debug("too quiet")
+	Info("analysis started", "repos", 3)
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected a single JSON record at info level, got %q: %v", buf.String(), err)
+	}
+	if record["msg"] != "analysis started" || record["level"] != "INFO" || record["repos"] != float64(3) {
+		t.Errorf("Unexpected record %v", record)
+	}
+
+	if err := Configure("verbose", false); err == nil {
+		t.Error("Expected an invalid level to be rejected")
+	}
+}
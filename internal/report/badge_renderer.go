@@ -0,0 +1,41 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/mikematt33/gh-inspect/pkg/insights"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// shieldsEndpoint is the JSON a shields.io endpoint badge reads
+// (https://shields.io/badges/endpoint-badge)
+type shieldsEndpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// BadgeRenderer writes a shields.io endpoint JSON for the engineering health score of a
+// single repository, colored like the markdown traffic lights
+type BadgeRenderer struct{}
+
+func (r *BadgeRenderer) Render(report *models.Report, w io.Writer) error {
+	return r.RenderWithOptions(report, w, RenderOptions{})
+}
+
+func (r *BadgeRenderer) RenderWithOptions(report *models.Report, w io.Writer, opts RenderOptions) error {
+	if len(report.Repositories) != 1 {
+		return fmt.Errorf("badge format needs exactly one repository, got %d", len(report.Repositories))
+	}
+	score := insights.CalculateEngineeringHealthScoreWithWeights(report.Repositories[0], opts.weights())
+	return json.NewEncoder(w).Encode(shieldsEndpoint{
+		SchemaVersion: 1,
+		Label:         "health",
+		Message:       strconv.Itoa(score),
+		Color:         bandForScore(score).color,
+	})
+}
@@ -16,6 +16,7 @@ func (r *ComparisonTextRenderer) Render(report *models.Report, w io.Writer) erro
 }
 
 func (r *ComparisonTextRenderer) RenderWithOptions(report *models.Report, w io.Writer, opts RenderOptions) error {
+	w = outputWriter(w, opts)
 	if len(report.Repositories) == 0 {
 		_, _ = fmt.Fprintln(w, "No repositories to compare.")
 		return nil
@@ -0,0 +1,92 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/mikematt33/gh-inspect/pkg/insights"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// csvSummaryColumns are the fixed per-repository columns emitted before the flattened analyzer metrics.
+// Each entry maps a column header to the analyzer metric it is sourced from.
+var csvSummaryColumns = []struct {
+	Header   string
+	Analyzer string
+	Key      string
+}{
+	{"commits", "activity", "commits_total"},
+	{"open_issues", "issue-hygiene", "open_issues_total"},
+	{"zombie_issues", "issue-hygiene", "zombie_issues"},
+	{"ci_success_rate", "ci", "success_rate"},
+	{"pr_cycle_time_hours", "pr-flow", "avg_cycle_time_hours"},
+}
+
+// CSVRenderer renders reports as CSV with one row per repository, suitable for spreadsheets
+type CSVRenderer struct{}
+
+func (r *CSVRenderer) Render(report *models.Report, w io.Writer) error {
+	return r.RenderWithOptions(report, w, RenderOptions{})
+}
+
+func (r *CSVRenderer) RenderWithOptions(report *models.Report, w io.Writer, opts RenderOptions) error {
+	cw := csv.NewWriter(w)
+
+	// Collect flattened "analyzer.metric" columns in first-seen order so the layout is stable
+	var metricColumns []string
+	seen := make(map[string]bool)
+	for _, repo := range report.Repositories {
+		for _, az := range repo.Analyzers {
+			for _, m := range az.Metrics {
+				col := az.Name + "." + m.Key
+				if !seen[col] {
+					seen[col] = true
+					metricColumns = append(metricColumns, col)
+				}
+			}
+		}
+	}
+
+	header := []string{"repository", "engineering_health_score"}
+	for _, c := range csvSummaryColumns {
+		header = append(header, c.Header)
+	}
+	header = append(header, metricColumns...)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, repo := range report.Repositories {
+		values := make(map[string]float64)
+		for _, az := range repo.Analyzers {
+			for _, m := range az.Metrics {
+				values[az.Name+"."+m.Key] = m.Value
+			}
+		}
+
+		row := []string{repo.Name, fmt.Sprintf("%d", insights.CalculateEngineeringHealthScoreWithWeights(repo, opts.weights()))}
+		for _, c := range csvSummaryColumns {
+			row = append(row, csvCell(values, c.Analyzer+"."+c.Key))
+		}
+		for _, col := range metricColumns {
+			row = append(row, csvCell(values, col))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvCell formats a metric value, returning an empty cell when the metric is missing
+func csvCell(values map[string]float64, key string) string {
+	v, ok := values[key]
+	if !ok {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,75 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+func TestCSVRenderer_Render(t *testing.T) {
+	report := &models.Report{
+		Repositories: []models.RepoResult{
+			{
+				Name: "owner/repo1",
+				Analyzers: []models.AnalyzerResult{
+					{Name: "activity", Metrics: []models.Metric{{Key: "commits_total", Value: 42}}},
+					{Name: "ci", Metrics: []models.Metric{{Key: "success_rate", Value: 95.5}}},
+				},
+			},
+			{
+				Name: "owner/repo2",
+				Analyzers: []models.AnalyzerResult{
+					{Name: "activity", Metrics: []models.Metric{{Key: "commits_total", Value: 7}}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&CSVRenderer{}).Render(report, &buf); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Output is not valid CSV: %v", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Expected header + 2 rows, got %d records", len(records))
+	}
+
+	header := records[0]
+	col := func(name string) int {
+		for i, h := range header {
+			if h == name {
+				return i
+			}
+		}
+		t.Fatalf("Column %q not found in header %v", name, header)
+		return -1
+	}
+
+	if records[1][col("repository")] != "owner/repo1" {
+		t.Errorf("Expected first row for owner/repo1, got %s", records[1][0])
+	}
+	if got := records[1][col("commits")]; got != "42" {
+		t.Errorf("Expected commits 42, got %q", got)
+	}
+	if got := records[1][col("ci.success_rate")]; got != "95.5" {
+		t.Errorf("Expected ci.success_rate 95.5, got %q", got)
+	}
+
+	// Missing metrics must produce empty cells without breaking alignment
+	if len(records[2]) != len(header) {
+		t.Errorf("Expected %d columns in row, got %d", len(header), len(records[2]))
+	}
+	if got := records[2][col("ci.success_rate")]; got != "" {
+		t.Errorf("Expected empty cell for missing metric, got %q", got)
+	}
+	if got := records[2][col("ci_success_rate")]; got != "" {
+		t.Errorf("Expected empty summary cell for missing metric, got %q", got)
+	}
+}
@@ -0,0 +1,82 @@
+package report
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mikematt33/gh-inspect/pkg/insights"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// previousRun indexes an earlier report so the text renderer can show what changed inline
+type previousRun struct {
+	metrics map[string]float64 // "repo/analyzer/key" -> value
+	scores  map[string]int     // repo -> engineering health score
+}
+
+// newPreviousRun returns nil when there is no earlier report, which disables deltas
+func newPreviousRun(prev *models.Report, w insights.ScoringWeights) *previousRun {
+	if prev == nil {
+		return nil
+	}
+	p := &previousRun{metrics: make(map[string]float64), scores: make(map[string]int)}
+	for _, repo := range prev.Repositories {
+		p.scores[repo.Name] = insights.CalculateEngineeringHealthScoreWithWeights(repo, w)
+		for _, az := range repo.Analyzers {
+			for _, m := range az.Metrics {
+				p.metrics[repo.Name+"/"+az.Name+"/"+m.Key] = m.Value
+			}
+		}
+	}
+	return p
+}
+
+// metricDelta formats the change of a metric since the previous run, e.g. " (↑2.00)".
+// A metric compared against a baseline carries its own trend, which takes precedence.
+func (p *previousRun) metricDelta(repo, analyzer string, m models.Metric) string {
+	if m.Trend != "" {
+		return metricTrend(m)
+	}
+	if p == nil {
+		return ""
+	}
+	prev, ok := p.metrics[repo+"/"+analyzer+"/"+m.Key]
+	if !ok {
+		return ""
+	}
+	return formatDelta(m.Value-prev, "%.2f")
+}
+
+// scoreDelta formats the change of a repository's health score since the previous run
+func (p *previousRun) scoreDelta(repo string, score int) string {
+	if p == nil {
+		return ""
+	}
+	prev, ok := p.scores[repo]
+	if !ok {
+		return ""
+	}
+	return formatDelta(float64(score-prev), "%.0f")
+}
+
+// metricTrend formats the change of a metric compared against a baseline, with " (→)"
+// for a metric that did not move
+func metricTrend(m models.Metric) string {
+	if m.PreviousValue == nil {
+		return ""
+	}
+	if m.Trend == models.TrendFlat {
+		return " (→)"
+	}
+	return formatDelta(m.Value-*m.PreviousValue, "%.2f")
+}
+
+func formatDelta(delta float64, format string) string {
+	switch {
+	case delta > 0:
+		return " (↑" + fmt.Sprintf(format, delta) + ")"
+	case delta < 0:
+		return " (↓" + fmt.Sprintf(format, math.Abs(delta)) + ")"
+	}
+	return ""
+}
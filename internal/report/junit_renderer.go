@@ -0,0 +1,149 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// The types below cover the JUnit XML elements CI test report viewers read
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnitRenderer renders the report as JUnit XML for CI test report views. Each
+// repository is a test suite and each finding a test case: analyzer errors and timeouts
+// are errors, info findings are skipped, and everything else fails with the severity
+// as its type. Analyzers without findings are reported as passing test cases.
+type JUnitRenderer struct{}
+
+func (r *JUnitRenderer) Render(report *models.Report, w io.Writer) error {
+	return r.RenderWithOptions(report, w, RenderOptions{})
+}
+
+func (r *JUnitRenderer) RenderWithOptions(report *models.Report, w io.Writer, opts RenderOptions) error {
+	report = applyOutputMode(filterBySeverity(report, opts.MinSeverity), opts.OutputMode)
+	suites := junitTestSuites{Name: "gh-inspect", Suites: []junitTestSuite{}}
+
+	for _, repo := range report.Repositories {
+		suite := junitTestSuite{Name: repo.Name}
+		if !report.Meta.GeneratedAt.IsZero() {
+			suite.Timestamp = report.Meta.GeneratedAt.UTC().Format("2006-01-02T15:04:05")
+		}
+		for _, az := range repo.Analyzers {
+			classname := repo.Name + "." + az.Name
+			if len(az.Findings) == 0 {
+				suite.Cases = append(suite.Cases, junitTestCase{Name: az.Name, Classname: classname})
+				continue
+			}
+			// Findings that share a type and location are numbered in report order
+			seen := make(map[string]int)
+			for _, f := range az.Findings {
+				c := junitFindingCase(classname, f)
+				if seen[c.Name]++; seen[c.Name] > 1 {
+					c.Name = fmt.Sprintf("%s #%d", c.Name, seen[c.Name])
+				}
+				suite.Cases = append(suite.Cases, c)
+			}
+		}
+
+		for _, c := range suite.Cases {
+			suite.Tests++
+			switch {
+			case c.Error != nil:
+				suite.Errors++
+			case c.Failure != nil:
+				suite.Failures++
+			case c.Skipped != nil:
+				suite.Skipped++
+			}
+		}
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitFindingCase maps a finding to a test case named after its type and location.
+// The message often carries counts or ages that change between runs, so it is only
+// reported in the failure, keeping the name stable for CI test history.
+func junitFindingCase(classname string, f models.Finding) junitTestCase {
+	c := junitTestCase{Name: f.Type, Classname: classname}
+	if f.Location != "" {
+		c.Name = fmt.Sprintf("%s (%s)", f.Type, f.Location)
+	}
+
+	var details []string
+	if f.Location != "" {
+		details = append(details, "Location: "+f.Location)
+	}
+	if f.Explanation != "" {
+		details = append(details, f.Explanation)
+	}
+	if f.Remediation != "" {
+		details = append(details, "Remediation: "+f.Remediation)
+	}
+	problem := &junitProblem{Message: f.Message, Type: string(f.Severity), Body: strings.Join(details, "\n")}
+
+	switch {
+	case f.Type == "analyzer_error" || f.Type == "analyzer_timeout":
+		problem.Type = f.Type
+		c.Error = problem
+	case f.Severity == models.SeverityInfo:
+		c.Skipped = &junitSkipped{Message: f.Message}
+	default:
+		c.Failure = problem
+	}
+	return c
+}
@@ -0,0 +1,121 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+func TestJUnitRenderer_Render(t *testing.T) {
+	report := &models.Report{
+		Repositories: []models.RepoResult{
+			{
+				Name: "owner/repo1",
+				Analyzers: []models.AnalyzerResult{
+					{Name: "pr-flow", Findings: []models.Finding{
+						{Type: "stale_pr", Severity: models.SeverityMedium, Message: "PR #1 is stale", Location: "https://github.com/owner/repo1/pull/1"},
+						{Type: "webhooks_unavailable", Severity: models.SeverityInfo, Message: "Webhook health not checked"},
+						{Type: "webhooks_unavailable", Severity: models.SeverityInfo, Message: "Webhook health not checked again"},
+					}},
+					{Name: "ci", Findings: []models.Finding{
+						{Type: "analyzer_error", Severity: models.SeverityHigh, Message: "Analysis failed: boom"},
+					}},
+				},
+			},
+			{
+				Name: "owner/repo2",
+				Analyzers: []models.AnalyzerResult{
+					{Name: "repo-health", Metrics: []models.Metric{{Key: "health_score", Value: 100}}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewRenderer(FormatJUnit).Render(report, &buf); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Errorf("Expected an XML header, got %q", buf.String()[:20])
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("Output is not valid XML: %v", err)
+	}
+	if suites.Tests != 5 || suites.Failures != 1 || suites.Errors != 1 || suites.Skipped != 2 {
+		t.Errorf("Expected 5 tests, 1 failure, 1 error, 2 skipped; got %+v", suites)
+	}
+	if len(suites.Suites) != 2 {
+		t.Fatalf("Expected one suite per repository, got %d", len(suites.Suites))
+	}
+
+	stale := suites.Suites[0].Cases[0]
+	if stale.Classname != "owner/repo1.pr-flow" || stale.Failure == nil || stale.Failure.Type != "medium" {
+		t.Errorf("Expected a medium failure for the stale PR, got %+v", stale)
+	}
+	if !strings.Contains(stale.Failure.Body, "pull/1") {
+		t.Errorf("Expected the failure to carry the location, got %q", stale.Failure.Body)
+	}
+
+	// Names leave out the message so CI history can follow a finding across runs
+	var names []string
+	for _, c := range suites.Suites[0].Cases {
+		names = append(names, c.Name)
+	}
+	want := "stale_pr (https://github.com/owner/repo1/pull/1),webhooks_unavailable,webhooks_unavailable #2,analyzer_error"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("Expected test cases named by type and location, got %q", got)
+	}
+	if stale.Failure.Message != "PR #1 is stale" {
+		t.Errorf("Expected the message in the failure, got %q", stale.Failure.Message)
+	}
+	if c := suites.Suites[0].Cases[3]; c.Error == nil || c.Error.Type != "analyzer_error" {
+		t.Errorf("Expected analyzer_error to be a JUnit error, got %+v", c)
+	}
+
+	passing := suites.Suites[1].Cases
+	if len(passing) != 1 || passing[0].Name != "repo-health" || passing[0].Failure != nil || passing[0].Skipped != nil {
+		t.Errorf("Expected a passing repo-health test case, got %+v", passing)
+	}
+}
+
+func TestJUnitRenderer_OutputMode(t *testing.T) {
+	report := &models.Report{
+		Repositories: []models.RepoResult{
+			{
+				Name: "owner/repo",
+				Analyzers: []models.AnalyzerResult{
+					{Name: "ci", Findings: []models.Finding{
+						{Type: "slow_builds", Severity: models.SeverityMedium, Message: "Builds are slow", Explanation: "Long builds delay feedback", Remediation: "Cache dependencies"},
+					}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		mode models.OutputMode
+		want string
+	}{
+		{models.OutputModeSuggestive, "Long builds delay feedback\nRemediation: Cache dependencies"},
+		{models.OutputModeObservational, "Long builds delay feedback"},
+		{models.OutputModeStatistical, ""},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := NewRenderer(FormatJUnit).RenderWithOptions(report, &buf, RenderOptions{OutputMode: tt.mode}); err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		var suites junitTestSuites
+		if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+			t.Fatalf("Output is not valid XML: %v", err)
+		}
+		if got := suites.Suites[0].Cases[0].Failure.Body; got != tt.want {
+			t.Errorf("%s: expected failure body %q, got %q", tt.mode, tt.want, got)
+		}
+	}
+}
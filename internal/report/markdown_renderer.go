@@ -17,22 +17,42 @@ func (r *MarkdownRenderer) Render(report *models.Report, w io.Writer) error {
 }
 
 func (r *MarkdownRenderer) RenderWithOptions(report *models.Report, w io.Writer, opts RenderOptions) error {
+	if opts.MarkdownNoEmoji {
+		// Same ASCII decoration as --no-color; scores get text labels below
+		opts.NoColor = true
+	}
+	w = outputWriter(w, opts)
+	if report.Meta.Note != "" {
+		_, _ = fmt.Fprintf(w, opts.decor("> ⚠️ %s\n\n"), report.Meta.Note)
+	}
+	for _, u := range report.Unavailable {
+		_, _ = fmt.Fprintf(w, opts.decor("> ⚠️ Skipped `%s`: %s\n\n"), u.Name, u.Message)
+	}
 	if len(report.Repositories) == 0 {
-		_, _ = fmt.Fprintln(w, "## 📊 Repository Analysis")
+		_, _ = fmt.Fprintln(w, opts.decor("## 📊 Repository Analysis"))
 		_, _ = fmt.Fprintln(w, "")
 		_, _ = fmt.Fprintln(w, "No repositories analyzed.")
 		return nil
 	}
 
-	_, _ = fmt.Fprintln(w, "## 📊 Repository Analysis Results")
+	_, _ = fmt.Fprintln(w, opts.decor("## 📊 Repository Analysis Results"))
 	_, _ = fmt.Fprintln(w, "")
 
-	for _, repo := range report.Repositories {
+	// Scores are computed from the unfiltered results so hiding findings doesn't change them
+	full := report
+	report = applyOutputMode(filterBySeverity(report, opts.MinSeverity), opts.OutputMode)
+
+	for i, repo := range report.Repositories {
+		if opts.OnlyFindings && !hasFindings(repo) {
+			_, _ = fmt.Fprintf(w, opts.decor("✓ **%s**: clean\n\n"), repo.Name)
+			continue
+		}
+
 		// Calculate score first
-		engScore := insights.CalculateEngineeringHealthScore(repo)
-		scoreEmoji := getScoreEmoji(engScore)
+		engScore := insights.CalculateEngineeringHealthScoreWithWeights(full.Repositories[i], opts.weights())
+		scoreEmoji := getScoreEmoji(engScore, opts.MarkdownNoEmoji)
 
-		_, _ = fmt.Fprintf(w, "### %s %s\n", scoreEmoji, repo.Name)
+		_, _ = fmt.Fprintf(w, "### %s%s\n", opts.decor(scoreEmoji+" "), repo.Name)
 		_, _ = fmt.Fprintf(w, "**Engineering Health Score: %d/100**\n\n", engScore)
 
 		// Show score breakdown if requested
@@ -41,41 +61,49 @@ func (r *MarkdownRenderer) RenderWithOptions(report *models.Report, w io.Writer,
 			if outputMode == "" {
 				outputMode = models.OutputModeObservational
 			}
-			r.renderScoreBreakdown(repo, engScore, w, outputMode)
+			r.renderScoreBreakdown(full.Repositories[i], engScore, w, outputMode, opts)
 		}
 
 		// Key Metrics Summary
-		_, _ = fmt.Fprintln(w, "#### 📈 Key Metrics")
-		_, _ = fmt.Fprintln(w, "")
-		_, _ = fmt.Fprintln(w, "| Category | Metrics |")
-		_, _ = fmt.Fprintln(w, "|----------|---------|")
-
-		for _, az := range repo.Analyzers {
-			if len(az.Metrics) > 0 {
-				metricsList := []string{}
-				for _, m := range az.Metrics {
-					val := m.DisplayValue
-					if val == "" {
-						val = fmt.Sprintf("%.2f", m.Value)
+		if !opts.OnlyFindings {
+			_, _ = fmt.Fprintln(w, opts.decor("#### 📈 Key Metrics"))
+			_, _ = fmt.Fprintln(w, "")
+			_, _ = fmt.Fprintln(w, "| Category | Metrics |")
+			_, _ = fmt.Fprintln(w, "|----------|---------|")
+
+			for _, az := range repo.Analyzers {
+				if len(az.Metrics) > 0 {
+					metricsList := []string{}
+					for _, m := range az.Metrics {
+						val := m.DisplayValue
+						if val == "" {
+							val = fmt.Sprintf("%.2f", m.Value)
+						}
+						metricsList = append(metricsList, fmt.Sprintf("**%s:** %s%s", m.Key, val, opts.decor(metricTrend(m))))
 					}
-					metricsList = append(metricsList, fmt.Sprintf("**%s:** %s", m.Key, val))
+					_, _ = fmt.Fprintf(w, "| %s | %s |\n", az.Name, strings.Join(metricsList, "<br>"))
 				}
-				_, _ = fmt.Fprintf(w, "| %s | %s |\n", az.Name, strings.Join(metricsList, "<br>"))
 			}
-		}
-		_, _ = fmt.Fprintln(w, "")
+			_, _ = fmt.Fprintln(w, "")
 
-		// Findings/Issues
-		hasFindings := false
-		for _, az := range repo.Analyzers {
-			if len(az.Findings) > 0 {
-				hasFindings = true
-				break
+			for _, az := range repo.Analyzers {
+				if len(az.TopContributors) == 0 {
+					continue
+				}
+				_, _ = fmt.Fprintln(w, opts.decor("#### 👥 Top Contributors"))
+				_, _ = fmt.Fprintln(w, "")
+				_, _ = fmt.Fprintln(w, "| # | Contributor | Commits | Share |")
+				_, _ = fmt.Fprintln(w, "|---|-------------|---------|-------|")
+				for i, c := range az.TopContributors {
+					_, _ = fmt.Fprintf(w, "| %d | %s | %d | %.0f%% |\n", i+1, c.Name, c.Commits, c.Percent)
+				}
+				_, _ = fmt.Fprintln(w, "")
 			}
 		}
 
-		if hasFindings {
-			_, _ = fmt.Fprintln(w, "#### 🔍 Findings")
+		// Findings/Issues
+		if hasFindings(repo) {
+			_, _ = fmt.Fprintln(w, opts.decor("#### 🔍 Findings"))
 			_, _ = fmt.Fprintln(w, "")
 
 			criticalCount := 0
@@ -98,7 +126,7 @@ func (r *MarkdownRenderer) RenderWithOptions(report *models.Report, w io.Writer,
 						default:
 							infoCount++
 						}
-						_, _ = fmt.Fprintf(w, "- %s **%s:** %s\n", icon, f.Type, f.Message)
+						_, _ = fmt.Fprintf(w, "- %s **%s:** %s\n", opts.decor(icon), f.Type, f.Message)
 
 						// Show explanation if available
 						if f.Explanation != "" {
@@ -121,26 +149,32 @@ func (r *MarkdownRenderer) RenderWithOptions(report *models.Report, w io.Writer,
 			// Summary badge
 			_, _ = fmt.Fprintf(w, "**Summary:** ")
 			if criticalCount > 0 {
-				_, _ = fmt.Fprintf(w, "🚨 %d critical ", criticalCount)
+				_, _ = fmt.Fprintf(w, opts.decor("🚨 %d critical "), criticalCount)
 			}
 			if warningCount > 0 {
-				_, _ = fmt.Fprintf(w, "⚠️ %d warnings ", warningCount)
+				_, _ = fmt.Fprintf(w, opts.decor("⚠️ %d warnings "), warningCount)
 			}
 			if infoCount > 0 {
-				_, _ = fmt.Fprintf(w, "ℹ️ %d info", infoCount)
+				_, _ = fmt.Fprintf(w, opts.decor("ℹ️ %d info"), infoCount)
 			}
 			_, _ = fmt.Fprintln(w, "")
 			_, _ = fmt.Fprintln(w, "")
 		}
 
+		if opts.OnlyFindings {
+			_, _ = fmt.Fprintln(w, "---")
+			_, _ = fmt.Fprintln(w, "")
+			continue
+		}
+
 		// Insights
 		outputMode := opts.OutputMode
 		if outputMode == "" {
 			outputMode = models.OutputModeObservational // default
 		}
-		repoInsights := insights.GenerateInsights(repo, outputMode)
+		repoInsights := insights.GenerateInsights(full.Repositories[i], outputMode, opts.thresholds())
 		if len(repoInsights) > 0 {
-			_, _ = fmt.Fprintln(w, "#### 💡 Recommendations")
+			_, _ = fmt.Fprintln(w, opts.decor("#### 💡 Recommendations"))
 			_, _ = fmt.Fprintln(w, "")
 
 			for _, ins := range repoInsights {
@@ -151,12 +185,12 @@ func (r *MarkdownRenderer) RenderWithOptions(report *models.Report, w io.Writer,
 				case insights.LevelCritical:
 					icon = "🚨"
 				}
-				_, _ = fmt.Fprintf(w, "> %s **%s:** %s\n", icon, ins.Category, ins.Description)
+				_, _ = fmt.Fprintf(w, "> %s **%s:** %s\n", opts.decor(icon), ins.Category, ins.Description)
 				_, _ = fmt.Fprintf(w, "> \n")
-				_, _ = fmt.Fprintf(w, "> 💡 **Action:** %s\n\n", ins.Action)
+				_, _ = fmt.Fprintf(w, opts.decor("> 💡 **Action:** %s\n\n"), ins.Action)
 			}
 		} else {
-			_, _ = fmt.Fprintln(w, "#### ✅ No Critical Insights")
+			_, _ = fmt.Fprintln(w, opts.decor("#### ✅ No Critical Insights"))
 			_, _ = fmt.Fprintln(w, "")
 			_, _ = fmt.Fprintln(w, "This repository is in good health!")
 			_, _ = fmt.Fprintln(w, "")
@@ -168,11 +202,17 @@ func (r *MarkdownRenderer) RenderWithOptions(report *models.Report, w io.Writer,
 
 	// Organization Summary
 	if len(report.Repositories) > 1 {
-		_, _ = fmt.Fprintln(w, "### 📊 Organization Summary")
+		_, _ = fmt.Fprintln(w, opts.decor("### 📊 Organization Summary"))
 		_, _ = fmt.Fprintln(w, "")
 		_, _ = fmt.Fprintln(w, "| Metric | Value |")
 		_, _ = fmt.Fprintln(w, "|--------|-------|")
 		_, _ = fmt.Fprintf(w, "| Repositories Analyzed | %d |\n", report.Summary.TotalReposAnalyzed)
+		if report.Summary.ReposFailed > 0 {
+			_, _ = fmt.Fprintf(w, "| Repos Failed (all analyzers) | %d |\n", report.Summary.ReposFailed)
+		}
+		if report.Summary.ReposUnavailable > 0 {
+			_, _ = fmt.Fprintf(w, "| Repos Unavailable (skipped) | %d |\n", report.Summary.ReposUnavailable)
+		}
 		_, _ = fmt.Fprintf(w, "| Total Commits | %d |\n", report.Summary.TotalCommits)
 		_, _ = fmt.Fprintf(w, "| Issues Found | %d |\n", report.Summary.IssuesFound)
 		_, _ = fmt.Fprintf(w, "| Open Issues | %d |\n", report.Summary.TotalOpenIssues)
@@ -180,6 +220,9 @@ func (r *MarkdownRenderer) RenderWithOptions(report *models.Report, w io.Writer,
 		if report.Summary.AvgHealthScore > 0 {
 			_, _ = fmt.Fprintf(w, "| Average Health Score | %.1f/100 |\n", report.Summary.AvgHealthScore)
 		}
+		if report.Summary.WeightedHealthScore > 0 {
+			_, _ = fmt.Fprintf(w, "| Weighted Health Score (by %s) | %.1f/100 |\n", report.Summary.HealthScoreWeighting, report.Summary.WeightedHealthScore)
+		}
 		if report.Summary.AvgPRCycleTime > 0 {
 			_, _ = fmt.Fprintf(w, "| Average PR Cycle Time | %.1fh |\n", report.Summary.AvgPRCycleTime)
 		}
@@ -188,6 +231,21 @@ func (r *MarkdownRenderer) RenderWithOptions(report *models.Report, w io.Writer,
 		}
 
 		_, _ = fmt.Fprintln(w, "")
+
+		if len(report.Summary.RiskiestRepos) > 1 {
+			_, _ = fmt.Fprintln(w, opts.decor("#### 🚨 Riskiest Repositories"))
+			_, _ = fmt.Fprintln(w, "")
+			_, _ = fmt.Fprintln(w, "| # | Repository | Engineering Health Score |")
+			_, _ = fmt.Fprintln(w, "|---|------------|--------------------------|")
+			for i, r := range report.Summary.RiskiestRepos {
+				_, _ = fmt.Fprintf(w, "| %d | %s | %.0f/100 |\n", i+1, r.Name, r.HealthScore)
+			}
+			_, _ = fmt.Fprintln(w, "")
+		}
+	}
+
+	if opts.ExplainSummary {
+		r.renderSystemicIssues(w, insights.ExplainScoreSummary(full.Repositories, opts.weights()), len(full.Repositories), opts)
 	}
 
 	// Footer
@@ -197,17 +255,17 @@ func (r *MarkdownRenderer) RenderWithOptions(report *models.Report, w io.Writer,
 	return nil
 }
 
-func (r *MarkdownRenderer) renderScoreBreakdown(repo models.RepoResult, engScore int, w io.Writer, outputMode models.OutputMode) {
+func (r *MarkdownRenderer) renderScoreBreakdown(repo models.RepoResult, engScore int, w io.Writer, outputMode models.OutputMode, opts RenderOptions) {
 	if outputMode == "" {
 		outputMode = models.OutputModeObservational // default
 	}
-	scoreComponents := insights.ExplainScore(repo, outputMode)
+	scoreComponents := insights.ExplainScoreWithWeights(repo, outputMode, opts.weights())
 	if len(scoreComponents) == 0 {
 		return
 	}
 
 	_, _ = fmt.Fprintln(w, "<details>")
-	_, _ = fmt.Fprintln(w, "<summary><b>📊 Score Breakdown</b></summary>")
+	_, _ = fmt.Fprintln(w, opts.decor("<summary><b>📊 Score Breakdown</b></summary>"))
 	_, _ = fmt.Fprintln(w, "")
 	_, _ = fmt.Fprintln(w, "| Component | Current | Target | Impact | Tips |")
 	_, _ = fmt.Fprintln(w, "|-----------|---------|--------|--------|------|")
@@ -220,6 +278,9 @@ func (r *MarkdownRenderer) renderScoreBreakdown(repo models.RepoResult, engScore
 		if comp.Impact > 0 {
 			impactStr = fmt.Sprintf("-%d pts", comp.Impact)
 		}
+		if comp.CustomWeight {
+			impactStr += " (custom)"
+		}
 
 		tips := comp.Tips
 		if tips == "" {
@@ -230,7 +291,7 @@ func (r *MarkdownRenderer) renderScoreBreakdown(repo models.RepoResult, engScore
 			comp.Category,
 			comp.Current,
 			comp.Target,
-			impactStr,
+			opts.decor(impactStr),
 			tips)
 	}
 
@@ -240,15 +301,57 @@ func (r *MarkdownRenderer) renderScoreBreakdown(repo models.RepoResult, engScore
 	_, _ = fmt.Fprintln(w, "")
 }
 
-func getScoreEmoji(score int) string {
-	switch {
-	case score >= 90:
-		return "🟢"
-	case score >= 75:
-		return "🟡"
-	case score >= 50:
-		return "🟠"
-	default:
-		return "🔴"
+// scoreBand is a range of health scores sharing a traffic light, text label and
+// shields.io badge color
+type scoreBand struct {
+	min   int
+	emoji string
+	label string
+	color string
+}
+
+// scoreBands are ordered from the highest minimum score down
+var scoreBands = []scoreBand{
+	{90, "🟢", "[GOOD]", "green"},
+	{75, "🟡", "[FAIR]", "yellow"},
+	{50, "🟠", "[NEEDS WORK]", "orange"},
+	{0, "🔴", "[AT RISK]", "red"},
+}
+
+// bandForScore returns the band a health score falls into
+func bandForScore(score int) scoreBand {
+	for _, b := range scoreBands {
+		if score >= b.min {
+			return b
+		}
 	}
+	return scoreBands[len(scoreBands)-1]
+}
+
+// getScoreEmoji returns the traffic light for a health score, or a text label when
+// noEmoji is set for wikis and PDF pipelines that render emoji poorly
+func getScoreEmoji(score int, noEmoji bool) string {
+	if noEmoji {
+		return bandForScore(score).label
+	}
+	return bandForScore(score).emoji
+}
+
+func (r *MarkdownRenderer) renderSystemicIssues(w io.Writer, issues []insights.SystemicIssue, totalRepos int, opts RenderOptions) {
+	_, _ = fmt.Fprintln(w, opts.decor("### 🧭 Top Systemic Issues"))
+	_, _ = fmt.Fprintln(w, "")
+	if len(issues) == 0 {
+		_, _ = fmt.Fprintln(w, "No score deductions across the analyzed repositories.")
+		_, _ = fmt.Fprintln(w, "")
+		return
+	}
+	_, _ = fmt.Fprintln(w, "| Category | Points Lost | Repos Affected |")
+	_, _ = fmt.Fprintln(w, "|----------|-------------|----------------|")
+	for i, issue := range issues {
+		if i == maxSystemicIssues {
+			break
+		}
+		_, _ = fmt.Fprintf(w, "| %s | -%d | %d/%d |\n", issue.Category, issue.PointsLost, issue.ReposAffected, totalRepos)
+	}
+	_, _ = fmt.Fprintln(w, "")
 }
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// ndjsonTrailer is the last NDJSON line, carrying everything but the repositories
+type ndjsonTrailer struct {
+	Type        string                   `json:"type"` // always "summary"
+	Meta        models.ReportMeta        `json:"meta"`
+	Summary     models.GlobalSummary     `json:"summary"`
+	Unavailable []models.UnavailableRepo `json:"unavailable,omitempty"`
+}
+
+// NDJSONStream writes a report as newline-delimited JSON while the analysis runs: one
+// RepoResult per line as each repository completes, then a trailer line with
+// "type": "summary". Severity, output mode and --only-findings apply per repository.
+type NDJSONStream struct {
+	enc  *json.Encoder
+	opts RenderOptions
+}
+
+func NewNDJSONStream(w io.Writer, opts RenderOptions) *NDJSONStream {
+	return &NDJSONStream{enc: json.NewEncoder(w), opts: opts}
+}
+
+// WriteRepo writes one repository line
+func (s *NDJSONStream) WriteRepo(repo models.RepoResult) error {
+	single := &models.Report{Repositories: []models.RepoResult{repo}}
+	single = applyOutputMode(filterBySeverity(single, s.opts.MinSeverity), s.opts.OutputMode)
+	if s.opts.OnlyFindings {
+		single = findingsOnly(single)
+	}
+	for _, r := range single.Repositories {
+		if err := s.enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteSummary writes the trailer line; the report's repositories are ignored
+func (s *NDJSONStream) WriteSummary(report *models.Report) error {
+	return s.enc.Encode(ndjsonTrailer{
+		Type:        "summary",
+		Meta:        report.Meta,
+		Summary:     report.Summary,
+		Unavailable: report.Unavailable,
+	})
+}
+
+// NDJSONRenderer renders a complete report in the same line format as NDJSONStream
+type NDJSONRenderer struct{}
+
+func (r *NDJSONRenderer) Render(report *models.Report, w io.Writer) error {
+	return r.RenderWithOptions(report, w, RenderOptions{})
+}
+
+func (r *NDJSONRenderer) RenderWithOptions(report *models.Report, w io.Writer, opts RenderOptions) error {
+	stream := NewNDJSONStream(w, opts)
+	for _, repo := range report.Repositories {
+		if err := stream.WriteRepo(repo); err != nil {
+			return err
+		}
+	}
+	return stream.WriteSummary(report)
+}
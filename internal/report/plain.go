@@ -0,0 +1,48 @@
+package report
+
+import (
+	"io"
+	"regexp"
+	"strings"
+)
+
+// plainReplacer maps the emoji and symbols used by the renderers to ASCII.
+// Entries with a trailing space come first so decorative emoji don't leave double spaces.
+var plainReplacer = strings.NewReplacer(
+	"🔎 ", "", "🔍 ", "", "📊 ", "", "📈 ", "", "📉 ", "", "🧭 ", "", "👥 ", "",
+	"🟢 ", "", "🟡 ", "", "🟠 ", "", "🔴 ", "",
+	"🚨", "[!!]", "⚠️", "[!]", "⚠", "[!]", "ℹ️", "[i]", "ℹ", "[i]",
+	"✅", "[ok]", "✓", "[ok]", "💡", "Tip:",
+	"→", "->", "↑", "+", "↓", "-", "•", "-", "─", "-",
+)
+
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// decor returns s, an emoji or symbol the renderer adds itself, as ASCII for plain output.
+// Repository names, messages and other report data are written as they are.
+func (o RenderOptions) decor(s string) string {
+	if o.NoColor {
+		return plainReplacer.Replace(s)
+	}
+	return s
+}
+
+// plainWriter strips ANSI color codes before writing to w
+type plainWriter struct {
+	w io.Writer
+}
+
+func (p plainWriter) Write(b []byte) (int, error) {
+	if _, err := io.WriteString(p.w, ansiPattern.ReplaceAllString(string(b), "")); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// outputWriter returns w, wrapped to strip colors when opts.NoColor is set
+func outputWriter(w io.Writer, opts RenderOptions) io.Writer {
+	if opts.NoColor {
+		return plainWriter{w: w}
+	}
+	return w
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+func TestPlainWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := plainWriter{w: &buf}
+
+	in := "\x1b[31mbus_factor_risk\x1b[0m: merge feature/✓-checks → main\n"
+	n, err := w.Write([]byte(in))
+	if err != nil || n != len(in) {
+		t.Fatalf("Write returned %d, %v; want %d, nil", n, err, len(in))
+	}
+
+	// Colors are stripped, but written text is left as it is
+	want := "bus_factor_risk: merge feature/✓-checks → main\n"
+	if buf.String() != want {
+		t.Errorf("Got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestDecor(t *testing.T) {
+	in := "🚨 🔎 REPORT 💡 → ✓"
+	if got, want := (RenderOptions{NoColor: true}).decor(in), "[!!] REPORT Tip: -> [ok]"; got != want {
+		t.Errorf("Got %q, want %q", got, want)
+	}
+	if got := (RenderOptions{}).decor(in); got != in {
+		t.Errorf("Expected decoration to be kept with colors on, got %q", got)
+	}
+}
+
+func TestNoColorKeepsReportData(t *testing.T) {
+	rep := onlyFindingsReport()
+	rep.Repositories[0].Analyzers[0].Findings[0].Message = "Branch 💡-ideas → main is stale ✓"
+
+	for name, r := range map[string]Renderer{"text": &TextRenderer{}, "markdown": &MarkdownRenderer{}} {
+		var buf bytes.Buffer
+		if err := r.RenderWithOptions(rep, &buf, RenderOptions{NoColor: true}); err != nil {
+			t.Fatalf("%s: render failed: %v", name, err)
+		}
+		if !strings.Contains(buf.String(), "Branch 💡-ideas → main is stale ✓") {
+			t.Errorf("%s: finding message was rewritten:\n%s", name, buf.String())
+		}
+	}
+}
+
+func TestNoColorRendersASCII(t *testing.T) {
+	rep := onlyFindingsReport()
+	rep.Repositories[0].Analyzers[0].Findings[0].Severity = models.SeverityHigh
+
+	for name, r := range map[string]Renderer{"text": &TextRenderer{}, "markdown": &MarkdownRenderer{}, "compare": &ComparisonTextRenderer{}} {
+		var buf bytes.Buffer
+		if err := r.RenderWithOptions(rep, &buf, RenderOptions{NoColor: true}); err != nil {
+			t.Fatalf("%s: render failed: %v", name, err)
+		}
+		for _, r := range buf.String() {
+			if r > 0x7f {
+				t.Errorf("%s: unexpected non-ASCII %q in output:\n%s", name, r, buf.String())
+				break
+			}
+		}
+		if strings.Contains(buf.String(), "\x1b[") {
+			t.Errorf("%s: unexpected ANSI escape in output", name)
+		}
+	}
+}
@@ -17,12 +17,147 @@ const (
 	FormatJSON     Format = "json"
 	FormatText     Format = "text"
 	FormatMarkdown Format = "markdown"
+	FormatCSV      Format = "csv"
+	FormatSARIF    Format = "sarif"
+	FormatScore    Format = "score"
+	FormatNDJSON   Format = "ndjson"
+	FormatJUnit    Format = "junit"
+	FormatBadge    Format = "badge"
 )
 
+// Formats lists every output format, in the order they are documented
+var Formats = []Format{FormatText, FormatJSON, FormatMarkdown, FormatCSV, FormatSARIF, FormatScore, FormatNDJSON, FormatJUnit, FormatBadge}
+
 // RenderOptions contains options for rendering reports
 type RenderOptions struct {
 	ShowExplanation bool
 	OutputMode      models.OutputMode
+	OnlyFindings    bool            // Omit metrics and show only repositories with findings
+	MinSeverity     models.Severity // Hide findings below this severity (empty = show all)
+	NoColor         bool            // Replace emoji and ANSI color with plain ASCII
+	Previous        *models.Report  // Earlier run to show metric changes against (text only)
+	CompactJSON     bool            // Emit JSON on a single line without indentation
+	ExplainSummary  bool            // Show score deductions aggregated across repositories (text and markdown)
+	ShowTimings     bool            // Show how long each repository and analyzer took (text)
+	MarkdownNoEmoji bool            // Use text labels such as [GOOD] instead of emoji in markdown
+	// ScoringWeights are the configured health score deductions (nil = built-in defaults)
+	ScoringWeights *insights.ScoringWeights
+	// InsightThresholds are the configured insight trigger points (nil = built-in defaults)
+	InsightThresholds *insights.InsightThresholds
+}
+
+// weights returns the scoring weights to render scores with
+func (o RenderOptions) weights() insights.ScoringWeights {
+	if o.ScoringWeights == nil {
+		return insights.DefaultScoringWeights()
+	}
+	return *o.ScoringWeights
+}
+
+// thresholds returns the trigger points to generate insights with
+func (o RenderOptions) thresholds() insights.InsightThresholds {
+	if o.InsightThresholds == nil {
+		return insights.DefaultInsightThresholds()
+	}
+	return *o.InsightThresholds
+}
+
+// filterBySeverity returns a copy of the report without findings below min, with
+// Summary.IssuesFound recounted. Metrics are kept so scores can still be computed.
+func filterBySeverity(report *models.Report, min models.Severity) *models.Report {
+	if min == "" || min == models.SeverityInfo {
+		return report
+	}
+	filtered := *report
+	filtered.Summary.IssuesFound = 0
+	filtered.Repositories = make([]models.RepoResult, len(report.Repositories))
+	for i, repo := range report.Repositories {
+		analyzers := make([]models.AnalyzerResult, len(repo.Analyzers))
+		for j, az := range repo.Analyzers {
+			var findings []models.Finding
+			for _, f := range az.Findings {
+				if f.Severity.AtLeast(min) {
+					findings = append(findings, f)
+				}
+			}
+			az.Findings = findings
+			analyzers[j] = az
+			filtered.Summary.IssuesFound += len(findings)
+		}
+		repo.Analyzers = analyzers
+		filtered.Repositories[i] = repo
+	}
+	return &filtered
+}
+
+// applyOutputMode returns a copy of the report with finding guidance trimmed to the
+// output mode, matching insights.GenerateInsights: statistical keeps only the message,
+// observational adds the neutral explanation, and suggestive keeps remediation and
+// suggested actions. An unset mode leaves findings untouched.
+func applyOutputMode(report *models.Report, mode models.OutputMode) *models.Report {
+	if mode != models.OutputModeStatistical && mode != models.OutputModeObservational {
+		return report
+	}
+	trimmed := *report
+	trimmed.Repositories = make([]models.RepoResult, len(report.Repositories))
+	for i, repo := range report.Repositories {
+		analyzers := make([]models.AnalyzerResult, len(repo.Analyzers))
+		for j, az := range repo.Analyzers {
+			findings := make([]models.Finding, len(az.Findings))
+			for k, f := range az.Findings {
+				if mode == models.OutputModeStatistical {
+					f.Explanation = ""
+				}
+				f.Remediation = ""
+				f.SuggestedActions = nil
+				findings[k] = f
+			}
+			az.Findings = findings
+			analyzers[j] = az
+		}
+		repo.Analyzers = analyzers
+		trimmed.Repositories[i] = repo
+	}
+	return &trimmed
+}
+
+// timing formats a duration for a repository or analyzer heading when opts.ShowTimings is set
+func timing(opts RenderOptions, ms int64) string {
+	if !opts.ShowTimings || ms <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" [%s]", time.Duration(ms)*time.Millisecond)
+}
+
+// hasFindings reports whether any analyzer produced a finding for the repository
+func hasFindings(repo models.RepoResult) bool {
+	for _, az := range repo.Analyzers {
+		if len(az.Findings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// findingsOnly returns a copy of the report keeping only repositories with findings,
+// with metrics and finding-free analyzers removed
+func findingsOnly(report *models.Report) *models.Report {
+	filtered := *report
+	filtered.Repositories = make([]models.RepoResult, 0, len(report.Repositories))
+	for _, repo := range report.Repositories {
+		if !hasFindings(repo) {
+			continue
+		}
+		analyzers := make([]models.AnalyzerResult, 0, len(repo.Analyzers))
+		for _, az := range repo.Analyzers {
+			if len(az.Findings) > 0 {
+				analyzers = append(analyzers, models.AnalyzerResult{Name: az.Name, Findings: az.Findings})
+			}
+		}
+		repo.Analyzers = analyzers
+		filtered.Repositories = append(filtered.Repositories, repo)
+	}
+	return &filtered
 }
 
 type Renderer interface {
@@ -38,6 +173,18 @@ func NewRenderer(f Format) Renderer {
 		return &TextRenderer{}
 	case FormatMarkdown:
 		return &MarkdownRenderer{}
+	case FormatCSV:
+		return &CSVRenderer{}
+	case FormatSARIF:
+		return &SARIFRenderer{}
+	case FormatScore:
+		return &ScoreRenderer{}
+	case FormatNDJSON:
+		return &NDJSONRenderer{}
+	case FormatJUnit:
+		return &JUnitRenderer{}
+	case FormatBadge:
+		return &BadgeRenderer{}
 	default:
 		return &TextRenderer{}
 	}
@@ -50,8 +197,14 @@ func (r *JSONRenderer) Render(report *models.Report, w io.Writer) error {
 }
 
 func (r *JSONRenderer) RenderWithOptions(report *models.Report, w io.Writer, opts RenderOptions) error {
+	report = applyOutputMode(filterBySeverity(report, opts.MinSeverity), opts.OutputMode)
+	if opts.OnlyFindings {
+		report = findingsOnly(report)
+	}
 	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
+	if !opts.CompactJSON {
+		enc.SetIndent("", "  ")
+	}
 	return enc.Encode(report)
 }
 
@@ -62,13 +215,30 @@ func (r *TextRenderer) Render(report *models.Report, w io.Writer) error {
 }
 
 func (r *TextRenderer) RenderWithOptions(report *models.Report, w io.Writer, opts RenderOptions) error {
+	w = outputWriter(w, opts)
+	if report.Meta.Note != "" {
+		_, _ = fmt.Fprintf(w, opts.decor("⚠️  %s\n"), report.Meta.Note)
+	}
+	for _, u := range report.Unavailable {
+		_, _ = fmt.Fprintf(w, opts.decor("⚠️  Skipped %s: %s\n"), u.Name, u.Message)
+	}
 	if len(report.Repositories) == 0 {
 		_, _ = fmt.Fprintln(w, "No repositories analyzed.")
 		return nil
 	}
 
-	for _, repo := range report.Repositories {
-		_, _ = fmt.Fprintf(w, "\n🔎 REPORT FOR: %s (%s)\n", repo.Name, repo.URL)
+	// Scores are computed from the unfiltered results so hiding findings doesn't change them
+	full := report
+	report = applyOutputMode(filterBySeverity(report, opts.MinSeverity), opts.OutputMode)
+	previous := newPreviousRun(opts.Previous, opts.weights())
+
+	for i, repo := range report.Repositories {
+		if opts.OnlyFindings && !hasFindings(repo) {
+			_, _ = fmt.Fprintf(w, opts.decor("\n✓ %s: clean\n"), repo.Name)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, opts.decor("\n🔎 REPORT FOR: %s (%s)%s\n"), repo.Name, repo.URL, timing(opts, repo.DurationMs))
 		_, _ = fmt.Fprintln(w, "==================================================")
 
 		if len(repo.Analyzers) == 0 {
@@ -77,17 +247,29 @@ func (r *TextRenderer) RenderWithOptions(report *models.Report, w io.Writer, opt
 		}
 
 		for _, az := range repo.Analyzers {
-			_, _ = fmt.Fprintf(w, "\n[ %s ]\n", az.Name)
+			if opts.OnlyFindings && len(az.Findings) == 0 {
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "\n[ %s ]%s\n", az.Name, timing(opts, az.DurationMs))
 
 			// 1. Metrics Table
-			if len(az.Metrics) > 0 {
+			if len(az.Metrics) > 0 && !opts.OnlyFindings {
 				tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 				for _, m := range az.Metrics {
 					val := m.DisplayValue
 					if val == "" {
 						val = fmt.Sprintf("%.2f", m.Value)
 					}
-					_, _ = fmt.Fprintf(tw, "  %s:\t%s\n", m.Key, val)
+					_, _ = fmt.Fprintf(tw, "  %s:\t%s%s\n", m.Key, val, opts.decor(previous.metricDelta(repo.Name, az.Name, m)))
+				}
+				_ = tw.Flush()
+				_, _ = fmt.Fprintln(w, "")
+			}
+			if len(az.TopContributors) > 0 && !opts.OnlyFindings {
+				_, _ = fmt.Fprintln(w, "  Top contributors:")
+				tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+				for i, c := range az.TopContributors {
+					_, _ = fmt.Fprintf(tw, "    %d. %s\t%d commits\t%.0f%%\n", i+1, c.Name, c.Commits, c.Percent)
 				}
 				_ = tw.Flush()
 				_, _ = fmt.Fprintln(w, "")
@@ -104,7 +286,7 @@ func (r *TextRenderer) RenderWithOptions(report *models.Report, w io.Writer, opt
 					case models.SeverityMedium:
 						icon = "⚠️"
 					}
-					_, _ = fmt.Fprintf(w, "    %s %s: %s\n", icon, f.Type, f.Message)
+					_, _ = fmt.Fprintf(w, "    %s %s: %s\n", opts.decor(icon), f.Type, f.Message)
 
 					// Show explanation if available
 					if f.Explanation != "" {
@@ -124,24 +306,29 @@ func (r *TextRenderer) RenderWithOptions(report *models.Report, w io.Writer, opt
 			}
 		}
 
+		if opts.OnlyFindings {
+			_, _ = fmt.Fprintln(w, "--------------------------------------------------")
+			continue
+		}
+
 		// 3. Opinionated Insights & Score
 		outputMode := opts.OutputMode
 		if outputMode == "" {
 			outputMode = models.OutputModeObservational // default
 		}
-		repoInsights := insights.GenerateInsights(repo, outputMode)
-		engScore := insights.CalculateEngineeringHealthScore(repo)
+		repoInsights := insights.GenerateInsights(full.Repositories[i], outputMode, opts.thresholds())
+		engScore := insights.CalculateEngineeringHealthScoreWithWeights(full.Repositories[i], opts.weights())
 
 		_, _ = fmt.Fprintf(w, "\n[ opinionated-insights ]\n")
-		_, _ = fmt.Fprintf(w, "  Engineering Health Score: %d/100\n", engScore)
+		_, _ = fmt.Fprintf(w, "  Engineering Health Score: %d/100%s\n", engScore, opts.decor(previous.scoreDelta(repo.Name, engScore)))
 
 		// Show score explanation if requested
 		if opts.ShowExplanation {
-			scoreComponents := insights.ExplainScore(repo, outputMode)
+			scoreComponents := insights.ExplainScoreWithWeights(full.Repositories[i], outputMode, opts.weights())
 			if len(scoreComponents) > 0 {
 				_, _ = fmt.Fprintln(w, "")
 				_, _ = fmt.Fprintln(w, "  Score Breakdown:")
-				_, _ = fmt.Fprintln(w, "  "+"─────────────────────────────────────────────────────")
+				_, _ = fmt.Fprintln(w, opts.decor("  ─────────────────────────────────────────────────────"))
 
 				totalImpact := 0
 				for _, comp := range scoreComponents {
@@ -154,11 +341,14 @@ func (r *TextRenderer) RenderWithOptions(report *models.Report, w io.Writer, opt
 					} else {
 						impactStr = " [✓]"
 					}
-					_, _ = fmt.Fprintf(w, "  • %s%s\n", comp.Category, impactStr)
+					if comp.CustomWeight {
+						impactStr += " (custom weight)"
+					}
+					_, _ = fmt.Fprintf(w, opts.decor("  • %s%s\n"), comp.Category, opts.decor(impactStr))
 					_, _ = fmt.Fprintf(w, "    Current: %s | Target: %s\n", comp.Current, comp.Target)
 
 					if comp.Tips != "" {
-						_, _ = fmt.Fprintf(w, "    💡 %s\n", comp.Tips)
+						_, _ = fmt.Fprintf(w, opts.decor("    💡 %s\n"), comp.Tips)
 					}
 					_, _ = fmt.Fprintln(w, "")
 				}
@@ -177,7 +367,7 @@ func (r *TextRenderer) RenderWithOptions(report *models.Report, w io.Writer, opt
 				case insights.LevelCritical:
 					icon = "🚨"
 				}
-				_, _ = fmt.Fprintf(w, "  %s %s: %s\n", icon, ins.Category, ins.Description)
+				_, _ = fmt.Fprintf(w, "  %s %s: %s\n", opts.decor(icon), ins.Category, ins.Description)
 				_, _ = fmt.Fprintf(w, "     Action: %s\n", ins.Action)
 			}
 		} else {
@@ -189,11 +379,17 @@ func (r *TextRenderer) RenderWithOptions(report *models.Report, w io.Writer, opt
 
 	// Render Summary
 	_, _ = fmt.Fprintln(w, "")
-	_, _ = fmt.Fprintln(w, "📊 ORGANIZATION SUMMARY")
+	_, _ = fmt.Fprintln(w, opts.decor("📊 ORGANIZATION SUMMARY"))
 	_, _ = fmt.Fprintln(w, "==================================================")
 
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 	_, _ = fmt.Fprintf(tw, "Repositories Analyzed:\t%d\n", report.Summary.TotalReposAnalyzed)
+	if report.Summary.ReposFailed > 0 {
+		_, _ = fmt.Fprintf(tw, "Repos Failed (all analyzers):\t%d\n", report.Summary.ReposFailed)
+	}
+	if report.Summary.ReposUnavailable > 0 {
+		_, _ = fmt.Fprintf(tw, "Repos Unavailable (skipped):\t%d\n", report.Summary.ReposUnavailable)
+	}
 	_, _ = fmt.Fprintf(tw, "Total Commits:\t%d\n", report.Summary.TotalCommits)
 	_, _ = fmt.Fprintf(tw, "Total Issues Found:\t%d\n", report.Summary.IssuesFound)
 	_, _ = fmt.Fprintf(tw, "Open Issues:\t%d\n", report.Summary.TotalOpenIssues)
@@ -204,6 +400,9 @@ func (r *TextRenderer) RenderWithOptions(report *models.Report, w io.Writer, opt
 	if report.Summary.AvgHealthScore > 0 {
 		_, _ = fmt.Fprintf(tw, "Avg Health Score:\t%.1f/100\n", report.Summary.AvgHealthScore)
 	}
+	if report.Summary.WeightedHealthScore > 0 {
+		_, _ = fmt.Fprintf(tw, "Weighted Health Score:\t%.1f/100 (by %s)\n", report.Summary.WeightedHealthScore, report.Summary.HealthScoreWeighting)
+	}
 	if report.Summary.AvgPRCycleTime > 0 {
 		_, _ = fmt.Fprintf(tw, "Avg PR Cycle Time:\t%.1fh\n", report.Summary.AvgPRCycleTime)
 	}
@@ -215,7 +414,42 @@ func (r *TextRenderer) RenderWithOptions(report *models.Report, w io.Writer, opt
 	}
 
 	_ = tw.Flush()
+
+	if len(report.Summary.RiskiestRepos) > 1 {
+		_, _ = fmt.Fprintln(w, "")
+		_, _ = fmt.Fprintln(w, "Riskiest Repositories:")
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for i, r := range report.Summary.RiskiestRepos {
+			_, _ = fmt.Fprintf(tw, "  %d. %s\t%.0f/100\n", i+1, r.Name, r.HealthScore)
+		}
+		_ = tw.Flush()
+	}
+
+	if opts.ExplainSummary {
+		renderSystemicIssues(w, insights.ExplainScoreSummary(full.Repositories, opts.weights()), len(full.Repositories))
+	}
 	_, _ = fmt.Fprintln(w, "--------------------------------------------------")
 
 	return nil
 }
+
+// maxSystemicIssues caps how many categories --explain-summary lists
+const maxSystemicIssues = 5
+
+// renderSystemicIssues lists the score categories that cost the most points across all repositories
+func renderSystemicIssues(w io.Writer, issues []insights.SystemicIssue, totalRepos int) {
+	_, _ = fmt.Fprintln(w, "")
+	_, _ = fmt.Fprintln(w, "Top Systemic Issues:")
+	if len(issues) == 0 {
+		_, _ = fmt.Fprintln(w, "  No score deductions across the analyzed repositories.")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, issue := range issues {
+		if i == maxSystemicIssues {
+			break
+		}
+		_, _ = fmt.Fprintf(tw, "  %d. %s\t-%d pts\t%d/%d repos\n", i+1, issue.Category, issue.PointsLost, issue.ReposAffected, totalRepos)
+	}
+	_ = tw.Flush()
+}
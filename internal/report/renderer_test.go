@@ -0,0 +1,545 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mikematt33/gh-inspect/pkg/insights"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+func onlyFindingsReport() *models.Report {
+	return &models.Report{
+		Repositories: []models.RepoResult{
+			{
+				Name: "owner/noisy",
+				Analyzers: []models.AnalyzerResult{
+					{Name: "pr-flow",
+						Metrics:  []models.Metric{{Key: "open_prs", Value: 7}},
+						Findings: []models.Finding{{Type: "stale_pr", Severity: models.SeverityMedium, Message: "PR #1 is stale"}},
+					},
+					{Name: "ci", Metrics: []models.Metric{{Key: "success_rate", Value: 98}}},
+				},
+			},
+			{
+				Name: "owner/tidy",
+				Analyzers: []models.AnalyzerResult{
+					{Name: "ci", Metrics: []models.Metric{{Key: "success_rate", Value: 100}}},
+				},
+			},
+		},
+	}
+}
+
+func TestOnlyFindings_TextAndMarkdown(t *testing.T) {
+	for name, r := range map[string]Renderer{"text": &TextRenderer{}, "markdown": &MarkdownRenderer{}} {
+		var buf bytes.Buffer
+		if err := r.RenderWithOptions(onlyFindingsReport(), &buf, RenderOptions{OnlyFindings: true}); err != nil {
+			t.Fatalf("%s: render failed: %v", name, err)
+		}
+		out := buf.String()
+
+		if !strings.Contains(out, "PR #1 is stale") {
+			t.Errorf("%s: expected finding in output", name)
+		}
+		if strings.Contains(out, "open_prs") || strings.Contains(out, "success_rate") {
+			t.Errorf("%s: expected metrics to be omitted:\n%s", name, out)
+		}
+		if !strings.Contains(out, "owner/tidy") || !strings.Contains(out, "✓") || !strings.Contains(out, "clean") {
+			t.Errorf("%s: expected clean line for repo without findings:\n%s", name, out)
+		}
+	}
+}
+
+func TestOnlyFindings_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&JSONRenderer{}).RenderWithOptions(onlyFindingsReport(), &buf, RenderOptions{OnlyFindings: true}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	var got models.Report
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if len(got.Repositories) != 1 || got.Repositories[0].Name != "owner/noisy" {
+		t.Fatalf("Expected only owner/noisy, got %+v", got.Repositories)
+	}
+	analyzers := got.Repositories[0].Analyzers
+	if len(analyzers) != 1 || analyzers[0].Name != "pr-flow" || len(analyzers[0].Metrics) != 0 {
+		t.Errorf("Expected only pr-flow findings without metrics, got %+v", analyzers)
+	}
+}
+
+func TestOnlyFindings_DoesNotMutateReport(t *testing.T) {
+	rep := onlyFindingsReport()
+	_ = (&JSONRenderer{}).RenderWithOptions(rep, &bytes.Buffer{}, RenderOptions{OnlyFindings: true})
+	if len(rep.Repositories) != 2 || len(rep.Repositories[0].Analyzers) != 2 {
+		t.Errorf("Expected original report to be untouched")
+	}
+}
+
+func TestMinSeverity_FiltersFindingsAndRecountsSummary(t *testing.T) {
+	rep := &models.Report{
+		Repositories: []models.RepoResult{{
+			Name: "owner/repo",
+			Analyzers: []models.AnalyzerResult{{Name: "pr-flow", Findings: []models.Finding{
+				{Type: "note", Severity: models.SeverityInfo, Message: "info finding"},
+				{Type: "stale_pr", Severity: models.SeverityMedium, Message: "medium finding"},
+				{Type: "secret", Severity: models.SeverityCritical, Message: "critical finding"},
+			}}},
+		}},
+		Summary: models.GlobalSummary{IssuesFound: 3},
+	}
+
+	var buf bytes.Buffer
+	if err := (&JSONRenderer{}).RenderWithOptions(rep, &buf, RenderOptions{MinSeverity: models.SeverityMedium}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	var got models.Report
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if got.Summary.IssuesFound != 2 {
+		t.Errorf("Expected IssuesFound to reflect the filter (2), got %d", got.Summary.IssuesFound)
+	}
+	if n := len(got.Repositories[0].Analyzers[0].Findings); n != 2 {
+		t.Errorf("Expected 2 findings at medium and above, got %d", n)
+	}
+	if rep.Summary.IssuesFound != 3 || len(rep.Repositories[0].Analyzers[0].Findings) != 3 {
+		t.Errorf("Expected the unfiltered report to be left intact for baseline comparison")
+	}
+
+	buf.Reset()
+	if err := (&TextRenderer{}).RenderWithOptions(rep, &buf, RenderOptions{MinSeverity: models.SeverityHigh}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if out := buf.String(); strings.Contains(out, "medium finding") || !strings.Contains(out, "critical finding") {
+		t.Errorf("Expected only high and critical findings in text output:\n%s", out)
+	}
+}
+
+func TestMinSeverity_DoesNotChangeScore(t *testing.T) {
+	findings := make([]models.Finding, 6)
+	for i := range findings {
+		findings[i] = models.Finding{Type: "stale_pr", Severity: models.SeverityMedium, Message: "stale"}
+	}
+	rep := &models.Report{Repositories: []models.RepoResult{{
+		Name:      "owner/repo",
+		Analyzers: []models.AnalyzerResult{{Name: "pr-flow", Findings: findings}},
+	}}}
+
+	var all, filtered bytes.Buffer
+	_ = (&MarkdownRenderer{}).RenderWithOptions(rep, &all, RenderOptions{})
+	_ = (&MarkdownRenderer{}).RenderWithOptions(rep, &filtered, RenderOptions{MinSeverity: models.SeverityHigh})
+
+	scoreLine := func(s string) string {
+		for _, line := range strings.Split(s, "\n") {
+			if strings.Contains(line, "Engineering Health Score") {
+				return line
+			}
+		}
+		return ""
+	}
+	if scoreLine(all.String()) == "" || scoreLine(all.String()) != scoreLine(filtered.String()) {
+		t.Errorf("Expected score to ignore the severity filter: %q vs %q", scoreLine(all.String()), scoreLine(filtered.String()))
+	}
+}
+
+func TestPreviousShowsInlineDeltas(t *testing.T) {
+	rep := func(success, open float64) *models.Report {
+		return &models.Report{Repositories: []models.RepoResult{{
+			Name: "owner/repo",
+			Analyzers: []models.AnalyzerResult{{Name: "ci", Metrics: []models.Metric{
+				{Key: "success_rate", Value: success, DisplayValue: fmt.Sprintf("%.0f%%", success)},
+				{Key: "open_prs", Value: open},
+			}}},
+		}}}
+	}
+
+	var buf bytes.Buffer
+	if err := (&TextRenderer{}).RenderWithOptions(rep(80, 4), &buf, RenderOptions{Previous: rep(95, 4)}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "80% (↓15.00)") {
+		t.Errorf("Expected success_rate delta inline:\n%s", out)
+	}
+	if strings.Contains(out, "4.00 (") {
+		t.Errorf("Expected no delta for an unchanged metric:\n%s", out)
+	}
+
+	buf.Reset()
+	_ = (&TextRenderer{}).RenderWithOptions(rep(80, 4), &buf, RenderOptions{})
+	if strings.Contains(buf.String(), "↓") {
+		t.Errorf("Expected no deltas without a previous report")
+	}
+}
+
+func TestMetricTrendsFromBaseline(t *testing.T) {
+	prev := func(v float64) *float64 { return &v }
+	r := &models.Report{Repositories: []models.RepoResult{{
+		Name: "owner/repo",
+		Analyzers: []models.AnalyzerResult{{Name: "ci", Metrics: []models.Metric{
+			{Key: "success_rate", Value: 80, DisplayValue: "80%", PreviousValue: prev(95), Trend: models.TrendDown},
+			{Key: "open_prs", Value: 4, DisplayValue: "4", PreviousValue: prev(4), Trend: models.TrendFlat},
+			{Key: "new_metric", Value: 1, DisplayValue: "1"},
+		}}},
+	}}}
+
+	for _, tc := range []struct {
+		renderer Renderer
+		want     []string
+	}{
+		{&TextRenderer{}, []string{"80% (↓15.00)", "4 (→)"}},
+		{&MarkdownRenderer{}, []string{"**success_rate:** 80% (↓15.00)", "**open_prs:** 4 (→)", "**new_metric:** 1 |"}},
+	} {
+		var buf bytes.Buffer
+		if err := tc.renderer.RenderWithOptions(r, &buf, RenderOptions{}); err != nil {
+			t.Fatalf("%T failed: %v", tc.renderer, err)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("%T output is missing %q:\n%s", tc.renderer, want, buf.String())
+			}
+		}
+	}
+}
+
+func TestCompactJSON(t *testing.T) {
+	var pretty, compact bytes.Buffer
+	_ = (&JSONRenderer{}).RenderWithOptions(onlyFindingsReport(), &pretty, RenderOptions{})
+	if err := (&JSONRenderer{}).RenderWithOptions(onlyFindingsReport(), &compact, RenderOptions{CompactJSON: true}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	if n := strings.Count(compact.String(), "\n"); n != 1 {
+		t.Errorf("Expected compact JSON on a single line, got %d lines", n)
+	}
+	if compact.Len() >= pretty.Len() {
+		t.Errorf("Expected compact output (%d bytes) to be smaller than pretty (%d bytes)", compact.Len(), pretty.Len())
+	}
+	var got models.Report
+	if err := json.Unmarshal(compact.Bytes(), &got); err != nil || len(got.Repositories) != 2 {
+		t.Errorf("Expected compact output to decode to the same report, err=%v", err)
+	}
+}
+
+func TestOutputModeTrimsFindingGuidance(t *testing.T) {
+	guided := &models.Report{Repositories: []models.RepoResult{{
+		Name: "owner/repo",
+		Analyzers: []models.AnalyzerResult{{Name: "dependencies", Findings: []models.Finding{{
+			Type:             "no_lock_file",
+			Severity:         models.SeverityMedium,
+			Message:          "No lock file found",
+			Explanation:      "Lock files pin exact versions.",
+			Remediation:      "Commit your lock file.",
+			SuggestedActions: []string{"Run npm install and commit package-lock.json"},
+		}}}},
+	}}}
+
+	tests := []struct {
+		mode        models.OutputMode
+		wantWhy     bool
+		wantActions bool
+	}{
+		{models.OutputModeStatistical, false, false},
+		{models.OutputModeObservational, true, false},
+		{models.OutputModeSuggestive, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			for _, renderer := range []Renderer{&TextRenderer{}, &MarkdownRenderer{}, &JSONRenderer{}} {
+				var buf bytes.Buffer
+				if err := renderer.RenderWithOptions(guided, &buf, RenderOptions{OutputMode: tt.mode, NoColor: true}); err != nil {
+					t.Fatalf("%T failed: %v", renderer, err)
+				}
+				out := buf.String()
+				if !strings.Contains(out, "No lock file found") {
+					t.Errorf("%T: message missing", renderer)
+				}
+				if strings.Contains(out, "Lock files pin exact versions.") != tt.wantWhy {
+					t.Errorf("%T: explanation shown = %v, want %v", renderer, !tt.wantWhy, tt.wantWhy)
+				}
+				if strings.Contains(out, "package-lock.json") != tt.wantActions {
+					t.Errorf("%T: suggested actions shown = %v, want %v", renderer, !tt.wantActions, tt.wantActions)
+				}
+			}
+		})
+	}
+
+	if guided.Repositories[0].Analyzers[0].Findings[0].Remediation == "" {
+		t.Error("Rendering must not modify the original report")
+	}
+}
+
+func TestExplainSummary(t *testing.T) {
+	r := &models.Report{Repositories: []models.RepoResult{
+		{Name: "org/a", Analyzers: []models.AnalyzerResult{{Name: "ci", Metrics: []models.Metric{{Key: "success_rate", Value: 40}}}}},
+		{Name: "org/b", Analyzers: []models.AnalyzerResult{{Name: "ci", Metrics: []models.Metric{{Key: "success_rate", Value: 80}}}}},
+	}}
+
+	var text, md bytes.Buffer
+	opts := RenderOptions{ExplainSummary: true, NoColor: true}
+	if err := (&TextRenderer{}).RenderWithOptions(r, &text, opts); err != nil {
+		t.Fatalf("Text render failed: %v", err)
+	}
+	if err := (&MarkdownRenderer{}).RenderWithOptions(r, &md, opts); err != nil {
+		t.Fatalf("Markdown render failed: %v", err)
+	}
+
+	if !strings.Contains(text.String(), "Top Systemic Issues:") || !strings.Contains(text.String(), "-45 pts") || !strings.Contains(text.String(), "2/2 repos") {
+		t.Errorf("Text output missing systemic issues:\n%s", text.String())
+	}
+	if !strings.Contains(md.String(), "| CI Stability | -45 | 2/2 |") {
+		t.Errorf("Markdown output missing systemic issues:\n%s", md.String())
+	}
+
+	var plain bytes.Buffer
+	_ = (&TextRenderer{}).RenderWithOptions(r, &plain, RenderOptions{NoColor: true})
+	if strings.Contains(plain.String(), "Systemic") {
+		t.Error("Systemic issues should only show with ExplainSummary")
+	}
+}
+
+func TestReportNoteIsShown(t *testing.T) {
+	partial := &models.Report{
+		Meta:         models.ReportMeta{Note: "Timeout of 30m0s reached: only 1 of 3 repositories were analyzed"},
+		Repositories: []models.RepoResult{{Name: "owner/repo"}},
+	}
+	for _, renderer := range []Renderer{&TextRenderer{}, &MarkdownRenderer{}, &JSONRenderer{}} {
+		var buf bytes.Buffer
+		if err := renderer.RenderWithOptions(partial, &buf, RenderOptions{NoColor: true}); err != nil {
+			t.Fatalf("%T failed: %v", renderer, err)
+		}
+		if !strings.Contains(buf.String(), "only 1 of 3 repositories") {
+			t.Errorf("%T output is missing the report note:\n%s", renderer, buf.String())
+		}
+	}
+}
+
+func TestShowTimings(t *testing.T) {
+	timed := &models.Report{Repositories: []models.RepoResult{{
+		Name:       "owner/repo",
+		DurationMs: 2500,
+		Analyzers:  []models.AnalyzerResult{{Name: "ci", DurationMs: 1200}},
+	}}}
+
+	var buf bytes.Buffer
+	if err := (&TextRenderer{}).RenderWithOptions(timed, &buf, RenderOptions{ShowTimings: true}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "owner/repo () [2.5s]") || !strings.Contains(buf.String(), "[ ci ] [1.2s]") {
+		t.Errorf("Expected repository and analyzer timings, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := (&TextRenderer{}).RenderWithOptions(timed, &buf, RenderOptions{}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "2.5s") {
+		t.Errorf("Expected timings to be hidden without ShowTimings, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := (&JSONRenderer{}).RenderWithOptions(timed, &buf, RenderOptions{}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"duration_ms": 2500`) || !strings.Contains(buf.String(), `"duration_ms": 1200`) {
+		t.Errorf("Expected durations in JSON, got:\n%s", buf.String())
+	}
+}
+
+func TestMarkdownNoEmoji(t *testing.T) {
+	var withEmoji, plain bytes.Buffer
+	if err := (&MarkdownRenderer{}).RenderWithOptions(onlyFindingsReport(), &withEmoji, RenderOptions{}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if err := (&MarkdownRenderer{}).RenderWithOptions(onlyFindingsReport(), &plain, RenderOptions{MarkdownNoEmoji: true}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	out := plain.String()
+	for _, emoji := range []string{"📊", "📈", "🔍", "⚠️", "🟢", "🟡", "🟠", "🔴", "💡"} {
+		if strings.Contains(out, emoji) {
+			t.Errorf("Expected no %s in plain markdown:\n%s", emoji, out)
+		}
+	}
+	if !strings.Contains(out, "### [GOOD] owner/tidy") {
+		t.Errorf("Expected a text score label, got:\n%s", out)
+	}
+	if !strings.Contains(out, "## Repository Analysis Results") || !strings.Contains(out, "#### Findings") {
+		t.Errorf("Expected the headings to be kept, got:\n%s", out)
+	}
+	if strings.Count(out, "\n") != strings.Count(withEmoji.String(), "\n") {
+		t.Errorf("Expected the same structure with and without emoji")
+	}
+}
+
+func TestRiskiestReposLeaderboard(t *testing.T) {
+	r := onlyFindingsReport()
+	r.Summary.RiskiestRepos = []models.RiskyRepo{{Name: "owner/noisy", HealthScore: 35}, {Name: "owner/tidy", HealthScore: 70}}
+
+	for _, tc := range []struct {
+		renderer Renderer
+		want     []string
+	}{
+		{&TextRenderer{}, []string{"Riskiest Repositories:", "1. owner/noisy  35/100", "2. owner/tidy   70/100"}},
+		{&MarkdownRenderer{}, []string{"Riskiest Repositories", "| 1 | owner/noisy | 35/100 |", "| 2 | owner/tidy | 70/100 |"}},
+	} {
+		var buf bytes.Buffer
+		if err := tc.renderer.RenderWithOptions(r, &buf, RenderOptions{}); err != nil {
+			t.Fatalf("%T failed: %v", tc.renderer, err)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("%T output is missing %q:\n%s", tc.renderer, want, buf.String())
+			}
+		}
+	}
+}
+
+func TestTopContributorsTable(t *testing.T) {
+	r := onlyFindingsReport()
+	r.Repositories[1].Analyzers = append(r.Repositories[1].Analyzers, models.AnalyzerResult{
+		Name:            "activity",
+		TopContributors: []models.Contributor{{Name: "alice", Commits: 30, Percent: 60}, {Name: "bob", Commits: 20, Percent: 40}},
+	})
+
+	for _, tc := range []struct {
+		renderer Renderer
+		want     []string
+	}{
+		{&TextRenderer{}, []string{"Top contributors:", "1. alice  30 commits  60%", "2. bob    20 commits  40%"}},
+		{&MarkdownRenderer{}, []string{"#### 👥 Top Contributors", "| 1 | alice | 30 | 60% |", "| 2 | bob | 20 | 40% |"}},
+	} {
+		var buf bytes.Buffer
+		if err := tc.renderer.RenderWithOptions(r, &buf, RenderOptions{}); err != nil {
+			t.Fatalf("%T failed: %v", tc.renderer, err)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("%T: expected %q in:\n%s", tc.renderer, want, buf.String())
+			}
+		}
+
+		buf.Reset()
+		_ = tc.renderer.RenderWithOptions(r, &buf, RenderOptions{OnlyFindings: true})
+		if strings.Contains(buf.String(), "alice") {
+			t.Errorf("%T: expected no contributors with --only-findings", tc.renderer)
+		}
+	}
+}
+
+func TestUnavailableReposAreListed(t *testing.T) {
+	withSkipped := &models.Report{
+		Repositories: []models.RepoResult{{Name: "owner/repo"}},
+		Unavailable:  []models.UnavailableRepo{{Name: "owner/deleted", Type: "repo_unavailable", Message: "Repository not found or not accessible with this token"}},
+		Summary:      models.GlobalSummary{TotalReposAnalyzed: 1, ReposUnavailable: 1},
+	}
+	for _, renderer := range []Renderer{&TextRenderer{}, &MarkdownRenderer{}, &JSONRenderer{}} {
+		var buf bytes.Buffer
+		if err := renderer.RenderWithOptions(withSkipped, &buf, RenderOptions{NoColor: true}); err != nil {
+			t.Fatalf("%T failed: %v", renderer, err)
+		}
+		if !strings.Contains(buf.String(), "owner/deleted") {
+			t.Errorf("%T output is missing the unavailable repository:\n%s", renderer, buf.String())
+		}
+	}
+}
+
+func TestScoreRenderer(t *testing.T) {
+	r := &models.Report{Repositories: []models.RepoResult{{Name: "owner/a"}, {Name: "owner/b"}}}
+	var buf bytes.Buffer
+	if err := NewRenderer(FormatScore).RenderWithOptions(r, &buf, RenderOptions{}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if got, want := buf.String(), "owner/a\t100\nowner/b\t100\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestNDJSONRenderer(t *testing.T) {
+	r := &models.Report{
+		Repositories: []models.RepoResult{
+			{Name: "owner/a", Analyzers: []models.AnalyzerResult{{Name: "ci", Findings: []models.Finding{{Type: "ci_failing", Severity: models.SeverityHigh}}}}},
+			{Name: "owner/b"},
+		},
+		Summary:     models.GlobalSummary{TotalReposAnalyzed: 2, IssuesFound: 1},
+		Unavailable: []models.UnavailableRepo{{Name: "owner/gone", Type: "repo_unavailable"}},
+	}
+	var buf bytes.Buffer
+	if err := NewRenderer(FormatNDJSON).RenderWithOptions(r, &buf, RenderOptions{OnlyFindings: true}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one repository line and the trailer, got %d lines:\n%s", len(lines), buf.String())
+	}
+	var repo models.RepoResult
+	if err := json.Unmarshal([]byte(lines[0]), &repo); err != nil || repo.Name != "owner/a" {
+		t.Errorf("Expected owner/a on the first line, got %q (%v)", lines[0], err)
+	}
+	var trailer struct {
+		Type        string                   `json:"type"`
+		Summary     models.GlobalSummary     `json:"summary"`
+		Unavailable []models.UnavailableRepo `json:"unavailable"`
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &trailer); err != nil {
+		t.Fatalf("Invalid trailer %q: %v", lines[1], err)
+	}
+	if trailer.Type != "summary" || trailer.Summary.TotalReposAnalyzed != 2 || len(trailer.Unavailable) != 1 {
+		t.Errorf("Unexpected trailer: %+v", trailer)
+	}
+}
+
+func TestBadgeRenderer(t *testing.T) {
+	r := onlyFindingsReport()
+	r.Repositories = r.Repositories[1:]
+	var buf bytes.Buffer
+	if err := (&BadgeRenderer{}).Render(r, &buf); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	want := `{"schemaVersion":1,"label":"health","message":"100","color":"green"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("Expected %s, got %s", want, buf.String())
+	}
+
+	if err := (&BadgeRenderer{}).Render(onlyFindingsReport(), &buf); err == nil {
+		t.Error("Expected an error for more than one repository")
+	}
+
+	for score, color := range map[int]string{90: "green", 89: "yellow", 75: "yellow", 74: "orange", 50: "orange", 49: "red", 0: "red"} {
+		if got := bandForScore(score).color; got != color {
+			t.Errorf("bandForScore(%d).color = %q, want %q", score, got, color)
+		}
+	}
+}
+
+func TestInsightThresholdsOption(t *testing.T) {
+	r := &models.Report{Repositories: []models.RepoResult{{
+		Name:      "owner/repo",
+		Analyzers: []models.AnalyzerResult{{Name: "ci", Metrics: []models.Metric{{Key: "success_rate", Value: 85}}}},
+	}}}
+	strict := insights.DefaultInsightThresholds()
+	strict.CIWarning = 90
+
+	for _, renderer := range []Renderer{&TextRenderer{}, &MarkdownRenderer{}} {
+		for _, tt := range []struct {
+			thresholds *insights.InsightThresholds
+			want       bool
+		}{{nil, false}, {&strict, true}} {
+			var buf bytes.Buffer
+			opts := RenderOptions{OutputMode: models.OutputModeStatistical, NoColor: true, InsightThresholds: tt.thresholds}
+			if err := renderer.RenderWithOptions(r, &buf, opts); err != nil {
+				t.Fatalf("%T failed: %v", renderer, err)
+			}
+			if got := strings.Contains(buf.String(), "CI Success Rate: 85.0%"); got != tt.want {
+				t.Errorf("%T: CI insight shown = %v with thresholds %v, want %v", renderer, got, tt.thresholds, tt.want)
+			}
+		}
+	}
+}
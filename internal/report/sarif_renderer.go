@@ -0,0 +1,184 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// The types below cover the subset of SARIF 2.1.0 that gh-inspect emits
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// sarifRepoRoot is the artifact location used for findings that concern the repository
+// as a whole (or a PR, issue or branch) rather than a file in it
+const sarifRepoRoot = "."
+
+// SARIFRenderer renders findings as a SARIF 2.1.0 log for GitHub code scanning
+type SARIFRenderer struct{}
+
+func (r *SARIFRenderer) Render(report *models.Report, w io.Writer) error {
+	return r.RenderWithOptions(report, w, RenderOptions{})
+}
+
+func (r *SARIFRenderer) RenderWithOptions(report *models.Report, w io.Writer, opts RenderOptions) error {
+	report = filterBySeverity(report, opts.MinSeverity)
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "gh-inspect",
+			InformationURI: "https://github.com/mikematt33/gh-inspect",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	// One rule per finding type, indexed in first-seen order
+	ruleIndex := make(map[string]int)
+	for _, repo := range report.Repositories {
+		for _, az := range repo.Analyzers {
+			for _, f := range az.Findings {
+				ruleID := f.Type
+				if ruleID == "" {
+					ruleID = az.Name
+				}
+				idx, ok := ruleIndex[ruleID]
+				if !ok {
+					idx = len(run.Tool.Driver.Rules)
+					ruleIndex[ruleID] = idx
+					run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+						ID:               ruleID,
+						ShortDescription: sarifMessage{Text: strings.ReplaceAll(ruleID, "_", " ")},
+					})
+				}
+
+				result := sarifResult{
+					RuleID:    ruleID,
+					RuleIndex: idx,
+					Level:     sarifLevel(f.Severity),
+					Message:   sarifMessage{Text: f.Message},
+					Properties: map[string]string{
+						"repository": repo.Name,
+						"analyzer":   az.Name,
+					},
+					// Code scanning rejects results without a location
+					Locations: []sarifLocation{{
+						PhysicalLocation: sarifPhysicalLocation{
+							ArtifactLocation: sarifArtifactLocation{
+								URI:       sarifArtifactURI(repo.URL, report.Meta.Ref, f.Location),
+								URIBaseID: "%SRCROOT%",
+							},
+						},
+					}},
+				}
+				if f.Location != "" {
+					result.Properties["location"] = f.Location
+				}
+				run.Results = append(run.Results, result)
+			}
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// sarifArtifactURI turns a finding location into a path relative to the repository root.
+// Blob URLs ("<repoURL>/blob/<ref>/<path>") and bare paths keep their file path; anything
+// else (PR, issue and branch URLs, webhook IDs, no location) falls back to the root.
+func sarifArtifactURI(repoURL, ref, location string) string {
+	p := location
+	if strings.Contains(location, "://") {
+		prefix := strings.TrimSuffix(repoURL, "/") + "/blob/"
+		if repoURL == "" || !strings.HasPrefix(location, prefix) {
+			return sarifRepoRoot
+		}
+		p = strings.TrimPrefix(location, prefix)
+		// The ref may contain slashes when it came from --ref; otherwise it is one segment
+		if ref != "" && strings.HasPrefix(p, ref+"/") {
+			p = strings.TrimPrefix(p, ref+"/")
+		} else if i := strings.Index(p, "/"); i >= 0 {
+			p = p[i+1:]
+		} else {
+			return sarifRepoRoot
+		}
+	}
+	p = strings.TrimPrefix(p, "/")
+	if p == "" || strings.ContainsAny(p, " \t") {
+		return sarifRepoRoot
+	}
+	return p
+}
+
+// sarifLevel maps a finding severity to a SARIF result level
+func sarifLevel(s models.Severity) string {
+	switch s {
+	case models.SeverityCritical, models.SeverityHigh:
+		return "error"
+	case models.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,126 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+func TestSARIFRenderer_Render(t *testing.T) {
+	report := &models.Report{
+		Repositories: []models.RepoResult{
+			{
+				Name: "owner/repo1",
+				Analyzers: []models.AnalyzerResult{
+					{Name: "pr-flow", Findings: []models.Finding{
+						{Type: "stale_pr", Severity: models.SeverityMedium, Message: "PR #1 is stale", Location: "https://github.com/owner/repo1/pull/1"},
+						{Type: "stale_pr", Severity: models.SeverityMedium, Message: "PR #2 is stale"},
+					}},
+					{Name: "security", Findings: []models.Finding{
+						{Type: "secret_scanning_disabled", Severity: models.SeverityHigh, Message: "Secret scanning is disabled"},
+					}},
+				},
+			},
+			{
+				Name: "owner/repo2",
+				Analyzers: []models.AnalyzerResult{
+					{Name: "repo-health", Findings: []models.Finding{
+						{Type: "missing_file", Severity: models.SeverityLow, Message: "Missing CONTRIBUTING.md"},
+					}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&SARIFRenderer{}).Render(report, &buf); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+
+	if log.Version != "2.1.0" || log.Schema == "" {
+		t.Errorf("Expected SARIF 2.1.0 with schema, got version %q schema %q", log.Version, log.Schema)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("Expected 1 run, got %d", len(log.Runs))
+	}
+
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 3 {
+		t.Errorf("Expected 3 distinct rules, got %d", len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if first.RuleID != "stale_pr" || first.Level != "warning" || first.Message.Text != "PR #1 is stale" {
+		t.Errorf("Unexpected first result: %+v", first)
+	}
+	if len(first.Locations) != 1 || first.Locations[0].PhysicalLocation.ArtifactLocation.URI != "." {
+		t.Errorf("Expected a PR finding to point at the repository root, got %+v", first.Locations)
+	}
+	if first.Properties["location"] != "https://github.com/owner/repo1/pull/1" {
+		t.Errorf("Expected the PR link to be kept as a property, got %v", first.Properties)
+	}
+	for i, res := range run.Results {
+		if len(res.Locations) != 1 || res.Locations[0].PhysicalLocation.ArtifactLocation.URI == "" {
+			t.Errorf("Expected result %d to carry a location, got %+v", i, res.Locations)
+		}
+	}
+	if run.Results[2].Level != "error" || run.Results[3].Level != "note" {
+		t.Errorf("Unexpected levels: %q, %q", run.Results[2].Level, run.Results[3].Level)
+	}
+	for _, res := range run.Results {
+		if run.Tool.Driver.Rules[res.RuleIndex].ID != res.RuleID {
+			t.Errorf("ruleIndex %d does not point at rule %q", res.RuleIndex, res.RuleID)
+		}
+	}
+	if run.Results[3].Properties["repository"] != "owner/repo2" {
+		t.Errorf("Expected repository property, got %v", run.Results[3].Properties)
+	}
+}
+
+func TestSARIFRenderer_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&SARIFRenderer{}).Render(&models.Report{}, &buf); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	// The schema requires results to be an array, never null
+	var raw map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	run := raw["runs"].([]any)[0].(map[string]any)
+	if _, ok := run["results"].([]any); !ok {
+		t.Errorf("Expected results array, got %v", run["results"])
+	}
+}
+
+func TestSARIFArtifactURI(t *testing.T) {
+	repoURL := "https://github.com/owner/repo"
+	cases := []struct {
+		ref, location, want string
+	}{
+		{"", repoURL + "/blob/main/docs/CODEOWNERS", "docs/CODEOWNERS"},
+		{"release/1.x", repoURL + "/blob/release/1.x/assets/big.bin", "assets/big.bin"},
+		{"", "docs/SUPPORT.md", "docs/SUPPORT.md"},
+		{"", repoURL + "/pull/1", "."},
+		{"", repoURL + "/tree/feature", "."},
+		{"", "https://github.com/other/repo/blob/main/README.md", "."},
+		{"", "Webhook 42", "."},
+		{"", "", "."},
+	}
+	for _, tc := range cases {
+		if got := sarifArtifactURI(repoURL, tc.ref, tc.location); got != tc.want {
+			t.Errorf("sarifArtifactURI(%q, %q) = %q, want %q", tc.ref, tc.location, got, tc.want)
+		}
+	}
+}
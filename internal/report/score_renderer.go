@@ -0,0 +1,26 @@
+package report
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mikematt33/gh-inspect/pkg/insights"
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// ScoreRenderer prints one "owner/repo<TAB>score" line per repository and nothing
+// else, for shell scripts that only need the engineering health score
+type ScoreRenderer struct{}
+
+func (r *ScoreRenderer) Render(report *models.Report, w io.Writer) error {
+	return r.RenderWithOptions(report, w, RenderOptions{})
+}
+
+func (r *ScoreRenderer) RenderWithOptions(report *models.Report, w io.Writer, opts RenderOptions) error {
+	for _, repo := range report.Repositories {
+		if _, err := fmt.Fprintf(w, "%s\t%d\n", repo.Name, insights.CalculateEngineeringHealthScoreWithWeights(repo, opts.weights())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
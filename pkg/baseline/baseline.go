@@ -9,13 +9,14 @@ import (
 	"time"
 
 	"github.com/mikematt33/gh-inspect/pkg/models"
-	"github.com/mikematt33/gh-inspect/pkg/util"
 )
 
 // Baseline stores a historical report for comparison
 type Baseline struct {
 	Timestamp time.Time      `json:"timestamp"`
 	Report    *models.Report `json:"report"`
+	// Warnings describes data Load could not carry over from an older or newer schema
+	Warnings []string `json:"-"`
 }
 
 // ComparisonResult contains the delta between two reports
@@ -43,11 +44,13 @@ type MetricChange struct {
 	Improved     bool    `json:"improved"` // Whether this change is positive
 }
 
-// FindingChange tracks changes in findings
+// FindingChange tracks changes in findings, matched across runs by finding ID
 type FindingChange struct {
-	Added     int `json:"added"`
-	Removed   int `json:"removed"`
-	Unchanged int `json:"unchanged"`
+	Added           int              `json:"added"`
+	Removed         int              `json:"removed"`
+	Unchanged       int              `json:"unchanged"`
+	AddedFindings   []models.Finding `json:"added_findings,omitempty"`
+	RemovedFindings []models.Finding `json:"removed_findings,omitempty"`
 }
 
 // ComparisonSummary provides high-level comparison stats
@@ -63,9 +66,11 @@ type ComparisonSummary struct {
 
 // Save persists a report as a baseline
 func Save(report *models.Report, path string) error {
+	stamped := *report
+	stamped.Meta.SchemaVersion = models.ReportSchemaVersion
 	baseline := Baseline{
 		Timestamp: time.Now(),
-		Report:    report,
+		Report:    &stamped,
 	}
 
 	// Ensure directory exists
@@ -87,17 +92,29 @@ func Save(report *models.Report, path string) error {
 	return nil
 }
 
-// Load reads a baseline from disk
+// Load reads a baseline from disk, upgrading baselines saved by older versions to the
+// current report schema. Fields that could not be carried over are listed in Warnings.
 func Load(path string) (*Baseline, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read baseline: %w", err)
 	}
 
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal baseline: %w", err)
+	}
+	warnings := migrate(doc)
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate baseline: %w", err)
+	}
 	var baseline Baseline
-	if err := json.Unmarshal(data, &baseline); err != nil {
+	if err := json.Unmarshal(migrated, &baseline); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal baseline: %w", err)
 	}
+	baseline.Warnings = warnings
 
 	return &baseline, nil
 }
@@ -184,17 +201,53 @@ func compareRepository(current, previous *models.RepoResult) RepositoryDelta {
 		}
 	}
 
-	// Compare findings counts
-	currFindings := countFindings(current)
-	prevFindings := countFindings(previous)
+	delta.FindingDiff = compareFindings(current, previous)
+
+	return delta
+}
 
-	delta.FindingDiff = FindingChange{
-		Added:     util.Max(0, currFindings-prevFindings),
-		Removed:   util.Max(0, prevFindings-currFindings),
-		Unchanged: util.Min(currFindings, prevFindings),
+// WithTrends returns a copy of current whose metrics carry PreviousValue and Trend from
+// the previous report, for rendering. current itself is left untouched so it can still
+// be saved as the next baseline without stale trend data.
+func WithTrends(current *models.Report, previous *Baseline) *models.Report {
+	if current == nil || previous == nil || previous.Report == nil {
+		return current
 	}
 
-	return delta
+	prevMetrics := make(map[string]float64)
+	for _, repo := range previous.Report.Repositories {
+		for _, analyzer := range repo.Analyzers {
+			for _, metric := range analyzer.Metrics {
+				prevMetrics[repo.Name+"/"+analyzer.Name+"."+metric.Key] = metric.Value
+			}
+		}
+	}
+
+	annotated := *current
+	annotated.Repositories = make([]models.RepoResult, len(current.Repositories))
+	for i, repo := range current.Repositories {
+		repo.Analyzers = make([]models.AnalyzerResult, len(repo.Analyzers))
+		for j, analyzer := range current.Repositories[i].Analyzers {
+			analyzer.Metrics = make([]models.Metric, len(analyzer.Metrics))
+			for k, metric := range current.Repositories[i].Analyzers[j].Metrics {
+				if prevValue, exists := prevMetrics[repo.Name+"/"+analyzer.Name+"."+metric.Key]; exists {
+					metric.PreviousValue = &prevValue
+					switch {
+					case metric.Value > prevValue:
+						metric.Trend = models.TrendUp
+					case metric.Value < prevValue:
+						metric.Trend = models.TrendDown
+					default:
+						metric.Trend = models.TrendFlat
+					}
+				}
+				analyzer.Metrics[k] = metric
+			}
+			repo.Analyzers[j] = analyzer
+		}
+		annotated.Repositories[i] = repo
+	}
+	return &annotated
 }
 
 // isImprovement determines if a metric change is positive
@@ -265,13 +318,45 @@ func generateSummary(current, previous *models.Report, deltas []RepositoryDelta)
 	return summary
 }
 
-// countFindings returns total findings count for a repo
-func countFindings(repo *models.RepoResult) int {
-	total := 0
+// compareFindings diffs the findings of two runs by ID, so a resolved finding and a
+// new one show up as one removed and one added rather than cancelling out
+func compareFindings(current, previous *models.RepoResult) FindingChange {
+	currFindings, currIDs := findingsByID(current)
+	prevFindings, prevIDs := findingsByID(previous)
+
+	var change FindingChange
+	for _, id := range currIDs {
+		if _, ok := prevFindings[id]; ok {
+			change.Unchanged++
+		} else {
+			change.AddedFindings = append(change.AddedFindings, currFindings[id])
+		}
+	}
+	for _, id := range prevIDs {
+		if _, ok := currFindings[id]; !ok {
+			change.RemovedFindings = append(change.RemovedFindings, prevFindings[id])
+		}
+	}
+	change.Added = len(change.AddedFindings)
+	change.Removed = len(change.RemovedFindings)
+	return change
+}
+
+// findingsByID indexes a repo's findings by ID and returns the IDs in report order.
+// IDs are recomputed so baselines saved with an older fingerprint still match.
+func findingsByID(repo *models.RepoResult) (map[string]models.Finding, []string) {
+	findings := make(map[string]models.Finding)
+	var ids []string
 	for _, analyzer := range repo.Analyzers {
-		total += len(analyzer.Findings)
+		for _, f := range analyzer.Findings {
+			f.ID = f.Fingerprint(repo.Name)
+			if _, dup := findings[f.ID]; !dup {
+				ids = append(ids, f.ID)
+			}
+			findings[f.ID] = f
+		}
 	}
-	return total
+	return findings, ids
 }
 
 // GetDefaultBaselinePath returns the default path for baseline storage
@@ -154,6 +154,36 @@ func TestCompare(t *testing.T) {
 	}
 }
 
+func TestWithTrends(t *testing.T) {
+	previous := &Baseline{Report: createTestReport(80.0, 90.0, 3.0, 10)}
+	current := createTestReport(85.0, 95.0, 3.0, 8)
+	current.Repositories[0].Analyzers[0].Metrics = append(current.Repositories[0].Analyzers[0].Metrics, models.Metric{Key: "new_metric", Value: 1})
+
+	annotated := WithTrends(current, previous)
+
+	ci := annotated.Repositories[0].Analyzers[0].Metrics
+	if ci[0].Trend != models.TrendUp || ci[0].PreviousValue == nil || *ci[0].PreviousValue != 90 {
+		t.Errorf("Expected success_rate to trend up from 90, got %+v", ci[0])
+	}
+	if ci[1].Trend != "" || ci[1].PreviousValue != nil {
+		t.Errorf("Expected no trend for a metric missing from the baseline, got %+v", ci[1])
+	}
+	if cycle := annotated.Repositories[0].Analyzers[1].Metrics[0]; cycle.Trend != models.TrendFlat {
+		t.Errorf("Expected an unchanged metric to be flat, got %+v", cycle)
+	}
+	if m := previous.Report.Repositories[0].Analyzers[0].Metrics[0]; m.Trend != "" {
+		t.Errorf("Expected the baseline to be left alone, got %+v", m)
+	}
+	if m := current.Repositories[0].Analyzers[0].Metrics[0]; m.Trend != "" || m.PreviousValue != nil {
+		t.Errorf("Expected the current report to be left alone, got %+v", m)
+	}
+
+	Compare(current, previous)
+	if m := current.Repositories[0].Analyzers[0].Metrics[0]; m.Trend != "" || m.PreviousValue != nil {
+		t.Errorf("Expected Compare not to annotate the current report, got %+v", m)
+	}
+}
+
 func TestCompareWithRegression(t *testing.T) {
 	// Create previous baseline
 	previousReport := createTestReport(85.0, 95.0, 2.5, 5)
@@ -289,9 +319,9 @@ func TestCompareFindingsChange(t *testing.T) {
 					{
 						Name: "security",
 						Findings: []models.Finding{
-							{Severity: models.SeverityMedium, Message: "Finding 1"},
-							{Severity: models.SeverityMedium, Message: "Finding 2"},
-							{Severity: models.SeverityHigh, Message: "Finding 3"},
+							{Severity: models.SeverityMedium, Message: "Finding A"},
+							{Severity: models.SeverityMedium, Message: "Finding B"},
+							{Severity: models.SeverityHigh, Message: "Finding C"},
 						},
 					},
 				},
@@ -313,11 +343,11 @@ func TestCompareFindingsChange(t *testing.T) {
 					{
 						Name: "security",
 						Findings: []models.Finding{
-							{Severity: models.SeverityMedium, Message: "Finding 1"},
-							{Severity: models.SeverityMedium, Message: "Finding 2"},
-							{Severity: models.SeverityHigh, Message: "Finding 3"},
-							{Severity: models.SeverityMedium, Message: "Finding 4"},
-							{Severity: models.SeverityHigh, Message: "Finding 5"},
+							{Severity: models.SeverityMedium, Message: "Finding A"},
+							{Severity: models.SeverityMedium, Message: "Finding B"},
+							{Severity: models.SeverityHigh, Message: "Finding C"},
+							{Severity: models.SeverityMedium, Message: "Finding D"},
+							{Severity: models.SeverityHigh, Message: "Finding E"},
 						},
 					},
 				},
@@ -430,29 +460,90 @@ func TestIsImprovement(t *testing.T) {
 	}
 }
 
-func TestCountFindings(t *testing.T) {
+func TestFindingsByID(t *testing.T) {
 	repo := &models.RepoResult{
 		Name: "test/repo",
 		Analyzers: []models.AnalyzerResult{
 			{
 				Name: "analyzer1",
 				Findings: []models.Finding{
-					{Severity: models.SeverityMedium, Message: "Finding 1"},
-					{Severity: models.SeverityHigh, Message: "Finding 2"},
+					{Severity: models.SeverityMedium, Message: "Finding A"},
+					{Severity: models.SeverityHigh, Message: "Finding B"},
 				},
 			},
 			{
 				Name: "analyzer2",
 				Findings: []models.Finding{
-					{Severity: models.SeverityInfo, Message: "Finding 3"},
+					{Severity: models.SeverityInfo, Message: "Finding C"},
+					{Severity: models.SeverityInfo, Message: "Finding C"}, // same fingerprint
 				},
 			},
 		},
 	}
 
-	count := countFindings(repo)
-	if count != 3 {
-		t.Errorf("Expected 3 findings, got %d", count)
+	findings, ids := findingsByID(repo)
+	if len(findings) != 3 || len(ids) != 3 {
+		t.Fatalf("Expected 3 distinct findings, got %d (%d ids)", len(findings), len(ids))
+	}
+	if findings[ids[0]].Message != "Finding A" || findings[ids[2]].Message != "Finding C" {
+		t.Errorf("Expected IDs in report order, got %v", ids)
+	}
+}
+
+func TestCompareFindingsByID(t *testing.T) {
+	repo := func(findings ...models.Finding) models.RepoResult {
+		return models.RepoResult{Name: "test/repo", Analyzers: []models.AnalyzerResult{{Name: "pr-flow", Findings: findings}}}
+	}
+	stale := models.Finding{Type: "stale_pr", Message: "PR #1 is stale", Location: "https://github.com/test/repo/pull/1"}
+	resolved := models.Finding{Type: "stale_pr", Message: "PR #2 is stale", Location: "https://github.com/test/repo/pull/2"}
+	added := models.Finding{Type: "stale_pr", Message: "PR #3 is stale", Location: "https://github.com/test/repo/pull/3"}
+	added.ID = added.Fingerprint("test/repo")
+
+	previous := &Baseline{Report: &models.Report{Repositories: []models.RepoResult{repo(stale, resolved)}}}
+	current := &models.Report{Repositories: []models.RepoResult{repo(stale, added)}}
+
+	diff := Compare(current, previous).Deltas[0].FindingDiff
+	if diff.Added != 1 || diff.Removed != 1 || diff.Unchanged != 1 {
+		t.Fatalf("Expected 1 added, 1 removed, 1 unchanged, got %+v", diff)
+	}
+	if diff.AddedFindings[0].Message != added.Message || diff.RemovedFindings[0].Message != resolved.Message {
+		t.Errorf("Unexpected finding lists: added %+v, removed %+v", diff.AddedFindings, diff.RemovedFindings)
+	}
+}
+
+func TestCompareFindingsIgnoreChangingCounts(t *testing.T) {
+	repo := func(findings ...models.Finding) models.RepoResult {
+		return models.RepoResult{Name: "test/repo", Analyzers: []models.AnalyzerResult{{Name: "branches", Findings: findings}}}
+	}
+	before := []models.Finding{
+		{Type: "stale_branches", Message: "14 branches have had no commits in 90 days", Location: "https://github.com/test/repo/branches"},
+		{Type: "low_review_coverage", Message: "Only 62% of PRs were reviewed"},
+		{Type: "missing_file", Message: "Missing CONTRIBUTING.md", ID: "id-from-an-older-fingerprint"},
+	}
+	after := []models.Finding{
+		{Type: "stale_branches", Message: "15 branches have had no commits in 90 days", Location: "https://github.com/test/repo/branches"},
+		{Type: "low_review_coverage", Message: "Only 58.5% of PRs were reviewed"},
+		{Type: "missing_file", Message: "Missing CONTRIBUTING.md"},
+		{Type: "missing_file", Message: "Missing SECURITY.md"},
+	}
+
+	previous := &Baseline{Report: &models.Report{Repositories: []models.RepoResult{repo(before...)}}}
+	current := &models.Report{Repositories: []models.RepoResult{repo(after...)}}
+
+	diff := Compare(current, previous).Deltas[0].FindingDiff
+	if diff.Added != 1 || diff.Removed != 0 || diff.Unchanged != 3 {
+		t.Fatalf("Expected only SECURITY.md to be new, got %+v", diff)
+	}
+	if diff.AddedFindings[0].Message != "Missing SECURITY.md" {
+		t.Errorf("Unexpected added finding: %+v", diff.AddedFindings[0])
+	}
+	pr12 := models.Finding{Type: "unreviewed_pr", Message: "PR #12 was merged without review"}
+	pr13 := models.Finding{Type: "unreviewed_pr", Message: "PR #13 was merged without review"}
+	if pr12.Fingerprint("test/repo") == pr13.Fingerprint("test/repo") {
+		t.Error("Expected PR references to keep findings without a location apart")
+	}
+	if after[0].Fingerprint("test/repo") == after[0].Fingerprint("test/other") {
+		t.Error("Expected the same finding in another repository to get another ID")
 	}
 }
 
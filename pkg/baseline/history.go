@@ -0,0 +1,98 @@
+package baseline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+const (
+	historyFilePrefix = "baseline-"
+	historyTimeLayout = "20060102T150405.000000000Z"
+)
+
+// SaveToHistory writes a report as a new timestamped baseline in dir and prunes the
+// oldest entries so that at most keep baselines remain (keep <= 0 keeps everything).
+// It returns the path of the file written.
+func SaveToHistory(report *models.Report, dir string, keep int) (string, error) {
+	now := time.Now().UTC()
+	path := filepath.Join(dir, historyFilePrefix+now.Format(historyTimeLayout)+".json")
+	if err := Save(report, path); err != nil {
+		return "", err
+	}
+
+	if keep > 0 {
+		files, err := listHistory(dir)
+		if err != nil {
+			return path, err
+		}
+		for len(files) > keep {
+			if err := os.Remove(files[0]); err != nil {
+				return path, fmt.Errorf("failed to prune baseline history: %w", err)
+			}
+			files = files[1:]
+		}
+	}
+
+	return path, nil
+}
+
+// LoadHistory reads up to the last n baselines from dir, ordered oldest to newest.
+// n <= 0 loads every baseline. A missing directory yields an empty history.
+func LoadHistory(dir string, n int) ([]*Baseline, error) {
+	files, err := listHistory(dir)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 && len(files) > n {
+		files = files[len(files)-n:]
+	}
+
+	history := make([]*Baseline, 0, len(files))
+	for _, f := range files {
+		b, err := Load(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(f), err)
+		}
+		history = append(history, b)
+	}
+
+	// File names sort chronologically, but trust the recorded timestamps
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Timestamp.Before(history[j].Timestamp)
+	})
+
+	return history, nil
+}
+
+// listHistory returns the baseline files in dir sorted oldest first
+func listHistory(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read baseline history: %w", err)
+	}
+
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, historyFilePrefix) || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// GetDefaultHistoryDir returns the default directory for timestamped baselines
+func GetDefaultHistoryDir() string {
+	return filepath.Join(filepath.Dir(GetDefaultBaselinePath()), "baselines")
+}
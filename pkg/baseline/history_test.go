@@ -0,0 +1,81 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveToHistoryAndLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "baselines")
+
+	for _, score := range []float64{60, 70, 80} {
+		if _, err := SaveToHistory(createTestReport(score, 90, 3, 1), dir, 0); err != nil {
+			t.Fatalf("Failed to save history: %v", err)
+		}
+	}
+
+	history, err := LoadHistory(dir, 0)
+	if err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 baselines, got %d", len(history))
+	}
+	for i, want := range []float64{60, 70, 80} {
+		if got := history[i].Report.Summary.AvgHealthScore; got != want {
+			t.Errorf("Baseline %d: expected health %.0f, got %.0f", i, want, got)
+		}
+	}
+
+	last, err := LoadHistory(dir, 2)
+	if err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	if len(last) != 2 || last[0].Report.Summary.AvgHealthScore != 70 {
+		t.Errorf("Expected the last 2 baselines starting at 70, got %d entries", len(last))
+	}
+}
+
+func TestSaveToHistoryPrunes(t *testing.T) {
+	dir := t.TempDir()
+
+	for i := 0; i < 5; i++ {
+		if _, err := SaveToHistory(createTestReport(float64(i), 90, 3, 1), dir, 3); err != nil {
+			t.Fatalf("Failed to save history: %v", err)
+		}
+	}
+
+	files, err := listHistory(dir)
+	if err != nil {
+		t.Fatalf("Failed to list history: %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("Expected 3 files after pruning, got %d", len(files))
+	}
+
+	history, _ := LoadHistory(dir, 0)
+	if history[0].Report.Summary.AvgHealthScore != 2 {
+		t.Errorf("Expected oldest kept baseline to have score 2, got %.0f", history[0].Report.Summary.AvgHealthScore)
+	}
+}
+
+func TestLoadHistoryIgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("hi"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := LoadHistory(dir, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(history) != 0 {
+		t.Errorf("Expected empty history, got %d", len(history))
+	}
+
+	missing, err := LoadHistory(filepath.Join(dir, "missing"), 0)
+	if err != nil || len(missing) != 0 {
+		t.Errorf("Expected empty history for missing dir, got %d entries, err %v", len(missing), err)
+	}
+}
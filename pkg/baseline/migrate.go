@@ -0,0 +1,107 @@
+package baseline
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+// migrations upgrade a decoded report one schema version at a time:
+// migrations[v] turns a version v report into version v+1. None are needed yet;
+// finding IDs are optional and recomputed on compare, see findingsByID.
+var migrations = map[int]func(report map[string]interface{}){}
+
+// migrate upgrades a decoded baseline document in place to models.ReportSchemaVersion
+// and returns warnings for data that cannot be carried over
+func migrate(doc map[string]interface{}) []string {
+	report, ok := doc["report"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	meta, ok := report["meta"].(map[string]interface{})
+	if !ok {
+		meta = make(map[string]interface{})
+		report["meta"] = meta
+	}
+
+	var warnings []string
+	version := 1 // baselines saved before the version was recorded
+	if v, ok := meta["schema_version"].(float64); ok && v >= 1 {
+		version = int(v)
+	}
+	if version > models.ReportSchemaVersion {
+		warnings = append(warnings, fmt.Sprintf("baseline uses report schema %d, newer than this version of gh-inspect supports (%d); upgrade to compare all of it",
+			version, models.ReportSchemaVersion))
+	}
+	for ; version < models.ReportSchemaVersion; version++ {
+		migrations[version](report)
+	}
+	meta["schema_version"] = version
+
+	unknown := make(map[string]bool)
+	unknownFields(doc, reflect.TypeOf(Baseline{}), "", unknown)
+	paths := make([]string, 0, len(unknown))
+	for p := range unknown {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		warnings = append(warnings, fmt.Sprintf("baseline field %s is not used by this version of gh-inspect and was ignored", p))
+	}
+	return warnings
+}
+
+// unknownFields records the paths of keys in value that typ has no JSON field for.
+// Array elements share one path, e.g. report.repositories[].url.
+func unknownFields(value interface{}, typ reflect.Type, path string, unknown map[string]bool) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Slice:
+		items, _ := value.([]interface{})
+		for _, item := range items {
+			unknownFields(item, typ.Elem(), path+"[]", unknown)
+		}
+	case reflect.Struct:
+		obj, ok := value.(map[string]interface{})
+		if !ok || typ == reflect.TypeOf(time.Time{}) {
+			return
+		}
+		fields := jsonFields(typ)
+		for key, v := range obj {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			fieldType, ok := fields[key]
+			if !ok {
+				unknown[fieldPath] = true
+				continue
+			}
+			unknownFields(v, fieldType, fieldPath, unknown)
+		}
+	}
+}
+
+// jsonFields maps JSON key names to field types for a struct
+func jsonFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
@@ -0,0 +1,64 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mikematt33/gh-inspect/pkg/models"
+)
+
+func TestLoadUnversionedBaseline(t *testing.T) {
+	// A baseline saved before schema versions and finding IDs, with a field since removed
+	v1 := `{
+  "timestamp": "2024-01-01T00:00:00Z",
+  "report": {
+    "meta": {"generated_at": "2024-01-01T00:00:00Z", "cli_version": "0.9.0", "command": "run", "duration": "1s"},
+    "repositories": [{
+      "name": "owner/repo",
+      "url": "https://github.com/owner/repo",
+      "legacy_score": 80,
+      "analyzers": [{"name": "pr-flow", "findings": [{"type": "stale_pr", "severity": "low", "message": "PR #1 is stale", "actionable": true}]}]
+    }],
+    "summary": {"total_repos_analyzed": 1}
+  }
+}`
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(path, []byte(v1), 0644); err != nil {
+		t.Fatalf("Failed to write baseline: %v", err)
+	}
+
+	b, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if b.Report.Meta.SchemaVersion != models.ReportSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", models.ReportSchemaVersion, b.Report.Meta.SchemaVersion)
+	}
+	current := &models.Report{Repositories: []models.RepoResult{{
+		Name:      "owner/repo",
+		Analyzers: []models.AnalyzerResult{{Name: "pr-flow", Findings: []models.Finding{{Type: "stale_pr", Message: "PR #1 is stale"}}}},
+	}}}
+	if diff := Compare(current, b).Deltas[0].FindingDiff; diff.Unchanged != 1 || diff.Added != 0 || diff.Removed != 0 {
+		t.Errorf("Expected the finding without an ID to match by fingerprint, got %+v", diff)
+	}
+	if len(b.Warnings) != 1 || !strings.Contains(b.Warnings[0], "report.repositories[].legacy_score") {
+		t.Errorf("Expected a warning about legacy_score, got %v", b.Warnings)
+	}
+}
+
+func TestLoadWarnsOnNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(path, []byte(`{"report": {"meta": {"schema_version": 99}}}`), 0644); err != nil {
+		t.Fatalf("Failed to write baseline: %v", err)
+	}
+
+	b, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(b.Warnings) != 1 || !strings.Contains(b.Warnings[0], "schema 99") {
+		t.Errorf("Expected a newer-schema warning, got %v", b.Warnings)
+	}
+}
@@ -2,6 +2,7 @@ package insights
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/mikematt33/gh-inspect/pkg/models"
 	"github.com/mikematt33/gh-inspect/pkg/util"
@@ -25,8 +26,9 @@ type Insight struct {
 }
 
 // GenerateInsights analyzes a single repository report and produces actionable insights
-// The output format is controlled by the outputMode parameter
-func GenerateInsights(repo models.RepoResult, outputMode models.OutputMode) []Insight {
+// The output format is controlled by the outputMode parameter, and t sets the trigger
+// points metrics are compared against (see DefaultInsightThresholds).
+func GenerateInsights(repo models.RepoResult, outputMode models.OutputMode, t InsightThresholds) []Insight {
 	var insights []Insight
 
 	// Helper to safely get metric
@@ -79,7 +81,7 @@ func GenerateInsights(repo models.RepoResult, outputMode models.OutputMode) []In
 	// 2. CI Stability Analysis
 	successRate, srOk := getMetric("ci", "success_rate")
 	if srOk {
-		if successRate < 50.0 {
+		if successRate < t.CICritical {
 			insights = append(insights, Insight{
 				Level:    LevelCritical,
 				Category: "Velocity",
@@ -92,7 +94,7 @@ func GenerateInsights(repo models.RepoResult, outputMode models.OutputMode) []In
 				Observation: fmt.Sprintf("CI is active but success rate is %.1f%%.", successRate),
 				StatValue:   fmt.Sprintf("CI Success Rate: %.1f%%", successRate),
 			})
-		} else if successRate < 80.0 {
+		} else if successRate < t.CIWarning {
 			insights = append(insights, Insight{
 				Level:    LevelWarning,
 				Category: "Velocity",
@@ -110,7 +112,7 @@ func GenerateInsights(repo models.RepoResult, outputMode models.OutputMode) []In
 
 	// 3. Issue Hygiene (Zombie Issues)
 	zombies, zOk := getMetric("issue-hygiene", "zombie_issues")
-	if zOk && zombies > 10 {
+	if zOk && zombies > float64(t.ZombieIssues) {
 		insights = append(insights, Insight{
 			Level:    LevelWarning,
 			Category: "Maintenance",
@@ -127,7 +129,7 @@ func GenerateInsights(repo models.RepoResult, outputMode models.OutputMode) []In
 
 	// 4. PR Velocity
 	cycleTime, ctOk := getMetric("pr-flow", "avg_cycle_time_hours")
-	if ctOk && cycleTime > 72.0 { // 3 days
+	if ctOk && cycleTime > t.CycleTimeHours {
 		insights = append(insights, Insight{
 			Level:    LevelInfo,
 			Category: "Velocity",
@@ -142,11 +144,37 @@ func GenerateInsights(repo models.RepoResult, outputMode models.OutputMode) []In
 		})
 	}
 
+	// 5. Unreviewed changes to an unprotected branch: each is a risk, together nothing stops them
+	protected, bpOk := getMetric("repo-health", "branch_protection_enabled")
+	selfMergeRate, smOk := getMetric("pr-flow", "self_merge_rate")
+	if bpOk && smOk && protected == 0 && selfMergeRate >= t.SelfMergeRate {
+		observation := fmt.Sprintf("The default branch is unprotected and %.0f%% of merged PRs were merged by their author, so changes reach it without any review.", selfMergeRate)
+		action := "Enable branch protection with required reviews so every change gets a second pair of eyes."
+		insights = append(insights, Insight{
+			Level:    LevelCritical,
+			Category: "Governance",
+			Description: formatMessage(
+				fmt.Sprintf("Unprotected Branch, Self-Merge Rate: %.0f%%", selfMergeRate),
+				observation,
+				action,
+			),
+			Action:      action,
+			Observation: observation,
+			StatValue:   fmt.Sprintf("Unprotected Branch, Self-Merge Rate: %.0f%%", selfMergeRate),
+		})
+	}
+
 	return insights
 }
 
-// CalculateEngineeringHealthScore produces a 0-100 score based on weighted sub-metrics
+// CalculateEngineeringHealthScore produces a 0-100 score based on weighted sub-metrics,
+// using the default weights
 func CalculateEngineeringHealthScore(repo models.RepoResult) int {
+	return CalculateEngineeringHealthScoreWithWeights(repo, DefaultScoringWeights())
+}
+
+// CalculateEngineeringHealthScoreWithWeights produces a 0-100 score using the given weights
+func CalculateEngineeringHealthScoreWithWeights(repo models.RepoResult, w ScoringWeights) int {
 	score := 100.0
 
 	getMetric := func(analyzerName, key string) (float64, bool) {
@@ -162,36 +190,36 @@ func CalculateEngineeringHealthScore(repo models.RepoResult) int {
 		return 0, false
 	}
 
-	// Deduct for CI instability (Weight: 30)
+	// Deduct for CI instability
 	successRate, srOk := getMetric("ci", "success_rate")
 	if srOk {
 		if successRate < 50 {
-			score -= 30
+			score -= float64(w.CIFailing)
 		} else if successRate < 90 {
-			score -= 15
+			score -= float64(w.CIUnstable)
 		}
 	}
 
-	// Deduct for Low Bus Factor (Weight: 20)
+	// Deduct for Low Bus Factor
 	busFactor, bfOk := getMetric("activity", "bus_factor")
 	activeContributors, acOk := getMetric("activity", "active_contributors")
 	if bfOk && acOk {
 		if busFactor == 1 && activeContributors > 1 {
-			score -= 20
+			score -= float64(w.BusFactor)
 		}
 	}
 
-	// Deduct for Zombie Issues (Weight: 15)
+	// Deduct for Zombie Issues
 	zombies, zOk := getMetric("issue-hygiene", "zombie_issues")
 	if zOk {
 		if zombies > 50 {
-			score -= 15
+			score -= float64(w.ZombiesHigh)
 		} else if zombies > 10 {
-			score -= 5
+			score -= float64(w.ZombiesModerate)
 		}
 	}
 
-	// Deduct for Missing Key Files (Weight: 5 per file, max 20)
+	// Deduct for Missing Key Files (per file, capped)
 	missingFiles := 0
 	// We need to look at findings for repo-health
 	for _, az := range repo.Analyzers {
@@ -204,10 +232,10 @@ func CalculateEngineeringHealthScore(repo models.RepoResult) int {
 		}
 	}
 	if missingFiles > 0 {
-		score -= float64(missingFiles * 5)
+		score -= float64(missingFileDeduction(missingFiles, w))
 	}
 
-	// Deduct for stale PRs (Weight: 15)
+	// Deduct for stale PRs
 	stalePRs := 0
 	for _, az := range repo.Analyzers {
 		if az.Name == "pr-flow" {
@@ -220,7 +248,7 @@ func CalculateEngineeringHealthScore(repo models.RepoResult) int {
 	}
 
 	if stalePRs > 5 {
-		score -= 15
+		score -= float64(w.StalePRs)
 	}
 
 	if score < 0 {
@@ -229,20 +257,77 @@ func CalculateEngineeringHealthScore(repo models.RepoResult) int {
 	return int(score)
 }
 
+// missingFileDeduction applies the per-file weight and its cap, if one is set
+func missingFileDeduction(missingFiles int, w ScoringWeights) int {
+	impact := missingFiles * w.MissingFile
+	if w.MissingFilesMax > 0 && impact > w.MissingFilesMax {
+		impact = w.MissingFilesMax
+	}
+	return impact
+}
+
 // ScoreComponent represents a component of the health score calculation
 type ScoreComponent struct {
-	Category    string
-	Description string
-	Impact      int    // Points deducted
-	Current     string // Current value
-	Target      string // Target/ideal value
-	Tips        string // Mode-aware improvement information
+	Category     string
+	Description  string
+	Impact       int    // Points deducted
+	Current      string // Current value
+	Target       string // Target/ideal value
+	Tips         string // Mode-aware improvement information
+	CustomWeight bool   // True if the applied weight differs from the built-in default
 }
 
 // ExplainScore returns detailed breakdown of how the health score was calculated
 // The output format is controlled by the outputMode parameter
 func ExplainScore(repo models.RepoResult, outputMode models.OutputMode) []ScoreComponent {
+	return ExplainScoreWithWeights(repo, outputMode, DefaultScoringWeights())
+}
+
+// SystemicIssue is a score component aggregated across repositories
+type SystemicIssue struct {
+	Category      string
+	PointsLost    int      // Total points deducted across all repositories
+	ReposAffected int      // Repositories losing points in this category
+	Repos         []string // Names of the affected repositories
+}
+
+// ExplainScoreSummary aggregates ExplainScoreWithWeights across repositories and returns
+// the categories that cost points, largest total deduction first
+func ExplainScoreSummary(repos []models.RepoResult, w ScoringWeights) []SystemicIssue {
+	byCategory := make(map[string]*SystemicIssue)
+	for _, repo := range repos {
+		for _, comp := range ExplainScoreWithWeights(repo, models.OutputModeStatistical, w) {
+			if comp.Impact <= 0 {
+				continue
+			}
+			issue, ok := byCategory[comp.Category]
+			if !ok {
+				issue = &SystemicIssue{Category: comp.Category}
+				byCategory[comp.Category] = issue
+			}
+			issue.PointsLost += comp.Impact
+			issue.ReposAffected++
+			issue.Repos = append(issue.Repos, repo.Name)
+		}
+	}
+
+	issues := make([]SystemicIssue, 0, len(byCategory))
+	for _, issue := range byCategory {
+		issues = append(issues, *issue)
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].PointsLost != issues[j].PointsLost {
+			return issues[i].PointsLost > issues[j].PointsLost
+		}
+		return issues[i].Category < issues[j].Category
+	})
+	return issues
+}
+
+// ExplainScoreWithWeights returns the score breakdown computed with the given weights
+func ExplainScoreWithWeights(repo models.RepoResult, outputMode models.OutputMode, w ScoringWeights) []ScoreComponent {
 	var components []ScoreComponent
+	defaults := DefaultScoringWeights()
 
 	getMetric := func(analyzerName, key string) (float64, bool) {
 		for _, az := range repo.Analyzers {
@@ -271,21 +356,24 @@ func ExplainScore(repo models.RepoResult, outputMode models.OutputMode) []ScoreC
 		}
 	}
 
-	// CI Stability (Weight: 30)
+	// CI Stability
 	successRate, srOk := getMetric("ci", "success_rate")
 	if srOk {
 		impact := 0
 		tips := ""
+		custom := false
 
 		if successRate < 50 {
-			impact = 30
+			impact = w.CIFailing
+			custom = w.CIFailing != defaults.CIFailing
 			tips = formatTips(
 				"",
 				"CI success rate below 50% correlates with reduced team productivity.",
 				"Fix failing builds immediately. CI below 50% blocks team productivity.",
 			)
 		} else if successRate < 90 {
-			impact = 15
+			impact = w.CIUnstable
+			custom = w.CIUnstable != defaults.CIUnstable
 			tips = formatTips(
 				"",
 				"CI success rate between 50-90% suggests intermittent build issues.",
@@ -294,16 +382,17 @@ func ExplainScore(repo models.RepoResult, outputMode models.OutputMode) []ScoreC
 		}
 
 		components = append(components, ScoreComponent{
-			Category:    "CI Stability",
-			Description: "Continuous Integration success rate",
-			Impact:      impact,
-			Current:     fmt.Sprintf("%.1f%%", successRate),
-			Target:      "≥90%",
-			Tips:        tips,
+			Category:     "CI Stability",
+			Description:  "Continuous Integration success rate",
+			Impact:       impact,
+			Current:      fmt.Sprintf("%.1f%%", successRate),
+			Target:       "≥90%",
+			Tips:         tips,
+			CustomWeight: custom,
 		})
 	}
 
-	// Bus Factor (Weight: 20)
+	// Bus Factor
 	busFactor, bfOk := getMetric("activity", "bus_factor")
 	activeContributors, acOk := getMetric("activity", "active_contributors")
 	if bfOk && acOk {
@@ -311,7 +400,7 @@ func ExplainScore(repo models.RepoResult, outputMode models.OutputMode) []ScoreC
 		tips := ""
 
 		if busFactor == 1 && activeContributors > 1 {
-			impact = 20
+			impact = w.BusFactor
 			tips = formatTips(
 				"",
 				"Single contributor accounts for >50% of commits.",
@@ -320,30 +409,34 @@ func ExplainScore(repo models.RepoResult, outputMode models.OutputMode) []ScoreC
 		}
 
 		components = append(components, ScoreComponent{
-			Category:    "Team Resilience",
-			Description: "Bus factor (key person dependency)",
-			Impact:      impact,
-			Current:     fmt.Sprintf("%.0f", busFactor),
-			Target:      "≥2",
-			Tips:        tips,
+			Category:     "Team Resilience",
+			Description:  "Bus factor (key person dependency)",
+			Impact:       impact,
+			Current:      fmt.Sprintf("%.0f", busFactor),
+			Target:       "≥2",
+			Tips:         tips,
+			CustomWeight: impact > 0 && w.BusFactor != defaults.BusFactor,
 		})
 	}
 
-	// Zombie Issues (Weight: 15)
+	// Zombie Issues
 	zombies, zOk := getMetric("issue-hygiene", "zombie_issues")
 	if zOk {
 		impact := 0
 		tips := ""
+		custom := false
 
 		if zombies > 50 {
-			impact = 15
+			impact = w.ZombiesHigh
+			custom = w.ZombiesHigh != defaults.ZombiesHigh
 			tips = formatTips(
 				"",
 				"High volume of inactive issues (>90 days without updates).",
 				"High zombie count. Schedule a bug bash to close stale issues.",
 			)
 		} else if zombies > 10 {
-			impact = 5
+			impact = w.ZombiesModerate
+			custom = w.ZombiesModerate != defaults.ZombiesModerate
 			tips = formatTips(
 				"",
 				"Moderate number of inactive issues detected.",
@@ -352,16 +445,17 @@ func ExplainScore(repo models.RepoResult, outputMode models.OutputMode) []ScoreC
 		}
 
 		components = append(components, ScoreComponent{
-			Category:    "Issue Hygiene",
-			Description: "Stale/zombie issues (>90 days inactive)",
-			Impact:      impact,
-			Current:     fmt.Sprintf("%.0f", zombies),
-			Target:      "≤10",
-			Tips:        tips,
+			Category:     "Issue Hygiene",
+			Description:  "Stale/zombie issues (>90 days inactive)",
+			Impact:       impact,
+			Current:      fmt.Sprintf("%.0f", zombies),
+			Target:       "≤10",
+			Tips:         tips,
+			CustomWeight: custom,
 		})
 	}
 
-	// Repository Health Files (Weight: 5 per file, max 20)
+	// Repository Health Files (per file, capped)
 	missingFiles := 0
 	missingFileNames := []string{}
 	for _, az := range repo.Analyzers {
@@ -377,10 +471,7 @@ func ExplainScore(repo models.RepoResult, outputMode models.OutputMode) []ScoreC
 	}
 
 	if missingFiles > 0 {
-		impact := missingFiles * 5
-		if impact > 20 {
-			impact = 20
-		}
+		impact := missingFileDeduction(missingFiles, w)
 
 		tips := formatTips(
 			"",
@@ -392,16 +483,17 @@ func ExplainScore(repo models.RepoResult, outputMode models.OutputMode) []ScoreC
 		}
 
 		components = append(components, ScoreComponent{
-			Category:    "Repository Health",
-			Description: "Essential documentation files",
-			Impact:      impact,
-			Current:     fmt.Sprintf("%d missing", missingFiles),
-			Target:      "All present",
-			Tips:        tips,
+			Category:     "Repository Health",
+			Description:  "Essential documentation files",
+			Impact:       impact,
+			Current:      fmt.Sprintf("%d missing", missingFiles),
+			Target:       "All present",
+			Tips:         tips,
+			CustomWeight: w.MissingFile != defaults.MissingFile || w.MissingFilesMax != defaults.MissingFilesMax,
 		})
 	}
 
-	// Stale PRs (Weight: 15)
+	// Stale PRs
 	stalePRs := 0
 	for _, az := range repo.Analyzers {
 		if az.Name == "pr-flow" {
@@ -420,12 +512,13 @@ func ExplainScore(repo models.RepoResult, outputMode models.OutputMode) []ScoreC
 			"Review and merge or close old PRs. Long-running PRs often have merge conflicts.",
 		)
 		components = append(components, ScoreComponent{
-			Category:    "PR Velocity",
-			Description: "Stale pull requests (>14 days old)",
-			Impact:      15,
-			Current:     fmt.Sprintf("%d stale", stalePRs),
-			Target:      "≤5",
-			Tips:        tips,
+			Category:     "PR Velocity",
+			Description:  "Stale pull requests (>14 days old)",
+			Impact:       w.StalePRs,
+			Current:      fmt.Sprintf("%d stale", stalePRs),
+			Target:       "≤5",
+			Tips:         tips,
+			CustomWeight: w.StalePRs != defaults.StalePRs,
 		})
 	}
 
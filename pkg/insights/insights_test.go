@@ -155,6 +155,73 @@ func TestCalculateEngineeringHealthScore(t *testing.T) {
 	}
 }
 
+func TestGenerateInsightsUnprotectedSelfMerge(t *testing.T) {
+	repo := func(protected, selfMergeRate float64) models.RepoResult {
+		return models.RepoResult{Analyzers: []models.AnalyzerResult{
+			{Name: "repo-health", Metrics: []models.Metric{{Key: "branch_protection_enabled", Value: protected}}},
+			{Name: "pr-flow", Metrics: []models.Metric{{Key: "self_merge_rate", Value: selfMergeRate}}},
+		}}
+	}
+	governance := func(r models.RepoResult) []Insight {
+		var found []Insight
+		for _, ins := range GenerateInsights(r, models.OutputModeObservational, DefaultInsightThresholds()) {
+			if ins.Category == "Governance" {
+				found = append(found, ins)
+			}
+		}
+		return found
+	}
+
+	if got := governance(repo(0, 80)); len(got) != 1 || got[0].Level != LevelCritical {
+		t.Errorf("Expected one critical insight for an unprotected branch with 80%% self-merges, got %+v", got)
+	}
+	if got := governance(repo(1, 80)); len(got) != 0 {
+		t.Errorf("Expected no insight when the branch is protected, got %+v", got)
+	}
+	if got := governance(repo(0, 20)); len(got) != 0 {
+		t.Errorf("Expected no insight for a low self-merge rate, got %+v", got)
+	}
+}
+
+func TestGenerateInsightsWithThresholds(t *testing.T) {
+	repo := models.RepoResult{
+		Analyzers: []models.AnalyzerResult{
+			{Name: "ci", Metrics: []models.Metric{{Key: "success_rate", Value: 85.0}}},
+			{Name: "issue-hygiene", Metrics: []models.Metric{{Key: "zombie_issues", Value: 8}}},
+			{Name: "pr-flow", Metrics: []models.Metric{{Key: "avg_cycle_time_hours", Value: 100.0}}},
+		},
+	}
+	levels := func(insights []Insight) map[string]InsightLevel {
+		got := make(map[string]InsightLevel)
+		for _, ins := range insights {
+			got[ins.StatValue] = ins.Level
+		}
+		return got
+	}
+
+	// Defaults: only the slow cycle time fires
+	defaults := levels(GenerateInsights(repo, models.OutputModeStatistical, DefaultInsightThresholds()))
+	if len(defaults) != 1 || defaults["Avg PR Cycle Time: 100.0h"] != LevelInfo {
+		t.Errorf("Expected only the cycle time insight with default thresholds, got %v", defaults)
+	}
+
+	strict := DefaultInsightThresholds()
+	strict.CICritical = 90
+	strict.CIWarning = 95
+	strict.ZombieIssues = 5
+	strict.CycleTimeHours = 120
+	got := levels(GenerateInsights(repo, models.OutputModeStatistical, strict))
+	if got["CI Success Rate: 85.0%"] != LevelCritical {
+		t.Errorf("Expected CI to be critical below 90%%, got %v", got)
+	}
+	if got["Zombie Issues: 8"] != LevelWarning {
+		t.Errorf("Expected a zombie warning above 5 issues, got %v", got)
+	}
+	if _, ok := got["Avg PR Cycle Time: 100.0h"]; ok {
+		t.Errorf("Expected no cycle time insight below 120h, got %v", got)
+	}
+}
+
 func TestGenerateInsights(t *testing.T) {
 	// Simple test to ensure insights are generated for specific conditions
 	repo := models.RepoResult{
@@ -170,7 +237,7 @@ func TestGenerateInsights(t *testing.T) {
 		},
 	}
 
-	insights := GenerateInsights(repo, models.OutputModeObservational)
+	insights := GenerateInsights(repo, models.OutputModeObservational, DefaultInsightThresholds())
 
 	// Expecting:
 	// 1. Critical Insight for CI < 50
@@ -405,9 +472,10 @@ func TestExplainScore_ZombieIssues(t *testing.T) {
 
 func TestExplainScore_MissingFiles(t *testing.T) {
 	tests := []struct {
-		name           string
-		missingCount   int
-		expectedImpact int
+		name            string
+		missingCount    int
+		missingFilesMax int
+		expectedImpact  int
 	}{
 		{
 			name:           "No missing files",
@@ -430,9 +498,15 @@ func TestExplainScore_MissingFiles(t *testing.T) {
 			expectedImpact: 20,
 		},
 		{
-			name:           "10 missing files (capped at 20)",
+			name:            "10 missing files (capped at 20)",
+			missingCount:    10,
+			missingFilesMax: 20,
+			expectedImpact:  20,
+		},
+		{
+			name:           "10 missing files (uncapped by default)",
 			missingCount:   10,
-			expectedImpact: 20,
+			expectedImpact: 50,
 		},
 	}
 
@@ -455,7 +529,9 @@ func TestExplainScore_MissingFiles(t *testing.T) {
 				},
 			}
 
-			components := ExplainScore(repo, models.OutputModeObservational)
+			weights := DefaultScoringWeights()
+			weights.MissingFilesMax = tt.missingFilesMax
+			components := ExplainScoreWithWeights(repo, models.OutputModeObservational, weights)
 
 			if tt.missingCount == 0 {
 				if len(components) != 0 {
@@ -475,6 +551,9 @@ func TestExplainScore_MissingFiles(t *testing.T) {
 			if comp.Impact != tt.expectedImpact {
 				t.Errorf("Expected impact %d, got %d", tt.expectedImpact, comp.Impact)
 			}
+			if score := CalculateEngineeringHealthScoreWithWeights(repo, weights); score != 100-tt.expectedImpact {
+				t.Errorf("Expected the score to deduct the same %d points, got %d", tt.expectedImpact, score)
+			}
 		})
 	}
 }
@@ -676,3 +755,82 @@ func TestExplainScore_OnlyMetricsNoFindings(t *testing.T) {
 		}
 	}
 }
+
+func TestCustomScoringWeights(t *testing.T) {
+	repo := models.RepoResult{
+		Analyzers: []models.AnalyzerResult{
+			{Name: "ci", Metrics: []models.Metric{{Key: "success_rate", Value: 40}}},
+			{Name: "issue-hygiene", Metrics: []models.Metric{{Key: "zombie_issues", Value: 20}}},
+		},
+	}
+
+	defaults := DefaultScoringWeights()
+	if got := CalculateEngineeringHealthScoreWithWeights(repo, defaults); got != 65 {
+		t.Errorf("Expected default score 65, got %d", got)
+	}
+
+	custom := defaults
+	custom.CIFailing = 10
+	custom.ZombiesModerate = 0
+	if got := CalculateEngineeringHealthScoreWithWeights(repo, custom); got != 90 {
+		t.Errorf("Expected custom score 90, got %d", got)
+	}
+
+	components := ExplainScoreWithWeights(repo, models.OutputModeObservational, custom)
+	for _, c := range components {
+		switch c.Category {
+		case "CI Stability":
+			if c.Impact != 10 || !c.CustomWeight {
+				t.Errorf("CI Stability: expected impact 10 with custom weight, got %d (custom=%v)", c.Impact, c.CustomWeight)
+			}
+		case "Issue Hygiene":
+			if c.Impact != 0 || !c.CustomWeight {
+				t.Errorf("Issue Hygiene: expected impact 0 with custom weight, got %d (custom=%v)", c.Impact, c.CustomWeight)
+			}
+		}
+	}
+
+	// The plain entry point always scores with the built-in weights
+	if got := CalculateEngineeringHealthScore(repo); got != 65 {
+		t.Errorf("Expected the default entry point to score 65, got %d", got)
+	}
+}
+
+func TestDefaultWeightsNotMarkedCustom(t *testing.T) {
+	repo := models.RepoResult{
+		Analyzers: []models.AnalyzerResult{
+			{Name: "ci", Metrics: []models.Metric{{Key: "success_rate", Value: 40}}},
+		},
+	}
+	for _, c := range ExplainScoreWithWeights(repo, models.OutputModeObservational, DefaultScoringWeights()) {
+		if c.CustomWeight {
+			t.Errorf("%s unexpectedly marked as custom weight", c.Category)
+		}
+	}
+}
+
+func TestExplainScoreSummary(t *testing.T) {
+	ci := func(rate float64) models.AnalyzerResult {
+		return models.AnalyzerResult{Name: "ci", Metrics: []models.Metric{{Key: "success_rate", Value: rate}}}
+	}
+	busFactor1 := models.AnalyzerResult{Name: "activity", Metrics: []models.Metric{
+		{Key: "bus_factor", Value: 1},
+		{Key: "active_contributors", Value: 5},
+	}}
+	repos := []models.RepoResult{
+		{Name: "org/a", Analyzers: []models.AnalyzerResult{ci(40), busFactor1}}, // -30 CI, -20 bus factor
+		{Name: "org/b", Analyzers: []models.AnalyzerResult{ci(80)}},             // -15 CI
+		{Name: "org/c", Analyzers: []models.AnalyzerResult{ci(99)}},             // healthy
+	}
+
+	issues := ExplainScoreSummary(repos, DefaultScoringWeights())
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 systemic issues, got %+v", issues)
+	}
+	if issues[0].Category != "CI Stability" || issues[0].PointsLost != 45 || issues[0].ReposAffected != 2 {
+		t.Errorf("Expected CI Stability first with 45 pts over 2 repos, got %+v", issues[0])
+	}
+	if issues[1].Category != "Team Resilience" || issues[1].PointsLost != 20 || issues[1].Repos[0] != "org/a" {
+		t.Errorf("Expected Team Resilience second with 20 pts in org/a, got %+v", issues[1])
+	}
+}
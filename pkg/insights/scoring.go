@@ -0,0 +1,28 @@
+package insights
+
+// ScoringWeights holds the point deductions used by CalculateEngineeringHealthScore.
+// Each field is the number of points removed from 100 when the condition applies.
+type ScoringWeights struct {
+	CIFailing       int // CI success rate below 50%
+	CIUnstable      int // CI success rate between 50% and 90%
+	BusFactor       int // Single author accounts for >50% of commits
+	ZombiesHigh     int // More than 50 zombie issues
+	ZombiesModerate int // More than 10 zombie issues
+	MissingFile     int // Per missing key file
+	MissingFilesMax int // Cap on the total missing-file deduction (0 = uncapped)
+	StalePRs        int // More than 5 stale pull requests
+}
+
+// DefaultScoringWeights returns the built-in deductions
+func DefaultScoringWeights() ScoringWeights {
+	return ScoringWeights{
+		CIFailing:       30,
+		CIUnstable:      15,
+		BusFactor:       20,
+		ZombiesHigh:     15,
+		ZombiesModerate: 5,
+		MissingFile:     5,
+		MissingFilesMax: 0,
+		StalePRs:        15,
+	}
+}
@@ -0,0 +1,21 @@
+package insights
+
+// InsightThresholds holds the trigger points GenerateInsights compares metrics against
+type InsightThresholds struct {
+	CICritical     float64 // CI success rate (percent) below which CI is a critical insight
+	CIWarning      float64 // CI success rate (percent) below which CI is a warning
+	ZombieIssues   int     // More zombie issues than this raise a maintenance warning
+	CycleTimeHours float64 // Average PR cycle time above this raises a velocity note
+	SelfMergeRate  float64 // Self-merge rate (percent) that is critical on an unprotected branch
+}
+
+// DefaultInsightThresholds returns the built-in trigger points
+func DefaultInsightThresholds() InsightThresholds {
+	return InsightThresholds{
+		CICritical:     50,
+		CIWarning:      80,
+		ZombieIssues:   10,
+		CycleTimeHours: 72,
+		SelfMergeRate:  50,
+	}
+}
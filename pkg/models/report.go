@@ -1,6 +1,9 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
 	"time"
 )
 
@@ -22,21 +25,45 @@ type Report struct {
 	Meta         ReportMeta    `json:"meta"`
 	Repositories []RepoResult  `json:"repositories"`
 	Summary      GlobalSummary `json:"summary"` // Aggregated stats across all repos
+
+	// Unavailable lists repositories skipped because they could not be found or accessed
+	Unavailable []UnavailableRepo `json:"unavailable,omitempty"`
+}
+
+// UnavailableRepo is a repository skipped before analysis (deleted, misspelled or private)
+type UnavailableRepo struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"` // "repo_unavailable"
+	Message string `json:"message"`
 }
 
 // ReportMeta contains metadata about the execution of the CLI.
 type ReportMeta struct {
 	GeneratedAt time.Time `json:"generated_at"`
 	CLIVersion  string    `json:"cli_version"`
-	Command     string    `json:"command"`  // e.g. "run"
-	Duration    string    `json:"duration"` // Execution duration
+	Command     string    `json:"command"`        // e.g. "run"
+	Duration    string    `json:"duration"`       // Execution duration
+	Note        string    `json:"note,omitempty"` // e.g. why the report is incomplete
+	// Ref is the branch or ref analyzed with --ref; empty means each repository's default branch
+	Ref string `json:"ref,omitempty"`
+	// SchemaVersion is the ReportSchemaVersion the report was written with; reports
+	// from before it was recorded are version 1
+	SchemaVersion int `json:"schema_version"`
 }
 
+// ReportSchemaVersion is the current shape of the report JSON. Bump it when a field
+// is removed, renamed or changes type, and add a migration to pkg/baseline so saved
+// baselines keep loading. New optional fields don't need a bump: older baselines
+// simply leave them empty.
+const ReportSchemaVersion = 1
+
 // RepoResult contains all metrics and findings for a specific repository.
 type RepoResult struct {
 	Name      string           `json:"name"` // owner/repo
 	URL       string           `json:"url"`
 	Analyzers []AnalyzerResult `json:"analyzers"` // Results grouped by analyzer
+	// DurationMs is how long the repository took to analyze, including its .gh-inspect.yml lookup
+	DurationMs int64 `json:"duration_ms,omitempty"`
 }
 
 // AnalyzerResult groups output by the specific analyzer that produced it.
@@ -44,6 +71,17 @@ type AnalyzerResult struct {
 	Name     string    `json:"name"` // e.g. "pr-flow", "security-policy"
 	Metrics  []Metric  `json:"metrics,omitempty"`
 	Findings []Finding `json:"findings,omitempty"`
+	// DurationMs is how long the analyzer ran for the repository
+	DurationMs int64 `json:"duration_ms,omitempty"`
+	// TopContributors lists the most active commit authors in the window (activity only)
+	TopContributors []Contributor `json:"top_contributors,omitempty"`
+}
+
+// Contributor is one commit author's share of the commits in the lookback window
+type Contributor struct {
+	Name    string  `json:"name"` // GitHub login, or the git author name for unlinked commits
+	Commits int     `json:"commits"`
+	Percent float64 `json:"percent"` // share of all commits in the window
 }
 
 // Metric represents a quantitative measurement.
@@ -54,11 +92,25 @@ type Metric struct {
 	Unit         string  `json:"unit"`          // e.g. "hours", "count", "percent"
 	DisplayValue string  `json:"display_value"` // Human readable: "4.5h"
 	Description  string  `json:"description,omitempty"`
+	// PreviousValue and Trend are set when the report is compared against a baseline
+	// that has the same metric
+	PreviousValue *float64 `json:"previous_value,omitempty"`
+	Trend         Trend    `json:"trend,omitempty"`
 }
 
+// Trend is the direction a metric moved since the baseline
+type Trend string
+
+const (
+	TrendUp   Trend = "up"
+	TrendDown Trend = "down"
+	TrendFlat Trend = "flat"
+)
+
 // Finding represents a qualitative insight or issue detection.
 type Finding struct {
-	Type             string   `json:"type"` // e.g. "stale_pr", "missing_owner"
+	ID               string   `json:"id,omitempty"` // Stable fingerprint, see Fingerprint
+	Type             string   `json:"type"`         // e.g. "stale_pr", "missing_owner"
 	Severity         Severity `json:"severity"`
 	Message          string   `json:"message"`
 	Location         string   `json:"location,omitempty"` // URL or file path
@@ -69,6 +121,23 @@ type Finding struct {
 	Observation      string   `json:"observation,omitempty"`       // Neutral observation (observational mode)
 }
 
+// Fingerprint identifies a finding of repo across runs by hashing its type and location.
+// Messages embed counts that change between runs, so they are only used for findings
+// without a location, and then with their counts replaced (e.g. "Only #% of …"); PR and
+// issue references such as "#12" are kept.
+func (f Finding) Fingerprint(repo string) string {
+	key := f.Location
+	if key == "" {
+		key = countPattern.ReplaceAllString(f.Message, "${1}#")
+	}
+	sum := sha256.Sum256([]byte(repo + "\x00" + f.Type + "\x00" + key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// countPattern matches the counts a finding message embeds, including decimals, but not
+// "#"-prefixed references
+var countPattern = regexp.MustCompile(`(^|[^#0-9.])[0-9]+(\.[0-9]+)?`)
+
 type Severity string
 
 const (
@@ -79,6 +148,21 @@ const (
 	SeverityCritical Severity = "critical"
 )
 
+// severityRank orders severities from least to most severe
+var severityRank = map[Severity]int{
+	SeverityInfo:     0,
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// AtLeast reports whether s is at least as severe as min.
+// Unknown severities rank as info.
+func (s Severity) AtLeast(min Severity) bool {
+	return severityRank[s] >= severityRank[min]
+}
+
 // GlobalSummary holds aggregated data useful for multi-repo runs.
 type GlobalSummary struct {
 	TotalReposAnalyzed int `json:"total_repos_analyzed"`
@@ -94,4 +178,21 @@ type GlobalSummary struct {
 	AvgCISuccessRate  float64 `json:"avg_ci_success_rate"`
 	AvgCIRuntime      float64 `json:"avg_ci_runtime"`    // Avg CI runtime in seconds
 	AvgPRCycleTime    float64 `json:"avg_pr_cycle_time"` // Avg of avg cycle times
+
+	// Weighted health score (stars, commits or explicit per-repo weights); zero when not configured
+	WeightedHealthScore  float64 `json:"weighted_health_score,omitempty"`
+	HealthScoreWeighting string  `json:"health_score_weighting,omitempty"`
+
+	// Repos skipped as unavailable, and analyzed repos where every analyzer failed
+	ReposUnavailable int `json:"repos_unavailable,omitempty"`
+	ReposFailed      int `json:"repos_failed,omitempty"`
+
+	// RiskiestRepos lists the repos with the lowest Engineering Health Scores, worst first
+	RiskiestRepos []RiskyRepo `json:"riskiest_repos,omitempty"`
+}
+
+// RiskyRepo is an entry in GlobalSummary.RiskiestRepos
+type RiskyRepo struct {
+	Name        string  `json:"name"`
+	HealthScore float64 `json:"health_score"`
 }
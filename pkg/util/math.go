@@ -15,3 +15,17 @@ func Min(a, b int) int {
 	}
 	return b
 }
+
+// Percentile returns the p-th percentile of sorted values, interpolating between
+// neighbours so the 50th percentile matches the median
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(rank)
+	if lower+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
@@ -0,0 +1,24 @@
+package util
+
+import "testing"
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 1},
+		{50, 2.5},
+		{90, 3.7},
+		{100, 4},
+	}
+	for _, tt := range tests {
+		if got := Percentile(values, tt.p); got < tt.want-1e-9 || got > tt.want+1e-9 {
+			t.Errorf("Percentile(%v, %v) = %v, want %v", values, tt.p, got, tt.want)
+		}
+	}
+	if got := Percentile(nil, 50); got != 0 {
+		t.Errorf("Expected 0 for no values, got %v", got)
+	}
+}